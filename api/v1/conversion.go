@@ -0,0 +1,82 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConversionDataAnnotation holds the v1beta1 fields that have no v1
+// equivalent, so that an object converted to v1 and back to v1beta1
+// comes back unchanged.
+const ConversionDataAnnotation = "ibp.com/v1beta1-conversion-data"
+
+// convertSpec copies src into dst by round tripping it through JSON. The v1
+// and v1beta1 specs share their field names, so every field present in both
+// versions is carried over as-is and fields unknown to dst are dropped.
+func convertSpec(src, dst interface{}) error {
+	bytes, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(bytes, dst)
+}
+
+// saveConversionData stores data in the ConversionDataAnnotation of meta. No
+// annotation is added if data is equal to empty.
+func saveConversionData(meta *metav1.ObjectMeta, data, empty interface{}) error {
+	if equality.Semantic.DeepEqual(data, empty) {
+		return nil
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[ConversionDataAnnotation] = string(bytes)
+
+	return nil
+}
+
+// restoreConversionData removes the ConversionDataAnnotation from meta and
+// unmarshals it into data. It returns false if meta has no such annotation.
+func restoreConversionData(meta *metav1.ObjectMeta, data interface{}) (bool, error) {
+	value, ok := meta.Annotations[ConversionDataAnnotation]
+	if !ok {
+		return false, nil
+	}
+	delete(meta.Annotations, ConversionDataAnnotation)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+
+	if err := json.Unmarshal([]byte(value), data); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal annotation '%s'", ConversionDataAnnotation)
+	}
+
+	return true, nil
+}
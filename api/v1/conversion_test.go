@@ -0,0 +1,252 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "github.com/IBM-Blockchain/fabric-operator/api/v1"
+	"github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	consolev1 "github.com/IBM-Blockchain/fabric-operator/pkg/apis/console/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("Conversion", func() {
+	var (
+		replicas      int32
+		maxNameLength int
+	)
+
+	BeforeEach(func() {
+		replicas = 1
+		maxNameLength = 20
+	})
+
+	Context("IBPCA", func() {
+		var hub *v1beta1.IBPCA
+
+		BeforeEach(func() {
+			hub = &v1beta1.IBPCA{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ca1",
+					Namespace:   "ns1",
+					Annotations: map[string]string{"key": "value"},
+				},
+				Spec: v1beta1.IBPCASpec{
+					License:       v1beta1.License{Accept: true},
+					FabricVersion: "1.5.3",
+					Replicas:      &replicas,
+					HSM:           &v1beta1.HSM{PKCS11Endpoint: "tcp://0.0.0.0:2345"},
+					ConfigOverride: &v1beta1.ConfigOverride{
+						CA:            &runtime.RawExtension{Raw: []byte(`{"debug":true}`)},
+						MaxNameLength: &maxNameLength,
+					},
+				},
+				Status: v1beta1.IBPCAStatus{
+					CRStatus: v1beta1.CRStatus{Type: v1beta1.Deployed},
+				},
+			}
+		})
+
+		It("moves the deprecated hsm field to the conversion data annotation", func() {
+			ca := &v1.IBPCA{}
+			Expect(ca.ConvertFrom(hub)).To(Succeed())
+			Expect(ca.Spec.FabricVersion).To(Equal("1.5.3"))
+			Expect(ca.Spec.ConfigOverride.CA.Raw).To(MatchJSON(`{"debug":true}`))
+			Expect(*ca.Spec.ConfigOverride.MaxNameLength).To(Equal(20))
+			Expect(ca.Status.Type).To(Equal(v1beta1.Deployed))
+			Expect(ca.Annotations).To(HaveKeyWithValue("key", "value"))
+			Expect(ca.Annotations[v1.ConversionDataAnnotation]).To(MatchJSON(`{"hsm":{"pkcs11endpoint":"tcp://0.0.0.0:2345"}}`))
+		})
+
+		It("converts from v1beta1 and back without losing data", func() {
+			ca := &v1.IBPCA{}
+			Expect(ca.ConvertFrom(hub)).To(Succeed())
+
+			converted := &v1beta1.IBPCA{}
+			Expect(ca.ConvertTo(converted)).To(Succeed())
+			Expect(converted.ObjectMeta).To(Equal(hub.ObjectMeta))
+			Expect(converted.Spec.ConfigOverride.CA.Raw).To(MatchJSON(`{"debug":true}`))
+			converted.Spec.ConfigOverride.CA = hub.Spec.ConfigOverride.CA
+			Expect(converted.Spec).To(Equal(hub.Spec))
+			Expect(converted.Status).To(Equal(hub.Status))
+		})
+
+		It("does not add an annotation if there is no v1beta1 only data", func() {
+			hub.Spec.HSM = nil
+
+			ca := &v1.IBPCA{}
+			Expect(ca.ConvertFrom(hub)).To(Succeed())
+			Expect(ca.Annotations).To(Equal(map[string]string{"key": "value"}))
+		})
+	})
+
+	Context("IBPPeer", func() {
+		var (
+			hub           *v1beta1.IBPPeer
+			replicaNumber int
+		)
+
+		BeforeEach(func() {
+			replicaNumber = 2
+			hub = &v1beta1.IBPPeer{
+				ObjectMeta: metav1.ObjectMeta{Name: "peer1", Namespace: "ns1"},
+				Spec: v1beta1.IBPPeerSpec{
+					MSPID:          "peer1msp",
+					StateDb:        "couchdb",
+					Replicas:       &replicas,
+					ReplicaNumber:  &replicaNumber,
+					HSM:            &v1beta1.HSM{PKCS11Endpoint: "tcp://0.0.0.0:2345"},
+					ConfigOverride: &runtime.RawExtension{Raw: []byte(`{"peer":{"id":"peer1"}}`)},
+				},
+			}
+		})
+
+		It("converts from v1beta1 and back without losing data", func() {
+			peer := &v1.IBPPeer{}
+			Expect(peer.ConvertFrom(hub)).To(Succeed())
+			Expect(peer.Spec.MSPID).To(Equal("peer1msp"))
+			Expect(peer.Spec.ConfigOverride.Raw).To(MatchJSON(`{"peer":{"id":"peer1"}}`))
+			Expect(peer.Annotations[v1.ConversionDataAnnotation]).To(MatchJSON(`{"hsm":{"pkcs11endpoint":"tcp://0.0.0.0:2345"},"replicaNumber":2}`))
+
+			converted := &v1beta1.IBPPeer{}
+			Expect(peer.ConvertTo(converted)).To(Succeed())
+			Expect(converted.ObjectMeta).To(Equal(hub.ObjectMeta))
+			Expect(converted.Spec.ConfigOverride.Raw).To(MatchJSON(`{"peer":{"id":"peer1"}}`))
+			converted.Spec.ConfigOverride = hub.Spec.ConfigOverride
+			Expect(converted.Spec).To(Equal(hub.Spec))
+		})
+
+		It("converts a v1 peer without conversion data", func() {
+			peer := &v1.IBPPeer{
+				ObjectMeta: metav1.ObjectMeta{Name: "peer1", Namespace: "ns1"},
+				Spec:       v1.IBPPeerSpec{MSPID: "peer1msp"},
+			}
+
+			converted := &v1beta1.IBPPeer{}
+			Expect(peer.ConvertTo(converted)).To(Succeed())
+			Expect(converted.Annotations).To(BeNil())
+			Expect(converted.Spec.MSPID).To(Equal("peer1msp"))
+			Expect(converted.Spec.HSM).To(BeNil())
+			Expect(converted.Spec.ReplicaNumber).To(BeNil())
+		})
+	})
+
+	Context("IBPOrderer", func() {
+		It("converts from v1beta1 and back without losing data", func() {
+			nodeNumber := 1
+			hub := &v1beta1.IBPOrderer{
+				ObjectMeta: metav1.ObjectMeta{Name: "orderer1", Namespace: "ns1"},
+				Spec: v1beta1.IBPOrdererSpec{
+					MSPID:           "orderermsp",
+					OrdererType:     "etcdraft",
+					ClusterSize:     3,
+					IsPrecreate:     pointer.Bool(true),
+					NodeNumber:      &nodeNumber,
+					ExternalAddress: "orderer1node1.ns1:7050",
+					ClusterConfigOverride: []*runtime.RawExtension{
+						{Raw: []byte(`{"general":{"listenPort":7050}}`)},
+					},
+				},
+			}
+
+			orderer := &v1.IBPOrderer{}
+			Expect(orderer.ConvertFrom(hub)).To(Succeed())
+			Expect(orderer.Spec.ClusterSize).To(Equal(3))
+			Expect(orderer.Spec.ClusterConfigOverride).To(HaveLen(1))
+			Expect(orderer.Annotations[v1.ConversionDataAnnotation]).To(MatchJSON(`{"isprecreate":true,"number":1,"externalAddress":"orderer1node1.ns1:7050"}`))
+
+			converted := &v1beta1.IBPOrderer{}
+			Expect(orderer.ConvertTo(converted)).To(Succeed())
+			Expect(converted.ObjectMeta).To(Equal(hub.ObjectMeta))
+			Expect(converted.Spec.ClusterConfigOverride[0].Raw).To(MatchJSON(`{"general":{"listenPort":7050}}`))
+			converted.Spec.ClusterConfigOverride = hub.Spec.ClusterConfigOverride
+			Expect(converted.Spec).To(Equal(hub.Spec))
+		})
+	})
+
+	Context("IBPConsole", func() {
+		var hub *v1beta1.IBPConsole
+
+		BeforeEach(func() {
+			hub = &v1beta1.IBPConsole{
+				ObjectMeta: metav1.ObjectMeta{Name: "console1", Namespace: "ns1"},
+				Spec: v1beta1.IBPConsoleSpec{
+					Email:           "admin@ibm.com",
+					IAMApiKey:       "apikey",
+					SegmentWriteKey: "segmentkey",
+					IBMID:           &consolev1.IBMID{URL: "https://ibmid"},
+					CRN:             &v1beta1.CRN{AccountID: "account"},
+					ConfigOverride: &v1beta1.ConsoleOverrides{
+						Console:       &runtime.RawExtension{Raw: []byte(`{"hostURL":"https://console","hsm":"true"}`)},
+						Deployer:      &runtime.RawExtension{Raw: []byte(`{"timeouts":{"componentDeploy":1000}}`)},
+						MaxNameLength: &maxNameLength,
+					},
+				},
+			}
+		})
+
+		It("types the config override", func() {
+			console := &v1.IBPConsole{}
+			Expect(console.ConvertFrom(hub)).To(Succeed())
+			Expect(console.Spec.Email).To(Equal("admin@ibm.com"))
+			Expect(console.Spec.ConfigOverride.Console.HostURL).To(Equal("https://console"))
+			Expect(console.Spec.ConfigOverride.Console.HSM).To(Equal(true))
+			Expect(console.Spec.ConfigOverride.Deployer.Timeouts.Deployment).To(Equal(1000))
+			Expect(*console.Spec.ConfigOverride.MaxNameLength).To(Equal(20))
+			Expect(console.Annotations).To(HaveKey(v1.ConversionDataAnnotation))
+		})
+
+		It("converts from v1beta1 and back without losing data", func() {
+			console := &v1.IBPConsole{}
+			Expect(console.ConvertFrom(hub)).To(Succeed())
+
+			converted := &v1beta1.IBPConsole{}
+			Expect(console.ConvertTo(converted)).To(Succeed())
+			Expect(converted.ObjectMeta).To(Equal(hub.ObjectMeta))
+			Expect(converted.Spec).To(Equal(hub.Spec))
+		})
+
+		It("uses the typed config override if it was changed", func() {
+			console := &v1.IBPConsole{}
+			Expect(console.ConvertFrom(hub)).To(Succeed())
+			console.Spec.ConfigOverride.Console.HSM = false
+
+			converted := &v1beta1.IBPConsole{}
+			Expect(console.ConvertTo(converted)).To(Succeed())
+			Expect(converted.Spec.IAMApiKey).To(Equal("apikey"))
+			Expect(converted.Spec.ConfigOverride.Console.Raw).To(MatchJSON(`{"hostURL":"https://console","hsm":"false"}`))
+		})
+
+		It("does not add an annotation if there is no v1beta1 only data", func() {
+			hub.Spec.IAMApiKey = ""
+			hub.Spec.SegmentWriteKey = ""
+			hub.Spec.IBMID = nil
+			hub.Spec.CRN = nil
+			hub.Spec.ConfigOverride = nil
+
+			console := &v1.IBPConsole{}
+			Expect(console.ConvertFrom(hub)).To(Succeed())
+			Expect(console.Annotations).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,152 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IBPCASpec defines the desired state of an IBP CA
+type IBPCASpec struct {
+	// License should be accepted by the user to be able to setup CA
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	License v1beta1.License `json:"license"`
+
+	/* generic configs - images/resources/storage/servicetype/version/replicas */
+
+	// Images (Optional) lists the images to be used for CA's deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Images *v1beta1.CAImages `json:"images,omitempty"`
+
+	// RegistryURL is registry url used to pull images
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	RegistryURL string `json:"registryURL,omitempty"`
+
+	// ImagePullSecrets (Optional) is the list of ImagePullSecrets to be used for CA's deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Replicas (Optional - default 1) is the number of CA replicas to be setup
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources (Optional) is the amount of resources to be provided to CA deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Resources *v1beta1.CAResources `json:"resources,omitempty"`
+
+	// Service (Optional) is the override object for CA's service
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Service *v1beta1.Service `json:"service,omitempty"`
+
+	// Storage (Optional - uses default storageclass if not provided) is the override object for CA's PVC config
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Storage *v1beta1.CAStorages `json:"storage,omitempty"`
+
+	/* CA specific configs */
+
+	// ConfigOverride (Optional) is the object to provide overrides to CA & TLSCA config
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ConfigOverride *CAConfigOverride `json:"configoverride,omitempty"`
+
+	// CustomNames (Optional) is to use pre-configured resources for CA's deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	CustomNames v1beta1.CACustomNames `json:"customNames,omitempty"`
+
+	// RestoreFrom (Optional) restores the CA from a backup when it is created
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	RestoreFrom *v1beta1.RestoreFrom `json:"restoreFrom,omitempty"`
+
+	// NumSecondsWarningPeriod (Optional - default 30 days) is used to define certificate expiry warning period.
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	NumSecondsWarningPeriod int64 `json:"numSecondsWarningPeriod,omitempty"`
+
+	// FabricVersion (Optional) set the fabric version you want to use.
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	FabricVersion string `json:"version"`
+
+	// Domain is the sub-domain used for CA's deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Domain string `json:"domain,omitempty"`
+
+	// Ingress (Optional) is ingress object for ingress overrides
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Ingress v1beta1.Ingress `json:"ingress,omitempty"`
+
+	/* cluster related configs */
+
+	// Arch (Optional) is the architecture of the nodes where CA should be deployed
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Arch []string `json:"arch,omitempty"`
+
+	// Region (Optional) is the region of the nodes where the CA should be deployed
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Region string `json:"region,omitempty"`
+
+	// Zone (Optional) is the zone of the nodes where the CA should be deployed
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Zone string `json:"zone,omitempty"`
+
+	// Action (Optional) is action object for trigerring actions
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Action v1beta1.CAAction `json:"action,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// IBPCA is the v1 representation of an IBPCA. It is converted to and from the
+// v1beta1 storage version by the conversion webhook.
+type IBPCA struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IBPCASpec           `json:"spec,omitempty"`
+	Status v1beta1.IBPCAStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// IBPCAList contains a list of IBPCA
+type IBPCAList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IBPCA `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IBPCA{}, &IBPCAList{})
+}
+
+// CAConfigOverride is the overrides to the CA and TLSCA configuration
+type CAConfigOverride struct {
+	// CA (Optional) is the overrides to CA's configuration
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	CA *apiextensionsv1.JSON `json:"ca,omitempty"`
+
+	// TLSCA (Optional) is the overrides to TLSCA's configuration
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	TLSCA *apiextensionsv1.JSON `json:"tlsca,omitempty"`
+
+	// MaxNameLength (Optional) is the maximum length of the name that the CA can have
+	MaxNameLength *int `json:"maxnamelength,omitempty"`
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	consolev1 "github.com/IBM-Blockchain/fabric-operator/pkg/apis/console/v1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// consoleConversionData is stored in the ConversionDataAnnotation of a v1
+// IBPConsole and carries the v1beta1 fields that were removed from v1.
+type consoleConversionData struct {
+	IAMApiKey       string           `json:"iamApiKey,omitempty"`
+	SegmentWriteKey string           `json:"segmentWriteKey,omitempty"`
+	IBMID           *consolev1.IBMID `json:"ibmid,omitempty"`
+	CRN             *v1beta1.CRN     `json:"crn,omitempty"`
+
+	// ConfigOverride is the original, untyped override. It is restored as
+	// long as the typed v1 override has not been changed.
+	ConfigOverride *v1beta1.ConsoleOverrides `json:"configoverride,omitempty"`
+}
+
+// ConvertTo converts this IBPConsole to the hub (v1beta1) version.
+func (src *IBPConsole) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.IBPConsole)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = v1beta1.IBPConsoleSpec{}
+
+	spec := src.Spec.DeepCopy()
+	spec.ConfigOverride = nil
+	if err := convertSpec(spec, &dst.Spec); err != nil {
+		return err
+	}
+	dst.Status = *src.Status.DeepCopy()
+
+	override, err := consoleOverridesToHub(src.Spec.ConfigOverride)
+	if err != nil {
+		return err
+	}
+	dst.Spec.ConfigOverride = override
+
+	restored := &consoleConversionData{}
+	ok, err := restoreConversionData(&dst.ObjectMeta, restored)
+	if err != nil || !ok {
+		return err
+	}
+	dst.Spec.IAMApiKey = restored.IAMApiKey
+	dst.Spec.SegmentWriteKey = restored.SegmentWriteKey
+	dst.Spec.IBMID = restored.IBMID
+	dst.Spec.CRN = restored.CRN
+
+	if restored.ConfigOverride != nil {
+		original, err := consoleOverridesFromHub(restored.ConfigOverride)
+		if err != nil {
+			return err
+		}
+		if equality.Semantic.DeepEqual(original, src.Spec.ConfigOverride) {
+			dst.Spec.ConfigOverride = restored.ConfigOverride
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the hub (v1beta1) version to this IBPConsole.
+func (dst *IBPConsole) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.IBPConsole)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = IBPConsoleSpec{}
+
+	spec := src.Spec.DeepCopy()
+	spec.ConfigOverride = nil
+	if err := convertSpec(spec, &dst.Spec); err != nil {
+		return err
+	}
+	dst.Status = *src.Status.DeepCopy()
+
+	override, err := consoleOverridesFromHub(src.Spec.ConfigOverride)
+	if err != nil {
+		return err
+	}
+	dst.Spec.ConfigOverride = override
+
+	data := &consoleConversionData{
+		IAMApiKey:       src.Spec.IAMApiKey,
+		SegmentWriteKey: src.Spec.SegmentWriteKey,
+		IBMID:           src.Spec.IBMID,
+		CRN:             src.Spec.CRN,
+		ConfigOverride:  src.Spec.ConfigOverride,
+	}
+
+	return saveConversionData(&dst.ObjectMeta, data, &consoleConversionData{})
+}
+
+func consoleOverridesFromHub(in *v1beta1.ConsoleOverrides) (*ConsoleOverrides, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := &ConsoleOverrides{
+		MaxNameLength: in.MaxNameLength,
+	}
+
+	if in.Console != nil {
+		console := &v1beta1.ConsoleOverridesConsole{}
+		if err := json.Unmarshal(in.Console.Raw, console); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal console overrides")
+		}
+		out.Console = &ConsoleOverridesConsole{
+			HostURL:                    console.HostURL,
+			ActivityTrackerConsolePath: console.ActivityTrackerConsolePath,
+			ActivityTrackerHostPath:    console.ActivityTrackerHostPath,
+			HSM:                        console.HSM != "" && console.HSM != "false",
+		}
+	}
+
+	if in.Deployer != nil {
+		out.Deployer = &v1beta1.ConsoleOverridesDeployer{}
+		if err := json.Unmarshal(in.Deployer.Raw, out.Deployer); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal deployer overrides")
+		}
+	}
+
+	return out, nil
+}
+
+func consoleOverridesToHub(in *ConsoleOverrides) (*v1beta1.ConsoleOverrides, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := &v1beta1.ConsoleOverrides{
+		MaxNameLength: in.MaxNameLength,
+	}
+
+	if in.Console != nil {
+		bytes, err := json.Marshal(&v1beta1.ConsoleOverridesConsole{
+			HostURL:                    in.Console.HostURL,
+			ActivityTrackerConsolePath: in.Console.ActivityTrackerConsolePath,
+			ActivityTrackerHostPath:    in.Console.ActivityTrackerHostPath,
+			HSM:                        strconv.FormatBool(in.Console.HSM),
+		})
+		if err != nil {
+			return nil, err
+		}
+		out.Console = &runtime.RawExtension{Raw: bytes}
+	}
+
+	if in.Deployer != nil {
+		bytes, err := json.Marshal(in.Deployer)
+		if err != nil {
+			return nil, err
+		}
+		out.Deployer = &runtime.RawExtension{Raw: bytes}
+	}
+
+	return out, nil
+}
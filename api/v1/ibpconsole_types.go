@@ -0,0 +1,224 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	consolev1 "github.com/IBM-Blockchain/fabric-operator/pkg/apis/console/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IBPConsoleSpec defines the desired state of an IBP console
+type IBPConsoleSpec struct {
+	// License should be accepted by the user to be able to setup console
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	License v1beta1.License `json:"license"`
+
+	// Images (Optional) lists the images to be used for console's deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Images *v1beta1.ConsoleImages `json:"images,omitempty"`
+
+	// ImagePullSecrets (Optional) is the list of ImagePullSecrets to be used for console's deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Replicas (Optional - default 1) is the number of console replicas to be setup
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources (Optional) is the amount of resources to be provided to console deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Resources *v1beta1.ConsoleResources `json:"resources,omitempty"`
+
+	// Service (Optional) is the override object for console's service
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Service *v1beta1.Service `json:"service,omitempty"`
+
+	// ServiceAccountName defines serviceaccount used for console deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// Storage (Optional - uses default storageclass if not provided) is the override object for CA's PVC config
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Storage *v1beta1.ConsoleStorage `json:"storage,omitempty"`
+
+	// NetworkInfo is object for network overrides
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	NetworkInfo *v1beta1.NetworkInfo `json:"networkinfo,omitempty"`
+
+	// Ingress (Optional) is ingress object for ingress overrides
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Ingress v1beta1.Ingress `json:"ingress,omitempty"`
+
+	/* console settings */
+	// AuthScheme is auth scheme for console access
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	AuthScheme string `json:"authScheme,omitempty"`
+
+	// AllowDefaultPassword, if true, will bypass the password reset flow
+	// on the first connection to the console GUI.  By default (false), all
+	// consoles require a password reset at the first login.
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	AllowDefaultPassword bool `json:"allowDefaultPassword,omitempty"`
+
+	// Components is database name used for components
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Components string `json:"components,omitempty"`
+
+	// ClusterData is object cluster data information
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ClusterData *consolev1.IBPConsoleClusterData `json:"clusterdata,omitempty"`
+
+	// ConfigtxlatorURL is url for configtxlator server
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ConfigtxlatorURL string `json:"configtxlator,omitempty"`
+
+	// ConnectionString is connection url for backend database
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ConnectionString string `json:"connectionString,omitempty"`
+
+	// DeployerTimeout is timeout value for deployer calls
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	DeployerTimeout int32 `json:"deployerTimeout,omitempty"`
+
+	// DeployerURL is url for deployer server
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	DeployerURL string `json:"deployerUrl,omitempty"`
+
+	// Email is the email used for initial access
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Email string `json:"email,omitempty"`
+
+	// FeatureFlags is object for feature flag settings
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	FeatureFlags *consolev1.FeatureFlags `json:"featureflags,omitempty"`
+
+	Proxying *bool `json:"proxying,omitempty"`
+
+	// Password is initial password to access console
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Password string `json:"password,omitempty"`
+
+	// PasswordSecretName is secretname where password is stored
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	PasswordSecretName string `json:"passwordSecretName,omitempty"`
+
+	// Sessions is sessions database name to use
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Sessions string `json:"sessions,omitempty"`
+
+	// System is system database name to use
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	System string `json:"system,omitempty"`
+
+	// SystemChannel is default systemchannel name
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	SystemChannel string `json:"systemChannel,omitempty"`
+
+	// TLSSecretName is secret name to load custom tls certs
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	Kubeconfig           *[]byte           `json:"kubeconfig,omitempty"`
+	KubeconfigSecretName string            `json:"kubeconfigsecretname,omitempty"`
+	Versions             *v1beta1.Versions `json:"versions,omitempty"`
+	KubeconfigNamespace  string            `json:"kubeconfignamespace,omitempty"`
+
+	// RegistryURL is registry url used to pull images
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	RegistryURL string `json:"registryURL,omitempty"`
+
+	// Deployer is object for deployer configs
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Deployer *v1beta1.Deployer `json:"deployer,omitempty"`
+
+	// Arch (Optional) is the architecture of the nodes where console should be deployed
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Arch []string `json:"arch,omitempty"`
+
+	// Region (Optional) is the region of the nodes where the console should be deployed
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Region string `json:"region,omitempty"`
+
+	// Zone (Optional) is the zone of the nodes where the console should be deployed
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Zone string `json:"zone,omitempty"`
+
+	// ConfigOverride (Optional) is the object to provide overrides
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ConfigOverride *ConsoleOverrides `json:"configoverride,omitempty"`
+
+	// Action (Optional) is action object for trigerring actions
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Action v1beta1.ConsoleAction `json:"action,omitempty"`
+
+	// Version (Optional) is version for the console
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Version string `json:"version"`
+
+	// UseTags (Optional) is a flag to switch between image digests and tags
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	UseTags *bool `json:"usetags"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// IBPConsole is the v1 representation of an IBPConsole. It is converted to and from the
+// v1beta1 storage version by the conversion webhook.
+type IBPConsole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IBPConsoleSpec           `json:"spec,omitempty"`
+	Status v1beta1.IBPConsoleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// IBPConsoleList contains a list of IBPConsole
+type IBPConsoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IBPConsole `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IBPConsole{}, &IBPConsoleList{})
+}
+
+// ConsoleOverrides is the overrides to console configuration
+type ConsoleOverrides struct {
+	// Console is the overrides to console configuration
+	Console *ConsoleOverridesConsole `json:"console,omitempty"`
+
+	// Deployer is the overrides to deployer configuration
+	Deployer *v1beta1.ConsoleOverridesDeployer `json:"deployer,omitempty"`
+
+	// MaxNameLength (Optional) is the maximum length of the name that the console can have
+	MaxNameLength *int `json:"maxnamelength,omitempty"`
+}
+
+// ConsoleOverridesConsole is the overrides to the console container's configuration
+type ConsoleOverridesConsole struct {
+	HostURL                    string `json:"hostURL,omitempty"`
+	ActivityTrackerConsolePath string `json:"activityTrackerConsolePath,omitempty"`
+	ActivityTrackerHostPath    string `json:"activityTrackerHostPath,omitempty"`
+
+	// HSM (Optional) enables HSM support in the console
+	HSM bool `json:"hsm,omitempty"`
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ordererConversionData is stored in the ConversionDataAnnotation of a v1
+// IBPOrderer and carries the v1beta1 fields that were removed from v1.
+type ordererConversionData struct {
+	HSM             *v1beta1.HSM `json:"hsm,omitempty"`
+	IsPrecreate     *bool        `json:"isprecreate,omitempty"`
+	NodeNumber      *int         `json:"number,omitempty"`
+	ExternalAddress string       `json:"externalAddress,omitempty"`
+}
+
+// ConvertTo converts this IBPOrderer to the hub (v1beta1) version.
+func (src *IBPOrderer) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.IBPOrderer)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = v1beta1.IBPOrdererSpec{}
+	if err := convertSpec(&src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+	dst.Status = *src.Status.DeepCopy()
+
+	restored := &ordererConversionData{}
+	ok, err := restoreConversionData(&dst.ObjectMeta, restored)
+	if err != nil || !ok {
+		return err
+	}
+	dst.Spec.HSM = restored.HSM
+	dst.Spec.IsPrecreate = restored.IsPrecreate
+	dst.Spec.NodeNumber = restored.NodeNumber
+	dst.Spec.ExternalAddress = restored.ExternalAddress
+
+	return nil
+}
+
+// ConvertFrom converts from the hub (v1beta1) version to this IBPOrderer.
+func (dst *IBPOrderer) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.IBPOrderer)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = IBPOrdererSpec{}
+	if err := convertSpec(&src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+	dst.Status = *src.Status.DeepCopy()
+
+	data := &ordererConversionData{
+		HSM:             src.Spec.HSM,
+		IsPrecreate:     src.Spec.IsPrecreate,
+		NodeNumber:      src.Spec.NodeNumber,
+		ExternalAddress: src.Spec.ExternalAddress,
+	}
+
+	return saveConversionData(&dst.ObjectMeta, data, &ordererConversionData{})
+}
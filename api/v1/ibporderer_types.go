@@ -0,0 +1,188 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IBPOrdererSpec defines the desired state of an IBP orderer
+type IBPOrdererSpec struct {
+	// License should be accepted by the user to be able to setup orderer
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	License v1beta1.License `json:"license"`
+
+	// Images (Optional) lists the images to be used for orderer's deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Images *v1beta1.OrdererImages `json:"images,omitempty"`
+
+	// RegistryURL is registry url used to pull images
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	RegistryURL string `json:"registryURL,omitempty"`
+
+	// ImagePullSecrets (Optional) is the list of ImagePullSecrets to be used for orderer's deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Replicas (Optional - default 1) is the number of orderer replicas to be setup
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// WorkloadType (Optional - default Deployment) is the type of workload that runs the orderer, an
+	// existing deployment is replaced in place by a stateful set that keeps its volumes
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	WorkloadType v1beta1.WorkloadType `json:"workloadType,omitempty"`
+
+	// Resources (Optional) is the amount of resources to be provided to orderer deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Resources *v1beta1.OrdererResources `json:"resources,omitempty"`
+
+	// Service (Optional) is the override object for orderer's service
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Service *v1beta1.Service `json:"service,omitempty"`
+
+	// Storage (Optional - uses default storageclass if not provided) is the override object for CA's PVC config
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Storage *v1beta1.OrdererStorages `json:"storage,omitempty"`
+
+	// GenesisBlock (Optional) is genesis block to start the orderer
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	GenesisBlock   string `json:"genesisBlock,omitempty"`
+	GenesisProfile string `json:"genesisProfile,omitempty"`
+	UseChannelLess *bool  `json:"useChannelLess,omitempty"`
+
+	// MSPID is the msp id of the orderer
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	MSPID string `json:"mspID,omitempty"`
+
+	// OrdererType is type of orderer you want to start
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	OrdererType string `json:"ordererType,omitempty"`
+
+	// OrgName is the organization name of the orderer
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	OrgName string `json:"orgName,omitempty"`
+
+	// SystemChannelName is the name of systemchannel
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	SystemChannelName string `json:"systemChannelName,omitempty"`
+
+	// Secret is object for msp crypto
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Secret *v1beta1.SecretSpec `json:"secret,omitempty"`
+
+	// RestoreFrom (Optional) restores the orderer from a backup when it is created
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	RestoreFrom *v1beta1.RestoreFrom `json:"restoreFrom,omitempty"`
+
+	// ConfigOverride (Optional) is the object to provide overrides to core yaml config
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ConfigOverride *apiextensionsv1.JSON `json:"configoverride,omitempty"`
+
+	// JoinCluster (Optional) is set on nodes added to a deployed cluster, the node is added as a
+	// consenter to the channels of the cluster and joined to them through the channel participation API
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	JoinCluster *bool `json:"joinCluster,omitempty"`
+
+	// FabricVersion (Optional) is fabric version for the orderer
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	FabricVersion string `json:"version"`
+
+	// NumSecondsWarningPeriod (Optional - default 30 days) is used to define certificate expiry warning period.
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	NumSecondsWarningPeriod int64 `json:"numSecondsWarningPeriod,omitempty"`
+
+	// ClusterSize (Optional) number of orderers if a cluster
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ClusterSize int `json:"clusterSize,omitempty"`
+
+	// ClusterLocation (Optional) is array of cluster location settings for cluster
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ClusterLocation []v1beta1.IBPOrdererClusterLocation `json:"location,omitempty"`
+
+	// ClusterConfigOverride (Optional) is array of config overrides for cluster
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ClusterConfigOverride []*apiextensionsv1.JSON `json:"clusterconfigoverride,omitempty"`
+
+	// ClusterSecret (Optional) is array of msp crypto for cluster
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ClusterSecret []*v1beta1.SecretSpec `json:"clustersecret,omitempty"`
+
+	// Ingress (Optional) is ingress object for ingress overrides
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Ingress v1beta1.Ingress `json:"ingress,omitempty"`
+
+	// Domain is the sub-domain used for orderer's deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Domain string `json:"domain,omitempty"`
+
+	// Arch (Optional) is the architecture of the nodes where orderer should be deployed
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Arch []string `json:"arch,omitempty"`
+
+	// Zone (Optional) is the zone of the nodes where the orderer should be deployed
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Zone string `json:"zone,omitempty"`
+
+	// Region (Optional) is the region of the nodes where the orderer should be deployed
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Region string `json:"region,omitempty"`
+
+	// DisableNodeOU (Optional) is used to switch nodeou on and off
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	DisableNodeOU *bool `json:"disablenodeou,omitempty"`
+
+	// CustomNames (Optional) is to use pre-configured resources for orderer's deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	CustomNames v1beta1.OrdererCustomNames `json:"customNames,omitempty"`
+
+	// Action (Optional) is object for orderer actions
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Action v1beta1.OrdererAction `json:"action,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// IBPOrderer is the v1 representation of an IBPOrderer. It is converted to and from the
+// v1beta1 storage version by the conversion webhook.
+type IBPOrderer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IBPOrdererSpec           `json:"spec,omitempty"`
+	Status v1beta1.IBPOrdererStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// IBPOrdererList contains a list of IBPOrderer
+type IBPOrdererList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IBPOrderer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IBPOrderer{}, &IBPOrdererList{})
+}
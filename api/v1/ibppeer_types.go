@@ -0,0 +1,191 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IBPPeerSpec defines the desired state of an IBP peer
+type IBPPeerSpec struct {
+	// License should be accepted by the user to be able to setup Peer
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	License v1beta1.License `json:"license"`
+
+	/* generic configs - images/resources/storage/servicetype/version/replicas */
+
+	// Images (Optional) lists the images to be used for peer's deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Images *v1beta1.PeerImages `json:"images,omitempty"`
+
+	// RegistryURL is registry url used to pull images
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	RegistryURL string `json:"registryURL,omitempty"`
+
+	// ImagePullSecrets (Optional) is the list of ImagePullSecrets to be used for peer's deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Replicas (Optional - default 1) is the number of peer replicas to be setup, each replica
+	// is a separate peer that enrolls with the enrollment IDs of this spec suffixed with the
+	// replica number, which must be registered with the CA beforehand. Scaling a single peer up
+	// replaces it with the replicas, its workload, volumes and crypto material are deleted.
+	// Scaling down to 1 deletes the replicas and runs this peer with the enrollment IDs of this spec
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// WorkloadType (Optional - default Deployment) is the type of workload that runs the peer, an
+	// existing deployment is replaced in place by a stateful set that keeps its volumes
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	WorkloadType v1beta1.WorkloadType `json:"workloadType,omitempty"`
+
+	// Resources (Optional) is the amount of resources to be provided to peer deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Resources *v1beta1.PeerResources `json:"resources,omitempty"`
+
+	// Service (Optional) is the override object for peer's service
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Service *v1beta1.Service `json:"service,omitempty"`
+
+	// Storage (Optional - uses default storageclass if not provided) is the override object for peer's PVC config
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Storage *v1beta1.PeerStorages `json:"storage,omitempty"`
+
+	/* peer specific configs */
+	// MSPID is the msp id of the peer
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	MSPID string `json:"mspID,omitempty"`
+
+	// StateDb (Optional) is the statedb used for peer, can be couchdb or leveldb
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	StateDb string `json:"stateDb,omitempty"`
+
+	// ConfigOverride (Optional) is the object to provide overrides to core yaml config
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ConfigOverride *apiextensionsv1.JSON `json:"configoverride,omitempty"`
+
+	// DisableNodeOU (Optional) is used to switch nodeou on and off
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	DisableNodeOU *bool `json:"disablenodeou,omitempty"`
+
+	// CustomNames (Optional) is to use pre-configured resources for peer's deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	CustomNames v1beta1.PeerCustomNames `json:"customNames,omitempty"`
+
+	// FabricVersion (Optional) is fabric version for the peer
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	FabricVersion string `json:"version"`
+
+	// NumSecondsWarningPeriod (Optional - default 30 days) is used to define certificate expiry warning period.
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	NumSecondsWarningPeriod int64 `json:"numSecondsWarningPeriod,omitempty"`
+
+	/* msp data can be passed in secret on in spec */
+	// MSPSecret (Optional) is secret used to store msp crypto
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	MSPSecret string `json:"mspSecret,omitempty"`
+
+	// Secret is object for msp crypto
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Secret *v1beta1.SecretSpec `json:"secret,omitempty"`
+
+	// RestoreFrom (Optional) restores the peer from a backup when it is created
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	RestoreFrom *v1beta1.RestoreFrom `json:"restoreFrom,omitempty"`
+
+	/* proxy ip passed if not OCP, domain for OCP */
+	// Domain is the sub-domain used for peer's deployment
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Domain string `json:"domain,omitempty"`
+
+	// Ingress (Optional) is ingress object for ingress overrides
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Ingress v1beta1.Ingress `json:"ingress,omitempty"`
+
+	// PeerExternalEndpoint (Optional) is used to override peer external endpoint
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	PeerExternalEndpoint string `json:"peerExternalEndpoint,omitempty"`
+
+	/* cluster related configs */
+	// Arch (Optional) is the architecture of the nodes where peer should be deployed
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Arch []string `json:"arch,omitempty"`
+
+	// Region (Optional) is the region of the nodes where the peer should be deployed
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Region string `json:"region,omitempty"`
+
+	// Zone (Optional) is the zone of the nodes where the peer should be deployed
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Zone string `json:"zone,omitempty"`
+
+	// Action (Optional) is object for peer actions
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Action v1beta1.PeerAction `json:"action,omitempty"`
+
+	// ChaincodeBuilderConfig (Optional) is a k/v map providing a scope for template
+	// substitutions defined in chaincode-as-a-service package metadata files.
+	// The map will be serialized as JSON and set in the peer deployment
+	// CHAINCODE_AS_A_SERVICE_BUILDER_CONFIG env variable.
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ChaincodeBuilderConfig v1beta1.ChaincodeBuilderConfig `json:"chaincodeBuilderConfig,omitempty"`
+
+	// AdminSecret (Optional) is the name of a secret holding the cert.pem and key.pem of an
+	// admin identity of the peer's organization, used by the operator to administer the peer
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	AdminSecret string `json:"adminSecret,omitempty"`
+
+	// Channels (Optional) is the list of channels the peer should join, requires AdminSecret
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Channels []v1beta1.PeerChannel `json:"channels,omitempty"`
+
+	// SnapshotStorage (Optional) is the object storage ledger snapshots are copied to by the
+	// snapshot action, and downloaded from when joining a channel from a snapshot
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	SnapshotStorage *v1beta1.BackupObjectStorage `json:"snapshotStorage,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// IBPPeer is the v1 representation of an IBPPeer. It is converted to and from the
+// v1beta1 storage version by the conversion webhook.
+type IBPPeer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IBPPeerSpec           `json:"spec,omitempty"`
+	Status v1beta1.IBPPeerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// IBPPeerList contains a list of IBPPeer
+type IBPPeerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IBPPeer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IBPPeer{}, &IBPPeerList{})
+}
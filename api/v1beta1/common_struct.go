@@ -20,6 +20,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // Service is the overrides to be used for Service of the component
@@ -28,6 +29,18 @@ type Service struct {
 	// The "type" of the service to be used
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// UseLoadBalancerAddress (Optional) uses the IP or hostname assigned to the LoadBalancer
+	// service of a peer or orderer node as its external address, in place of the ingress hostname.
+	// Only applies when the type of the service is LoadBalancer.
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	UseLoadBalancerAddress bool `json:"useLoadBalancerAddress,omitempty"`
+}
+
+// UsesLoadBalancerAddress returns true if the component is reached at the address assigned to
+// its LoadBalancer service
+func (s *Service) UsesLoadBalancerAddress() bool {
+	return s != nil && s.Type == corev1.ServiceTypeLoadBalancer && s.UseLoadBalancerAddress
 }
 
 // StorageSpec is the overrides to be used for storage of the component
@@ -72,8 +85,62 @@ type Ingress struct {
 	// Class (Optional) is the class to set for ingress
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	Class string `json:"class,omitempty"`
+
+	// Mode (Optional - default Ingress) selects how the endpoints are exposed, either with an
+	// Ingress or with Gateway API routes attached to Gateway
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Mode IngressMode `json:"mode,omitempty"`
+
+	// Gateway (Optional) is the Gateway the routes attach to in GatewayAPI mode, its
+	// listeners must allow TLS passthrough for TLSRoutes and terminate HTTPS for HTTPRoutes
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Gateway *GatewayReference `json:"gateway,omitempty"`
+}
+
+// UsesGatewayAPI returns true if the endpoints are exposed with Gateway API routes
+func (i Ingress) UsesGatewayAPI() bool {
+	return i.Mode == GatewayAPIMode
+}
+
+// IngressMode is the way the endpoints of a component are exposed
+// +kubebuilder:validation:Enum=Ingress;GatewayAPI
+type IngressMode string
+
+const (
+	// IngressModeIngress exposes the endpoints with an Ingress
+	IngressModeIngress IngressMode = "Ingress"
+
+	// GatewayAPIMode exposes the gRPC endpoints with TLSRoutes that pass TLS through to the
+	// component, and the operations and grpcweb endpoints with HTTPRoutes whose BackendTLSPolicies
+	// verify the TLS certificate of the component
+	GatewayAPIMode IngressMode = "GatewayAPI"
+)
+
+// GatewayReference is a reference to a Gateway API Gateway
+// +k8s:deepcopy-gen=true
+type GatewayReference struct {
+	// Name is the name of the Gateway
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Name string `json:"name"`
+
+	// Namespace (Optional) is the namespace of the Gateway, defaults to the namespace of the component
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Namespace string `json:"namespace,omitempty"`
 }
 
+// WorkloadType is the type of workload that runs a component
+// +kubebuilder:validation:Enum=Deployment;StatefulSet
+type WorkloadType string
+
+const (
+	// DeploymentWorkload runs the component as a deployment with separately managed volumes
+	DeploymentWorkload WorkloadType = "Deployment"
+
+	// StatefulSetWorkload runs the component as a stateful set, volumes are created from the
+	// volume claim templates of the stateful set
+	StatefulSetWorkload WorkloadType = "StatefulSet"
+)
+
 // IBPCRStatus is the string that defines if status is set by the controller
 // +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
 type IBPCRStatus string
@@ -122,6 +189,24 @@ const (
 	Initializing IBPCRStatusType = "Initializing"
 )
 
+// Condition types reported in the conditions of a CR status
+const (
+	// ReadyCondition is true when the component is deployed and serving
+	ReadyCondition = "Ready"
+
+	// DeploymentAvailableCondition is true when all the pods of the component are running
+	DeploymentAvailableCondition = "DeploymentAvailable"
+
+	// CertificatesValidCondition is false when a certificate of the component is expiring or has expired
+	CertificatesValidCondition = "CertificatesValid"
+
+	// RestartPendingCondition is true when a restart of the component has been requested but not performed yet
+	RestartPendingCondition = "RestartPending"
+
+	// MigrationCompleteCondition is true when the component has been migrated to the running operator version
+	MigrationCompleteCondition = "MigrationComplete"
+)
+
 // +k8s:deepcopy-gen=true
 // CRStatus is the object that defines the status of a CR
 // +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
@@ -157,6 +242,16 @@ type CRStatus struct {
 	// Versions is the operand version of the component
 	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
 	Versions CRStatusVersion `json:"versions,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the CR observed by the controller
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions are the latest observations of the state of the component
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // CRStatusVersion provides the current reconciled version of the operand
@@ -252,6 +347,47 @@ type Enrollment struct {
 	// CSR is the CSR override object
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	CSR *CSR `json:"csr,omitempty"`
+
+	// CertManager (Optional) is the cert-manager issuer that issues the certificate instead
+	// of a Fabric CA, only supported for the TLS certificate
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	CertManager *CertManagerIssuer `json:"certmanager,omitempty"`
+}
+
+// UsesCertManager returns true if the certificate is issued by cert-manager
+func (e *Enrollment) UsesCertManager() bool {
+	return e != nil && e.CertManager != nil
+}
+
+// UsesCertManagerForTLS returns true if the TLS certificate is issued by cert-manager, TLS
+// crypto passed in the MSP spec takes precedence over the TLS enrollment
+func (s *SecretSpec) UsesCertManagerForTLS() bool {
+	if s == nil || s.Enrollment == nil {
+		return false
+	}
+	if s.MSP != nil && s.MSP.TLS != nil {
+		return false
+	}
+	return s.Enrollment.TLS.UsesCertManager()
+}
+
+// CertManagerIssuer is a reference to a cert-manager Issuer or ClusterIssuer. The issuer must
+// set ca.crt in the certificate secret, or include its CA certificate as the last certificate of
+// the chain in tls.crt.
+// +k8s:deepcopy-gen=true
+type CertManagerIssuer struct {
+	// Name is the name of the issuer
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Name string `json:"name"`
+
+	// Kind (Optional) is the kind of the issuer, defaults to Issuer
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Kind string `json:"kind,omitempty"`
+
+	// Group (Optional) is the API group of the issuer, defaults to cert-manager.io
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Group string `json:"group,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
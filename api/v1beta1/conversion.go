@@ -0,0 +1,34 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+// v1beta1 is the storage version and acts as the conversion hub; all other
+// served versions convert to and from these types.
+
+// Hub marks IBPCA as a conversion hub.
+func (*IBPCA) Hub() {}
+
+// Hub marks IBPPeer as a conversion hub.
+func (*IBPPeer) Hub() {}
+
+// Hub marks IBPOrderer as a conversion hub.
+func (*IBPOrderer) Hub() {}
+
+// Hub marks IBPConsole as a conversion hub.
+func (*IBPConsole) Hub() {}
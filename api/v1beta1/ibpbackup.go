@@ -0,0 +1,98 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultBackupRetention is the number of completed backups kept for every volume by default
+const DefaultBackupRetention = int32(7)
+
+// GetMethod returns the backup method, which defaults to Archive
+func (b *IBPBackup) GetMethod() BackupMethod {
+	if b.Spec.Method != "" {
+		return b.Spec.Method
+	}
+	return BackupArchive
+}
+
+// GetRetention returns the number of completed backups kept for every volume
+func (b *IBPBackup) GetRetention() int32 {
+	if b.Spec.Retention != nil {
+		return *b.Spec.Retention
+	}
+	return DefaultBackupRetention
+}
+
+// Pending returns the backups that have not completed or failed yet
+func (s *IBPBackupStatus) Pending() []BackupRecord {
+	pending := []BackupRecord{}
+	for _, backup := range s.Backups {
+		if backup.Phase == BackupPending {
+			pending = append(pending, backup)
+		}
+	}
+	return pending
+}
+
+// Latest returns the latest completed backup of the volume that started before the given
+// time, or nil if the volume has no such backup
+func (s *IBPBackupStatus) Latest(volume string, before *metav1.Time) *BackupRecord {
+	var latest *BackupRecord
+	for i, backup := range s.Backups {
+		if backup.Volume != volume || backup.Phase != BackupCompleted {
+			continue
+		}
+		if before != nil && !backup.StartTime.Before(before) {
+			continue
+		}
+		if latest == nil || latest.StartTime.Before(&backup.StartTime) {
+			latest = &s.Backups[i]
+		}
+	}
+	return latest
+}
+
+func (s *IBPBackupStatus) HasType() bool {
+	if s.CRStatus.Type != "" {
+		return true
+	}
+	return false
+}
+
+// GetComponent returns the name of the component that was backed up
+func (r *RestoreFrom) GetComponent(name string) string {
+	if r.Component != "" {
+		return r.Component
+	}
+	return name
+}
+
+// GetCryptoBackupSecret returns the name of the secret holding the crypto backup of the
+// component that was backed up
+func (r *RestoreFrom) GetCryptoBackupSecret(name string) string {
+	return fmt.Sprintf("%s-crypto-backup", r.GetComponent(name))
+}
+
+func init() {
+	SchemeBuilder.Register(&IBPBackup{}, &IBPBackupList{})
+}
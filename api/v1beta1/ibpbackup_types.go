@@ -0,0 +1,253 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// BackupMethod is the method used to back up the volumes of a component
+// +kubebuilder:validation:Enum=Archive;Snapshot
+type BackupMethod string
+
+const (
+	// BackupArchive runs a job that archives the content of the volume and uploads
+	// the archive to an S3 compatible object storage. The volume is archived while
+	// the component is running, so the archive is crash-consistent: restoring it is
+	// equivalent to restarting the component after a crash
+	BackupArchive BackupMethod = "Archive"
+
+	// BackupSnapshot creates a VolumeSnapshot of the volume using the CSI driver
+	// of the storage class
+	BackupSnapshot BackupMethod = "Snapshot"
+)
+
+// BackupPhase is the phase of a single backup
+type BackupPhase string
+
+const (
+	BackupPending   BackupPhase = "Pending"
+	BackupCompleted BackupPhase = "Completed"
+	BackupFailed    BackupPhase = "Failed"
+)
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+// IBPBackupSpec defines the desired state of IBPBackup
+// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+type IBPBackupSpec struct {
+	// Schedule is the schedule of the backups in cron format, e.g. "0 2 * * *".
+	// The descriptors @hourly, @daily, @weekly and @monthly are also supported
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Schedule string `json:"schedule"`
+
+	// Suspend (Optional) stops scheduling new backups, backups in progress are not affected
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Components is the list of components whose volumes are backed up
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Components []BackupComponent `json:"components"`
+
+	// Method (Optional) is the backup method, defaults to Archive. Archives are taken
+	// from the live volumes and are crash-consistent, use Snapshot for point-in-time copies
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Method BackupMethod `json:"method,omitempty"`
+
+	// VolumeSnapshotClassName (Optional) is the VolumeSnapshotClass used when the method
+	// is Snapshot, defaults to the default class of the cluster
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+
+	// ObjectStorage is the S3 compatible object storage archives are uploaded to,
+	// required when the method is Archive
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ObjectStorage *BackupObjectStorage `json:"objectStorage,omitempty"`
+
+	// Retention (Optional) is the number of completed backups kept for every volume,
+	// older backups are pruned. Defaults to 7
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +kubebuilder:validation:Minimum=1
+	Retention *int32 `json:"retention,omitempty"`
+
+	// Images (Optional) overrides the images of the archive jobs
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Images *BackupImages `json:"images,omitempty"`
+
+	// ImagePullSecrets (Optional) is the list of secrets used to pull the images of the archive jobs
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+}
+
+// BackupComponent is a component whose volumes are backed up
+type BackupComponent struct {
+	// Kind is the kind of the custom resource of the component
+	// +kubebuilder:validation:Enum=IBPPeer;IBPOrderer;IBPCA
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Kind string `json:"kind"`
+
+	// Name is the name of the custom resource of the component, for orderers
+	// this is the name of an orderer node
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Name string `json:"name"`
+}
+
+// BackupObjectStorage is an S3 compatible object storage
+type BackupObjectStorage struct {
+	// Endpoint is the URL of the object storage, e.g. https://minio.example.com:9000
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Endpoint string `json:"endpoint"`
+
+	// Bucket is the bucket archives are uploaded to
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Bucket string `json:"bucket"`
+
+	// Prefix (Optional) is prepended to the key of every archive
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Prefix string `json:"prefix,omitempty"`
+
+	// CredentialsSecret is the name of the secret holding the 'accessKeyID' and
+	// 'secretAccessKey' of the object storage
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	CredentialsSecret string `json:"credentialsSecret"`
+
+	// Insecure (Optional) skips the verification of the TLS certificate of the endpoint
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// BackupImages are the images of the archive jobs
+type BackupImages struct {
+	// ArchiveImage is the image that archives the content of a volume, it must provide tar
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ArchiveImage string `json:"archiveImage,omitempty"`
+
+	// ArchiveTag is the tag of the archive image
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ArchiveTag string `json:"archiveTag,omitempty"`
+
+	// UploadImage is the image that uploads and prunes archives, it must provide the MinIO client (mc)
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	UploadImage string `json:"uploadImage,omitempty"`
+
+	// UploadTag is the tag of the upload image
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	UploadTag string `json:"uploadTag,omitempty"`
+}
+
+// BackupRecord is a single backup of a volume
+// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+type BackupRecord struct {
+	// Name is the name of the job or VolumeSnapshot that performs the backup
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Name string `json:"name"`
+
+	// Component is the component the volume belongs to
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Component BackupComponent `json:"component"`
+
+	// Volume is the name of the persistent volume claim that was backed up
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Volume string `json:"volume"`
+
+	// Method is the method used for the backup
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Method BackupMethod `json:"method"`
+
+	// Location is the object key of the archive, or the name of the VolumeSnapshot
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Location string `json:"location"`
+
+	// Phase is the phase of the backup
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Phase BackupPhase `json:"phase"`
+
+	// StartTime is the time the backup was started
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	StartTime metav1.Time `json:"startTime"`
+}
+
+// RestoreFrom is the backup a peer, orderer or CA is restored from when it is created.
+// The volumes are provisioned from the latest completed backup of the IBPBackup, and the
+// ecert and TLS crypto is repopulated from the `<component>-crypto-backup` secret.
+// +k8s:deepcopy-gen=true
+type RestoreFrom struct {
+	// Backup is the name of the IBPBackup holding the backups of the volumes
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Backup string `json:"backup"`
+
+	// Component (Optional) is the name of the component that was backed up, defaults to the
+	// name of the component being restored. The nodes of an orderer cluster are restored from
+	// the nodes with the same number
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Component string `json:"component,omitempty"`
+
+	// Before (Optional) restores the latest backup started before this time, defaults to the
+	// latest completed backup
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Before *metav1.Time `json:"before,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+// IBPBackupStatus defines the observed state of IBPBackup
+// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+type IBPBackupStatus struct {
+	CRStatus `json:",inline"`
+
+	// LastScheduleTime is the time the last backup was scheduled
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// Backups are the backups in progress and the completed backups that have not been pruned yet
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Backups []BackupRecord `json:"backups,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen=true
+// IBPBackup backs up the ledger, state database and CA database volumes of components on a
+// schedule, either by archiving them to an S3 compatible object storage or by creating VolumeSnapshots.
+// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="IBP Backup"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources=`Jobs,v1,""`
+// +operator-sdk:gen-csv:customresourcedefinitions.resources=`PersistentVolumeClaims,v1,""`
+// +operator-sdk:gen-csv:customresourcedefinitions.resources=`VolumeSnapshots,v1,""`
+type IBPBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IBPBackupSpec `json:"spec,omitempty"`
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Status IBPBackupStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:deepcopy-gen=true
+// IBPBackupList contains a list of IBPBackup
+type IBPBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IBPBackup `json:"items"`
+}
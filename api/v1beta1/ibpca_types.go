@@ -78,6 +78,10 @@ type IBPCASpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	CustomNames CACustomNames `json:"customNames,omitempty"`
 
+	// RestoreFrom (Optional) restores the CA from a backup when it is created
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	RestoreFrom *RestoreFrom `json:"restoreFrom,omitempty"`
+
 	// NumSecondsWarningPeriod (Optional - default 30 days) is used to define certificate expiry warning period.
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	NumSecondsWarningPeriod int64 `json:"numSecondsWarningPeriod,omitempty"`
@@ -0,0 +1,96 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import "fmt"
+
+// DefaultChaincodePort is the port the chaincode server listens on by default
+const DefaultChaincodePort = int32(9999)
+
+// GetChaincodeName returns the name of the chaincode, which defaults
+// to the name of the custom resource
+func (c *IBPChaincode) GetChaincodeName() string {
+	if c.Spec.ChaincodeName != "" {
+		return c.Spec.ChaincodeName
+	}
+	return c.Name
+}
+
+// GetLabel returns the label of the chaincode package, which defaults
+// to <chaincodeName>_<version>
+func (c *IBPChaincode) GetLabel() string {
+	if c.Spec.Label != "" {
+		return c.Spec.Label
+	}
+	return fmt.Sprintf("%s_%s", c.GetChaincodeName(), c.Spec.Version)
+}
+
+// GetPort returns the port the chaincode server listens on
+func (c *IBPChaincode) GetPort() int32 {
+	if c.Spec.Port != 0 {
+		return c.Spec.Port
+	}
+	return DefaultChaincodePort
+}
+
+// GetReplicas returns the number of chaincode server replicas
+func (c *IBPChaincode) GetReplicas() int32 {
+	if c.Spec.Replicas != nil {
+		return *c.Spec.Replicas
+	}
+	return 1
+}
+
+// AllInstalled returns true if the chaincode is installed on every peer reported in status
+func (s *IBPChaincodeStatus) AllInstalled() bool {
+	if len(s.Peers) == 0 {
+		return false
+	}
+	for _, peer := range s.Peers {
+		if !peer.Installed {
+			return false
+		}
+	}
+	return true
+}
+
+// AllApproved returns true if every organization reported in status approved the
+// chaincode definition
+func (s *IBPChaincodeStatus) AllApproved() bool {
+	if len(s.Organizations) == 0 {
+		return false
+	}
+	for _, org := range s.Organizations {
+		if !org.Approved {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *IBPChaincodeStatus) HasType() bool {
+	if s.CRStatus.Type != "" {
+		return true
+	}
+	return false
+}
+
+func init() {
+	SchemeBuilder.Register(&IBPChaincode{}, &IBPChaincodeList{})
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+// IBPChaincodeSpec defines the desired state of IBPChaincode
+// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+type IBPChaincodeSpec struct {
+	// ChaincodeName (Optional) is the name of the chaincode on the channel, defaults to the name of the custom resource
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ChaincodeName string `json:"chaincodeName,omitempty"`
+
+	// Version is the version of the chaincode definition
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Version string `json:"version"`
+
+	// Sequence is the sequence of the chaincode definition, it must be incremented
+	// on every change of the definition
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Sequence int64 `json:"sequence"`
+
+	// Channel is the name of the channel the chaincode is defined on, every peer
+	// must list the channel in its spec
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Channel string `json:"channel"`
+
+	// Label (Optional) is the label of the chaincode package, defaults to <chaincodeName>_<version>
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Label string `json:"label,omitempty"`
+
+	// Image is the image of the chaincode server
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Image string `json:"image"`
+
+	// ImagePullSecrets (Optional) is the list of secrets used to pull the chaincode image
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Port (Optional) is the port the chaincode server listens on, defaults to 9999
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Port int32 `json:"port,omitempty"`
+
+	// Replicas (Optional) is the number of chaincode server replicas, defaults to 1
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources (Optional) is the amount of resources to be provided to the chaincode server
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// EndorsementPolicy (Optional) is the signature policy of the chaincode, for example
+	// "OR('Org1MSP.peer','Org2MSP.peer')", defaults to the endorsement policy of the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	EndorsementPolicy string `json:"endorsementPolicy,omitempty"`
+
+	// InitRequired (Optional) requires the Init function to be invoked before any other transaction
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	InitRequired bool `json:"initRequired,omitempty"`
+
+	// Peers is the list of IBPPeer custom resources the chaincode is installed on, the chaincode
+	// definition is approved for the organization of every peer using the adminSecret of the peer
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Peers []string `json:"peers"`
+}
+
+// ChaincodePeerStatus is the install status of the chaincode on a peer
+// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+type ChaincodePeerStatus struct {
+	// Name is the name of the IBPPeer custom resource
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Name string `json:"name"`
+
+	// Installed is true if the chaincode package is installed on the peer
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Installed bool `json:"installed"`
+
+	// Message provides a message for the status of the peer
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Message string `json:"message,omitempty"`
+}
+
+// ChaincodeOrganizationStatus is the approval status of the chaincode definition for an organization
+// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+type ChaincodeOrganizationStatus struct {
+	// MSPID is the MSP ID of the organization
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	MSPID string `json:"mspID"`
+
+	// Approved is true if the organization approved the chaincode definition
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Approved bool `json:"approved"`
+
+	// Message provides a message for the status of the organization
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+// IBPChaincodeStatus defines the observed state of IBPChaincode
+// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+type IBPChaincodeStatus struct {
+	CRStatus `json:",inline"`
+
+	// PackageID is the ID of the chaincode package installed on the peers
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	PackageID string `json:"packageID,omitempty"`
+
+	// Peers is the install status of the chaincode on every peer
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Peers []ChaincodePeerStatus `json:"peers,omitempty"`
+
+	// Organizations is the approval status of the chaincode definition for every organization
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Organizations []ChaincodeOrganizationStatus `json:"organizations,omitempty"`
+
+	// CommittedSequence is the sequence of the chaincode definition committed on the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	CommittedSequence int64 `json:"committedSequence,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen=true
+// IBPChaincode deploys a chaincode as an external service and drives its lifecycle on the channel:
+// the chaincode package is installed on the peers, approved by their organizations and committed.
+// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="IBP Chaincode"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources=`Deployments,v1,""`
+// +operator-sdk:gen-csv:customresourcedefinitions.resources=`Services,v1,""`
+// +operator-sdk:gen-csv:customresourcedefinitions.resources=`Secrets,v1,""`
+// +operator-sdk:gen-csv:customresourcedefinitions.resources=`IBPPeer,v1beta1,""`
+type IBPChaincode struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IBPChaincodeSpec `json:"spec,omitempty"`
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Status IBPChaincodeStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:deepcopy-gen=true
+// IBPChaincodeList contains a list of IBPChaincode
+type IBPChaincodeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IBPChaincode `json:"items"`
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+// GetChannelName returns the name of the channel, which defaults
+// to the name of the custom resource
+func (c *IBPChannel) GetChannelName() string {
+	if c.Spec.ChannelName != "" {
+		return c.Spec.ChannelName
+	}
+	return c.Name
+}
+
+// GetName returns the name of the organization in the channel
+// configuration, which defaults to the MSP ID of the organization
+func (o *ChannelOrganization) GetName(mspID string) string {
+	if o.Name != "" {
+		return o.Name
+	}
+	return mspID
+}
+
+// GetNode returns the join status of the node and whether it
+// has been reported yet
+func (s *IBPChannelStatus) GetNode(name string) (ChannelNodeStatus, bool) {
+	for _, node := range s.Nodes {
+		if node.Name == name {
+			return node, true
+		}
+	}
+	return ChannelNodeStatus{}, false
+}
+
+// AllNodesJoined returns true if every node reported in status has
+// joined the channel
+func (s *IBPChannelStatus) AllNodesJoined() bool {
+	if len(s.Nodes) == 0 {
+		return false
+	}
+	for _, node := range s.Nodes {
+		if node.Status != NodeJoined {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *IBPChannelStatus) HasType() bool {
+	if s.CRStatus.Type != "" {
+		return true
+	}
+	return false
+}
+
+func init() {
+	SchemeBuilder.Register(&IBPChannel{}, &IBPChannelList{})
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+// IBPChannelSpec defines the desired state of IBPChannel. The spec defines the genesis block
+// of the channel, changes to the spec are applied until the first orderer node joined the
+// channel. Later changes are not applied, the channel configuration must then be changed with
+// config updates.
+// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+type IBPChannelSpec struct {
+	// ChannelName (Optional) is the name of the channel, defaults to the name of the custom resource
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ChannelName string `json:"channelName,omitempty"`
+
+	// Orderer is the ordering service that serves the channel, every listed node is
+	// a consenter of the channel and is joined to it
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Orderer ChannelOrderer `json:"orderer"`
+
+	// ApplicationOrganizations is the list of organizations that are members of the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ApplicationOrganizations []ChannelOrganization `json:"applicationOrganizations"`
+
+	// Policies (Optional) are the application policies of the channel, defaults to implicit meta
+	// policies for Readers, Writers, Admins, Endorsement and LifecycleEndorsement
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Policies map[string]ChannelPolicy `json:"policies,omitempty"`
+
+	// Capabilities (Optional) are the capabilities of the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Capabilities *ChannelCapabilities `json:"capabilities,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+// ChannelOrderer is the ordering service that serves the channel
+// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+type ChannelOrderer struct {
+	// ClusterName is the name of the parent IBPOrderer custom resource of the ordering service
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ClusterName string `json:"clusterName"`
+
+	// Nodes (Optional) is the list of orderer node custom resources that should serve the channel,
+	// defaults to every node of the cluster
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// ChannelOrganization is an application organization that is a member of the channel
+// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+type ChannelOrganization struct {
+	// Name (Optional) is the name of the organization in the channel configuration, defaults to the MSP ID
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Name string `json:"name,omitempty"`
+
+	// PeerName is the name of an IBPPeer custom resource of the organization, the crypto
+	// material of the peer is used to build the MSP definition of the organization
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	PeerName string `json:"peerName"`
+}
+
+// ChannelPolicy is a policy of the channel configuration
+// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+type ChannelPolicy struct {
+	// Type is the type of the policy, either ImplicitMeta or Signature
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Type string `json:"type"`
+
+	// Rule is the rule of the policy
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Rule string `json:"rule"`
+}
+
+// +k8s:deepcopy-gen=true
+// ChannelCapabilities are the capabilities of the channel
+// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+type ChannelCapabilities struct {
+	// Channel (Optional) is the list of channel capabilities
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Channel []string `json:"channel,omitempty"`
+
+	// Orderer (Optional) is the list of orderer capabilities
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Orderer []string `json:"orderer,omitempty"`
+
+	// Application (Optional) is the list of application capabilities
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Application []string `json:"application,omitempty"`
+}
+
+// ChannelNodeStatusType is the join status of an orderer node
+// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+type ChannelNodeStatusType string
+
+const (
+	// NodePending is the status of an orderer node that has not been joined to the channel yet
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	NodePending ChannelNodeStatusType = "Pending"
+
+	// NodeJoined is the status of an orderer node that has joined the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	NodeJoined ChannelNodeStatusType = "Joined"
+
+	// NodeFailed is the status of an orderer node that failed to join the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	NodeFailed ChannelNodeStatusType = "Failed"
+)
+
+// ChannelNodeStatus is the join status of an orderer node
+// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+type ChannelNodeStatus struct {
+	// Name is the name of the orderer node custom resource
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Name string `json:"name"`
+
+	// Status is the join status of the node
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Status ChannelNodeStatusType `json:"status"`
+
+	// ConsensusRelation is the relation of the node to the channel as reported by the orderer
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	ConsensusRelation string `json:"consensusRelation,omitempty"`
+
+	// Height is the ledger height of the channel on the node as reported by the orderer
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Height uint64 `json:"height,omitempty"`
+
+	// Message provides a message for the status of the node
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+// IBPChannelStatus defines the observed state of IBPChannel
+// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+type IBPChannelStatus struct {
+	CRStatus `json:",inline"`
+
+	// Nodes is the join status of every orderer node of the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Nodes []ChannelNodeStatus `json:"nodes,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen=true
+// IBPChannel declares an application channel that is created on the orderer nodes through
+// the channel participation API.
+// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="IBP Channel"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources=`Secrets,v1,""`
+// +operator-sdk:gen-csv:customresourcedefinitions.resources=`ConfigMaps,v1,""`
+// +operator-sdk:gen-csv:customresourcedefinitions.resources=`IBPPeer,v1beta1,""`
+// +operator-sdk:gen-csv:customresourcedefinitions.resources=`IBPOrderer,v1beta1,""`
+type IBPChannel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IBPChannelSpec `json:"spec,omitempty"`
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Status IBPChannelStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:deepcopy-gen=true
+// IBPChannelList contains a list of IBPChannel
+type IBPChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IBPChannel `json:"items"`
+}
@@ -19,6 +19,8 @@
 package v1beta1
 
 import (
+	"strings"
+
 	config "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/orderer/config/v1"
 	v2config "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/orderer/config/v2"
 	v24config "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/orderer/config/v24"
@@ -250,10 +252,27 @@ func (s *IBPOrdererSpec) IsPrecreateOrderer() bool {
 	return s.IsPrecreate != nil && *s.IsPrecreate
 }
 
+func (s *IBPOrdererSpec) IsJoiningCluster() bool {
+	return s.JoinCluster != nil && *s.JoinCluster
+}
+
 func (s *IBPOrdererSpec) IsUsingChannelLess() bool {
 	return s.UseChannelLess != nil && *s.UseChannelLess
 }
 
+func (s *IBPOrdererSpec) IsRaft() bool {
+	return strings.ToLower(s.OrdererType) == "etcdraft"
+}
+
+// UsesStatefulSet returns true if the orderer runs as a stateful set
+func (s *IBPOrdererSpec) UsesStatefulSet() bool {
+	return s.WorkloadType == StatefulSetWorkload
+}
+
+func (s *IBPOrdererSpec) IsBFT() bool {
+	return strings.ToLower(s.OrdererType) == "bft"
+}
+
 func (s *IBPOrdererSpec) GetNumSecondsWarningPeriod() int64 {
 	daysToSecondsConversion := int64(24 * 60 * 60)
 	if s.NumSecondsWarningPeriod == 0 {
@@ -293,3 +312,13 @@ func (o *IBPOrdererStatus) HasType() bool {
 	}
 	return false
 }
+
+// GetChannel returns the status of the channel, or nil if the channel is not in the status
+func (s *IBPOrdererStatus) GetChannel(name string) *OrdererChannelStatus {
+	for i := range s.Channels {
+		if s.Channels[i].Name == name {
+			return &s.Channels[i]
+		}
+	}
+	return nil
+}
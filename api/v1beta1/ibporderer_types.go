@@ -51,6 +51,11 @@ type IBPOrdererSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// WorkloadType (Optional - default Deployment) is the type of workload that runs the orderer, an
+	// existing deployment is replaced in place by a stateful set that keeps its volumes
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	WorkloadType WorkloadType `json:"workloadType,omitempty"`
+
 	// Resources (Optional) is the amount of resources to be provided to orderer deployment
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	Resources *OrdererResources `json:"resources,omitempty"`
@@ -89,6 +94,10 @@ type IBPOrdererSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	Secret *SecretSpec `json:"secret,omitempty"`
 
+	// RestoreFrom (Optional) restores the orderer from a backup when it is created
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	RestoreFrom *RestoreFrom `json:"restoreFrom,omitempty"`
+
 	// ConfigOverride (Optional) is the object to provide overrides to core yaml config
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +kubebuilder:validation:Type=object
@@ -104,6 +113,11 @@ type IBPOrdererSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	IsPrecreate *bool `json:"isprecreate,omitempty"`
 
+	// JoinCluster (Optional) is set on nodes added to a deployed cluster, the node is added as a
+	// consenter to the channels of the cluster and joined to them through the channel participation API
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	JoinCluster *bool `json:"joinCluster,omitempty"`
+
 	// FabricVersion (Optional) is fabric version for the orderer
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	FabricVersion string `json:"version"`
@@ -188,6 +202,30 @@ type IBPOrdererClusterLocation struct {
 // +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
 type IBPOrdererStatus struct {
 	CRStatus `json:",inline"`
+
+	// Channels is the join status of the channels of the cluster on a node that joined a deployed cluster
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Channels []OrdererChannelStatus `json:"channels,omitempty"`
+}
+
+// OrdererChannelStatus is the join status of a channel of a node that joined a deployed cluster
+// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+type OrdererChannelStatus struct {
+	// Name is the name of the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Name string `json:"name"`
+
+	// Status is the join status of the node
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Status ChannelNodeStatusType `json:"status"`
+
+	// Consenter is true if the node is a consenter of the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Consenter bool `json:"consenter,omitempty"`
+
+	// Message provides a message for the status of the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Message string `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
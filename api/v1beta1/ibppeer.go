@@ -61,6 +61,10 @@ func (s *IBPPeer) ResetUpgradeDBs() {
 	s.Spec.Action.UpgradeDBs = false
 }
 
+func (s *IBPPeer) ResetSnapshot() {
+	s.Spec.Action.Snapshot = PeerSnapshotAction{}
+}
+
 func (p *IBPPeer) ClientAuthCryptoSet() bool {
 	secret := p.Spec.Secret
 	if secret != nil {
@@ -155,6 +159,17 @@ func (s *IBPPeer) IsHSMEnabled() bool {
 	return configOverride.(CoreConfig).UsingPKCS11()
 }
 
+// HasReplicas returns true if the peer runs more than one replica, every replica is a
+// separate IBPPeer created from this spec
+func (s *IBPPeerSpec) HasReplicas() bool {
+	return s.ReplicaNumber == nil && s.Replicas != nil && *s.Replicas > 1
+}
+
+// UsesStatefulSet returns true if the peer runs as a stateful set
+func (s *IBPPeerSpec) UsesStatefulSet() bool {
+	return s.WorkloadType == StatefulSetWorkload
+}
+
 func (s *IBPPeer) UsingCouchDB() bool {
 	if strings.ToLower(s.Spec.StateDb) == "couchdb" {
 		return true
@@ -328,6 +343,27 @@ func (i *PeerImages) Override(requested *PeerImages, registryURL string, arch st
 	i.EnrollerTag = image.GetTag(arch, i.EnrollerTag, requested.EnrollerTag)
 }
 
+// GetChannel returns the status of the channel, or nil if the channel is not in the status
+func (s *IBPPeerStatus) GetChannel(name string) *PeerChannelStatus {
+	for i := range s.Channels {
+		if s.Channels[i].Name == name {
+			return &s.Channels[i]
+		}
+	}
+	return nil
+}
+
+// SnapshotsPending returns true if any ledger snapshot has not been copied to the
+// snapshot storage yet
+func (s *IBPPeerStatus) SnapshotsPending() bool {
+	for _, snapshot := range s.Snapshots {
+		if snapshot.Phase == BackupPending {
+			return true
+		}
+	}
+	return false
+}
+
 func init() {
 	SchemeBuilder.Register(&IBPPeer{}, &IBPPeerList{})
 }
@@ -49,10 +49,23 @@ type IBPPeerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
 
-	// Replicas (Optional - default 1) is the number of peer replicas to be setup
+	// Replicas (Optional - default 1) is the number of peer replicas to be setup, each replica
+	// is a separate peer that enrolls with the enrollment IDs of this spec suffixed with the
+	// replica number, which must be registered with the CA beforehand. Scaling a single peer up
+	// replaces it with the replicas, its workload, volumes and crypto material are deleted.
+	// Scaling down to 1 deletes the replicas and runs this peer with the enrollment IDs of this spec
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// ReplicaNumber (Optional) is the number of this peer in the replicas of its parent - used internally
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	ReplicaNumber *int `json:"replicaNumber,omitempty"`
+
+	// WorkloadType (Optional - default Deployment) is the type of workload that runs the peer, an
+	// existing deployment is replaced in place by a stateful set that keeps its volumes
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	WorkloadType WorkloadType `json:"workloadType,omitempty"`
+
 	// Resources (Optional) is the amount of resources to be provided to peer deployment
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	Resources *PeerResources `json:"resources,omitempty"`
@@ -110,6 +123,10 @@ type IBPPeerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	Secret *SecretSpec `json:"secret,omitempty"`
 
+	// RestoreFrom (Optional) restores the peer from a backup when it is created
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	RestoreFrom *RestoreFrom `json:"restoreFrom,omitempty"`
+
 	/* proxy ip passed if not OCP, domain for OCP */
 	// Domain is the sub-domain used for peer's deployment
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
@@ -146,6 +163,47 @@ type IBPPeerSpec struct {
 	// CHAINCODE_AS_A_SERVICE_BUILDER_CONFIG env variable.
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	ChaincodeBuilderConfig ChaincodeBuilderConfig `json:"chaincodeBuilderConfig,omitempty"`
+
+	// AdminSecret (Optional) is the name of a secret holding the cert.pem and key.pem of an
+	// admin identity of the peer's organization, used by the operator to administer the peer
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	AdminSecret string `json:"adminSecret,omitempty"`
+
+	// Channels (Optional) is the list of channels the peer should join, requires AdminSecret
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Channels []PeerChannel `json:"channels,omitempty"`
+
+	// SnapshotStorage (Optional) is the object storage ledger snapshots are copied to by the
+	// snapshot action, and downloaded from when joining a channel from a snapshot
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	SnapshotStorage *BackupObjectStorage `json:"snapshotStorage,omitempty"`
+}
+
+// PeerChannel is a channel the peer joins
+// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+type PeerChannel struct {
+	// Name is the name of the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Name string `json:"name"`
+
+	// OrdererEndpoint is the host:port of an orderer of the channel, used to fetch the
+	// genesis block of the channel and to submit config updates
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	OrdererEndpoint string `json:"ordererEndpoint"`
+
+	// OrdererTLSCACert is the base64 encoded TLS CA certificate of the orderer
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	OrdererTLSCACert string `json:"ordererTLSCACert"`
+
+	// AnchorPeer (Optional) sets the peer as an anchor peer of its organization on the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	AnchorPeer bool `json:"anchorPeer,omitempty"`
+
+	// Snapshot (Optional) is the location of a ledger snapshot of the channel in the snapshot
+	// storage, as reported in the snapshots status of the peer that generated it. The peer
+	// joins the channel from the snapshot instead of the genesis block, requires Fabric 2.3+
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Snapshot string `json:"snapshot,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -154,6 +212,67 @@ type IBPPeerSpec struct {
 // +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
 type IBPPeerStatus struct {
 	CRStatus `json:",inline"`
+
+	// Channels is the join status of every channel listed in spec
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Channels []PeerChannelStatus `json:"channels,omitempty"`
+
+	// Snapshots is the status of the ledger snapshots requested through the snapshot action
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Snapshots []PeerSnapshotStatus `json:"snapshots,omitempty"`
+}
+
+// PeerChannelStatus is the join status of a channel of the peer
+// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+type PeerChannelStatus struct {
+	// Name is the name of the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Name string `json:"name"`
+
+	// Status is the join status of the peer
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Status ChannelNodeStatusType `json:"status"`
+
+	// AnchorPeer is true if the peer is set as an anchor peer of its organization on the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	AnchorPeer bool `json:"anchorPeer,omitempty"`
+
+	// Message provides a message for the status of the channel
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Message string `json:"message,omitempty"`
+}
+
+// PeerSnapshotStatus is the status of a ledger snapshot of a channel of the peer
+// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+type PeerSnapshotStatus struct {
+	// Name is the name of the job that copies the snapshot to the snapshot storage
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Name string `json:"name"`
+
+	// Channel is the channel of the snapshot
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Channel string `json:"channel"`
+
+	// BlockNumber is the block number the snapshot was requested at, 0 is the last
+	// committed block at the time of the request
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	BlockNumber uint64 `json:"blockNumber,omitempty"`
+
+	// Location is the object key of the snapshot archive in the snapshot storage
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Location string `json:"location"`
+
+	// Phase is the phase of the snapshot
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Phase BackupPhase `json:"phase"`
+
+	// RequestTime is the time the snapshot was requested
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	RequestTime metav1.Time `json:"requestTime"`
+
+	// Message provides a message for the status of the snapshot
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	Message string `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -361,6 +480,11 @@ type PeerConnectionProfile struct {
 	// Component is object with ecert crypto material for peer
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	Component *MSP `json:"component"`
+
+	// Replicas are the connection profiles of the replicas of a peer with more than one
+	// replica, keyed by the name of the replica
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Replicas map[string]*PeerConnectionProfile `json:"replicas,omitempty"`
 }
 
 // PeerEndpoints is the list of endpoints to communicate with the peer
@@ -418,6 +542,24 @@ type PeerAction struct {
 	// UpgradeDBs action is used to trigger peer node upgrade-dbs command
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	UpgradeDBs bool `json:"upgradedbs,omitempty"`
+
+	// Snapshot action is used to generate a ledger snapshot of a channel and copy it to the
+	// snapshot storage, requires Fabric 2.3+
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Snapshot PeerSnapshotAction `json:"snapshot,omitempty"`
+}
+
+// PeerSnapshotAction contains actions for generating ledger snapshots
+// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+type PeerSnapshotAction struct {
+	// Channel is the channel to generate a snapshot of, setting it triggers the snapshot
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	Channel string `json:"channel,omitempty"`
+
+	// BlockNumber (Optional) is the block number to generate the snapshot at, defaults to
+	// the last committed block
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	BlockNumber uint64 `json:"blockNumber,omitempty"`
 }
 
 // PeerReenrollAction contains actions for reenrolling crypto
@@ -25,10 +25,73 @@ package v1beta1
 
 import (
 	consolev1 "github.com/IBM-Blockchain/fabric-operator/pkg/apis/console/v1"
-	"k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupComponent) DeepCopyInto(out *BackupComponent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupComponent.
+func (in *BackupComponent) DeepCopy() *BackupComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupImages) DeepCopyInto(out *BackupImages) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupImages.
+func (in *BackupImages) DeepCopy() *BackupImages {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupImages)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupObjectStorage) DeepCopyInto(out *BackupObjectStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupObjectStorage.
+func (in *BackupObjectStorage) DeepCopy() *BackupObjectStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupObjectStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRecord) DeepCopyInto(out *BackupRecord) {
+	*out = *in
+	out.Component = in.Component
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRecord.
+func (in *BackupRecord) DeepCopy() *BackupRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CAAction) DeepCopyInto(out *CAAction) {
 	*out = *in
@@ -142,22 +205,22 @@ func (in *CAResources) DeepCopyInto(out *CAResources) {
 	*out = *in
 	if in.Init != nil {
 		in, out := &in.Init, &out.Init
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.CA != nil {
 		in, out := &in.CA, &out.CA
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.EnrollJob != nil {
 		in, out := &in.EnrollJob, &out.EnrollJob
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.HSMDaemon != nil {
 		in, out := &in.HSMDaemon, &out.HSMDaemon
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -226,6 +289,13 @@ func (in *CRN) DeepCopy() *CRN {
 func (in *CRStatus) DeepCopyInto(out *CRStatus) {
 	*out = *in
 	out.Versions = in.Versions
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRStatus.
@@ -273,6 +343,21 @@ func (in *CSR) DeepCopy() *CSR {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuer) DeepCopyInto(out *CertManagerIssuer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuer.
+func (in *CertManagerIssuer) DeepCopy() *CertManagerIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in ChaincodeBuilderConfig) DeepCopyInto(out *ChaincodeBuilderConfig) {
 	{
@@ -294,6 +379,131 @@ func (in ChaincodeBuilderConfig) DeepCopy() ChaincodeBuilderConfig {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaincodeOrganizationStatus) DeepCopyInto(out *ChaincodeOrganizationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaincodeOrganizationStatus.
+func (in *ChaincodeOrganizationStatus) DeepCopy() *ChaincodeOrganizationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaincodeOrganizationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaincodePeerStatus) DeepCopyInto(out *ChaincodePeerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaincodePeerStatus.
+func (in *ChaincodePeerStatus) DeepCopy() *ChaincodePeerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaincodePeerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelCapabilities) DeepCopyInto(out *ChannelCapabilities) {
+	*out = *in
+	if in.Channel != nil {
+		in, out := &in.Channel, &out.Channel
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Orderer != nil {
+		in, out := &in.Orderer, &out.Orderer
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Application != nil {
+		in, out := &in.Application, &out.Application
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelCapabilities.
+func (in *ChannelCapabilities) DeepCopy() *ChannelCapabilities {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelCapabilities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelNodeStatus) DeepCopyInto(out *ChannelNodeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelNodeStatus.
+func (in *ChannelNodeStatus) DeepCopy() *ChannelNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelOrderer) DeepCopyInto(out *ChannelOrderer) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelOrderer.
+func (in *ChannelOrderer) DeepCopy() *ChannelOrderer {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelOrderer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelOrganization) DeepCopyInto(out *ChannelOrganization) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelOrganization.
+func (in *ChannelOrganization) DeepCopy() *ChannelOrganization {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelOrganization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelPolicy) DeepCopyInto(out *ChannelPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelPolicy.
+func (in *ChannelPolicy) DeepCopy() *ChannelPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigOverride) DeepCopyInto(out *ConfigOverride) {
 	*out = *in
@@ -439,27 +649,27 @@ func (in *ConsoleResources) DeepCopyInto(out *ConsoleResources) {
 	*out = *in
 	if in.Init != nil {
 		in, out := &in.Init, &out.Init
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.CouchDB != nil {
 		in, out := &in.CouchDB, &out.CouchDB
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Console != nil {
 		in, out := &in.Console, &out.Console
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Deployer != nil {
 		in, out := &in.Deployer, &out.Deployer
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Configtxlator != nil {
 		in, out := &in.Configtxlator, &out.Configtxlator
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -495,131 +705,541 @@ func (in *ConsoleStorage) DeepCopy() *ConsoleStorage {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Deployer) DeepCopyInto(out *Deployer) {
+func (in *Deployer) DeepCopyInto(out *Deployer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Deployer.
+func (in *Deployer) DeepCopy() *Deployer {
+	if in == nil {
+		return nil
+	}
+	out := new(Deployer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeployerTimeouts) DeepCopyInto(out *DeployerTimeouts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeployerTimeouts.
+func (in *DeployerTimeouts) DeepCopy() *DeployerTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(DeployerTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Enrollment) DeepCopyInto(out *Enrollment) {
+	*out = *in
+	if in.CATLS != nil {
+		in, out := &in.CATLS, &out.CATLS
+		*out = new(CATLS)
+		**out = **in
+	}
+	if in.AdminCerts != nil {
+		in, out := &in.AdminCerts, &out.AdminCerts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CSR != nil {
+		in, out := &in.CSR, &out.CSR
+		*out = new(CSR)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerIssuer)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Enrollment.
+func (in *Enrollment) DeepCopy() *Enrollment {
+	if in == nil {
+		return nil
+	}
+	out := new(Enrollment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnrollmentSpec) DeepCopyInto(out *EnrollmentSpec) {
+	*out = *in
+	if in.Component != nil {
+		in, out := &in.Component, &out.Component
+		*out = new(Enrollment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(Enrollment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientAuth != nil {
+		in, out := &in.ClientAuth, &out.ClientAuth
+		*out = new(Enrollment)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnrollmentSpec.
+func (in *EnrollmentSpec) DeepCopy() *EnrollmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EnrollmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayReference) DeepCopyInto(out *GatewayReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayReference.
+func (in *GatewayReference) DeepCopy() *GatewayReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HSM) DeepCopyInto(out *HSM) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HSM.
+func (in *HSM) DeepCopy() *HSM {
+	if in == nil {
+		return nil
+	}
+	out := new(HSM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBPBackup) DeepCopyInto(out *IBPBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPBackup.
+func (in *IBPBackup) DeepCopy() *IBPBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(IBPBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IBPBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBPBackupList) DeepCopyInto(out *IBPBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IBPBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPBackupList.
+func (in *IBPBackupList) DeepCopy() *IBPBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(IBPBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IBPBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBPBackupSpec) DeepCopyInto(out *IBPBackupSpec) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]BackupComponent, len(*in))
+		copy(*out, *in)
+	}
+	if in.ObjectStorage != nil {
+		in, out := &in.ObjectStorage, &out.ObjectStorage
+		*out = new(BackupObjectStorage)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = new(BackupImages)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPBackupSpec.
+func (in *IBPBackupSpec) DeepCopy() *IBPBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IBPBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBPBackupStatus) DeepCopyInto(out *IBPBackupStatus) {
+	*out = *in
+	in.CRStatus.DeepCopyInto(&out.CRStatus)
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]BackupRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPBackupStatus.
+func (in *IBPBackupStatus) DeepCopy() *IBPBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IBPBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBPCA) DeepCopyInto(out *IBPCA) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPCA.
+func (in *IBPCA) DeepCopy() *IBPCA {
+	if in == nil {
+		return nil
+	}
+	out := new(IBPCA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IBPCA) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBPCAList) DeepCopyInto(out *IBPCAList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IBPCA, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPCAList.
+func (in *IBPCAList) DeepCopy() *IBPCAList {
+	if in == nil {
+		return nil
+	}
+	out := new(IBPCAList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IBPCAList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBPCASpec) DeepCopyInto(out *IBPCASpec) {
+	*out = *in
+	out.License = in.License
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = new(CAImages)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(CAResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(Service)
+		**out = **in
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(CAStorages)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigOverride != nil {
+		in, out := &in.ConfigOverride, &out.ConfigOverride
+		*out = new(ConfigOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HSM != nil {
+		in, out := &in.HSM, &out.HSM
+		*out = new(HSM)
+		**out = **in
+	}
+	out.CustomNames = in.CustomNames
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(RestoreFrom)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	if in.Arch != nil {
+		in, out := &in.Arch, &out.Arch
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Action = in.Action
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPCASpec.
+func (in *IBPCASpec) DeepCopy() *IBPCASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IBPCASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBPCAStatus) DeepCopyInto(out *IBPCAStatus) {
 	*out = *in
+	in.CRStatus.DeepCopyInto(&out.CRStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Deployer.
-func (in *Deployer) DeepCopy() *Deployer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPCAStatus.
+func (in *IBPCAStatus) DeepCopy() *IBPCAStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Deployer)
+	out := new(IBPCAStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeployerTimeouts) DeepCopyInto(out *DeployerTimeouts) {
+func (in *IBPChaincode) DeepCopyInto(out *IBPChaincode) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeployerTimeouts.
-func (in *DeployerTimeouts) DeepCopy() *DeployerTimeouts {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPChaincode.
+func (in *IBPChaincode) DeepCopy() *IBPChaincode {
 	if in == nil {
 		return nil
 	}
-	out := new(DeployerTimeouts)
+	out := new(IBPChaincode)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IBPChaincode) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Enrollment) DeepCopyInto(out *Enrollment) {
+func (in *IBPChaincodeList) DeepCopyInto(out *IBPChaincodeList) {
 	*out = *in
-	if in.CATLS != nil {
-		in, out := &in.CATLS, &out.CATLS
-		*out = new(CATLS)
-		**out = **in
-	}
-	if in.AdminCerts != nil {
-		in, out := &in.AdminCerts, &out.AdminCerts
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.CSR != nil {
-		in, out := &in.CSR, &out.CSR
-		*out = new(CSR)
-		(*in).DeepCopyInto(*out)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IBPChaincode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Enrollment.
-func (in *Enrollment) DeepCopy() *Enrollment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPChaincodeList.
+func (in *IBPChaincodeList) DeepCopy() *IBPChaincodeList {
 	if in == nil {
 		return nil
 	}
-	out := new(Enrollment)
+	out := new(IBPChaincodeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IBPChaincodeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnrollmentSpec) DeepCopyInto(out *EnrollmentSpec) {
+func (in *IBPChaincodeSpec) DeepCopyInto(out *IBPChaincodeSpec) {
 	*out = *in
-	if in.Component != nil {
-		in, out := &in.Component, &out.Component
-		*out = new(Enrollment)
-		(*in).DeepCopyInto(*out)
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(Enrollment)
-		(*in).DeepCopyInto(*out)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
 	}
-	if in.ClientAuth != nil {
-		in, out := &in.ClientAuth, &out.ClientAuth
-		*out = new(Enrollment)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnrollmentSpec.
-func (in *EnrollmentSpec) DeepCopy() *EnrollmentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPChaincodeSpec.
+func (in *IBPChaincodeSpec) DeepCopy() *IBPChaincodeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EnrollmentSpec)
+	out := new(IBPChaincodeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HSM) DeepCopyInto(out *HSM) {
+func (in *IBPChaincodeStatus) DeepCopyInto(out *IBPChaincodeStatus) {
 	*out = *in
+	in.CRStatus.DeepCopyInto(&out.CRStatus)
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]ChaincodePeerStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]ChaincodeOrganizationStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HSM.
-func (in *HSM) DeepCopy() *HSM {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPChaincodeStatus.
+func (in *IBPChaincodeStatus) DeepCopy() *IBPChaincodeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HSM)
+	out := new(IBPChaincodeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IBPCA) DeepCopyInto(out *IBPCA) {
+func (in *IBPChannel) DeepCopyInto(out *IBPChannel) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPCA.
-func (in *IBPCA) DeepCopy() *IBPCA {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPChannel.
+func (in *IBPChannel) DeepCopy() *IBPChannel {
 	if in == nil {
 		return nil
 	}
-	out := new(IBPCA)
+	out := new(IBPChannel)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IBPCA) DeepCopyObject() runtime.Object {
+func (in *IBPChannel) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -627,31 +1247,31 @@ func (in *IBPCA) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IBPCAList) DeepCopyInto(out *IBPCAList) {
+func (in *IBPChannelList) DeepCopyInto(out *IBPChannelList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]IBPCA, len(*in))
+		*out = make([]IBPChannel, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPCAList.
-func (in *IBPCAList) DeepCopy() *IBPCAList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPChannelList.
+func (in *IBPChannelList) DeepCopy() *IBPChannelList {
 	if in == nil {
 		return nil
 	}
-	out := new(IBPCAList)
+	out := new(IBPChannelList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IBPCAList) DeepCopyObject() runtime.Object {
+func (in *IBPChannelList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -659,81 +1279,55 @@ func (in *IBPCAList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IBPCASpec) DeepCopyInto(out *IBPCASpec) {
+func (in *IBPChannelSpec) DeepCopyInto(out *IBPChannelSpec) {
 	*out = *in
-	out.License = in.License
-	if in.Images != nil {
-		in, out := &in.Images, &out.Images
-		*out = new(CAImages)
-		**out = **in
-	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]string, len(*in))
+	in.Orderer.DeepCopyInto(&out.Orderer)
+	if in.ApplicationOrganizations != nil {
+		in, out := &in.ApplicationOrganizations, &out.ApplicationOrganizations
+		*out = make([]ChannelOrganization, len(*in))
 		copy(*out, *in)
 	}
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(CAResources)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Service != nil {
-		in, out := &in.Service, &out.Service
-		*out = new(Service)
-		**out = **in
-	}
-	if in.Storage != nil {
-		in, out := &in.Storage, &out.Storage
-		*out = new(CAStorages)
-		(*in).DeepCopyInto(*out)
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make(map[string]ChannelPolicy, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.ConfigOverride != nil {
-		in, out := &in.ConfigOverride, &out.ConfigOverride
-		*out = new(ConfigOverride)
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(ChannelCapabilities)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.HSM != nil {
-		in, out := &in.HSM, &out.HSM
-		*out = new(HSM)
-		**out = **in
-	}
-	out.CustomNames = in.CustomNames
-	out.Ingress = in.Ingress
-	if in.Arch != nil {
-		in, out := &in.Arch, &out.Arch
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	out.Action = in.Action
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPCASpec.
-func (in *IBPCASpec) DeepCopy() *IBPCASpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPChannelSpec.
+func (in *IBPChannelSpec) DeepCopy() *IBPChannelSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IBPCASpec)
+	out := new(IBPChannelSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IBPCAStatus) DeepCopyInto(out *IBPCAStatus) {
+func (in *IBPChannelStatus) DeepCopyInto(out *IBPChannelStatus) {
 	*out = *in
-	out.CRStatus = in.CRStatus
+	in.CRStatus.DeepCopyInto(&out.CRStatus)
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]ChannelNodeStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPCAStatus.
-func (in *IBPCAStatus) DeepCopy() *IBPCAStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPChannelStatus.
+func (in *IBPChannelStatus) DeepCopy() *IBPChannelStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(IBPCAStatus)
+	out := new(IBPChannelStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -744,7 +1338,7 @@ func (in *IBPConsole) DeepCopyInto(out *IBPConsole) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPConsole.
@@ -836,7 +1430,7 @@ func (in *IBPConsoleSpec) DeepCopyInto(out *IBPConsoleSpec) {
 		*out = new(NetworkInfo)
 		**out = **in
 	}
-	out.Ingress = in.Ingress
+	in.Ingress.DeepCopyInto(&out.Ingress)
 	if in.ClusterData != nil {
 		in, out := &in.ClusterData, &out.ClusterData
 		*out = new(consolev1.IBPConsoleClusterData)
@@ -912,7 +1506,7 @@ func (in *IBPConsoleSpec) DeepCopy() *IBPConsoleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IBPConsoleStatus) DeepCopyInto(out *IBPConsoleStatus) {
 	*out = *in
-	out.CRStatus = in.CRStatus
+	in.CRStatus.DeepCopyInto(&out.CRStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPConsoleStatus.
@@ -931,7 +1525,7 @@ func (in *IBPOrderer) DeepCopyInto(out *IBPOrderer) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPOrderer.
@@ -1043,6 +1637,11 @@ func (in *IBPOrdererSpec) DeepCopyInto(out *IBPOrdererSpec) {
 		*out = new(SecretSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(RestoreFrom)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ConfigOverride != nil {
 		in, out := &in.ConfigOverride, &out.ConfigOverride
 		*out = new(runtime.RawExtension)
@@ -1058,6 +1657,11 @@ func (in *IBPOrdererSpec) DeepCopyInto(out *IBPOrdererSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.JoinCluster != nil {
+		in, out := &in.JoinCluster, &out.JoinCluster
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ClusterLocation != nil {
 		in, out := &in.ClusterLocation, &out.ClusterLocation
 		*out = make([]IBPOrdererClusterLocation, len(*in))
@@ -1090,7 +1694,7 @@ func (in *IBPOrdererSpec) DeepCopyInto(out *IBPOrdererSpec) {
 		*out = new(int)
 		**out = **in
 	}
-	out.Ingress = in.Ingress
+	in.Ingress.DeepCopyInto(&out.Ingress)
 	if in.Arch != nil {
 		in, out := &in.Arch, &out.Arch
 		*out = make([]string, len(*in))
@@ -1118,7 +1722,12 @@ func (in *IBPOrdererSpec) DeepCopy() *IBPOrdererSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IBPOrdererStatus) DeepCopyInto(out *IBPOrdererStatus) {
 	*out = *in
-	out.CRStatus = in.CRStatus
+	in.CRStatus.DeepCopyInto(&out.CRStatus)
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]OrdererChannelStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPOrdererStatus.
@@ -1137,7 +1746,7 @@ func (in *IBPPeer) DeepCopyInto(out *IBPPeer) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPPeer.
@@ -1209,6 +1818,11 @@ func (in *IBPPeerSpec) DeepCopyInto(out *IBPPeerSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ReplicaNumber != nil {
+		in, out := &in.ReplicaNumber, &out.ReplicaNumber
+		*out = new(int)
+		**out = **in
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(PeerResources)
@@ -1245,7 +1859,12 @@ func (in *IBPPeerSpec) DeepCopyInto(out *IBPPeerSpec) {
 		*out = new(SecretSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	out.Ingress = in.Ingress
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(RestoreFrom)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Ingress.DeepCopyInto(&out.Ingress)
 	if in.Arch != nil {
 		in, out := &in.Arch, &out.Arch
 		*out = make([]string, len(*in))
@@ -1259,6 +1878,16 @@ func (in *IBPPeerSpec) DeepCopyInto(out *IBPPeerSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]PeerChannel, len(*in))
+		copy(*out, *in)
+	}
+	if in.SnapshotStorage != nil {
+		in, out := &in.SnapshotStorage, &out.SnapshotStorage
+		*out = new(BackupObjectStorage)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPPeerSpec.
@@ -1274,7 +1903,19 @@ func (in *IBPPeerSpec) DeepCopy() *IBPPeerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IBPPeerStatus) DeepCopyInto(out *IBPPeerStatus) {
 	*out = *in
-	out.CRStatus = in.CRStatus
+	in.CRStatus.DeepCopyInto(&out.CRStatus)
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]PeerChannelStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]PeerSnapshotStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBPPeerStatus.
@@ -1290,6 +1931,11 @@ func (in *IBPPeerStatus) DeepCopy() *IBPPeerStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Ingress) DeepCopyInto(out *Ingress) {
 	*out = *in
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewayReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ingress.
@@ -1409,6 +2055,21 @@ func (in *OrdererAction) DeepCopy() *OrdererAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrdererChannelStatus) DeepCopyInto(out *OrdererChannelStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrdererChannelStatus.
+func (in *OrdererChannelStatus) DeepCopy() *OrdererChannelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrdererChannelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OrdererConnectionProfile) DeepCopyInto(out *OrdererConnectionProfile) {
 	*out = *in
@@ -1531,27 +2192,27 @@ func (in *OrdererResources) DeepCopyInto(out *OrdererResources) {
 	*out = *in
 	if in.Init != nil {
 		in, out := &in.Init, &out.Init
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Orderer != nil {
 		in, out := &in.Orderer, &out.Orderer
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.GRPCProxy != nil {
 		in, out := &in.GRPCProxy, &out.GRPCProxy
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Enroller != nil {
 		in, out := &in.Enroller, &out.Enroller
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.HSMDaemon != nil {
 		in, out := &in.HSMDaemon, &out.HSMDaemon
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -1591,6 +2252,7 @@ func (in *PeerAction) DeepCopyInto(out *PeerAction) {
 	*out = *in
 	out.Reenroll = in.Reenroll
 	out.Enroll = in.Enroll
+	out.Snapshot = in.Snapshot
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerAction.
@@ -1603,6 +2265,36 @@ func (in *PeerAction) DeepCopy() *PeerAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeerChannel) DeepCopyInto(out *PeerChannel) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerChannel.
+func (in *PeerChannel) DeepCopy() *PeerChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(PeerChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeerChannelStatus) DeepCopyInto(out *PeerChannelStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerChannelStatus.
+func (in *PeerChannelStatus) DeepCopy() *PeerChannelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PeerChannelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PeerConnectionProfile) DeepCopyInto(out *PeerConnectionProfile) {
 	*out = *in
@@ -1617,6 +2309,22 @@ func (in *PeerConnectionProfile) DeepCopyInto(out *PeerConnectionProfile) {
 		*out = new(MSP)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make(map[string]*PeerConnectionProfile, len(*in))
+		for key, val := range *in {
+			var outVal *PeerConnectionProfile
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(PeerConnectionProfile)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerConnectionProfile.
@@ -1725,37 +2433,37 @@ func (in *PeerResources) DeepCopyInto(out *PeerResources) {
 	*out = *in
 	if in.Init != nil {
 		in, out := &in.Init, &out.Init
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Peer != nil {
 		in, out := &in.Peer, &out.Peer
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.GRPCProxy != nil {
 		in, out := &in.GRPCProxy, &out.GRPCProxy
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.CouchDB != nil {
 		in, out := &in.CouchDB, &out.CouchDB
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.CCLauncher != nil {
 		in, out := &in.CCLauncher, &out.CCLauncher
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Enroller != nil {
 		in, out := &in.Enroller, &out.Enroller
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.HSMDaemon != nil {
 		in, out := &in.HSMDaemon, &out.HSMDaemon
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -1770,6 +2478,37 @@ func (in *PeerResources) DeepCopy() *PeerResources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeerSnapshotAction) DeepCopyInto(out *PeerSnapshotAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerSnapshotAction.
+func (in *PeerSnapshotAction) DeepCopy() *PeerSnapshotAction {
+	if in == nil {
+		return nil
+	}
+	out := new(PeerSnapshotAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeerSnapshotStatus) DeepCopyInto(out *PeerSnapshotStatus) {
+	*out = *in
+	in.RequestTime.DeepCopyInto(&out.RequestTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerSnapshotStatus.
+func (in *PeerSnapshotStatus) DeepCopy() *PeerSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PeerSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PeerStorages) DeepCopyInto(out *PeerStorages) {
 	*out = *in
@@ -1810,6 +2549,25 @@ func (in *Renew) DeepCopy() *Renew {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreFrom) DeepCopyInto(out *RestoreFrom) {
+	*out = *in
+	if in.Before != nil {
+		in, out := &in.Before, &out.Before
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreFrom.
+func (in *RestoreFrom) DeepCopy() *RestoreFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretSpec) DeepCopyInto(out *SecretSpec) {
 	*out = *in
@@ -24,10 +24,16 @@ import (
 	"reflect"
 
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/conditions"
+	"github.com/IBM-Blockchain/fabric-operator/version"
 
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+var log = logf.Log.WithName("controller_common")
+
 const (
 	IBPCA      = "IBPCA"
 	IBPPEER    = "IBPPeer"
@@ -143,3 +149,29 @@ func MSPInfoUpdateDetected(oldSecret, newSecret *current.SecretSpec) bool {
 
 	return false
 }
+
+//go:generate counterfeiter -o ../mocks/restartmanager.go -fake-name RestartManager . RestartManager
+
+// RestartManager returns the reasons of the restart requests of an instance that
+// have not been completed yet
+type RestartManager interface {
+	PendingReasons(instance v1.Object) ([]string, error)
+}
+
+// SetConditions sets the observed generation of the status and the conditions shared
+// by all components, Ready, MigrationComplete and RestartPending. The conditions specific
+// to a component are set by its controller.
+func SetConditions(instance v1.Object, status *current.CRStatus, restartManager RestartManager) {
+	generation := instance.GetGeneration()
+	status.ObservedGeneration = generation
+
+	conditions.SetReady(status, generation)
+	conditions.SetMigrationComplete(status, generation, version.Operator, version.String(status.Version).Equal(version.Operator))
+
+	reasons, err := restartManager.PendingReasons(instance)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("failed to get pending restart requests of '%s', not updating %s condition", instance.GetName(), current.RestartPendingCondition))
+		return
+	}
+	conditions.SetRestartPending(status, generation, reasons)
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connectionprofile
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/connectionprofile"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/global"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/metrics"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_connectionprofile")
+
+// Add creates a new connection profile Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, config *config.Config) error {
+	r, err := newReconciler(mgr, config)
+	if err != nil {
+		return err
+	}
+	return add(mgr, r)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, cfg *config.Config) (*ReconcileConnectionProfile, error) {
+	client := k8sclient.New(mgr.GetClient(), &global.ConfigSetter{Config: cfg.Operator.Globals})
+
+	return &ReconcileConnectionProfile{
+		Config:            cfg,
+		ConnectionProfile: connectionprofile.New(client),
+	}, nil
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r *ReconcileConnectionProfile) error {
+	// Create a new controller
+	c, err := controller.New("connectionprofile-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the connection profiles of the peers, orderers and CAs, they are
+	// updated when endpoints change or certificates are renewed. Every change requeues the
+	// namespace of the component.
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(NamespaceRequest), predicate.NewPredicateFuncs(IsComponentProfile))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IsComponentProfile returns true if the object is the connection profile configmap of a component
+func IsComponentProfile(obj client.Object) bool {
+	return strings.HasSuffix(obj.GetName(), connectionprofile.ComponentSuffix)
+}
+
+// NamespaceRequest maps an object to a request for its namespace
+func NamespaceRequest(obj client.Object) []reconcile.Request {
+	return []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace()}},
+	}
+}
+
+var _ reconcile.Reconciler = &ReconcileConnectionProfile{}
+
+//go:generate counterfeiter -o mocks/connectionprofilereconcile.go -fake-name ConnectionProfileReconcile . connectionProfileReconcile
+
+type connectionProfileReconcile interface {
+	Reconcile(namespace string) error
+}
+
+// ReconcileConnectionProfile reconciles the connection profiles of the organizations in a namespace
+type ReconcileConnectionProfile struct {
+	ConnectionProfile connectionProfileReconcile
+	Config            *config.Config
+}
+
+// Reconcile aggregates the connection profiles of the peers, orderers and CAs of the namespace
+// of the request into a common connection profile per organization
+// Note:
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileConnectionProfile) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := r.Config.Logger.With(
+		zap.String("Request.Namespace", request.Namespace),
+	)
+	reqLogger.Info("Reconciling connection profiles")
+
+	err := r.ConnectionProfile.Reconcile(request.Namespace)
+	metrics.RecordReconcile("connectionprofile", err)
+	if err != nil {
+		return reconcile.Result{}, operatorerrors.IsBreakingError(errors.Wrapf(err, "connection profiles of namespace '%s' encountered error", request.Namespace), "stopping reconcile loop", log)
+	}
+
+	reqLogger.Info(fmt.Sprintf("Finished reconciling connection profiles of namespace '%s'", request.Namespace))
+	return reconcile.Result{}, nil
+}
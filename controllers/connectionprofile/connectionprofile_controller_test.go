@@ -0,0 +1,88 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connectionprofile
+
+import (
+	"context"
+
+	"github.com/IBM-Blockchain/fabric-operator/controllers/connectionprofile/mocks"
+	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("ReconcileConnectionProfile", func() {
+	var (
+		reconciler                     *ReconcileConnectionProfile
+		mockConnectionProfileReconcile *mocks.ConnectionProfileReconcile
+		request                        reconcile.Request
+	)
+
+	BeforeEach(func() {
+		mockConnectionProfileReconcile = &mocks.ConnectionProfileReconcile{}
+		logger, err := util.SetupLogging("INFO")
+		Expect(err).NotTo(HaveOccurred())
+
+		reconciler = &ReconcileConnectionProfile{
+			ConnectionProfile: mockConnectionProfileReconcile,
+			Config: &config.Config{
+				Logger: logger,
+			},
+		}
+		request = reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: "test-namespace"},
+		}
+	})
+
+	Context("reconciles", func() {
+		It("reconciles the connection profiles of the namespace of the request", func() {
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockConnectionProfileReconcile.ReconcileCallCount()).To(Equal(1))
+			Expect(mockConnectionProfileReconcile.ReconcileArgsForCall(0)).To(Equal("test-namespace"))
+		})
+
+		It("returns an error if the connection profiles fail to reconcile", func() {
+			mockConnectionProfileReconcile.ReconcileReturns(errors.New("list error"))
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("list error"))
+		})
+	})
+
+	Context("watches", func() {
+		It("only watches the connection profiles of components", func() {
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "peer1-connection-profile", Namespace: "test-namespace"}}
+			Expect(IsComponentProfile(cm)).To(Equal(true))
+			cm.Name = "connection-org1msp"
+			Expect(IsComponentProfile(cm)).To(Equal(false))
+		})
+
+		It("requeues the namespace of the connection profile", func() {
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "peer1-connection-profile", Namespace: "test-namespace"}}
+			Expect(NamespaceRequest(cm)).To(Equal([]reconcile.Request{request}))
+		})
+	})
+})
@@ -0,0 +1,283 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ibpbackup
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/backup"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/global"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/metrics"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	batchv1 "k8s.io/api/batch/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_ibpbackup")
+
+// Add creates a new IBPBackup Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, config *config.Config) error {
+	r, err := newReconciler(mgr, config)
+	if err != nil {
+		return err
+	}
+	return add(mgr, r)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, cfg *config.Config) (*ReconcileIBPBackup, error) {
+	client := k8sclient.New(mgr.GetClient(), &global.ConfigSetter{Config: cfg.Operator.Globals})
+	scheme := mgr.GetScheme()
+
+	return &ReconcileIBPBackup{
+		client: client,
+		scheme: scheme,
+		Config: cfg,
+		Backup: backup.New(client, scheme, cfg.Operator.Backup.Images),
+	}, nil
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r *ReconcileIBPBackup) error {
+	// Create a new controller
+	predicateFuncs := predicate.Funcs{
+		CreateFunc: r.CreateFunc,
+		UpdateFunc: r.UpdateFunc,
+	}
+
+	c, err := controller.New("ibpbackup-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource IBPBackup
+	err = c.Watch(&source.Kind{Type: &current.IBPBackup{}}, &handler.EnqueueRequestForObject{}, predicateFuncs)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the archive jobs to update the phase of the backups as soon as they finish
+	err = c.Watch(&source.Kind{Type: &batchv1.Job{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &current.IBPBackup{},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileIBPBackup{}
+
+//go:generate counterfeiter -o mocks/backupreconcile.go -fake-name BackupReconcile . backupReconcile
+
+type backupReconcile interface {
+	Reconcile(*current.IBPBackup) (*backup.Result, error)
+}
+
+// ReconcileIBPBackup reconciles a IBPBackup object
+type ReconcileIBPBackup struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client k8sclient.Client
+	scheme *runtime.Scheme
+
+	Backup backupReconcile
+	Config *config.Config
+}
+
+// Reconcile reads that state of the cluster for a IBPBackup object and backs up the volumes
+// of the components listed in the IBPBackup.Spec when the schedule is due
+// Note:
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileIBPBackup) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	var err error
+
+	reqLogger := r.Config.Logger.With(
+		zap.String("Request.Namespace", request.Namespace),
+		zap.String("Request.Name", request.Name),
+	)
+	reqLogger.Info("Reconciling IBPBackup")
+
+	// Fetch the IBPBackup instance
+	instance := &current.IBPBackup{}
+	err = r.client.Get(context.TODO(), request.NamespacedName, instance)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	result, err := r.Backup.Reconcile(instance)
+	metrics.RecordReconcile("ibpbackup", err)
+	setStatusErr := r.SetStatus(instance, result, err)
+	if setStatusErr != nil {
+		return reconcile.Result{}, operatorerrors.IsBreakingError(setStatusErr, "failed to update status", log)
+	}
+
+	if err != nil {
+		return reconcile.Result{}, operatorerrors.IsBreakingError(errors.Wrapf(err, "Backup instance '%s' encountered error", instance.GetName()), "stopping reconcile loop", log)
+	}
+
+	reqLogger.Info(fmt.Sprintf("Finished reconciling IBPBackup '%s'", instance.GetName()))
+	return reconcile.Result{RequeueAfter: result.RequeueAfter}, nil
+}
+
+// SetStatus updates the status of the backup with the backups in progress and the
+// backups kept by the retention
+func (r *ReconcileIBPBackup) SetStatus(instance *current.IBPBackup, result *backup.Result, reconcileErr error) error {
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}, instance)
+	if err != nil {
+		return err
+	}
+
+	status := instance.Status.DeepCopy()
+
+	if reconcileErr != nil {
+		status.Type = current.Error
+		status.Status = current.True
+		status.Reason = "errorOccurredDuringReconcile"
+		status.Message = reconcileErr.Error()
+		status.ErrorCode = operatorerrors.GetErrorCode(reconcileErr)
+	} else {
+		status.LastScheduleTime = result.LastScheduleTime
+		status.Backups = result.Backups
+
+		failed := latestFailed(result.Backups)
+		status.ErrorCode = 0
+		status.Status = current.True
+		switch {
+		case len(failed) > 0:
+			status.Type = current.Warning
+			status.Reason = "backupFailed"
+			status.Message = fmt.Sprintf("Latest backup failed for volumes: %s", strings.Join(failed, ", "))
+		case len(status.Pending()) > 0:
+			status.Type = current.Deploying
+			status.Reason = "backupInProgress"
+			status.Message = ""
+		default:
+			status.Type = current.Deployed
+			status.Reason = "backupsScheduled"
+			status.Message = ""
+		}
+
+		if instance.Status.CRStatus.Type == status.Type &&
+			reflect.DeepEqual(instance.Status.LastScheduleTime, status.LastScheduleTime) &&
+			reflect.DeepEqual(instance.Status.Backups, status.Backups) {
+			return nil
+		}
+	}
+
+	instance.Status = *status
+	instance.Status.LastHeartbeatTime = time.Now().String()
+	log.Info(fmt.Sprintf("Updating status of IBPBackup custom resource to %s phase", instance.Status.Type))
+	err = r.client.PatchStatus(context.TODO(), instance, nil, k8sclient.PatchOption{
+		Resilient: &k8sclient.ResilientPatch{
+			Retry:    2,
+			Into:     &current.IBPBackup{},
+			Strategy: client.MergeFrom,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// latestFailed returns the volumes whose latest finished backup failed
+func latestFailed(backups []current.BackupRecord) []string {
+	latest := map[string]current.BackupRecord{}
+	volumes := []string{}
+	for _, backup := range backups {
+		if backup.Phase == current.BackupPending {
+			continue
+		}
+		previous, found := latest[backup.Volume]
+		if !found {
+			volumes = append(volumes, backup.Volume)
+		}
+		if !found || !backup.StartTime.Before(&previous.StartTime) {
+			latest[backup.Volume] = backup
+		}
+	}
+
+	failed := []string{}
+	for _, volume := range volumes {
+		if latest[volume].Phase == current.BackupFailed {
+			failed = append(failed, volume)
+		}
+	}
+	return failed
+}
+
+// CreateFunc always triggers a reconcile, on operator restart this schedules the
+// backups that were missed while the operator was not running
+func (r *ReconcileIBPBackup) CreateFunc(e event.CreateEvent) bool {
+	return true
+}
+
+func (r *ReconcileIBPBackup) UpdateFunc(e event.UpdateEvent) bool {
+	oldBackup := e.ObjectOld.(*current.IBPBackup)
+	newBackup := e.ObjectNew.(*current.IBPBackup)
+
+	if reflect.DeepEqual(oldBackup.Spec, newBackup.Spec) {
+		return false
+	}
+
+	log.Info(fmt.Sprintf("Spec update detected on IBPBackup custom resource: %s", oldBackup.Name))
+	return true
+}
+
+func (r *ReconcileIBPBackup) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&current.IBPBackup{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ibpbackup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	backupmocks "github.com/IBM-Blockchain/fabric-operator/controllers/ibpbackup/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/backup"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("ReconcileIBPBackup", func() {
+	var (
+		reconciler          *ReconcileIBPBackup
+		request             reconcile.Request
+		mockKubeClient      *mocks.Client
+		mockBackupReconcile *backupmocks.BackupReconcile
+		instance            *current.IBPBackup
+		now                 metav1.Time
+	)
+
+	BeforeEach(func() {
+		mockKubeClient = &mocks.Client{}
+		mockBackupReconcile = &backupmocks.BackupReconcile{}
+		instance = &current.IBPBackup{
+			Spec: current.IBPBackupSpec{
+				Schedule: "0 2 * * *",
+				Method:   current.BackupSnapshot,
+				Components: []current.BackupComponent{
+					{Kind: "IBPPeer", Name: "peer1"},
+				},
+			},
+		}
+		instance.Name = "test-backup"
+		instance.Namespace = "test-namespace"
+		now = metav1.NewTime(time.Date(2026, 1, 2, 2, 0, 0, 0, time.UTC))
+
+		mockKubeClient.GetStub = func(ctx context.Context, types types.NamespacedName, obj client.Object) error {
+			switch obj.(type) {
+			case *current.IBPBackup:
+				o := obj.(*current.IBPBackup)
+				o.Kind = "IBPBackup"
+				o.Spec = instance.Spec
+				o.Name = instance.Name
+				o.Status = instance.Status
+			}
+			return nil
+		}
+
+		mockBackupReconcile.ReconcileReturns(&backup.Result{
+			LastScheduleTime: &now,
+			Backups: []current.BackupRecord{
+				{Name: "test-backup-peer1-pvc-1", Volume: "peer1-pvc", Phase: current.BackupCompleted, StartTime: now},
+			},
+			RequeueAfter: 24 * time.Hour,
+		}, nil)
+
+		reconciler = &ReconcileIBPBackup{
+			Config: &config.Config{},
+			Backup: mockBackupReconcile,
+			client: mockKubeClient,
+			scheme: &runtime.Scheme{},
+		}
+		zaplogger, _ := util.SetupLogging("DEBUG")
+		reconciler.Config.Logger = zaplogger
+		request = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: "test-namespace",
+				Name:      "test-backup",
+			},
+		}
+	})
+
+	Context("Reconciles", func() {
+		It("does not return an error if the custom resource is 'not found'", func() {
+			notFoundErr := &k8serror.StatusError{
+				ErrStatus: metav1.Status{
+					Reason: metav1.StatusReasonNotFound,
+				},
+			}
+			mockKubeClient.GetReturns(notFoundErr)
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error if it encountered a non-breaking error", func() {
+			errMsg := "failed to create job"
+			mockBackupReconcile.ReconcileReturns(nil, errors.New(errMsg))
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(fmt.Sprintf("Backup instance '%s' encountered error: %s", instance.Name, errMsg)))
+		})
+
+		It("does not return an error if it encountered a breaking error", func() {
+			mockBackupReconcile.ReconcileReturns(nil, operatorerrors.New(operatorerrors.InvalidCustomResourceCreateRequest, "invalid schedule"))
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("requeues for the next scheduled backup", func() {
+			result, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(24 * time.Hour))
+		})
+	})
+
+	Context("set status", func() {
+		var result *backup.Result
+
+		BeforeEach(func() {
+			result = &backup.Result{
+				LastScheduleTime: &now,
+				Backups: []current.BackupRecord{
+					{Name: "backup-1", Volume: "peer1-pvc", Phase: current.BackupCompleted, StartTime: metav1.NewTime(now.Add(-24 * time.Hour))},
+					{Name: "backup-2", Volume: "peer1-pvc", Phase: current.BackupCompleted, StartTime: now},
+				},
+			}
+		})
+
+		It("sets the status to error if error occured during IBPBackup reconciliation", func() {
+			err := reconciler.SetStatus(instance, nil, errors.New("ibpbackup error"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Type).To(Equal(current.Error))
+			Expect(instance.Status.Message).To(Equal("ibpbackup error"))
+		})
+
+		It("sets the status to warning if the latest backup of a volume failed", func() {
+			result.Backups[1].Phase = current.BackupFailed
+			err := reconciler.SetStatus(instance, result, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Type).To(Equal(current.Warning))
+			Expect(instance.Status.Message).To(ContainSubstring("peer1-pvc"))
+		})
+
+		It("does not set the status to warning if an older backup failed", func() {
+			result.Backups[0].Phase = current.BackupFailed
+			err := reconciler.SetStatus(instance, result, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Type).To(Equal(current.Deployed))
+		})
+
+		It("sets the status to deploying if a backup is in progress", func() {
+			result.Backups[1].Phase = current.BackupPending
+			err := reconciler.SetStatus(instance, result, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Type).To(Equal(current.Deploying))
+		})
+
+		It("sets the status to deployed if every backup completed", func() {
+			err := reconciler.SetStatus(instance, result, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Type).To(Equal(current.Deployed))
+			Expect(instance.Status.LastScheduleTime).To(Equal(&now))
+			Expect(instance.Status.Backups).To(HaveLen(2))
+			Expect(mockKubeClient.PatchStatusCallCount()).To(Equal(1))
+		})
+
+		It("does not patch the status if nothing changed", func() {
+			instance.Status = current.IBPBackupStatus{
+				CRStatus: current.CRStatus{
+					Type: current.Deployed,
+				},
+				LastScheduleTime: result.LastScheduleTime,
+				Backups:          result.Backups,
+			}
+			err := reconciler.SetStatus(instance, result, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.PatchStatusCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("update func predicate", func() {
+		var (
+			newInstance *current.IBPBackup
+			e           event.UpdateEvent
+		)
+
+		BeforeEach(func() {
+			newInstance = instance.DeepCopy()
+			e = event.UpdateEvent{
+				ObjectOld: instance,
+				ObjectNew: newInstance,
+			}
+		})
+
+		It("returns false if spec did not change", func() {
+			newInstance.Status.Type = current.Deployed
+			Expect(reconciler.UpdateFunc(e)).To(Equal(false))
+		})
+
+		It("returns true if spec changed", func() {
+			newInstance.Spec.Schedule = "@hourly"
+			Expect(reconciler.UpdateFunc(e)).To(Equal(true))
+		})
+	})
+})
@@ -30,19 +30,24 @@ import (
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
 	commoncontroller "github.com/IBM-Blockchain/fabric-operator/controllers/common"
 	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/conditions"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/events"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/global"
 	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/metrics"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering"
 	baseca "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/ca"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
 	k8sca "github.com/IBM-Blockchain/fabric-operator/pkg/offering/k8s/ca"
 	openshiftca "github.com/IBM-Blockchain/fabric-operator/pkg/offering/openshift/ca"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/restart"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/restart/staggerrestarts"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
 	"github.com/go-test/deep"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
 	ctrl "sigs.k8s.io/controller-runtime"
 	yaml "sigs.k8s.io/yaml"
 
@@ -53,6 +58,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -84,21 +90,24 @@ func Add(mgr manager.Manager, cfg *config.Config) error {
 func newReconciler(mgr manager.Manager, cfg *config.Config) (*ReconcileIBPCA, error) {
 	client := k8sclient.New(mgr.GetClient(), &global.ConfigSetter{Config: cfg.Operator.Globals})
 	scheme := mgr.GetScheme()
+	recorder := mgr.GetEventRecorderFor("ibpca-controller")
 
 	ibpca := &ReconcileIBPCA{
 		client:         client,
 		scheme:         scheme,
 		Config:         cfg,
+		Recorder:       recorder,
 		update:         map[string][]Update{},
 		mutex:          &sync.Mutex{},
 		RestartService: staggerrestarts.New(client, cfg.Operator.Restart.Timeout.Get()),
+		RestartManager: restart.New(client, recorder, cfg.Operator.Restart.WaitTime.Get(), cfg.Operator.Restart.Timeout.Get()),
 	}
 
 	switch cfg.Offering {
 	case offering.K8S:
-		ibpca.Offering = k8sca.New(client, scheme, cfg)
+		ibpca.Offering = k8sca.New(client, scheme, cfg, recorder)
 	case offering.OPENSHIFT:
-		ibpca.Offering = openshiftca.New(client, scheme, cfg)
+		ibpca.Offering = openshiftca.New(client, scheme, cfg, recorder)
 	}
 
 	return ibpca, nil
@@ -167,6 +176,8 @@ type ReconcileIBPCA struct {
 	Offering       caReconcile
 	Config         *config.Config
 	RestartService *staggerrestarts.StaggerRestartsService
+	RestartManager commoncontroller.RestartManager
+	Recorder       record.EventRecorder
 
 	update map[string][]Update
 	mutex  *sync.Mutex
@@ -190,6 +201,7 @@ type ReconcileIBPCA struct {
 // +kubebuilder:rbac:groups=ibp.com,resources=ibpcas.ibp.com;ibppeers.ibp.com;ibporderers.ibp.com;ibpcas;ibppeers;ibporderers;ibpconsoles;ibpcas/finalizers;ibppeer/finalizers;ibporderers/finalizers;ibpconsole/finalizers;ibpcas/status;ibppeers/status;ibporderers/status;ibpconsoles/status,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=extensions;networking.k8s.io;config.openshift.io,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete;deletecollection
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 func (r *ReconcileIBPCA) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	var err error
 
@@ -222,6 +234,7 @@ func (r *ReconcileIBPCA) Reconcile(ctx context.Context, request reconcile.Reques
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			metrics.DeleteInstance(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -234,12 +247,14 @@ func (r *ReconcileIBPCA) Reconcile(ctx context.Context, request reconcile.Reques
 	reqLogger.Info(fmt.Sprintf("Reconciling IBPCA '%s' with update values of [ %+v ]", instance.GetName(), update.GetUpdateStackWithTrues()))
 
 	result, err := r.Offering.Reconcile(instance, r.PopUpdate(instance.GetName()))
+	metrics.RecordReconcile("ibpca", err)
 	setStatusErr := r.SetStatus(instance, result.Status, err)
 	if setStatusErr != nil {
 		return reconcile.Result{}, operatorerrors.IsBreakingError(setStatusErr, "failed to update status", log)
 	}
 
 	if err != nil {
+		events.Error(r.Recorder, instance, err)
 		return reconcile.Result{}, operatorerrors.IsBreakingError(errors.Wrapf(err, "CA instance '%s' encountered error", instance.GetName()), "stopping reconcile loop", log)
 	}
 
@@ -276,94 +291,62 @@ func (r *ReconcileIBPCA) SetStatus(instance *current.IBPCA, reconcileStatus *cur
 		return err
 	}
 
-	status := instance.Status.CRStatus
+	status := *instance.Status.CRStatus.DeepCopy()
 
 	if reconcileErr != nil {
 		status.Type = current.Error
 		status.Status = current.True
 		status.Reason = "errorOccurredDuringReconcile"
 		status.Message = reconcileErr.Error()
-		status.LastHeartbeatTime = time.Now().String()
 		status.ErrorCode = operatorerrors.GetErrorCode(reconcileErr)
+	} else {
+		status.Versions.Reconciled = instance.Spec.FabricVersion
 
-		instance.Status = current.IBPCAStatus{
-			CRStatus: status,
-		}
-
-		log.Info(fmt.Sprintf("Updating status of IBPCA custom resource to %s phase", instance.Status.Type))
-		err = r.client.PatchStatus(context.TODO(), instance, nil, k8sclient.PatchOption{
-			Resilient: &k8sclient.ResilientPatch{
-				Retry:    2,
-				Into:     &current.IBPCA{},
-				Strategy: client.MergeFrom,
-			},
-		})
-		if err != nil {
-			return err
-		}
-
-		return nil
-	}
-
-	status.Versions.Reconciled = instance.Spec.FabricVersion
-
-	// Check if reconcile loop returned an updated status that differs from exisiting status.
-	// If so, set status to the reconcile status.
-	if reconcileStatus != nil {
-		if instance.Status.Type != reconcileStatus.Type || instance.Status.Reason != reconcileStatus.Reason || instance.Status.Message != reconcileStatus.Message {
+		// Check if reconcile loop returned an updated status that differs from exisiting status.
+		// If so, set status to the reconcile status.
+		if reconcileStatus != nil && (instance.Status.Type != reconcileStatus.Type || instance.Status.Reason != reconcileStatus.Reason || instance.Status.Message != reconcileStatus.Message) {
 			status.Type = reconcileStatus.Type
 			status.Status = current.True
 			status.Reason = reconcileStatus.Reason
 			status.Message = reconcileStatus.Message
-			status.LastHeartbeatTime = time.Now().String()
-
-			instance.Status = current.IBPCAStatus{
-				CRStatus: status,
-			}
-
-			log.Info(fmt.Sprintf("Updating status of IBPPeer custom resource to %s phase", instance.Status.Type))
-			err := r.client.PatchStatus(context.TODO(), instance, nil, k8sclient.PatchOption{
-				Resilient: &k8sclient.ResilientPatch{
-					Retry:    2,
-					Into:     &current.IBPCA{},
-					Strategy: client.MergeFrom,
-				},
-			})
+		} else {
+			running, err := r.PodsRunning(instance)
 			if err != nil {
 				return err
 			}
+			conditions.SetDeploymentAvailable(&status, instance.GetGeneration(), running)
+
+			if running {
+				if instance.Status.Type != current.Deployed {
+					status.Type = current.Deployed
+					status.Status = current.True
+					status.Reason = "allPodsRunning"
+					status.Message = "All pods running"
+				}
+			} else if instance.Status.Type != current.Deploying {
+				status.Type = current.Deploying
+				status.Status = current.True
+				status.Reason = "waitingForPods"
+				status.Message = "Waiting for pods"
+			}
+		}
 
-			return nil
+		if reconcileStatus != nil {
+			conditions.SetCertificatesValid(&status, instance.GetGeneration(), reconcileStatus)
 		}
 	}
 
-	running, err := r.PodsRunning(instance)
-	if err != nil {
-		return err
-	}
+	commoncontroller.SetConditions(instance, &status, r.RestartManager)
 
-	if running {
-		if instance.Status.Type == current.Deployed {
-			return nil
-		}
-		status.Type = current.Deployed
-		status.Status = current.True
-		status.Reason = "allPodsRunning"
-		status.Message = "All pods running"
-	} else {
-		if instance.Status.Type == current.Deploying {
-			return nil
-		}
-		status.Type = current.Deploying
-		status.Status = current.True
-		status.Reason = "waitingForPods"
-		status.Message = "Waiting for pods"
+	// Errors are always reported to refresh the heartbeat, otherwise the status is
+	// only updated if it changed
+	if reconcileErr == nil && equality.Semantic.DeepEqual(instance.Status.CRStatus, status) {
+		return nil
 	}
 
-	instance.Status = current.IBPCAStatus{
-		CRStatus: status,
-	}
-	instance.Status.LastHeartbeatTime = time.Now().String()
+	status.LastHeartbeatTime = time.Now().String()
+	instance.Status.CRStatus = status
+
 	log.Info(fmt.Sprintf("Updating status of IBPCA custom resource to %s phase", instance.Status.Type))
 	err = r.client.PatchStatus(context.TODO(), instance, nil, k8sclient.PatchOption{
 		Resilient: &k8sclient.ResilientPatch{
@@ -38,9 +38,11 @@ import (
 	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
 	corev1 "k8s.io/api/core/v1"
 	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -52,12 +54,15 @@ var _ = Describe("ReconcileIBPCA", func() {
 		request         reconcile.Request
 		mockKubeClient  *mocks.Client
 		mockCAReconcile *camocks.CAReconcile
+		mockRestart     *mocks.RestartManager
 		instance        *current.IBPCA
+		recorder        *record.FakeRecorder
 	)
 
 	BeforeEach(func() {
 		mockKubeClient = &mocks.Client{}
 		mockCAReconcile = &camocks.CAReconcile{}
+		mockRestart = &mocks.RestartManager{}
 		instance = &current.IBPCA{
 			Spec: current.IBPCASpec{},
 		}
@@ -111,13 +116,16 @@ var _ = Describe("ReconcileIBPCA", func() {
 			return nil
 		}
 
+		recorder = record.NewFakeRecorder(10)
 		reconciler = &ReconcileIBPCA{
-			Offering: mockCAReconcile,
-			client:   mockKubeClient,
-			scheme:   &runtime.Scheme{},
-			update:   map[string][]Update{},
-			mutex:    &sync.Mutex{},
-			Config:   &opconfig.Config{},
+			Recorder:       recorder,
+			Offering:       mockCAReconcile,
+			RestartManager: mockRestart,
+			client:         mockKubeClient,
+			scheme:         &runtime.Scheme{},
+			update:         map[string][]Update{},
+			mutex:          &sync.Mutex{},
+			Config:         &opconfig.Config{},
 		}
 		zaplogger, _ := util.SetupLogging("DEBUG")
 		reconciler.Config.Logger = zaplogger
@@ -167,6 +175,13 @@ var _ = Describe("ReconcileIBPCA", func() {
 			_, err := reconciler.Reconcile(context.TODO(), request)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("records a warning event if it encountered an error", func() {
+			mockCAReconcile.ReconcileReturns(common.Result{}, operatorerrors.New(operatorerrors.InvalidDeploymentCreateRequest, "invalid request"))
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning InvalidDeploymentCreateRequest ")))
+		})
 	})
 
 	Context("update reconcile", func() {
@@ -303,6 +318,22 @@ var _ = Describe("ReconcileIBPCA", func() {
 			reconciler.SetStatus(instance, nil, nil)
 			Expect(instance.Status.Type).To(Equal(current.Deployed))
 		})
+
+		It("sets the conditions of the status", func() {
+			mockKubeClient.ListStub = func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+				podList := obj.(*corev1.PodList)
+				pod := corev1.Pod{}
+				podList.Items = append(podList.Items, pod)
+				return nil
+			}
+			mockRestart.PendingReasonsReturns([]string{"tlsUpdate"}, nil)
+
+			err := reconciler.SetStatus(instance, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.IsStatusConditionFalse(instance.Status.Conditions, current.ReadyCondition)).To(Equal(true))
+			Expect(meta.IsStatusConditionFalse(instance.Status.Conditions, current.DeploymentAvailableCondition)).To(Equal(true))
+			Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, current.RestartPendingCondition)).To(Equal(true))
+		})
 	})
 
 	Context("add owner reference to secret", func() {
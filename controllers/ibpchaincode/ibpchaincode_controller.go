@@ -0,0 +1,269 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ibpchaincode
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/chaincode"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/global"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/metrics"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_ibpchaincode")
+
+// RequeueInterval is the interval at which chaincodes that are not committed yet
+// are reconciled again
+const RequeueInterval = 1 * time.Minute
+
+// Add creates a new IBPChaincode Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, config *config.Config) error {
+	r, err := newReconciler(mgr, config)
+	if err != nil {
+		return err
+	}
+	return add(mgr, r)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, cfg *config.Config) (*ReconcileIBPChaincode, error) {
+	client := k8sclient.New(mgr.GetClient(), &global.ConfigSetter{Config: cfg.Operator.Globals})
+	scheme := mgr.GetScheme()
+
+	return &ReconcileIBPChaincode{
+		client:    client,
+		scheme:    scheme,
+		Config:    cfg,
+		Chaincode: chaincode.New(client, scheme),
+	}, nil
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r *ReconcileIBPChaincode) error {
+	// Create a new controller
+	predicateFuncs := predicate.Funcs{
+		CreateFunc: r.CreateFunc,
+		UpdateFunc: r.UpdateFunc,
+	}
+
+	c, err := controller.New("ibpchaincode-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource IBPChaincode
+	err = c.Watch(&source.Kind{Type: &current.IBPChaincode{}}, &handler.EnqueueRequestForObject{}, predicateFuncs)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileIBPChaincode{}
+
+//go:generate counterfeiter -o mocks/chaincodereconcile.go -fake-name ChaincodeReconcile . chaincodeReconcile
+
+type chaincodeReconcile interface {
+	Reconcile(*current.IBPChaincode) (*current.IBPChaincodeStatus, error)
+}
+
+// ReconcileIBPChaincode reconciles a IBPChaincode object
+type ReconcileIBPChaincode struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client k8sclient.Client
+	scheme *runtime.Scheme
+
+	Chaincode chaincodeReconcile
+	Config    *config.Config
+}
+
+// Reconcile reads that state of the cluster for a IBPChaincode object, deploys the chaincode
+// server and drives the chaincode definition through install, approve and commit on the
+// peers listed in the IBPChaincode.Spec
+// Note:
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileIBPChaincode) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	var err error
+
+	reqLogger := r.Config.Logger.With(
+		zap.String("Request.Namespace", request.Namespace),
+		zap.String("Request.Name", request.Name),
+	)
+	reqLogger.Info("Reconciling IBPChaincode")
+
+	// Fetch the IBPChaincode instance
+	instance := &current.IBPChaincode{}
+	err = r.client.Get(context.TODO(), request.NamespacedName, instance)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	status, err := r.Chaincode.Reconcile(instance)
+	metrics.RecordReconcile("ibpchaincode", err)
+	setStatusErr := r.SetStatus(instance, status, err)
+	if setStatusErr != nil {
+		return reconcile.Result{}, operatorerrors.IsBreakingError(setStatusErr, "failed to update status", log)
+	}
+
+	if err != nil {
+		return reconcile.Result{}, operatorerrors.IsBreakingError(errors.Wrapf(err, "Chaincode instance '%s' encountered error", instance.GetName()), "stopping reconcile loop", log)
+	}
+
+	reqLogger.Info(fmt.Sprintf("Finished reconciling IBPChaincode '%s'", instance.GetName()))
+	if instance.Status.Type != current.Deployed {
+		return reconcile.Result{RequeueAfter: RequeueInterval}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// SetStatus updates the status of the chaincode with the install state of every peer and
+// the approval state of every organization
+func (r *ReconcileIBPChaincode) SetStatus(instance *current.IBPChaincode, chaincodeStatus *current.IBPChaincodeStatus, reconcileErr error) error {
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}, instance)
+	if err != nil {
+		return err
+	}
+
+	status := instance.Status.CRStatus
+
+	if reconcileErr != nil {
+		status.Type = current.Error
+		status.Status = current.True
+		status.Reason = "errorOccurredDuringReconcile"
+		status.Message = reconcileErr.Error()
+		status.ErrorCode = operatorerrors.GetErrorCode(reconcileErr)
+		chaincodeStatus = instance.Status.DeepCopy()
+	} else {
+		failed := []string{}
+		for _, peer := range chaincodeStatus.Peers {
+			if peer.Message != "" {
+				failed = append(failed, peer.Name)
+			}
+		}
+		for _, org := range chaincodeStatus.Organizations {
+			if org.Message != "" {
+				failed = append(failed, org.MSPID)
+			}
+		}
+
+		status.ErrorCode = 0
+		status.Status = current.True
+		switch {
+		case len(failed) > 0:
+			status.Type = current.Warning
+			status.Reason = "lifecycleFailed"
+			status.Message = fmt.Sprintf("Chaincode lifecycle failed for: %s", strings.Join(failed, ", "))
+		case chaincodeStatus.AllInstalled() && chaincodeStatus.CommittedSequence >= instance.Spec.Sequence:
+			status.Type = current.Deployed
+			status.Reason = "chaincodeCommitted"
+			status.Message = ""
+		default:
+			status.Type = current.Deploying
+			status.Reason = "waitingForApprovals"
+			status.Message = ""
+		}
+
+		chaincodeStatus.CRStatus = instance.Status.CRStatus
+		if instance.Status.CRStatus.Type == status.Type && reflect.DeepEqual(instance.Status, *chaincodeStatus) {
+			return nil
+		}
+	}
+
+	instance.Status = *chaincodeStatus
+	instance.Status.CRStatus = status
+	instance.Status.LastHeartbeatTime = time.Now().String()
+	log.Info(fmt.Sprintf("Updating status of IBPChaincode custom resource to %s phase", instance.Status.Type))
+	err = r.client.PatchStatus(context.TODO(), instance, nil, k8sclient.PatchOption{
+		Resilient: &k8sclient.ResilientPatch{
+			Retry:    2,
+			Into:     &current.IBPChaincode{},
+			Strategy: client.MergeFrom,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateFunc always triggers a reconcile, on operator restart this verifies that the
+// chaincode is still installed and committed
+func (r *ReconcileIBPChaincode) CreateFunc(e event.CreateEvent) bool {
+	return true
+}
+
+func (r *ReconcileIBPChaincode) UpdateFunc(e event.UpdateEvent) bool {
+	oldChaincode := e.ObjectOld.(*current.IBPChaincode)
+	newChaincode := e.ObjectNew.(*current.IBPChaincode)
+
+	if oldChaincode.GetChaincodeName() != newChaincode.GetChaincodeName() {
+		log.Error(errors.New("Chaincode name update is not allowed"), "invalid spec update")
+		return false
+	}
+
+	if reflect.DeepEqual(oldChaincode.Spec, newChaincode.Spec) {
+		return false
+	}
+
+	log.Info(fmt.Sprintf("Spec update detected on IBPChaincode custom resource: %s", oldChaincode.Name))
+	return true
+}
+
+func (r *ReconcileIBPChaincode) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&current.IBPChaincode{}).
+		Complete(r)
+}
@@ -0,0 +1,252 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ibpchaincode
+
+import (
+	"context"
+	"fmt"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	chaincodemocks "github.com/IBM-Blockchain/fabric-operator/controllers/ibpchaincode/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("ReconcileIBPChaincode", func() {
+	var (
+		reconciler             *ReconcileIBPChaincode
+		request                reconcile.Request
+		mockKubeClient         *mocks.Client
+		mockChaincodeReconcile *chaincodemocks.ChaincodeReconcile
+		instance               *current.IBPChaincode
+		committed              *current.IBPChaincodeStatus
+	)
+
+	BeforeEach(func() {
+		mockKubeClient = &mocks.Client{}
+		mockChaincodeReconcile = &chaincodemocks.ChaincodeReconcile{}
+		instance = &current.IBPChaincode{
+			Spec: current.IBPChaincodeSpec{
+				Version:  "1.0",
+				Sequence: 1,
+				Channel:  "mychannel",
+				Image:    "chaincode-image",
+				Peers:    []string{"org1peer1"},
+			},
+		}
+		instance.Name = "test-chaincode"
+		instance.Namespace = "test-namespace"
+
+		mockKubeClient.GetStub = func(ctx context.Context, types types.NamespacedName, obj client.Object) error {
+			switch obj.(type) {
+			case *current.IBPChaincode:
+				o := obj.(*current.IBPChaincode)
+				o.Kind = "IBPChaincode"
+				o.Spec = instance.Spec
+				o.Name = instance.Name
+				o.Status = instance.Status
+			}
+			return nil
+		}
+
+		committed = &current.IBPChaincodeStatus{
+			PackageID: "test-chaincode_1.0:1234",
+			Peers: []current.ChaincodePeerStatus{
+				{Name: "org1peer1", Installed: true},
+			},
+			Organizations: []current.ChaincodeOrganizationStatus{
+				{MSPID: "Org1MSP", Approved: true},
+			},
+			CommittedSequence: 1,
+		}
+		mockChaincodeReconcile.ReconcileReturns(committed, nil)
+
+		reconciler = &ReconcileIBPChaincode{
+			Config:    &config.Config{},
+			Chaincode: mockChaincodeReconcile,
+			client:    mockKubeClient,
+			scheme:    &runtime.Scheme{},
+		}
+		zaplogger, _ := util.SetupLogging("DEBUG")
+		reconciler.Config.Logger = zaplogger
+		request = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: "test-namespace",
+				Name:      "test-chaincode",
+			},
+		}
+	})
+
+	Context("Reconciles", func() {
+		It("does not return an error if the custom resource is 'not found'", func() {
+			notFoundErr := &k8serror.StatusError{
+				ErrStatus: metav1.Status{
+					Reason: metav1.StatusReasonNotFound,
+				},
+			}
+			mockKubeClient.GetReturns(notFoundErr)
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error if the request to get custom resource return any other errors besides 'not found'", func() {
+			alreadyExistsErr := &k8serror.StatusError{
+				ErrStatus: metav1.Status{
+					Message: "already exists",
+					Reason:  metav1.StatusReasonAlreadyExists,
+				},
+			}
+			mockKubeClient.GetReturns(alreadyExistsErr)
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("already exists"))
+		})
+
+		It("returns an error if it encountered a non-breaking error", func() {
+			errMsg := "failed to reconcile chaincode deployment"
+			mockChaincodeReconcile.ReconcileReturns(nil, errors.New(errMsg))
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(fmt.Sprintf("Chaincode instance '%s' encountered error: %s", instance.Name, errMsg)))
+		})
+
+		It("does not return an error if it encountered a breaking error", func() {
+			mockChaincodeReconcile.ReconcileReturns(nil, operatorerrors.New(operatorerrors.InvalidCustomResourceCreateRequest, "invalid chaincode"))
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("requeues if the chaincode is not committed", func() {
+			committed.CommittedSequence = 0
+			committed.Organizations[0].Approved = false
+			result, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(RequeueInterval))
+		})
+
+		It("does not requeue if the chaincode is committed", func() {
+			result, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+		})
+	})
+
+	Context("set status", func() {
+		It("returns an error if the custom resource is not found", func() {
+			notFoundErr := &k8serror.StatusError{
+				ErrStatus: metav1.Status{
+					Reason: metav1.StatusReasonNotFound,
+				},
+			}
+			mockKubeClient.GetReturns(notFoundErr)
+			err := reconciler.SetStatus(instance, nil, notFoundErr)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("sets the status to error if error occured during IBPChaincode reconciliation", func() {
+			err := reconciler.SetStatus(instance, nil, errors.New("ibpchaincode error"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Type).To(Equal(current.Error))
+			Expect(instance.Status.Message).To(Equal("ibpchaincode error"))
+		})
+
+		It("sets the status to warning if a peer failed to install", func() {
+			committed.Peers[0] = current.ChaincodePeerStatus{Name: "org1peer1", Message: "connection refused"}
+			err := reconciler.SetStatus(instance, committed, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Type).To(Equal(current.Warning))
+			Expect(instance.Status.Message).To(ContainSubstring("org1peer1"))
+		})
+
+		It("sets the status to warning if an organization failed to approve", func() {
+			committed.CommittedSequence = 0
+			committed.Organizations[0] = current.ChaincodeOrganizationStatus{MSPID: "Org1MSP", Message: "orderer unavailable"}
+			err := reconciler.SetStatus(instance, committed, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Type).To(Equal(current.Warning))
+			Expect(instance.Status.Message).To(ContainSubstring("Org1MSP"))
+		})
+
+		It("sets the status to deploying if the chaincode is not committed", func() {
+			committed.CommittedSequence = 0
+			err := reconciler.SetStatus(instance, committed, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Type).To(Equal(current.Deploying))
+		})
+
+		It("sets the status to deployed if the sequence is committed", func() {
+			err := reconciler.SetStatus(instance, committed, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Type).To(Equal(current.Deployed))
+			Expect(instance.Status.PackageID).To(Equal("test-chaincode_1.0:1234"))
+			Expect(mockKubeClient.PatchStatusCallCount()).To(Equal(1))
+		})
+
+		It("does not patch the status if nothing changed", func() {
+			instance.Status = *committed.DeepCopy()
+			instance.Status.CRStatus = current.CRStatus{
+				Type: current.Deployed,
+			}
+			err := reconciler.SetStatus(instance, committed, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.PatchStatusCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("update func predicate", func() {
+		var (
+			newInstance *current.IBPChaincode
+			e           event.UpdateEvent
+		)
+
+		BeforeEach(func() {
+			newInstance = instance.DeepCopy()
+			e = event.UpdateEvent{
+				ObjectOld: instance,
+				ObjectNew: newInstance,
+			}
+		})
+
+		It("returns false if spec did not change", func() {
+			Expect(reconciler.UpdateFunc(e)).To(Equal(false))
+		})
+
+		It("returns false if chaincode name changed", func() {
+			newInstance.Spec.ChaincodeName = "otherchaincode"
+			Expect(reconciler.UpdateFunc(e)).To(Equal(false))
+		})
+
+		It("returns true if spec changed", func() {
+			newInstance.Spec.Sequence = 2
+			Expect(reconciler.UpdateFunc(e)).To(Equal(true))
+		})
+	})
+})
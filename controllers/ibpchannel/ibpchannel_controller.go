@@ -0,0 +1,263 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ibpchannel
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/global"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/metrics"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_ibpchannel")
+
+// RequeueInterval is the interval at which channels with nodes that have not
+// joined yet are reconciled again
+const RequeueInterval = 1 * time.Minute
+
+// Add creates a new IBPChannel Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, config *config.Config) error {
+	r, err := newReconciler(mgr, config)
+	if err != nil {
+		return err
+	}
+	return add(mgr, r)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, cfg *config.Config) (*ReconcileIBPChannel, error) {
+	client := k8sclient.New(mgr.GetClient(), &global.ConfigSetter{Config: cfg.Operator.Globals})
+	scheme := mgr.GetScheme()
+
+	return &ReconcileIBPChannel{
+		client:  client,
+		scheme:  scheme,
+		Config:  cfg,
+		Channel: channel.New(client, scheme),
+	}, nil
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r *ReconcileIBPChannel) error {
+	// Create a new controller
+	predicateFuncs := predicate.Funcs{
+		CreateFunc: r.CreateFunc,
+		UpdateFunc: r.UpdateFunc,
+	}
+
+	c, err := controller.New("ibpchannel-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource IBPChannel
+	err = c.Watch(&source.Kind{Type: &current.IBPChannel{}}, &handler.EnqueueRequestForObject{}, predicateFuncs)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileIBPChannel{}
+
+//go:generate counterfeiter -o mocks/channelreconcile.go -fake-name ChannelReconcile . channelReconcile
+
+type channelReconcile interface {
+	Reconcile(*current.IBPChannel) ([]current.ChannelNodeStatus, error)
+}
+
+// ReconcileIBPChannel reconciles a IBPChannel object
+type ReconcileIBPChannel struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client k8sclient.Client
+	scheme *runtime.Scheme
+
+	Channel channelReconcile
+	Config  *config.Config
+}
+
+// Reconcile reads that state of the cluster for a IBPChannel object and joins the orderer nodes
+// listed in the IBPChannel.Spec to the channel
+// Note:
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileIBPChannel) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	var err error
+
+	reqLogger := r.Config.Logger.With(
+		zap.String("Request.Namespace", request.Namespace),
+		zap.String("Request.Name", request.Name),
+	)
+	reqLogger.Info("Reconciling IBPChannel")
+
+	// Fetch the IBPChannel instance
+	instance := &current.IBPChannel{}
+	err = r.client.Get(context.TODO(), request.NamespacedName, instance)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	nodes, err := r.Channel.Reconcile(instance)
+	metrics.RecordReconcile("ibpchannel", err)
+	setStatusErr := r.SetStatus(instance, nodes, err)
+	if setStatusErr != nil {
+		return reconcile.Result{}, operatorerrors.IsBreakingError(setStatusErr, "failed to update status", log)
+	}
+
+	if err != nil {
+		return reconcile.Result{}, operatorerrors.IsBreakingError(errors.Wrapf(err, "Channel instance '%s' encountered error", instance.GetName()), "stopping reconcile loop", log)
+	}
+
+	reqLogger.Info(fmt.Sprintf("Finished reconciling IBPChannel '%s'", instance.GetName()))
+	if !instance.Status.AllNodesJoined() {
+		return reconcile.Result{RequeueAfter: RequeueInterval}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// SetStatus updates the status of the channel with the join status of every orderer node
+func (r *ReconcileIBPChannel) SetStatus(instance *current.IBPChannel, nodes []current.ChannelNodeStatus, reconcileErr error) error {
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}, instance)
+	if err != nil {
+		return err
+	}
+
+	status := instance.Status.CRStatus
+
+	if reconcileErr != nil {
+		status.Type = current.Error
+		status.Status = current.True
+		status.Reason = "errorOccurredDuringReconcile"
+		status.Message = reconcileErr.Error()
+		status.ErrorCode = operatorerrors.GetErrorCode(reconcileErr)
+		nodes = instance.Status.Nodes
+	} else {
+		failed := []string{}
+		for _, node := range nodes {
+			if node.Status == current.NodeFailed {
+				failed = append(failed, node.Name)
+			}
+		}
+
+		status.ErrorCode = 0
+		status.Status = current.True
+		switch {
+		case len(failed) > 0:
+			status.Type = current.Warning
+			status.Reason = "nodesFailedToJoin"
+			status.Message = fmt.Sprintf("Orderer nodes failed to join channel: %s", strings.Join(failed, ", "))
+		case (&current.IBPChannelStatus{Nodes: nodes}).AllNodesJoined():
+			status.Type = current.Deployed
+			status.Reason = "allNodesJoined"
+			status.Message = ""
+		default:
+			status.Type = current.Deploying
+			status.Reason = "waitingForNodes"
+			status.Message = ""
+		}
+
+		if instance.Status.CRStatus.Type == status.Type && reflect.DeepEqual(instance.Status.Nodes, nodes) {
+			return nil
+		}
+	}
+
+	instance.Status = current.IBPChannelStatus{
+		CRStatus: status,
+		Nodes:    nodes,
+	}
+	instance.Status.LastHeartbeatTime = time.Now().String()
+	log.Info(fmt.Sprintf("Updating status of IBPChannel custom resource to %s phase", instance.Status.Type))
+	err = r.client.PatchStatus(context.TODO(), instance, nil, k8sclient.PatchOption{
+		Resilient: &k8sclient.ResilientPatch{
+			Retry:    2,
+			Into:     &current.IBPChannel{},
+			Strategy: client.MergeFrom,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateFunc always triggers a reconcile, on operator restart this verifies that the
+// orderer nodes are still members of the channel
+func (r *ReconcileIBPChannel) CreateFunc(e event.CreateEvent) bool {
+	return true
+}
+
+func (r *ReconcileIBPChannel) UpdateFunc(e event.UpdateEvent) bool {
+	oldChannel := e.ObjectOld.(*current.IBPChannel)
+	newChannel := e.ObjectNew.(*current.IBPChannel)
+
+	if oldChannel.GetChannelName() != newChannel.GetChannelName() {
+		log.Error(errors.New("Channel name update is not allowed"), "invalid spec update")
+		return false
+	}
+
+	if reflect.DeepEqual(oldChannel.Spec, newChannel.Spec) {
+		return false
+	}
+
+	log.Info(fmt.Sprintf("Spec update detected on IBPChannel custom resource: %s", oldChannel.Name))
+	return true
+}
+
+func (r *ReconcileIBPChannel) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&current.IBPChannel{}).
+		Complete(r)
+}
@@ -0,0 +1,246 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ibpchannel
+
+import (
+	"context"
+	"fmt"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	channelmocks "github.com/IBM-Blockchain/fabric-operator/controllers/ibpchannel/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("ReconcileIBPChannel", func() {
+	var (
+		reconciler           *ReconcileIBPChannel
+		request              reconcile.Request
+		mockKubeClient       *mocks.Client
+		mockChannelReconcile *channelmocks.ChannelReconcile
+		instance             *current.IBPChannel
+	)
+
+	BeforeEach(func() {
+		mockKubeClient = &mocks.Client{}
+		mockChannelReconcile = &channelmocks.ChannelReconcile{}
+		instance = &current.IBPChannel{
+			Spec: current.IBPChannelSpec{
+				Orderer: current.ChannelOrderer{
+					ClusterName: "orderer",
+				},
+			},
+		}
+		instance.Name = "test-channel"
+		instance.Namespace = "test-namespace"
+
+		mockKubeClient.GetStub = func(ctx context.Context, types types.NamespacedName, obj client.Object) error {
+			switch obj.(type) {
+			case *current.IBPChannel:
+				o := obj.(*current.IBPChannel)
+				o.Kind = "IBPChannel"
+				o.Spec = instance.Spec
+				o.Name = instance.Name
+				o.Status = instance.Status
+			}
+			return nil
+		}
+
+		mockChannelReconcile.ReconcileReturns([]current.ChannelNodeStatus{
+			{Name: "orderernode1", Status: current.NodeJoined},
+			{Name: "orderernode2", Status: current.NodeJoined},
+		}, nil)
+
+		reconciler = &ReconcileIBPChannel{
+			Config:  &config.Config{},
+			Channel: mockChannelReconcile,
+			client:  mockKubeClient,
+			scheme:  &runtime.Scheme{},
+		}
+		zaplogger, _ := util.SetupLogging("DEBUG")
+		reconciler.Config.Logger = zaplogger
+		request = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: "test-namespace",
+				Name:      "test-channel",
+			},
+		}
+	})
+
+	Context("Reconciles", func() {
+		It("does not return an error if the custom resource is 'not found'", func() {
+			notFoundErr := &k8serror.StatusError{
+				ErrStatus: metav1.Status{
+					Reason: metav1.StatusReasonNotFound,
+				},
+			}
+			mockKubeClient.GetReturns(notFoundErr)
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error if the request to get custom resource return any other errors besides 'not found'", func() {
+			alreadyExistsErr := &k8serror.StatusError{
+				ErrStatus: metav1.Status{
+					Message: "already exists",
+					Reason:  metav1.StatusReasonAlreadyExists,
+				},
+			}
+			mockKubeClient.GetReturns(alreadyExistsErr)
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("already exists"))
+		})
+
+		It("returns an error if it encountered a non-breaking error", func() {
+			errMsg := "failed to get orderer"
+			mockChannelReconcile.ReconcileReturns(nil, errors.New(errMsg))
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(fmt.Sprintf("Channel instance '%s' encountered error: %s", instance.Name, errMsg)))
+		})
+
+		It("does not return an error if it encountered a breaking error", func() {
+			mockChannelReconcile.ReconcileReturns(nil, operatorerrors.New(operatorerrors.InvalidCustomResourceCreateRequest, "invalid channel"))
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("requeues if not every node has joined the channel", func() {
+			mockChannelReconcile.ReconcileReturns([]current.ChannelNodeStatus{
+				{Name: "orderernode1", Status: current.NodeJoined},
+				{Name: "orderernode2", Status: current.NodeFailed},
+			}, nil)
+			result, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(RequeueInterval))
+		})
+
+		It("does not requeue if every node has joined the channel", func() {
+			result, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+		})
+	})
+
+	Context("set status", func() {
+		It("returns an error if the custom resource is not found", func() {
+			notFoundErr := &k8serror.StatusError{
+				ErrStatus: metav1.Status{
+					Reason: metav1.StatusReasonNotFound,
+				},
+			}
+			mockKubeClient.GetReturns(notFoundErr)
+			err := reconciler.SetStatus(instance, nil, notFoundErr)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("sets the status to error if error occured during IBPChannel reconciliation", func() {
+			err := reconciler.SetStatus(instance, nil, errors.New("ibpchannel error"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Type).To(Equal(current.Error))
+			Expect(instance.Status.Message).To(Equal("ibpchannel error"))
+		})
+
+		It("sets the status to warning if a node failed to join", func() {
+			err := reconciler.SetStatus(instance, []current.ChannelNodeStatus{
+				{Name: "orderernode1", Status: current.NodeJoined},
+				{Name: "orderernode2", Status: current.NodeFailed, Message: "connection refused"},
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Type).To(Equal(current.Warning))
+			Expect(instance.Status.Message).To(ContainSubstring("orderernode2"))
+			Expect(instance.Status.Nodes).To(HaveLen(2))
+		})
+
+		It("sets the status to deploying if a node is pending", func() {
+			err := reconciler.SetStatus(instance, []current.ChannelNodeStatus{
+				{Name: "orderernode1", Status: current.NodePending},
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Type).To(Equal(current.Deploying))
+		})
+
+		It("sets the status to deployed if every node joined", func() {
+			err := reconciler.SetStatus(instance, []current.ChannelNodeStatus{
+				{Name: "orderernode1", Status: current.NodeJoined},
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Type).To(Equal(current.Deployed))
+			Expect(mockKubeClient.PatchStatusCallCount()).To(Equal(1))
+		})
+
+		It("does not patch the status if nothing changed", func() {
+			instance.Status = current.IBPChannelStatus{
+				CRStatus: current.CRStatus{
+					Type: current.Deployed,
+				},
+				Nodes: []current.ChannelNodeStatus{
+					{Name: "orderernode1", Status: current.NodeJoined},
+				},
+			}
+			err := reconciler.SetStatus(instance, []current.ChannelNodeStatus{
+				{Name: "orderernode1", Status: current.NodeJoined},
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.PatchStatusCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("update func predicate", func() {
+		var (
+			newInstance *current.IBPChannel
+			e           event.UpdateEvent
+		)
+
+		BeforeEach(func() {
+			newInstance = instance.DeepCopy()
+			e = event.UpdateEvent{
+				ObjectOld: instance,
+				ObjectNew: newInstance,
+			}
+		})
+
+		It("returns false if spec did not change", func() {
+			Expect(reconciler.UpdateFunc(e)).To(Equal(false))
+		})
+
+		It("returns false if channel name changed", func() {
+			newInstance.Spec.ChannelName = "otherchannel"
+			Expect(reconciler.UpdateFunc(e)).To(Equal(false))
+		})
+
+		It("returns true if spec changed", func() {
+			newInstance.Spec.Orderer.Nodes = []string{"orderernode1"}
+			Expect(reconciler.UpdateFunc(e)).To(Equal(true))
+		})
+	})
+})
@@ -28,15 +28,19 @@ import (
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
 	commoncontroller "github.com/IBM-Blockchain/fabric-operator/controllers/common"
 	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/conditions"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/events"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/global"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
 	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/metrics"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering"
 	baseconsole "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/console"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
 	k8sconsole "github.com/IBM-Blockchain/fabric-operator/pkg/offering/k8s/console"
 	openshiftconsole "github.com/IBM-Blockchain/fabric-operator/pkg/offering/openshift/console"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/restart"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -44,11 +48,13 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -77,18 +83,21 @@ func Add(mgr manager.Manager, config *config.Config) error {
 func newReconciler(mgr manager.Manager, cfg *config.Config) (*ReconcileIBPConsole, error) {
 	client := k8sclient.New(mgr.GetClient(), &global.ConfigSetter{Config: cfg.Operator.Globals})
 	scheme := mgr.GetScheme()
+	recorder := mgr.GetEventRecorderFor("ibpconsole-controller")
 
 	ibpconsole := &ReconcileIBPConsole{
-		client: client,
-		scheme: scheme,
-		Config: cfg,
+		client:         client,
+		scheme:         scheme,
+		Config:         cfg,
+		Recorder:       recorder,
+		RestartManager: restart.New(client, recorder, cfg.Operator.Restart.WaitTime.Get(), cfg.Operator.Restart.Timeout.Get()),
 	}
 
 	switch cfg.Offering {
 	case offering.K8S:
-		ibpconsole.Offering = k8sconsole.New(client, scheme, cfg)
+		ibpconsole.Offering = k8sconsole.New(client, scheme, cfg, recorder)
 	case offering.OPENSHIFT:
-		ibpconsole.Offering = openshiftconsole.New(client, scheme, cfg)
+		ibpconsole.Offering = openshiftconsole.New(client, scheme, cfg, recorder)
 	}
 
 	return ibpconsole, nil
@@ -140,8 +149,10 @@ type ReconcileIBPConsole struct {
 	client k8sclient.Client
 	scheme *runtime.Scheme
 
-	Offering consoleReconcile
-	Config   *config.Config
+	Offering       consoleReconcile
+	Config         *config.Config
+	Recorder       record.EventRecorder
+	RestartManager commoncontroller.RestartManager
 
 	update Update
 }
@@ -168,6 +179,7 @@ func (r *ReconcileIBPConsole) Reconcile(ctx context.Context, request reconcile.R
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			metrics.DeleteInstance(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -175,12 +187,14 @@ func (r *ReconcileIBPConsole) Reconcile(ctx context.Context, request reconcile.R
 	}
 
 	result, err := r.Offering.Reconcile(instance, &r.update)
+	metrics.RecordReconcile("ibpconsole", err)
 	setStatusErr := r.SetStatus(instance, err)
 	if setStatusErr != nil {
 		return reconcile.Result{}, operatorerrors.IsBreakingError(setStatusErr, "failed to update status", log)
 	}
 
 	if err != nil {
+		events.Error(r.Recorder, instance, err)
 		return reconcile.Result{}, operatorerrors.IsBreakingError(errors.Wrapf(err, "Console instance '%s' encountered error", instance.GetName()), "stopping reconcile loop", log)
 	}
 
@@ -199,62 +213,47 @@ func (r *ReconcileIBPConsole) SetStatus(instance *current.IBPConsole, reconcileE
 		return err
 	}
 
-	status := instance.Status.CRStatus
+	status := *instance.Status.CRStatus.DeepCopy()
 
 	if reconcileErr != nil {
 		status.Type = current.Error
 		status.Status = current.True
 		status.Reason = "errorOccurredDuringReconcile"
 		status.Message = reconcileErr.Error()
-		status.LastHeartbeatTime = time.Now().String()
 		status.ErrorCode = operatorerrors.GetErrorCode(reconcileErr)
+	} else {
+		status.Versions.Reconciled = instance.Spec.Version
 
-		instance.Status = current.IBPConsoleStatus{
-			CRStatus: status,
-		}
-
-		log.Info(fmt.Sprintf("Updating status of IBPConsole custom resource to %s phase", instance.Status.Type))
-		err := r.client.PatchStatus(context.TODO(), instance, nil, k8sclient.PatchOption{
-			Resilient: &k8sclient.ResilientPatch{
-				Retry:    2,
-				Into:     &current.IBPConsole{},
-				Strategy: client.MergeFrom,
-			},
-		})
+		running, err := r.GetPodStatus(instance)
 		if err != nil {
 			return err
 		}
+		conditions.SetDeploymentAvailable(&status, instance.GetGeneration(), running)
 
-		return nil
+		if running {
+			if instance.Status.Type != current.Deployed {
+				status.Type = current.Deployed
+				status.Status = current.True
+				status.Reason = "allPodsRunning"
+			}
+		} else if instance.Status.Type != current.Deploying {
+			status.Type = current.Deploying
+			status.Status = current.True
+			status.Reason = "waitingForPods"
+		}
 	}
 
-	status.Versions.Reconciled = instance.Spec.Version
+	commoncontroller.SetConditions(instance, &status, r.RestartManager)
 
-	running, err := r.GetPodStatus(instance)
-	if err != nil {
-		return err
+	// Errors are always reported to refresh the heartbeat, otherwise the status is
+	// only updated if it changed
+	if reconcileErr == nil && equality.Semantic.DeepEqual(instance.Status.CRStatus, status) {
+		return nil
 	}
 
-	if running {
-		if instance.Status.Type == current.Deployed {
-			return nil
-		}
-		status.Type = current.Deployed
-		status.Status = current.True
-		status.Reason = "allPodsRunning"
-	} else {
-		if instance.Status.Type == current.Deploying {
-			return nil
-		}
-		status.Type = current.Deploying
-		status.Status = current.True
-		status.Reason = "waitingForPods"
-	}
+	status.LastHeartbeatTime = time.Now().String()
+	instance.Status.CRStatus = status
 
-	instance.Status = current.IBPConsoleStatus{
-		CRStatus: status,
-	}
-	instance.Status.LastHeartbeatTime = time.Now().String()
 	log.Info(fmt.Sprintf("Updating status of IBPConsole custom resource to %s phase", instance.Status.Type))
 	err = r.client.PatchStatus(context.TODO(), instance, nil, k8sclient.PatchOption{
 		Resilient: &k8sclient.ResilientPatch{
@@ -34,6 +34,7 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -53,12 +54,14 @@ var _ = Describe("ReconcileIBPConsole", func() {
 		request              reconcile.Request
 		mockKubeClient       *mocks.Client
 		mockConsoleReconcile *consolemocks.ConsoleReconcile
+		mockRestart          *mocks.RestartManager
 		instance             *current.IBPConsole
 	)
 
 	BeforeEach(func() {
 		mockKubeClient = &mocks.Client{}
 		mockConsoleReconcile = &consolemocks.ConsoleReconcile{}
+		mockRestart = &mocks.RestartManager{}
 		instance = &current.IBPConsole{
 			Spec: current.IBPConsoleSpec{},
 		}
@@ -113,10 +116,11 @@ var _ = Describe("ReconcileIBPConsole", func() {
 		}
 
 		reconciler = &ReconcileIBPConsole{
-			Config:   &config.Config{},
-			Offering: mockConsoleReconcile,
-			client:   mockKubeClient,
-			scheme:   &runtime.Scheme{},
+			Config:         &config.Config{},
+			Offering:       mockConsoleReconcile,
+			RestartManager: mockRestart,
+			client:         mockKubeClient,
+			scheme:         &runtime.Scheme{},
 		}
 		zaplogger, _ := util.SetupLogging("DEBUG")
 		reconciler.Config.Logger = zaplogger
@@ -201,6 +205,23 @@ var _ = Describe("ReconcileIBPConsole", func() {
 			reconciler.SetStatus(instance, nil)
 			Expect(instance.Status.Type).To(Equal(current.Deployed))
 		})
+
+		It("sets the ready and deployment available conditions if pod is running", func() {
+			err := reconciler.SetStatus(instance, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, current.ReadyCondition)).To(Equal(true))
+			Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, current.DeploymentAvailableCondition)).To(Equal(true))
+		})
+
+		It("does not patch the status if nothing changed", func() {
+			err := reconciler.SetStatus(instance, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.PatchStatusCallCount()).To(Equal(1))
+
+			err = reconciler.SetStatus(instance, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.PatchStatusCallCount()).To(Equal(1))
+		})
 	})
 
 	Context("create func predicate", func() {
@@ -30,20 +30,25 @@ import (
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
 	commoncontroller "github.com/IBM-Blockchain/fabric-operator/controllers/common"
 	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/conditions"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/events"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/global"
 	orderer "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/orderer/config/v1"
 	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/metrics"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering"
 	baseorderer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
 	k8sorderer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/k8s/orderer"
 	openshiftorderer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/openshift/orderer"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/restart"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/restart/staggerrestarts"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
 	"github.com/IBM-Blockchain/fabric-operator/version"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
 	yaml "sigs.k8s.io/yaml"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -53,6 +58,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -85,21 +91,24 @@ func Add(mgr manager.Manager, config *config.Config) error {
 func newReconciler(mgr manager.Manager, cfg *config.Config) (*ReconcileIBPOrderer, error) {
 	client := k8sclient.New(mgr.GetClient(), &global.ConfigSetter{Config: cfg.Operator.Globals})
 	scheme := mgr.GetScheme()
+	recorder := mgr.GetEventRecorderFor("ibporderer-controller")
 
 	ibporderer := &ReconcileIBPOrderer{
 		client:         client,
 		scheme:         scheme,
 		Config:         cfg,
+		Recorder:       recorder,
 		update:         map[string][]Update{},
 		mutex:          &sync.Mutex{},
 		RestartService: staggerrestarts.New(client, cfg.Operator.Restart.Timeout.Get()),
+		RestartManager: restart.New(client, recorder, cfg.Operator.Restart.WaitTime.Get(), cfg.Operator.Restart.Timeout.Get()),
 	}
 
 	switch cfg.Offering {
 	case offering.K8S:
-		ibporderer.Offering = k8sorderer.New(client, scheme, cfg)
+		ibporderer.Offering = k8sorderer.New(client, scheme, cfg, recorder)
 	case offering.OPENSHIFT:
-		ibporderer.Offering = openshiftorderer.New(client, scheme, cfg)
+		ibporderer.Offering = openshiftorderer.New(client, scheme, cfg, recorder)
 	}
 
 	return ibporderer, nil
@@ -141,6 +150,15 @@ func add(mgr manager.Manager, r *ReconcileIBPOrderer) error {
 		return err
 	}
 
+	// Watch for changes to secondary resource StatefulSets and requeue the owner IBPOrderer
+	err = c.Watch(&source.Kind{Type: &appsv1.StatefulSet{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &current.IBPOrderer{},
+	})
+	if err != nil {
+		return err
+	}
+
 	// Watch for changes to tertiary resource Secrets and requeue the owner IBPOrderer
 	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestForOwner{
 		IsController: true,
@@ -171,6 +189,8 @@ type ReconcileIBPOrderer struct {
 	Offering       ordererReconcile
 	Config         *config.Config
 	RestartService *staggerrestarts.StaggerRestartsService
+	RestartManager commoncontroller.RestartManager
+	Recorder       record.EventRecorder
 
 	update map[string][]Update
 	mutex  *sync.Mutex
@@ -212,6 +232,7 @@ func (r *ReconcileIBPOrderer) Reconcile(ctx context.Context, request reconcile.R
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			metrics.DeleteInstance(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -238,10 +259,17 @@ func (r *ReconcileIBPOrderer) Reconcile(ctx context.Context, request reconcile.R
 		// loop needs to be triggered to allow for instance migration
 		if instance.Status.Version != "" {
 			if instance.Status.Type == current.Deployed || instance.Status.Type == current.Warning {
-				// This is cluster's update, we don't want to reconcile.
-				// It should only be status update
-				log.Info(fmt.Sprintf("Update detected on %s cluster spec '%s', not supported", instance.Status.Type, instance.GetName()))
-				return reconcile.Result{}, nil
+				resize, err := r.ClusterResizeNeeded(instance)
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+				if !resize {
+					// This is cluster's update, we don't want to reconcile.
+					// It should only be status update
+					log.Info(fmt.Sprintf("Update detected on %s cluster spec '%s', not supported", instance.Status.Type, instance.GetName()))
+					return reconcile.Result{}, nil
+				}
+				log.Info(fmt.Sprintf("Resizing cluster '%s' to %d nodes", instance.GetName(), instance.Spec.ClusterSize))
 			}
 		}
 	}
@@ -252,12 +280,14 @@ func (r *ReconcileIBPOrderer) Reconcile(ctx context.Context, request reconcile.R
 	reqLogger.Info(fmt.Sprintf("Reconciling IBPOrderer '%s' with update values of [ %+v ]", instance.GetName(), update.GetUpdateStackWithTrues()))
 
 	result, err := r.Offering.Reconcile(instance, r.PopUpdate(instance.Name))
+	metrics.RecordReconcile("ibporderer", err)
 	setStatusErr := r.SetStatus(instance, &result, err)
 	if setStatusErr != nil {
 		return reconcile.Result{}, operatorerrors.IsBreakingError(setStatusErr, "failed to update status", log)
 	}
 
 	if err != nil {
+		events.Error(r.Recorder, instance, err)
 		return reconcile.Result{}, operatorerrors.IsBreakingError(errors.Wrapf(err, "Orderer instance '%s' encountered error", instance.GetName()), "stopping reconcile loop", log)
 	}
 
@@ -306,158 +336,129 @@ func (r *ReconcileIBPOrderer) SetStatus(instance *current.IBPOrderer, result *co
 		return err
 	}
 
-	status := instance.Status.CRStatus
+	status := *instance.Status.CRStatus.DeepCopy()
 
 	if reconcileErr != nil {
 		status.Type = current.Error
 		status.Status = current.True
 		status.Reason = "errorOccurredDuringReconcile"
 		status.Message = reconcileErr.Error()
-		status.LastHeartbeatTime = time.Now().String()
 		status.ErrorCode = operatorerrors.GetErrorCode(reconcileErr)
-
-		instance.Status = current.IBPOrdererStatus{
-			CRStatus: status,
+	} else {
+		status.Versions.Reconciled = instance.Spec.FabricVersion
+
+		// If this is a parent (cluster spec), then ignore setting status. Status should
+		// be set by the child nodes only, and child nodes should update the status of parent
+		// according to the statuses of the child nodes. This needs to stay after the check for
+		// reconcile error otherwise the CR won't get updated with error on parent CR if there
+		// are validation errors on the spec.
+		if instance.Spec.NodeNumber == nil {
+			return nil
 		}
 
-		log.Info(fmt.Sprintf("Updating status of IBPOrderer custom resource (%s) to %s phase", instance.GetName(), instance.Status.Type))
-		err := r.client.PatchStatus(context.TODO(), instance, nil, k8sclient.PatchOption{
-			Resilient: &k8sclient.ResilientPatch{
-				Retry:    2,
-				Into:     &current.IBPOrderer{},
-				Strategy: client.MergeFrom,
-			},
-		})
+		podStatus, err := r.GetPodStatus(instance)
 		if err != nil {
 			return err
 		}
 
-		return nil
-	}
-
-	status.Versions.Reconciled = instance.Spec.FabricVersion
-
-	// If this is a parent (cluster spec), then ignore setting status. Status should
-	// be set by the child nodes only, and child nodes should update the status of parent
-	// according to the statuses of the child nodes. This needs to stay after the check for
-	// reconcile error otherwise the CR won't get updated with error on parent CR if there
-	// are validation errors on the spec.
-	if instance.Spec.NodeNumber == nil {
-		return nil
-	}
-
-	podStatus, err := r.GetPodStatus(instance)
-	if err != nil {
-		return err
-	}
+		numberOfPodsRunning := 0
+		for _, status := range podStatus {
+			if status.Phase == corev1.PodRunning {
+				numberOfPodsRunning++
+			}
+		}
+		conditions.SetDeploymentAvailable(&status, instance.GetGeneration(), len(podStatus) > 0 && len(podStatus) == numberOfPodsRunning)
 
-	numberOfPodsRunning := 0
-	for _, status := range podStatus {
-		if status.Phase == corev1.PodRunning {
-			numberOfPodsRunning++
+		var reconcileStatus *current.CRStatus
+		overrideUpdateStatus := false
+		if result != nil {
+			reconcileStatus = result.Status
+			overrideUpdateStatus = result.OverrideUpdateStatus
 		}
-	}
 
-	// if numberOfPodsRunning == len(podStatus) && len(podStatus) > 0 {
-	if len(podStatus) > 0 {
-		if len(podStatus) == numberOfPodsRunning {
-			status.Type = current.Deployed
+		checkGenesis := false
+		switch {
+		case reconcileStatus != nil && (instance.Status.Type != reconcileStatus.Type || instance.Status.Reason != reconcileStatus.Reason || instance.Status.Message != reconcileStatus.Message):
+			// Reconcile loop returned an updated status that differs from exisiting status,
+			// set status to the reconcile status
+			status.Type = reconcileStatus.Type
 			status.Status = current.True
-			status.Reason = "allPodsRunning"
-			status.Message = "allPodsRunning"
-		} else {
-			status.Type = current.Deploying
-			status.Status = current.True
-			status.Reason = "waitingForPods"
-			status.Message = "waitingForPods"
+			status.Reason = reconcileStatus.Reason
+			status.Message = reconcileStatus.Message
+			conditions.SetCertificatesValid(&status, instance.GetGeneration(), reconcileStatus)
+
+			checkGenesis = !overrideUpdateStatus
+		case reconcileStatus != nil:
+			// If the reconcile loop returned an updated status that is the same as the current instance status, then no update of the
+			// status type is required.
+			// NOTE: This will only occur once the instance has hit Deployed state for the first time and would only switch between Deployed and
+			// Warning states.
+			log.Info(fmt.Sprintf("Reconcile loop returned a status that is the same as %s's current status (%s), not updating status type", reconcileStatus.Type, instance.Name))
+			conditions.SetCertificatesValid(&status, instance.GetGeneration(), reconcileStatus)
+		case overrideUpdateStatus:
+			// There are cases we want to return before checking for genesis secrets, such as updating the spec with default values
+			// during prereconcile checks
+		default:
+			if len(podStatus) > 0 {
+				if len(podStatus) == numberOfPodsRunning {
+					status.Type = current.Deployed
+					status.Status = current.True
+					status.Reason = "allPodsRunning"
+					status.Message = "allPodsRunning"
+				} else {
+					status.Type = current.Deploying
+					status.Status = current.True
+					status.Reason = "waitingForPods"
+					status.Message = "waitingForPods"
+				}
+			}
+
+			checkGenesis = true
 		}
-	}
 
-	// Check if reconcile loop returned an updated status that differs from exisiting status.
-	// If so, set status to the reconcile status.
-	if result != nil {
-		reconcileStatus := result.Status
-		if reconcileStatus != nil {
-			if instance.Status.Type != reconcileStatus.Type || instance.Status.Reason != reconcileStatus.Reason || instance.Status.Message != reconcileStatus.Message {
-				status.Type = reconcileStatus.Type
-				status.Status = current.True
-				status.Reason = reconcileStatus.Reason
-				status.Message = reconcileStatus.Message
-				status.LastHeartbeatTime = time.Now().String()
-
-				if result.OverrideUpdateStatus {
-					instance.Status = current.IBPOrdererStatus{
-						CRStatus: status,
-					}
-
-					log.Info(fmt.Sprintf("Updating status returned by reconcile loop of IBPOrderer custom resource (%s) to %s phase", instance.GetName(), instance.Status.Type))
-					err := r.client.PatchStatus(context.TODO(), instance, nil, k8sclient.PatchOption{
-						Resilient: &k8sclient.ResilientPatch{
-							Retry:    2,
-							Into:     &current.IBPOrderer{},
-							Strategy: client.MergeFrom,
-						},
-					})
-					if err != nil {
-						return err
-					}
-
-					return nil
-				}
+		if checkGenesis {
+			precreated := false
+			if instance.Spec.IsUsingChannelLess() {
+				log.Info(fmt.Sprintf("IBPOrderer custom resource (%s) is using channel less mode", instance.GetName()))
+				precreated = false
 			} else {
-				// If the reconcile loop returned an updated status that is the same as the current instance status, then no status update required.
-				// NOTE: This will only occur once the instance has hit Deployed state for the first time and would only switch between Deployed and
-				// Warning states.
-				log.Info(fmt.Sprintf("Reconcile loop returned a status that is the same as %s's current status (%s), not updating status", reconcileStatus.Type, instance.Name))
-				return nil
+				err = r.GetGenesisSecret(instance)
+				if err != nil {
+					log.Info(fmt.Sprintf("IBPOrderer custom resource (%s) pods are waiting for genesis block, setting status to precreate", instance.GetName()))
+					precreated = true
+				}
 			}
-		}
 
-		// There are cases we want to return before checking for genesis secrets, such as updating the spec with default values
-		// during prereconcile checks
-		if result.OverrideUpdateStatus {
-			return nil
+			if precreated {
+				status.Type = current.Precreated
+				status.Status = current.True
+				status.Reason = "waiting for genesis block"
+				status.Message = "waiting for genesis block"
+			}
 		}
 	}
 
-	precreated := false
-	if instance.Spec.IsUsingChannelLess() {
-		log.Info(fmt.Sprintf("IBPOrderer custom resource (%s) is using channel less mode", instance.GetName()))
-		precreated = false
-	} else {
-		err = r.GetGenesisSecret(instance)
-		if err != nil {
-			log.Info(fmt.Sprintf("IBPOrderer custom resource (%s) pods are waiting for genesis block, setting status to precreate", instance.GetName()))
-			precreated = true
-		}
-	}
+	commoncontroller.SetConditions(instance, &status, r.RestartManager)
 
-	if precreated {
-		status.Type = current.Precreated
-		status.Status = current.True
-		status.Reason = "waiting for genesis block"
-		status.Message = "waiting for genesis block"
+	// Errors are always reported to refresh the heartbeat, otherwise the status is
+	// only updated if it changed
+	if reconcileErr == nil && equality.Semantic.DeepEqual(instance.Status.CRStatus, status) {
+		return nil
 	}
 
-	// Only update status if status is different from current status
-	if status.Type != "" && (instance.Status.Type != status.Type || instance.Status.Reason != status.Reason || instance.Status.Message != status.Message) {
-		status.LastHeartbeatTime = time.Now().String()
-		log.Info(fmt.Sprintf("Updating status of IBPOrderer custom resource (%s) from %s to %s phase", instance.GetName(), instance.Status.Type, status.Type))
+	status.LastHeartbeatTime = time.Now().String()
+	log.Info(fmt.Sprintf("Updating status of IBPOrderer custom resource (%s) from %s to %s phase", instance.GetName(), instance.Status.Type, status.Type))
+	instance.Status.CRStatus = status
 
-		instance.Status = current.IBPOrdererStatus{
-			CRStatus: status,
-		}
-
-		err = r.client.PatchStatus(context.TODO(), instance, nil, k8sclient.PatchOption{
-			Resilient: &k8sclient.ResilientPatch{
-				Retry:    2,
-				Into:     &current.IBPOrderer{},
-				Strategy: client.MergeFrom,
-			},
-		})
-		if err != nil {
-			return err
-		}
+	err = r.client.PatchStatus(context.TODO(), instance, nil, k8sclient.PatchOption{
+		Resilient: &k8sclient.ResilientPatch{
+			Retry:    2,
+			Into:     &current.IBPOrderer{},
+			Strategy: client.MergeFrom,
+		},
+	})
+	if err != nil {
+		return err
 	}
 
 	return nil
@@ -529,6 +530,27 @@ func (r *ReconcileIBPOrderer) GetPodStatus(instance *current.IBPOrderer) (map[st
 	return statuses, nil
 }
 
+// ClusterResizeNeeded returns true if the number of nodes of the cluster does not match
+// the cluster size of the parent, or if a node added to the cluster is still joining the
+// channels of the cluster
+func (r *ReconcileIBPOrderer) ClusterResizeNeeded(instance *current.IBPOrderer) (bool, error) {
+	labelSelector, err := labels.Parse(fmt.Sprintf("parent=%s", instance.GetName()))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse selector for parent name")
+	}
+
+	ordererList := &current.IBPOrdererList{}
+	err = r.client.List(context.TODO(), ordererList, &client.ListOptions{
+		LabelSelector: labelSelector,
+		Namespace:     instance.GetNamespace(),
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list nodes of cluster")
+	}
+
+	return len(ordererList.Items) != instance.Spec.ClusterSize || baseorderer.JoinPending(ordererList.Items), nil
+}
+
 func (r *ReconcileIBPOrderer) GetGenesisSecret(instance *current.IBPOrderer) error {
 	nn := types.NamespacedName{
 		Name:      fmt.Sprintf("%s-genesis", instance.GetName()),
@@ -659,7 +681,11 @@ func (r *ReconcileIBPOrderer) UpdateFunc(e event.UpdateEvent) bool {
 				log.Info(fmt.Sprintf("Parent orderer %s status updated from %s to %s", oldOrderer.Name, oldOrderer.Status.Type, newOrderer.Status.Type))
 			}
 
-			if oldOrderer.Status.Type == current.Deployed || oldOrderer.Status.Type == current.Error || oldOrderer.Status.Type == current.Warning {
+			if oldOrderer.Spec.ClusterSize != newOrderer.Spec.ClusterSize {
+				// Resizing is the only update supported on a deployed cluster
+				log.Info(fmt.Sprintf("Parent orderer %s cluster size updated from %d to %d", oldOrderer.Name, oldOrderer.Spec.ClusterSize, newOrderer.Spec.ClusterSize))
+				update.clusterSizeUpdated = true
+			} else if oldOrderer.Status.Type == current.Deployed || oldOrderer.Status.Type == current.Error || oldOrderer.Status.Type == current.Warning {
 				// Parent orderer has been fully deployed by this point
 				log.Info(fmt.Sprintf("Ignoring the IBPOrderer cluster (parent) update after %s", oldOrderer.Status.Type))
 				return false
@@ -681,7 +707,7 @@ func (r *ReconcileIBPOrderer) UpdateFunc(e event.UpdateEvent) bool {
 		// status of the parent, since the status of the parent depends on the status
 		// of its children .Only flag status update when there is a meaninful change
 		// and not everytime the heartbeat is updated
-		if oldOrderer.Status != newOrderer.Status {
+		if !reflect.DeepEqual(oldOrderer.Status, newOrderer.Status) {
 			if oldOrderer.Status.Type != newOrderer.Status.Type ||
 				oldOrderer.Status.Reason != newOrderer.Status.Reason ||
 				oldOrderer.Status.Message != newOrderer.Status.Message {
@@ -836,6 +862,12 @@ func (r *ReconcileIBPOrderer) UpdateFunc(e event.UpdateEvent) bool {
 				update.ecertUpdated = true
 				log.Info(fmt.Sprintf("ecert updated for %s", instanceName))
 			}
+			if util.IsSecretCertManagerTLSCert(oldSecret.Name) {
+				// The TLS secrets are updated from the certificate renewed by cert-manager during reconcile
+				log.Info(fmt.Sprintf("cert-manager TLS cert updated for %s, triggering reconcile", instanceName))
+				r.PushUpdate(instanceName, update)
+				return true
+			}
 
 			if update.CertificateUpdated() {
 				log.Info(fmt.Sprintf("Orderer crypto update triggering reconcile on IBPOrderer custom resource %s: update [ %+v ]", instanceName, update.GetUpdateStackWithTrues()))
@@ -28,6 +28,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/utils/pointer"
 
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
@@ -60,12 +61,14 @@ var _ = Describe("ReconcileIBPOrderer", func() {
 		request              reconcile.Request
 		mockKubeClient       *mocks.Client
 		mockOrdererReconcile *orderermocks.OrdererReconcile
+		mockRestart          *mocks.RestartManager
 		instance             *current.IBPOrderer
 	)
 
 	BeforeEach(func() {
 		mockKubeClient = &mocks.Client{}
 		mockOrdererReconcile = &orderermocks.OrdererReconcile{}
+		mockRestart = &mocks.RestartManager{}
 		nodeNumber := 1
 		instance = &current.IBPOrderer{
 			Spec: current.IBPOrdererSpec{
@@ -119,12 +122,13 @@ var _ = Describe("ReconcileIBPOrderer", func() {
 		}
 
 		reconciler = &ReconcileIBPOrderer{
-			Offering: mockOrdererReconcile,
-			client:   mockKubeClient,
-			scheme:   &runtime.Scheme{},
-			update:   map[string][]Update{},
-			mutex:    &sync.Mutex{},
-			Config:   &opconfig.Config{},
+			Offering:       mockOrdererReconcile,
+			RestartManager: mockRestart,
+			client:         mockKubeClient,
+			scheme:         &runtime.Scheme{},
+			update:         map[string][]Update{},
+			mutex:          &sync.Mutex{},
+			Config:         &opconfig.Config{},
 		}
 		zaplogger, _ := util.SetupLogging("DEBUG")
 		reconciler.Config.Logger = zaplogger
@@ -240,6 +244,31 @@ var _ = Describe("ReconcileIBPOrderer", func() {
 				Expect(instance.Status.Type).To(Equal(current.Deployed))
 			})
 
+			It("sets the ready and deployment available conditions if pod is running", func() {
+				mockKubeClient.ListStub = func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+					switch obj.(type) {
+					case *corev1.PodList:
+						podList := obj.(*corev1.PodList)
+						pod := corev1.Pod{
+							Status: corev1.PodStatus{
+								Phase: corev1.PodRunning,
+							},
+						}
+						podList.Items = append(podList.Items, pod)
+					}
+					return nil
+				}
+
+				instance.Generation = 2
+				instance.Spec.ClusterSize = 1
+				err := reconciler.SetStatus(instance, nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(instance.Status.ObservedGeneration).To(Equal(int64(2)))
+				Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, current.ReadyCondition)).To(Equal(true))
+				Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, current.DeploymentAvailableCondition)).To(Equal(true))
+				Expect(meta.IsStatusConditionFalse(instance.Status.Conditions, current.RestartPendingCondition)).To(Equal(true))
+			})
+
 			It("sets the status to warning if the reconcile loop returns a warning status", func() {
 				mockKubeClient.ListStub = func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
 					switch obj.(type) {
@@ -541,6 +570,33 @@ var _ = Describe("ReconcileIBPOrderer", func() {
 			Expect(reconciler.UpdateFunc(e)).To(Equal(true))
 		})
 
+		It("detects cluster size update of a deployed parent", func() {
+			oldParent := &current.IBPOrderer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "orderer1",
+				},
+				Spec: current.IBPOrdererSpec{
+					ClusterSize: 3,
+				},
+				Status: current.IBPOrdererStatus{
+					CRStatus: current.CRStatus{
+						Type: current.Deployed,
+					},
+				},
+			}
+			newParent := oldParent.DeepCopy()
+
+			e = event.UpdateEvent{
+				ObjectOld: oldParent,
+				ObjectNew: newParent,
+			}
+			Expect(reconciler.UpdateFunc(e)).To(Equal(false))
+
+			newParent.Spec.ClusterSize = 1
+			Expect(reconciler.UpdateFunc(e)).To(Equal(true))
+			Expect(reconciler.GetUpdateStatus(oldParent).ClusterSizeUpdated()).To(Equal(true))
+		})
+
 		It("properly pops update flags from stack", func() {
 			By("popping first update - config overrides", func() {
 				Expect(reconciler.GetUpdateStatus(instance).ConfigOverridesUpdated()).To(Equal(true))
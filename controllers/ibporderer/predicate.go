@@ -50,6 +50,7 @@ type Update struct {
 	nodeOUUpdated         bool
 	imagesUpdated         bool
 	fabricVersionUpdated  bool
+	clusterSizeUpdated    bool
 	// update GetUpdateStackWithTrues when new fields are added
 }
 
@@ -73,7 +74,8 @@ func (u *Update) Detected() bool {
 		u.migrateToV25 ||
 		u.nodeOUUpdated ||
 		u.imagesUpdated ||
-		u.fabricVersionUpdated
+		u.fabricVersionUpdated ||
+		u.clusterSizeUpdated
 }
 
 func (u *Update) SpecUpdated() bool {
@@ -269,6 +271,9 @@ func (u *Update) GetUpdateStackWithTrues() string {
 	if u.fabricVersionUpdated {
 		stack += "fabricVersionUpdated "
 	}
+	if u.clusterSizeUpdated {
+		stack += "clusterSizeUpdated "
+	}
 
 	if len(stack) == 0 {
 		stack = "emptystack "
@@ -287,6 +292,11 @@ func (u *Update) FabricVersionUpdated() bool {
 	return u.fabricVersionUpdated
 }
 
+// ClusterSizeUpdated returns true if the cluster size of the parent updated
+func (u *Update) ClusterSizeUpdated() bool {
+	return u.clusterSizeUpdated
+}
+
 func imagesUpdated(old, new *current.IBPOrderer) bool {
 	if new.Spec.Images != nil {
 		if old.Spec.Images == nil {
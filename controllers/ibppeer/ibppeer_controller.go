@@ -30,20 +30,25 @@ import (
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
 	commoncontroller "github.com/IBM-Blockchain/fabric-operator/controllers/common"
 	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/conditions"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/events"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/global"
 	controllerclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/metrics"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering"
 	basepeer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
 	k8speer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/k8s/peer"
 	openshiftpeer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/openshift/peer"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/restart"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/restart/staggerrestarts"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
 	"github.com/IBM-Blockchain/fabric-operator/version"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/equality"
 	yaml "sigs.k8s.io/yaml"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -53,6 +58,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -90,14 +96,17 @@ func Add(mgr manager.Manager, config *config.Config) error {
 func newReconciler(mgr manager.Manager, cfg *config.Config) (*ReconcileIBPPeer, error) {
 	client := controllerclient.New(mgr.GetClient(), &global.ConfigSetter{Config: cfg.Operator.Globals})
 	scheme := mgr.GetScheme()
+	recorder := mgr.GetEventRecorderFor("ibppeer-controller")
 
 	ibppeer := &ReconcileIBPPeer{
 		client:         client,
 		scheme:         scheme,
 		Config:         cfg,
+		Recorder:       recorder,
 		update:         map[string][]Update{},
 		mutex:          &sync.Mutex{},
 		RestartService: staggerrestarts.New(client, cfg.Operator.Restart.Timeout.Get()),
+		RestartManager: restart.New(client, recorder, cfg.Operator.Restart.WaitTime.Get(), cfg.Operator.Restart.Timeout.Get()),
 	}
 
 	restClient, err := clientset.NewForConfig(mgr.GetConfig())
@@ -107,9 +116,9 @@ func newReconciler(mgr manager.Manager, cfg *config.Config) (*ReconcileIBPPeer,
 
 	switch cfg.Offering {
 	case offering.K8S:
-		ibppeer.Offering = k8speer.New(client, scheme, cfg)
+		ibppeer.Offering = k8speer.New(client, scheme, cfg, recorder)
 	case offering.OPENSHIFT:
-		ibppeer.Offering = openshiftpeer.New(client, scheme, cfg, restClient)
+		ibppeer.Offering = openshiftpeer.New(client, scheme, cfg, recorder, restClient)
 	}
 
 	return ibppeer, nil
@@ -150,6 +159,24 @@ func add(mgr manager.Manager, r *ReconcileIBPPeer) error {
 		return err
 	}
 
+	// Watch for changes to secondary resource StatefulSets and requeue the owner IBPPeer
+	err = c.Watch(&source.Kind{Type: &appsv1.StatefulSet{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &current.IBPPeer{},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the replicas of a peer and requeue the owner IBPPeer
+	err = c.Watch(&source.Kind{Type: &current.IBPPeer{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &current.IBPPeer{},
+	})
+	if err != nil {
+		return err
+	}
+
 	// Watch for changes to tertiary resource Secrets and requeue the owner IBPPeer
 	err = c.Watch(&source.Kind{Type: &corev1.Service{}}, &handler.EnqueueRequestForOwner{
 		IsController: true,
@@ -191,6 +218,8 @@ type ReconcileIBPPeer struct {
 	Offering       peerReconcile
 	Config         *config.Config
 	RestartService *staggerrestarts.StaggerRestartsService
+	RestartManager commoncontroller.RestartManager
+	Recorder       record.EventRecorder
 
 	update map[string][]Update
 	mutex  *sync.Mutex
@@ -232,6 +261,7 @@ func (r *ReconcileIBPPeer) Reconcile(ctx context.Context, request reconcile.Requ
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			metrics.DeleteInstance(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -259,12 +289,14 @@ func (r *ReconcileIBPPeer) Reconcile(ctx context.Context, request reconcile.Requ
 	reqLogger.Info(fmt.Sprintf("Reconciling IBPPeer '%s' with update values of [ %+v ]", instance.GetName(), update.GetUpdateStackWithTrues()))
 
 	result, err := r.Offering.Reconcile(instance, r.PopUpdate(instance.GetName()))
+	metrics.RecordReconcile("ibppeer", err)
 	setStatusErr := r.SetStatus(instance, result.Status, err)
 	if setStatusErr != nil {
 		return reconcile.Result{}, operatorerrors.IsBreakingError(setStatusErr, "failed to update status", log)
 	}
 
 	if err != nil {
+		events.Error(r.Recorder, instance, err)
 		return reconcile.Result{}, operatorerrors.IsBreakingError(errors.Wrapf(err, "Peer instance '%s' encountered error", instance.GetName()), "stopping reconcile loop", log)
 	}
 
@@ -307,92 +339,60 @@ func (r *ReconcileIBPPeer) SetStatus(instance *current.IBPPeer, reconcileStatus
 		return err
 	}
 
-	status := instance.Status.CRStatus
+	status := *instance.Status.CRStatus.DeepCopy()
 
 	if reconcileErr != nil {
 		status.Type = current.Error
 		status.Status = current.True
 		status.Reason = "errorOccurredDuringReconcile"
 		status.Message = reconcileErr.Error()
-		status.LastHeartbeatTime = time.Now().String()
 		status.ErrorCode = operatorerrors.GetErrorCode(reconcileErr)
+	} else {
+		status.Versions.Reconciled = instance.Spec.FabricVersion
 
-		instance.Status = current.IBPPeerStatus{
-			CRStatus: status,
-		}
-
-		log.Info(fmt.Sprintf("Updating status of IBPPeer custom resource to %s phase", instance.Status.Type))
-		err = r.client.PatchStatus(context.TODO(), instance, nil, controllerclient.PatchOption{
-			Resilient: &controllerclient.ResilientPatch{
-				Retry:    2,
-				Into:     &current.IBPPeer{},
-				Strategy: k8sclient.MergeFrom,
-			},
-		})
-		if err != nil {
-			return err
-		}
-
-		return nil
-	}
-
-	status.Versions.Reconciled = instance.Spec.FabricVersion
-
-	// Check if reconcile loop returned an updated status that differs from exisiting status.
-	// If so, set status to the reconcile status.
-	if reconcileStatus != nil {
-		if instance.Status.Type != reconcileStatus.Type || instance.Status.Reason != reconcileStatus.Reason || instance.Status.Message != reconcileStatus.Message {
+		// Check if reconcile loop returned an updated status that differs from exisiting status.
+		// If so, set status to the reconcile status.
+		if reconcileStatus != nil && (instance.Status.Type != reconcileStatus.Type || instance.Status.Reason != reconcileStatus.Reason || instance.Status.Message != reconcileStatus.Message) {
 			status.Type = reconcileStatus.Type
 			status.Status = current.True
 			status.Reason = reconcileStatus.Reason
 			status.Message = reconcileStatus.Message
-			status.LastHeartbeatTime = time.Now().String()
-
-			instance.Status = current.IBPPeerStatus{
-				CRStatus: status,
-			}
-
-			log.Info(fmt.Sprintf("Updating status of IBPPeer custom resource to %s phase", instance.Status.Type))
-			err := r.client.PatchStatus(context.TODO(), instance, nil, controllerclient.PatchOption{
-				Resilient: &controllerclient.ResilientPatch{
-					Retry:    2,
-					Into:     &current.IBPPeer{},
-					Strategy: k8sclient.MergeFrom,
-				},
-			})
+		} else if !instance.Spec.HasReplicas() {
+			running, err := r.GetPodStatus(instance)
 			if err != nil {
 				return err
 			}
+			conditions.SetDeploymentAvailable(&status, instance.GetGeneration(), running)
+
+			if running {
+				if !(instance.Status.Type == current.Deployed || instance.Status.Type == current.Warning) {
+					status.Type = current.Deployed
+					status.Status = current.True
+					status.Reason = "allPodsRunning"
+				}
+			} else if instance.Status.Type != current.Deploying {
+				status.Type = current.Deploying
+				status.Status = current.True
+				status.Reason = "waitingForPods"
+			}
+		}
 
-			return nil
+		if reconcileStatus != nil {
+			conditions.SetCertificatesValid(&status, instance.GetGeneration(), reconcileStatus)
 		}
 	}
 
-	running, err := r.GetPodStatus(instance)
-	if err != nil {
-		return err
-	}
+	commoncontroller.SetConditions(instance, &status, r.RestartManager)
 
-	if running {
-		if instance.Status.Type == current.Deployed || instance.Status.Type == current.Warning {
-			return nil
-		}
-		status.Type = current.Deployed
-		status.Status = current.True
-		status.Reason = "allPodsRunning"
-	} else {
-		if instance.Status.Type == current.Deploying {
-			return nil
-		}
-		status.Type = current.Deploying
-		status.Status = current.True
-		status.Reason = "waitingForPods"
+	// Errors are always reported to refresh the heartbeat, otherwise the status is
+	// only updated if it changed
+	if reconcileErr == nil && equality.Semantic.DeepEqual(instance.Status.CRStatus, status) {
+		return nil
 	}
 
-	instance.Status = current.IBPPeerStatus{
-		CRStatus: status,
-	}
-	instance.Status.LastHeartbeatTime = time.Now().String()
+	status.LastHeartbeatTime = time.Now().String()
+	instance.Status.CRStatus = status
+
 	log.Info(fmt.Sprintf("Updating status of IBPPeer custom resource to %s phase", instance.Status.Type))
 	err = r.client.PatchStatus(context.TODO(), instance, nil, controllerclient.PatchOption{
 		Resilient: &controllerclient.ResilientPatch{
@@ -702,6 +702,10 @@ func (r *ReconcileIBPPeer) UpdateFunc(e event.UpdateEvent) bool {
 			update.upgradedbs = true
 		}
 
+		if newPeer.Spec.Action.Snapshot.Channel != "" {
+			update.snapshot = true
+		}
+
 		if newPeer.Spec.Action.Enroll.Ecert == true {
 			update.ecertEnroll = true
 		}
@@ -753,6 +757,9 @@ func (r *ReconcileIBPPeer) UpdateFunc(e event.UpdateEvent) bool {
 			} else if util.IsSecretEcert(oldSecret.Name) {
 				update.ecertUpdated = true
 				log.Info(fmt.Sprintf("ecert update detected on IBPPeer custom resource %s", instanceName))
+			} else if util.IsSecretCertManagerTLSCert(oldSecret.Name) {
+				// The TLS secrets are updated from the certificate renewed by cert-manager during reconcile
+				log.Info(fmt.Sprintf("cert-manager TLS cert update detected on IBPPeer custom resource %s", instanceName))
 			} else {
 				return false
 			}
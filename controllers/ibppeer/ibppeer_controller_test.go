@@ -27,6 +27,8 @@ import (
 	opconfig "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
@@ -53,12 +55,15 @@ var _ = Describe("ReconcileIBPPeer", func() {
 		request           reconcile.Request
 		mockKubeClient    *mocks.Client
 		mockPeerReconcile *peermocks.PeerReconcile
+		mockRestart       *mocks.RestartManager
 		instance          *current.IBPPeer
+		recorder          *record.FakeRecorder
 	)
 
 	BeforeEach(func() {
 		mockKubeClient = &mocks.Client{}
 		mockPeerReconcile = &peermocks.PeerReconcile{}
+		mockRestart = &mocks.RestartManager{}
 		instance = &current.IBPPeer{
 			Spec: current.IBPPeerSpec{
 				Images: &current.PeerImages{
@@ -121,13 +126,16 @@ var _ = Describe("ReconcileIBPPeer", func() {
 			return nil
 		}
 
+		recorder = record.NewFakeRecorder(10)
 		reconciler = &ReconcileIBPPeer{
-			Offering: mockPeerReconcile,
-			client:   mockKubeClient,
-			scheme:   &runtime.Scheme{},
-			update:   map[string][]Update{},
-			mutex:    &sync.Mutex{},
-			Config:   &opconfig.Config{},
+			Recorder:       recorder,
+			Offering:       mockPeerReconcile,
+			RestartManager: mockRestart,
+			client:         mockKubeClient,
+			scheme:         &runtime.Scheme{},
+			update:         map[string][]Update{},
+			mutex:          &sync.Mutex{},
+			Config:         &opconfig.Config{},
 		}
 		zaplogger, _ := util.SetupLogging("DEBUG")
 		reconciler.Config.Logger = zaplogger
@@ -177,6 +185,13 @@ var _ = Describe("ReconcileIBPPeer", func() {
 			_, err := reconciler.Reconcile(context.TODO(), request)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("records a warning event if it encountered an error", func() {
+			mockPeerReconcile.ReconcileReturns(common.Result{}, operatorerrors.New(operatorerrors.InvalidDeploymentCreateRequest, "invalid request"))
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning InvalidDeploymentCreateRequest ")))
+		})
 	})
 
 	Context("update reconcile", func() {
@@ -660,6 +675,72 @@ var _ = Describe("ReconcileIBPPeer", func() {
 			reconciler.SetStatus(instance, nil, nil)
 			Expect(instance.Status.Type).To(Equal(current.Deployed))
 		})
+
+		Context("conditions", func() {
+			BeforeEach(func() {
+				mockKubeClient.ListStub = func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+					podList := obj.(*corev1.PodList)
+					pod := corev1.Pod{
+						Status: corev1.PodStatus{
+							Phase: corev1.PodRunning,
+						},
+					}
+					podList.Items = append(podList.Items, pod)
+					return nil
+				}
+			})
+
+			It("sets the ready and deployment available conditions if pod is running", func() {
+				err := reconciler.SetStatus(instance, nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, current.ReadyCondition)).To(Equal(true))
+				Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, current.DeploymentAvailableCondition)).To(Equal(true))
+				Expect(meta.IsStatusConditionFalse(instance.Status.Conditions, current.RestartPendingCondition)).To(Equal(true))
+			})
+
+			It("sets the ready condition to false if error occured during reconciliation", func() {
+				err := reconciler.SetStatus(instance, nil, errors.New("ibppeer error"))
+				Expect(err).NotTo(HaveOccurred())
+				condition := meta.FindStatusCondition(instance.Status.Conditions, current.ReadyCondition)
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Message).To(Equal("ibppeer error"))
+			})
+
+			It("sets the restart pending condition if restart requests are pending", func() {
+				mockRestart.PendingReasonsReturns([]string{"tlsUpdate"}, nil)
+				err := reconciler.SetStatus(instance, nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+				condition := meta.FindStatusCondition(instance.Status.Conditions, current.RestartPendingCondition)
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Message).To(ContainSubstring("tlsUpdate"))
+			})
+
+			It("keeps the certificates valid condition when a certificate is expiring", func() {
+				err := reconciler.SetStatus(instance, &current.CRStatus{
+					Type:    current.Warning,
+					Reason:  "certRenewalRequired",
+					Message: "tls-test-peer-signcert expires soon",
+				}, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(instance.Status.Type).To(Equal(current.Warning))
+				Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, current.ReadyCondition)).To(Equal(true))
+				Expect(meta.IsStatusConditionFalse(instance.Status.Conditions, current.CertificatesValidCondition)).To(Equal(true))
+			})
+
+			It("sets the observed generation", func() {
+				mockKubeClient.GetStub = func(ctx context.Context, types types.NamespacedName, obj client.Object) error {
+					if o, ok := obj.(*current.IBPPeer); ok {
+						o.Name = instance.Name
+						o.Generation = 3
+						instance = o
+					}
+					return nil
+				}
+				err := reconciler.SetStatus(instance, nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(instance.Status.ObservedGeneration).To(Equal(int64(3)))
+			})
+		})
 	})
 
 	Context("create func predicate", func() {
@@ -43,6 +43,7 @@ type Update struct {
 	ecertEnroll           bool
 	tlscertEnroll         bool
 	upgradedbs            bool
+	snapshot              bool
 	tlsCertCreated        bool
 	ecertCreated          bool
 	nodeOUUpdated         bool
@@ -120,6 +121,10 @@ func (u *Update) UpgradeDBs() bool {
 	return u.upgradedbs
 }
 
+func (u *Update) SnapshotRequested() bool {
+	return u.snapshot
+}
+
 func (u *Update) EcertEnroll() bool {
 	return u.ecertEnroll
 }
@@ -194,6 +199,7 @@ func (u *Update) Needed() bool {
 		u.mspUpdated ||
 		u.ecertEnroll ||
 		u.upgradedbs ||
+		u.snapshot ||
 		u.nodeOUUpdated ||
 		u.imagesUpdated ||
 		u.fabricVersionUpdated
@@ -248,6 +254,9 @@ func (u *Update) GetUpdateStackWithTrues() string {
 	if u.upgradedbs {
 		stack += "upgradedbs "
 	}
+	if u.snapshot {
+		stack += "snapshot "
+	}
 	if u.tlsCertCreated {
 		stack += "tlsCertCreated "
 	}
@@ -55,7 +55,9 @@ func GetOperatorConfig(configs, caFiles, peerFiles, ordererFiles, consoleFiles s
 			RoleBindingFile:        filepath.Join(caFiles, "rolebinding.yaml"),
 			ConfigMapFile:          filepath.Join(caFiles, "configmap-caoverride.yaml"),
 			IngressFile:            filepath.Join(caFiles, "ingress.yaml"),
-			Ingressv1beta1File:     filepath.Join(caFiles, "ingressv1beta1.yaml"),
+			TLSRouteFile:           filepath.Join(caFiles, "tlsroute.yaml"),
+			HTTPRouteFile:          filepath.Join(caFiles, "httproute.yaml"),
+			BackendTLSPolicyFile:   filepath.Join(caFiles, "backendtlspolicy.yaml"),
 			RouteFile:              filepath.Join(caFiles, "route.yaml"),
 			SharedPath:             "/tmp/data",
 		},
@@ -66,6 +68,7 @@ func GetOperatorConfig(configs, caFiles, peerFiles, ordererFiles, consoleFiles s
 			OUFile:                 filepath.Join(configs, "peer/ouconfig.yaml"),
 			InterOUFile:            filepath.Join(configs, "peer/ouconfig-inter.yaml"),
 			DeploymentFile:         filepath.Join(peerFiles, "deployment.yaml"),
+			StatefulSetFile:        filepath.Join(peerFiles, "statefulset.yaml"),
 			PVCFile:                filepath.Join(peerFiles, "pvc.yaml"),
 			CouchDBPVCFile:         filepath.Join(peerFiles, "couchdb-pvc.yaml"),
 			ServiceFile:            filepath.Join(peerFiles, "service.yaml"),
@@ -75,30 +78,35 @@ func GetOperatorConfig(configs, caFiles, peerFiles, ordererFiles, consoleFiles s
 			CouchContainerFile:     filepath.Join(peerFiles, "couchdb.yaml"),
 			CouchInitContainerFile: filepath.Join(peerFiles, "couchdb-init.yaml"),
 			IngressFile:            filepath.Join(peerFiles, "ingress.yaml"),
-			Ingressv1beta1File:     filepath.Join(peerFiles, "ingressv1beta1.yaml"),
+			TLSRouteFile:           filepath.Join(peerFiles, "tlsroute.yaml"),
+			HTTPRouteFile:          filepath.Join(peerFiles, "httproute.yaml"),
+			BackendTLSPolicyFile:   filepath.Join(peerFiles, "backendtlspolicy.yaml"),
 			CCLauncherFile:         filepath.Join(peerFiles, "chaincode-launcher.yaml"),
 			RouteFile:              filepath.Join(peerFiles, "route.yaml"),
 			StoragePath:            "/tmp/peerinit",
 		},
 		OrdererInitConfig: &ordererinit.Config{
-			OrdererV2File:      filepath.Join(configs, "orderer/v2/orderer.yaml"),
-			OrdererV24File:     filepath.Join(configs, "orderer/v24/orderer.yaml"),
-			OrdererV25File:     filepath.Join(configs, "orderer/v25/orderer.yaml"),
-			OrdererFile:        filepath.Join(configs, "orderer/orderer.yaml"),
-			ConfigTxFile:       filepath.Join(configs, "orderer/configtx.yaml"),
-			OUFile:             filepath.Join(configs, "orderer/ouconfig.yaml"),
-			InterOUFile:        filepath.Join(configs, "orderer/ouconfig-inter.yaml"),
-			DeploymentFile:     filepath.Join(ordererFiles, "deployment.yaml"),
-			PVCFile:            filepath.Join(ordererFiles, "pvc.yaml"),
-			ServiceFile:        filepath.Join(ordererFiles, "service.yaml"),
-			CMFile:             filepath.Join(ordererFiles, "configmap.yaml"),
-			RoleFile:           filepath.Join(ordererFiles, "role.yaml"),
-			ServiceAccountFile: filepath.Join(ordererFiles, "serviceaccount.yaml"),
-			RoleBindingFile:    filepath.Join(ordererFiles, "rolebinding.yaml"),
-			IngressFile:        filepath.Join(ordererFiles, "ingress.yaml"),
-			Ingressv1beta1File: filepath.Join(ordererFiles, "ingressv1beta1.yaml"),
-			RouteFile:          filepath.Join(ordererFiles, "route.yaml"),
-			StoragePath:        "/tmp/ordererinit",
+			OrdererV2File:        filepath.Join(configs, "orderer/v2/orderer.yaml"),
+			OrdererV24File:       filepath.Join(configs, "orderer/v24/orderer.yaml"),
+			OrdererV25File:       filepath.Join(configs, "orderer/v25/orderer.yaml"),
+			OrdererFile:          filepath.Join(configs, "orderer/orderer.yaml"),
+			ConfigTxFile:         filepath.Join(configs, "orderer/configtx.yaml"),
+			OUFile:               filepath.Join(configs, "orderer/ouconfig.yaml"),
+			InterOUFile:          filepath.Join(configs, "orderer/ouconfig-inter.yaml"),
+			DeploymentFile:       filepath.Join(ordererFiles, "deployment.yaml"),
+			StatefulSetFile:      filepath.Join(ordererFiles, "statefulset.yaml"),
+			PVCFile:              filepath.Join(ordererFiles, "pvc.yaml"),
+			ServiceFile:          filepath.Join(ordererFiles, "service.yaml"),
+			CMFile:               filepath.Join(ordererFiles, "configmap.yaml"),
+			RoleFile:             filepath.Join(ordererFiles, "role.yaml"),
+			ServiceAccountFile:   filepath.Join(ordererFiles, "serviceaccount.yaml"),
+			RoleBindingFile:      filepath.Join(ordererFiles, "rolebinding.yaml"),
+			IngressFile:          filepath.Join(ordererFiles, "ingress.yaml"),
+			TLSRouteFile:         filepath.Join(ordererFiles, "tlsroute.yaml"),
+			HTTPRouteFile:        filepath.Join(ordererFiles, "httproute.yaml"),
+			BackendTLSPolicyFile: filepath.Join(ordererFiles, "backendtlspolicy.yaml"),
+			RouteFile:            filepath.Join(ordererFiles, "route.yaml"),
+			StoragePath:          "/tmp/ordererinit",
 		},
 		ConsoleInitConfig: &config.ConsoleConfig{
 			DeploymentFile:           filepath.Join(consoleFiles, "deployment.yaml"),
@@ -111,7 +119,7 @@ func GetOperatorConfig(configs, caFiles, peerFiles, ordererFiles, consoleFiles s
 			RoleBindingFile:          filepath.Join(consoleFiles, "rolebinding.yaml"),
 			ServiceAccountFile:       filepath.Join(consoleFiles, "serviceaccount.yaml"),
 			IngressFile:              filepath.Join(consoleFiles, "ingress.yaml"),
-			Ingressv1beta1File:       filepath.Join(consoleFiles, "ingressv1beta1.yaml"),
+			TLSRouteFile:             filepath.Join(consoleFiles, "tlsroute.yaml"),
 			NetworkPolicyIngressFile: filepath.Join(consoleFiles, "networkpolicy-ingress.yaml"),
 			NetworkPolicyDenyAllFile: filepath.Join(consoleFiles, "networkpolicy-denyall.yaml"),
 		},
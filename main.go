@@ -131,7 +131,9 @@ func setDefaultCADefinitions(cfg *config.Config) {
 		RoleBindingFile:        filepath.Join(defaultCADef, "rolebinding.yaml"),
 		ConfigMapFile:          filepath.Join(defaultCADef, "configmap-caoverride.yaml"),
 		IngressFile:            filepath.Join(defaultCADef, "ingress.yaml"),
-		Ingressv1beta1File:     filepath.Join(defaultCADef, "ingressv1beta1.yaml"),
+		TLSRouteFile:           filepath.Join(defaultCADef, "tlsroute.yaml"),
+		HTTPRouteFile:          filepath.Join(defaultCADef, "httproute.yaml"),
+		BackendTLSPolicyFile:   filepath.Join(defaultCADef, "backendtlspolicy.yaml"),
 		RouteFile:              filepath.Join(defaultCADef, "route.yaml"),
 		SharedPath:             "/tmp/data",
 	}
@@ -145,6 +147,7 @@ func setDefaultPeerDefinitions(cfg *config.Config) {
 		CorePeerV2File:         filepath.Join(defaultConfigs, "peer/v2/core.yaml"),
 		CorePeerV25File:        filepath.Join(defaultConfigs, "peer/v25/core.yaml"),
 		DeploymentFile:         filepath.Join(defaultPeerDef, "deployment.yaml"),
+		StatefulSetFile:        filepath.Join(defaultPeerDef, "statefulset.yaml"),
 		PVCFile:                filepath.Join(defaultPeerDef, "pvc.yaml"),
 		CouchDBPVCFile:         filepath.Join(defaultPeerDef, "couchdb-pvc.yaml"),
 		ServiceFile:            filepath.Join(defaultPeerDef, "service.yaml"),
@@ -154,7 +157,9 @@ func setDefaultPeerDefinitions(cfg *config.Config) {
 		CouchContainerFile:     filepath.Join(defaultPeerDef, "couchdb.yaml"),
 		CouchInitContainerFile: filepath.Join(defaultPeerDef, "couchdb-init.yaml"),
 		IngressFile:            filepath.Join(defaultPeerDef, "ingress.yaml"),
-		Ingressv1beta1File:     filepath.Join(defaultPeerDef, "ingressv1beta1.yaml"),
+		TLSRouteFile:           filepath.Join(defaultPeerDef, "tlsroute.yaml"),
+		HTTPRouteFile:          filepath.Join(defaultPeerDef, "httproute.yaml"),
+		BackendTLSPolicyFile:   filepath.Join(defaultPeerDef, "backendtlspolicy.yaml"),
 		CCLauncherFile:         filepath.Join(defaultPeerDef, "chaincode-launcher.yaml"),
 		RouteFile:              filepath.Join(defaultPeerDef, "route.yaml"),
 		StoragePath:            "/tmp/peerinit",
@@ -163,24 +168,27 @@ func setDefaultPeerDefinitions(cfg *config.Config) {
 
 func setDefaultOrdererDefinitions(cfg *config.Config) {
 	cfg.OrdererInitConfig = &ordererinit.Config{
-		OrdererV2File:      filepath.Join(defaultConfigs, "orderer/v2/orderer.yaml"),
-		OrdererV24File:     filepath.Join(defaultConfigs, "orderer/v24/orderer.yaml"),
-		OrdererV25File:     filepath.Join(defaultConfigs, "orderer/v25/orderer.yaml"),
-		OrdererFile:        filepath.Join(defaultConfigs, "orderer/orderer.yaml"),
-		ConfigTxFile:       filepath.Join(defaultConfigs, "orderer/configtx.yaml"),
-		OUFile:             filepath.Join(defaultConfigs, "orderer/ouconfig.yaml"),
-		InterOUFile:        filepath.Join(defaultConfigs, "orderer/ouconfig-inter.yaml"),
-		DeploymentFile:     filepath.Join(defaultOrdererDef, "deployment.yaml"),
-		PVCFile:            filepath.Join(defaultOrdererDef, "pvc.yaml"),
-		ServiceFile:        filepath.Join(defaultOrdererDef, "service.yaml"),
-		CMFile:             filepath.Join(defaultOrdererDef, "configmap.yaml"),
-		RoleFile:           filepath.Join(defaultOrdererDef, "role.yaml"),
-		ServiceAccountFile: filepath.Join(defaultOrdererDef, "serviceaccount.yaml"),
-		RoleBindingFile:    filepath.Join(defaultOrdererDef, "rolebinding.yaml"),
-		IngressFile:        filepath.Join(defaultOrdererDef, "ingress.yaml"),
-		Ingressv1beta1File: filepath.Join(defaultOrdererDef, "ingressv1beta1.yaml"),
-		RouteFile:          filepath.Join(defaultOrdererDef, "route.yaml"),
-		StoragePath:        "/tmp/ordererinit",
+		OrdererV2File:        filepath.Join(defaultConfigs, "orderer/v2/orderer.yaml"),
+		OrdererV24File:       filepath.Join(defaultConfigs, "orderer/v24/orderer.yaml"),
+		OrdererV25File:       filepath.Join(defaultConfigs, "orderer/v25/orderer.yaml"),
+		OrdererFile:          filepath.Join(defaultConfigs, "orderer/orderer.yaml"),
+		ConfigTxFile:         filepath.Join(defaultConfigs, "orderer/configtx.yaml"),
+		OUFile:               filepath.Join(defaultConfigs, "orderer/ouconfig.yaml"),
+		InterOUFile:          filepath.Join(defaultConfigs, "orderer/ouconfig-inter.yaml"),
+		DeploymentFile:       filepath.Join(defaultOrdererDef, "deployment.yaml"),
+		StatefulSetFile:      filepath.Join(defaultOrdererDef, "statefulset.yaml"),
+		PVCFile:              filepath.Join(defaultOrdererDef, "pvc.yaml"),
+		ServiceFile:          filepath.Join(defaultOrdererDef, "service.yaml"),
+		CMFile:               filepath.Join(defaultOrdererDef, "configmap.yaml"),
+		RoleFile:             filepath.Join(defaultOrdererDef, "role.yaml"),
+		ServiceAccountFile:   filepath.Join(defaultOrdererDef, "serviceaccount.yaml"),
+		RoleBindingFile:      filepath.Join(defaultOrdererDef, "rolebinding.yaml"),
+		IngressFile:          filepath.Join(defaultOrdererDef, "ingress.yaml"),
+		TLSRouteFile:         filepath.Join(defaultOrdererDef, "tlsroute.yaml"),
+		HTTPRouteFile:        filepath.Join(defaultOrdererDef, "httproute.yaml"),
+		BackendTLSPolicyFile: filepath.Join(defaultOrdererDef, "backendtlspolicy.yaml"),
+		RouteFile:            filepath.Join(defaultOrdererDef, "route.yaml"),
+		StoragePath:          "/tmp/ordererinit",
 	}
 }
 
@@ -197,7 +205,7 @@ func setDefaultConsoleDefinitions(cfg *config.Config) {
 		ServiceAccountFile:       filepath.Join(defaultConsoleDef, "serviceaccount.yaml"),
 		RoleBindingFile:          filepath.Join(defaultConsoleDef, "rolebinding.yaml"),
 		IngressFile:              filepath.Join(defaultConsoleDef, "ingress.yaml"),
-		Ingressv1beta1File:       filepath.Join(defaultConsoleDef, "ingressv1beta1.yaml"),
+		TLSRouteFile:             filepath.Join(defaultConsoleDef, "tlsroute.yaml"),
 		RouteFile:                filepath.Join(defaultConsoleDef, "route.yaml"),
 		NetworkPolicyIngressFile: filepath.Join(defaultConsoleDef, "networkpolicy-ingress.yaml"),
 		NetworkPolicyDenyAllFile: filepath.Join(defaultConsoleDef, "networkpolicy-denyall.yaml"),
@@ -50,6 +50,6 @@ type ConsoleConfig struct {
 	RoleBindingFile          string
 	ServiceAccountFile       string
 	IngressFile              string
-	Ingressv1beta1File       string
+	TLSRouteFile             string
 	RouteFile                string
 }
@@ -21,6 +21,7 @@ package operatorconfig
 import (
 	"context"
 
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/apis/common"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/apis/deployer"
 	cainit "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/ca"
@@ -84,6 +85,7 @@ type Operator struct {
 	Peer     Peer               `json:"peer" yaml:"peer"`
 	CA       CA                 `json:"ca" yaml:"ca"`
 	Console  Console            `json:"console" yaml:"console"`
+	Backup   Backup             `json:"backup" yaml:"backup"`
 	Restart  Restart            `json:"restart" yaml:"restart"`
 	Versions *deployer.Versions `json:"versions,omitempty" yaml:"versions,omitempty"`
 	Globals  Globals            `json:"globals,omitempty" yaml:"globals,omitempty" envconfig:"optional"`
@@ -144,14 +146,19 @@ type DisableRestart struct {
 }
 
 type Globals struct {
-	SecurityContext         *container.SecurityContext `json:"securityContext,omitempty" yaml:"securityContext,omitempty"`
-	AllowKubernetesEighteen string                     `json:"allowKubernetesEighteen,omitempty" yaml:"allowKubernetesEighteen,omitempty"`
+	SecurityContext *container.SecurityContext `json:"securityContext,omitempty" yaml:"securityContext,omitempty"`
 }
 
 type Debug struct {
 	DisableDeploymentChecks string `json:"disableDeploymentChecks,omitempty" yaml:"disableDeploymentChecks,omitempty"`
 }
 
+// Backup defines configurable properties for IBPBackup custom resource
+type Backup struct {
+	// Images are the default images of the archive jobs
+	Images current.BackupImages `json:"images" yaml:"images"`
+}
+
 type Console struct {
 	ApplyNetworkPolicy string `json:"applyNetworkPolicy" yaml:"applyNetworkPolicy"`
 }
@@ -192,6 +199,14 @@ func (o *Operator) SetDefaults() {
 				},
 			},
 		},
+		Backup: Backup{
+			Images: current.BackupImages{
+				ArchiveImage: BackupArchiveImage,
+				ArchiveTag:   LatestTag,
+				UploadImage:  BackupUploadImage,
+				UploadTag:    LatestTag,
+			},
+		},
 		Restart: Restart{
 			WaitTime: common.MustParseDuration("10m"),
 			Timeout:  common.MustParseDuration("5m"),
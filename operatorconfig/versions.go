@@ -27,6 +27,12 @@ const (
 	FabricVersion   = "2.5.8"
 )
 
+const (
+	// BackupArchiveImage provides tar, which is not part of the minimal init image
+	BackupArchiveImage = "registry.access.redhat.com/ubi8/ubi"
+	BackupUploadImage  = "minio/mc"
+)
+
 func getDefaultVersions() *deployer.Versions {
 	return &deployer.Versions{
 		CA: map[string]deployer.VersionCA{
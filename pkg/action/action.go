@@ -27,6 +27,7 @@ import (
 	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
 
 	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -44,6 +45,9 @@ func Restart(client k8sclient.Client, name, namespace string) error {
 	deployment := &appsv1.Deployment{}
 	err := client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, deployment)
 	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return restartStatefulSet(client, name, namespace)
+		}
 		return err
 	}
 
@@ -70,6 +74,33 @@ func Restart(client k8sclient.Client, name, namespace string) error {
 	return nil
 }
 
+// restartStatefulSet restarts the pods of a component that runs as a stateful set
+func restartStatefulSet(client k8sclient.Client, name, namespace string) error {
+	sts := &appsv1.StatefulSet{}
+	err := client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, sts)
+	if err != nil {
+		return err
+	}
+
+	if sts.Spec.Template.ObjectMeta.Annotations == nil {
+		sts.Spec.Template.ObjectMeta.Annotations = make(map[string]string)
+	}
+	sts.Spec.Template.ObjectMeta.Annotations["kubectl.kubernetes.io/restartedAt"] = time.Now().Format(time.RFC3339)
+
+	err = client.Patch(context.TODO(), sts, nil, k8sclient.PatchOption{
+		Resilient: &k8sclient.ResilientPatch{
+			Retry:    3,
+			Into:     &appsv1.StatefulSet{},
+			Strategy: runtimeclient.MergeFrom,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 //go:generate counterfeiter -o mocks/reenroller.go -fake-name Reenroller . Reenroller
 
 type Reenroller interface {
@@ -77,7 +77,12 @@ func UpgradeDBs(deploymentManager DeploymentReset, client controller.Client, ins
 		return errors.Wrap(err, "failed to get deployment")
 	}
 
-	dep := deployment.New(obj.(*appsv1.Deployment))
+	d, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		return errors.Errorf("database migration is only supported for peers that run as a deployment, '%s' does not", instance.GetName())
+	}
+
+	dep := deployment.New(d)
 	originalReplicas := dep.Spec.Replicas
 
 	// Need to set replica to 0, otherwise migration job won't be able start to due to
@@ -0,0 +1,446 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	jobv1 "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/job"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/statefulset"
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("backup")
+
+// PendingRequeueInterval is the interval at which backups in progress are checked
+const PendingRequeueInterval = 30 * time.Second
+
+// maxNameLength is the maximum length of the name of a job, which is also used as label value
+const maxNameLength = 63
+
+// Result is the outcome of reconciling an IBPBackup
+type Result struct {
+	// LastScheduleTime is the time the last backup was scheduled
+	LastScheduleTime *metav1.Time
+
+	// Backups are the backups in progress and the completed backups kept by the retention
+	Backups []current.BackupRecord
+
+	// RequeueAfter is the time until the next backup is scheduled or the backups
+	// in progress need to be checked again, zero if nothing is scheduled
+	RequeueAfter time.Duration
+}
+
+// Backup backs up the volumes of components on a schedule
+type Backup struct {
+	Client k8sclient.Client
+	Scheme *runtime.Scheme
+
+	// Images are the default images of the archive jobs
+	Images current.BackupImages
+
+	// Now returns the current time
+	Now func() time.Time
+}
+
+func New(client k8sclient.Client, scheme *runtime.Scheme, images current.BackupImages) *Backup {
+	return &Backup{
+		Client: client,
+		Scheme: scheme,
+		Images: images,
+		Now:    time.Now,
+	}
+}
+
+// Reconcile updates the phase of the backups in progress, starts a backup of every
+// volume of the components when the schedule is due and prunes the backups that
+// exceed the retention
+func (b *Backup) Reconcile(instance *current.IBPBackup) (*Result, error) {
+	schedule, err := ParseSchedule(instance.Spec.Schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	if instance.GetMethod() == current.BackupArchive && instance.Spec.ObjectStorage == nil {
+		return nil, errors.New("object storage is required for archive backups")
+	}
+
+	now := b.Now()
+	result := &Result{
+		LastScheduleTime: instance.Status.LastScheduleTime,
+		Backups:          append([]current.BackupRecord{}, instance.Status.Backups...),
+	}
+
+	for i, record := range result.Backups {
+		if record.Phase != current.BackupPending {
+			continue
+		}
+
+		phase, err := b.phase(instance, record)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get phase of backup '%s'", record.Name)
+		}
+		result.Backups[i].Phase = phase
+	}
+
+	last := instance.GetCreationTimestamp().Time
+	if instance.Status.LastScheduleTime != nil {
+		last = instance.Status.LastScheduleTime.Time
+	}
+
+	next := schedule.Next(last)
+	if !instance.Spec.Suspend && !next.IsZero() && !next.After(now) {
+		log.Info(fmt.Sprintf("Starting scheduled backup '%s'", instance.GetName()))
+		started, err := b.start(instance, next, now)
+		if err != nil {
+			return nil, err
+		}
+		result.Backups = append(result.Backups, started...)
+		result.LastScheduleTime = &metav1.Time{Time: now}
+		next = schedule.Next(now)
+	}
+
+	result.Backups, err = b.prune(instance, result.Backups, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if !instance.Spec.Suspend && !next.IsZero() {
+		result.RequeueAfter = next.Sub(now)
+	}
+	if len((&current.IBPBackupStatus{Backups: result.Backups}).Pending()) > 0 {
+		if result.RequeueAfter == 0 || result.RequeueAfter > PendingRequeueInterval {
+			result.RequeueAfter = PendingRequeueInterval
+		}
+	}
+
+	return result, nil
+}
+
+// start starts a backup of every volume of the components. The names of the jobs and
+// snapshots and the archive locations are derived from the scheduled time rather than
+// the current time, so a reconcile that is retried after some of them were created,
+// before the status was updated, does not start a second backup of the same volumes:
+// the client ignores objects that already exist.
+func (b *Backup) start(instance *current.IBPBackup, scheduled, now time.Time) ([]current.BackupRecord, error) {
+	records := []current.BackupRecord{}
+	for _, component := range instance.Spec.Components {
+		volumes, err := b.Volumes(instance.GetNamespace(), component)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, volume := range volumes {
+			record := current.BackupRecord{
+				Name:      backupName(instance.GetName(), volume, scheduled),
+				Component: component,
+				Volume:    volume,
+				Method:    instance.GetMethod(),
+				Phase:     current.BackupPending,
+				StartTime: metav1.Time{Time: now},
+			}
+
+			switch record.Method {
+			case current.BackupSnapshot:
+				record.Location = record.Name
+				err = b.Client.Create(context.TODO(), volumeSnapshot(instance, record), k8sclient.CreateOption{
+					Owner:  instance,
+					Scheme: b.Scheme,
+				})
+			default:
+				record.Location = archiveLocation(instance, volume, scheduled)
+				var job *batchv1.Job
+				job, err = b.archiveJob(instance, record)
+				if err != nil {
+					return nil, err
+				}
+				err = b.Client.Create(context.TODO(), job, k8sclient.CreateOption{
+					Owner:  instance,
+					Scheme: b.Scheme,
+				})
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to start backup of volume '%s'", volume)
+			}
+
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// Volumes returns the persistent volume claims of the component that exist, volumes
+// that are not used by the component, e.g. the CA database volume when the CA uses
+// a remote database, are skipped. For components that run as a stateful set both the
+// claims created from the volume claim templates for every ordinal of the stateful set
+// and the claims of the deployment the stateful set replaced are returned, as the latter
+// are kept when the component is migrated. The volumes are in use by the running
+// component, archives of them are crash-consistent.
+func (b *Backup) Volumes(namespace string, component current.BackupComponent) ([]string, error) {
+	nn := types.NamespacedName{Name: component.Name, Namespace: namespace}
+
+	claims := []string{}
+	switch component.Kind {
+	case "IBPPeer":
+		peer := &current.IBPPeer{}
+		if err := b.Client.Get(context.TODO(), nn, peer); err != nil {
+			return nil, errors.Wrapf(err, "failed to get peer '%s'", component.Name)
+		}
+		claims = append(claims, claimName(peer.Name+"-pvc", peer.Spec.CustomNames.PVC.Peer))
+		if peer.UsingCouchDB() {
+			claims = append(claims, claimName(peer.Name+"-statedb-pvc", peer.Spec.CustomNames.PVC.StateDB))
+		}
+		if peer.Spec.UsesStatefulSet() {
+			templates := []string{"fabric-peer-0"}
+			if peer.UsingCouchDB() {
+				templates = append(templates, "db-data")
+			}
+			stsClaims, err := b.statefulSetClaims(namespace, peer.Name, templates...)
+			if err != nil {
+				return nil, err
+			}
+			claims = append(claims, stsClaims...)
+		}
+	case "IBPOrderer":
+		orderer := &current.IBPOrderer{}
+		if err := b.Client.Get(context.TODO(), nn, orderer); err != nil {
+			return nil, errors.Wrapf(err, "failed to get orderer '%s'", component.Name)
+		}
+		claims = append(claims, claimName(orderer.Name+"-pvc", orderer.Spec.CustomNames.PVC.Orderer))
+		if orderer.Spec.UsesStatefulSet() {
+			stsClaims, err := b.statefulSetClaims(namespace, orderer.Name, "orderer-data")
+			if err != nil {
+				return nil, err
+			}
+			claims = append(claims, stsClaims...)
+		}
+	case "IBPCA":
+		ca := &current.IBPCA{}
+		if err := b.Client.Get(context.TODO(), nn, ca); err != nil {
+			return nil, errors.Wrapf(err, "failed to get CA '%s'", component.Name)
+		}
+		claims = append(claims, claimName(ca.Name+"-pvc", ca.Spec.CustomNames.PVC.CA))
+	default:
+		return nil, errors.Errorf("kind '%s' of component '%s' is not supported", component.Kind, component.Name)
+	}
+
+	volumes := []string{}
+	for _, claim := range claims {
+		pvc := &corev1.PersistentVolumeClaim{}
+		err := b.Client.Get(context.TODO(), types.NamespacedName{Name: claim, Namespace: namespace}, pvc)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				log.Info(fmt.Sprintf("Skipping backup of volume '%s' of '%s', volume does not exist", claim, component.Name))
+				continue
+			}
+			return nil, err
+		}
+		volumes = append(volumes, claim)
+	}
+
+	return volumes, nil
+}
+
+// statefulSetClaims returns the names of the claims created from the volume claim templates
+// for every ordinal of the stateful set, ordinal 0 is assumed if the stateful set doesn't exist
+func (b *Backup) statefulSetClaims(namespace, name string, templates ...string) ([]string, error) {
+	replicas := 1
+	sts := &appsv1.StatefulSet{}
+	err := b.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, sts)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to get stateful set '%s'", name)
+		}
+	} else if sts.Spec.Replicas != nil && *sts.Spec.Replicas > 1 {
+		replicas = int(*sts.Spec.Replicas)
+	}
+
+	claims := []string{}
+	for ordinal := 0; ordinal < replicas; ordinal++ {
+		for _, template := range templates {
+			claims = append(claims, statefulset.ClaimName(template, name, ordinal))
+		}
+	}
+
+	return claims, nil
+}
+
+// phase returns the phase of the backup, finished archive jobs are deleted
+func (b *Backup) phase(instance *current.IBPBackup, record current.BackupRecord) (current.BackupPhase, error) {
+	nn := types.NamespacedName{Name: record.Name, Namespace: instance.GetNamespace()}
+
+	if record.Method == current.BackupSnapshot {
+		snapshot := newVolumeSnapshot(instance.GetNamespace(), record.Location)
+		err := b.Client.Get(context.TODO(), nn, snapshot)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return current.BackupFailed, nil
+			}
+			return "", err
+		}
+		return snapshotPhase(snapshot), nil
+	}
+
+	job := &batchv1.Job{}
+	err := b.Client.Get(context.TODO(), nn, job)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return current.BackupFailed, nil
+		}
+		return "", err
+	}
+
+	phase := jobPhase(job)
+	if phase != current.BackupPending {
+		log.Info(fmt.Sprintf("Backup '%s' of volume '%s' %s", record.Name, record.Volume, phase))
+		if err := jobv1.NewWithDefaultsUseExistingName(job).Delete(b.Client); err != nil {
+			return "", errors.Wrapf(err, "failed to delete job '%s'", job.GetName())
+		}
+	}
+
+	return phase, nil
+}
+
+// prune removes the backups of every volume that exceed the retention. Failed backups
+// are kept as long as they are newer than the oldest completed backup that is kept.
+func (b *Backup) prune(instance *current.IBPBackup, records []current.BackupRecord, now time.Time) ([]current.BackupRecord, error) {
+	sorted := append([]current.BackupRecord{}, records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.After(sorted[j].StartTime.Time)
+	})
+
+	retention := instance.GetRetention()
+	completed := map[string]int32{}
+	kept := []current.BackupRecord{}
+	pruned := []current.BackupRecord{}
+	for _, record := range sorted {
+		if record.Phase == current.BackupPending || completed[record.Volume] < retention {
+			if record.Phase == current.BackupCompleted {
+				completed[record.Volume]++
+			}
+			kept = append(kept, record)
+			continue
+		}
+		pruned = append(pruned, record)
+	}
+
+	if len(pruned) == 0 {
+		return records, nil
+	}
+
+	locations := []string{}
+	for _, record := range pruned {
+		log.Info(fmt.Sprintf("Pruning backup '%s' of volume '%s'", record.Name, record.Volume))
+		switch record.Method {
+		case current.BackupSnapshot:
+			err := b.Client.Delete(context.TODO(), newVolumeSnapshot(instance.GetNamespace(), record.Location))
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return nil, errors.Wrapf(err, "failed to delete volume snapshot '%s'", record.Location)
+			}
+		default:
+			// Failed archive jobs did not upload an archive
+			if record.Phase == current.BackupCompleted {
+				locations = append(locations, record.Location)
+			}
+		}
+	}
+
+	if len(locations) > 0 {
+		job, err := b.pruneJob(instance, truncateName(fmt.Sprintf("%s-prune", instance.GetName()), now), locations)
+		if err != nil {
+			return nil, err
+		}
+		err = b.Client.Create(context.TODO(), job, k8sclient.CreateOption{
+			Owner:  instance,
+			Scheme: b.Scheme,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create prune job")
+		}
+	}
+
+	// Keep the order of the records in status
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].StartTime.Before(&kept[j].StartTime)
+	})
+
+	return kept, nil
+}
+
+// jobPhase returns the phase of the job based on its conditions
+func jobPhase(job *batchv1.Job) current.BackupPhase {
+	phase := current.BackupPending
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			phase = current.BackupCompleted
+		case batchv1.JobFailed:
+			phase = current.BackupFailed
+		}
+	}
+	return phase
+}
+
+func claimName(name, customName string) string {
+	if customName != "" {
+		return customName
+	}
+	return name
+}
+
+// backupName returns the name of the job or VolumeSnapshot of the backup of the volume
+func backupName(backup, volume string, now time.Time) string {
+	return truncateName(fmt.Sprintf("%s-%s", backup, volume), now)
+}
+
+// truncateName appends the unix time to the name, truncating the name so the result
+// is a valid label value
+func truncateName(name string, now time.Time) string {
+	suffix := fmt.Sprintf("-%d", now.Unix())
+	if len(name)+len(suffix) > maxNameLength {
+		name = name[:maxNameLength-len(suffix)]
+	}
+	return name + suffix
+}
+
+// archiveLocation returns the path of the archive in the object storage:
+// <bucket>/<prefix>/<namespace>/<volume>/<time>.tar.gz
+func archiveLocation(instance *current.IBPBackup, volume string, now time.Time) string {
+	storage := instance.Spec.ObjectStorage
+	return path.Join(storage.Bucket, storage.Prefix, instance.GetNamespace(), volume, now.UTC().Format("20060102T150405Z")+".tar.gz")
+}
@@ -0,0 +1,320 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/backup"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("backup", func() {
+	var (
+		b          *backup.Backup
+		instance   *current.IBPBackup
+		mockClient *mocks.Client
+		now        time.Time
+		jobs       map[string]*batchv1.Job
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2021, time.March, 10, 2, 0, 30, 0, time.UTC)
+		jobs = map[string]*batchv1.Job{}
+
+		mockClient = &mocks.Client{}
+		mockClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+			switch o := obj.(type) {
+			case *current.IBPPeer:
+				o.Name = nn.Name
+				o.Spec.StateDb = "couchdb"
+			case *current.IBPCA:
+				o.Name = nn.Name
+			case *corev1.PersistentVolumeClaim:
+				if nn.Name == "ca1-pvc" {
+					return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+				}
+			case *batchv1.Job:
+				job, found := jobs[nn.Name]
+				if !found {
+					return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+				}
+				job.DeepCopyInto(o)
+			}
+			return nil
+		}
+		mockClient.CreateStub = func(ctx context.Context, obj client.Object, opts ...k8sclient.CreateOption) error {
+			if job, ok := obj.(*batchv1.Job); ok {
+				jobs[job.Name] = job
+			}
+			return nil
+		}
+
+		instance = &current.IBPBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "nightly",
+				Namespace:         "test",
+				CreationTimestamp: metav1.Time{Time: time.Date(2021, time.March, 9, 12, 0, 0, 0, time.UTC)},
+			},
+			Spec: current.IBPBackupSpec{
+				Schedule: "0 2 * * *",
+				Components: []current.BackupComponent{
+					{Kind: "IBPPeer", Name: "peer1"},
+					{Kind: "IBPCA", Name: "ca1"},
+				},
+				ObjectStorage: &current.BackupObjectStorage{
+					Endpoint:          "https://minio.example.com:9000",
+					Bucket:            "fabric",
+					Prefix:            "backups",
+					CredentialsSecret: "minio-creds",
+				},
+			},
+		}
+
+		b = backup.New(mockClient, &runtime.Scheme{}, current.BackupImages{
+			ArchiveImage: "ubi",
+			ArchiveTag:   "latest",
+			UploadImage:  "mc",
+			UploadTag:    "latest",
+		})
+		b.Now = func() time.Time { return now }
+	})
+
+	Context("schedule is due", func() {
+		It("starts an archive job for every existing volume of the components", func() {
+			result, err := b.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.LastScheduleTime.Time).To(Equal(now))
+			Expect(result.RequeueAfter).To(Equal(backup.PendingRequeueInterval))
+
+			Expect(len(result.Backups)).To(Equal(2))
+			Expect(result.Backups[0].Volume).To(Equal("peer1-pvc"))
+			Expect(result.Backups[0].Phase).To(Equal(current.BackupPending))
+			Expect(result.Backups[0].Location).To(Equal("fabric/backups/test/peer1-pvc/20210310T020000Z.tar.gz"))
+			Expect(result.Backups[1].Volume).To(Equal("peer1-statedb-pvc"))
+
+			By("creating the archive jobs", func() {
+				Expect(mockClient.CreateCallCount()).To(Equal(2))
+				_, obj, _ := mockClient.CreateArgsForCall(0)
+				job := obj.(*batchv1.Job)
+				Expect(job.Name).To(Equal(result.Backups[0].Name))
+				Expect(job.Spec.Template.Spec.InitContainers[0].Image).To(Equal("ubi:latest"))
+				Expect(job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("peer1-pvc"))
+				Expect(job.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{"cp", "/archive/backup.tar.gz", "backup/fabric/backups/test/peer1-pvc/20210310T020000Z.tar.gz"}))
+				Expect(job.Spec.Template.Spec.Containers[0].Command[2]).To(ContainSubstring(`alias set backup "$ENDPOINT" "$ACCESS_KEY_ID" "$SECRET_ACCESS_KEY"`))
+				Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+					Name:  "ENDPOINT",
+					Value: "https://minio.example.com:9000",
+				}))
+				Expect(job.Spec.Template.Spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].LabelSelector.MatchLabels).To(Equal(map[string]string{"app": "peer1"}))
+			})
+		})
+
+		It("does not start the backup again when a failed start is retried", func() {
+			createStub := mockClient.CreateStub
+			mockClient.CreateStub = func(ctx context.Context, obj client.Object, opts ...k8sclient.CreateOption) error {
+				if mockClient.CreateCallCount() == 2 {
+					return errors.New("create error")
+				}
+				return createStub(ctx, obj, opts...)
+			}
+
+			_, err := b.Reconcile(instance)
+			Expect(err).To(HaveOccurred())
+
+			now = now.Add(time.Minute)
+			mockClient.CreateStub = createStub
+			result, err := b.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(result.Backups)).To(Equal(2))
+			Expect(result.Backups[0].Location).To(Equal("fabric/backups/test/peer1-pvc/20210310T020000Z.tar.gz"))
+
+			_, first, _ := mockClient.CreateArgsForCall(0)
+			_, retried, _ := mockClient.CreateArgsForCall(2)
+			Expect(retried.GetName()).To(Equal(first.GetName()))
+			Expect(retried.GetName()).To(Equal(result.Backups[0].Name))
+		})
+
+		It("backs up the volumes of the claim templates if the peer runs as a stateful set", func() {
+			getStub := mockClient.GetStub
+			mockClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+				switch o := obj.(type) {
+				case *current.IBPPeer:
+					o.Name = nn.Name
+					o.Spec.StateDb = "couchdb"
+					o.Spec.WorkloadType = current.StatefulSetWorkload
+					return nil
+				case *corev1.PersistentVolumeClaim:
+					if nn.Name == "peer1-pvc" || nn.Name == "peer1-statedb-pvc" {
+						return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+					}
+				}
+				return getStub(ctx, nn, obj)
+			}
+
+			result, err := b.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(result.Backups)).To(Equal(2))
+			Expect(result.Backups[0].Volume).To(Equal("fabric-peer-0-peer1-0"))
+			Expect(result.Backups[1].Volume).To(Equal("db-data-peer1-0"))
+		})
+
+		It("backs up the volumes of every ordinal of the stateful set", func() {
+			getStub := mockClient.GetStub
+			mockClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+				switch o := obj.(type) {
+				case *current.IBPPeer:
+					o.Name = nn.Name
+					o.Spec.WorkloadType = current.StatefulSetWorkload
+					return nil
+				case *appsv1.StatefulSet:
+					replicas := int32(2)
+					o.Spec.Replicas = &replicas
+					return nil
+				case *corev1.PersistentVolumeClaim:
+					if nn.Name == "peer1-pvc" {
+						return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+					}
+				}
+				return getStub(ctx, nn, obj)
+			}
+
+			result, err := b.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(result.Backups)).To(Equal(2))
+			Expect(result.Backups[0].Volume).To(Equal("fabric-peer-0-peer1-0"))
+			Expect(result.Backups[1].Volume).To(Equal("fabric-peer-0-peer1-1"))
+		})
+
+		It("creates volume snapshots if the method is snapshot", func() {
+			instance.Spec.Method = current.BackupSnapshot
+			instance.Spec.VolumeSnapshotClassName = "csi-snapclass"
+
+			result, err := b.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(result.Backups)).To(Equal(2))
+
+			_, obj, _ := mockClient.CreateArgsForCall(0)
+			snapshot := obj.(*unstructured.Unstructured)
+			Expect(snapshot.GetKind()).To(Equal("VolumeSnapshot"))
+			Expect(snapshot.GetName()).To(Equal(result.Backups[0].Location))
+			claim, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")
+			Expect(claim).To(Equal("peer1-pvc"))
+			class, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName")
+			Expect(class).To(Equal("csi-snapclass"))
+		})
+
+		It("does not start a backup if suspended", func() {
+			instance.Spec.Suspend = true
+
+			result, err := b.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Backups).To(BeEmpty())
+			Expect(result.RequeueAfter).To(Equal(time.Duration(0)))
+			Expect(mockClient.CreateCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if the object storage is missing", func() {
+			instance.Spec.ObjectStorage = nil
+
+			_, err := b.Reconcile(instance)
+			Expect(err).To(MatchError("object storage is required for archive backups"))
+		})
+	})
+
+	Context("schedule is not due", func() {
+		BeforeEach(func() {
+			instance.Status.LastScheduleTime = &metav1.Time{Time: now}
+			now = now.Add(time.Hour)
+		})
+
+		It("requeues until the next scheduled backup", func() {
+			result, err := b.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(23*time.Hour - 30*time.Second))
+			Expect(mockClient.CreateCallCount()).To(Equal(0))
+		})
+
+		It("completes backups whose job completed and deletes the job", func() {
+			jobs["nightly-peer1-pvc-1"] = &batchv1.Job{
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+					},
+				},
+			}
+			instance.Status.Backups = []current.BackupRecord{
+				{Name: "nightly-peer1-pvc-1", Volume: "peer1-pvc", Method: current.BackupArchive, Phase: current.BackupPending},
+				{Name: "nightly-peer1-statedb-pvc-1", Volume: "peer1-statedb-pvc", Method: current.BackupArchive, Phase: current.BackupPending},
+			}
+
+			result, err := b.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Backups[0].Phase).To(Equal(current.BackupCompleted))
+			Expect(result.Backups[1].Phase).To(Equal(current.BackupFailed))
+			Expect(mockClient.DeleteCallCount()).To(Equal(1))
+		})
+
+		It("prunes completed backups that exceed the retention", func() {
+			retention := int32(2)
+			instance.Spec.Retention = &retention
+			for i := 1; i <= 3; i++ {
+				instance.Status.Backups = append(instance.Status.Backups, current.BackupRecord{
+					Name:      "backup",
+					Volume:    "peer1-pvc",
+					Method:    current.BackupArchive,
+					Phase:     current.BackupCompleted,
+					Location:  "fabric/peer1-pvc/" + string(rune('0'+i)) + ".tar.gz",
+					StartTime: metav1.Time{Time: now.AddDate(0, 0, -4+i)},
+				})
+			}
+
+			result, err := b.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(result.Backups)).To(Equal(2))
+			Expect(result.Backups[0].Location).To(Equal("fabric/peer1-pvc/2.tar.gz"))
+			Expect(result.Backups[1].Location).To(Equal("fabric/peer1-pvc/3.tar.gz"))
+
+			By("creating a job removing the pruned archives", func() {
+				Expect(mockClient.CreateCallCount()).To(Equal(1))
+				_, obj, _ := mockClient.CreateArgsForCall(0)
+				job := obj.(*batchv1.Job)
+				Expect(job.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{"rm", "backup/fabric/peer1-pvc/1.tar.gz"}))
+			})
+		})
+	})
+})
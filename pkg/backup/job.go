@@ -0,0 +1,274 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util/image"
+	"github.com/pkg/errors"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ArchiveContainer is the name of the container that archives the volume
+	ArchiveContainer = "archive"
+	// UploadContainer is the name of the container that uploads the archive
+	UploadContainer = "upload"
+
+	// mcAlias is the alias of the object storage in the MinIO client
+	mcAlias = "backup"
+	// mcConfigDir is the configuration directory of the MinIO client, the home directory
+	// of the user of the image might not be writable
+	mcConfigDir = "/tmp/.mc"
+
+	archiveFile = "/archive/backup.tar.gz"
+
+	// pruneJobTTL is the time after which finished prune jobs are removed
+	pruneJobTTL = int32(3600)
+)
+
+// archiveJob returns the job that archives the content of the volume and uploads
+// the archive to the object storage. Volumes are usually ReadWriteOnce, so the job
+// is scheduled on the node of the pods of the component to be able to mount the volume.
+func (b *Backup) archiveJob(instance *current.IBPBackup, record current.BackupRecord) (*batchv1.Job, error) {
+	upload, err := b.uploadContainer(instance, "cp", archiveFile, fmt.Sprintf("%s/%s", mcAlias, record.Location))
+	if err != nil {
+		return nil, err
+	}
+	upload.VolumeMounts = []corev1.VolumeMount{
+		{
+			Name:      "archive",
+			MountPath: "/archive",
+		},
+	}
+
+	images := b.images(instance)
+	user := int64(0)
+	f := false
+	backoffLimit := int32(2)
+
+	job := b.job(instance, record.Name, backoffLimit)
+	job.Labels["volume"] = record.Volume
+	job.Spec.Template.Spec.InitContainers = []corev1.Container{
+		{
+			Name:            ArchiveContainer,
+			Image:           image.Format(images.ArchiveImage, images.ArchiveTag),
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command: []string{
+				"sh",
+				"-c",
+				fmt.Sprintf("tar -czf %s -C /data .", archiveFile),
+			},
+			SecurityContext: &corev1.SecurityContext{
+				RunAsUser:    &user,
+				RunAsNonRoot: &f,
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "data",
+					MountPath: "/data",
+					ReadOnly:  true,
+				},
+				{
+					Name:      "archive",
+					MountPath: "/archive",
+				},
+			},
+		},
+	}
+	job.Spec.Template.Spec.Containers = []corev1.Container{*upload}
+	job.Spec.Template.Spec.Volumes = []corev1.Volume{
+		{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: record.Volume,
+					ReadOnly:  true,
+				},
+			},
+		},
+		{
+			Name: "archive",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	job.Spec.Template.Spec.Affinity = &corev1.Affinity{
+		PodAffinity: &corev1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"app": record.Component.Name,
+						},
+					},
+					TopologyKey: "kubernetes.io/hostname",
+				},
+			},
+		},
+	}
+
+	return job, nil
+}
+
+// pruneJob returns the job that removes the archives from the object storage
+func (b *Backup) pruneJob(instance *current.IBPBackup, name string, locations []string) (*batchv1.Job, error) {
+	targets := []string{}
+	for _, location := range locations {
+		targets = append(targets, fmt.Sprintf("%s/%s", mcAlias, location))
+	}
+
+	upload, err := b.uploadContainer(instance, "rm", targets...)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := pruneJobTTL
+	job := b.job(instance, name, int32(2))
+	job.Spec.TTLSecondsAfterFinished = &ttl
+	job.Spec.Template.Spec.Containers = []corev1.Container{*upload}
+
+	return job, nil
+}
+
+func (b *Backup) job(instance *current.IBPBackup, name string, backoffLimit int32) *batchv1.Job {
+	return newJob(instance.GetNamespace(), name, "backup", instance.GetName(), instance.Spec.ImagePullSecrets, backoffLimit)
+}
+
+// newJob returns a job labeled with the owner label, which is set to the name of the
+// resource that owns the job
+func newJob(namespace, name, ownerLabel, owner string, imagePullSecrets []string, backoffLimit int32) *batchv1.Job {
+	pullSecrets := []corev1.LocalObjectReference{}
+	for _, secret := range imagePullSecrets {
+		pullSecrets = append(pullSecrets, corev1.LocalObjectReference{Name: secret})
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"name":     name,
+				ownerLabel: owner,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						ownerLabel: owner,
+					},
+				},
+				Spec: corev1.PodSpec{
+					ImagePullSecrets: pullSecrets,
+					RestartPolicy:    corev1.RestartPolicyNever,
+				},
+			},
+		},
+	}
+}
+
+// uploadContainer returns a container running the MinIO client with the object
+// storage configured under the 'backup' alias
+func (b *Backup) uploadContainer(instance *current.IBPBackup, command string, args ...string) (*corev1.Container, error) {
+	storage := instance.Spec.ObjectStorage
+	if storage == nil {
+		return nil, errors.New("object storage is required for archive backups")
+	}
+
+	return mcContainer(storage, b.images(instance), command, args...)
+}
+
+// mcContainer returns a container running the MinIO client with the object storage
+// configured under the 'backup' alias. The alias is set from the environment by a shell
+// so that the credentials are passed as separate arguments and don't have to be escaped
+// in a URL.
+func mcContainer(storage *current.BackupObjectStorage, images current.BackupImages, command string, args ...string) (*corev1.Container, error) {
+	endpoint, err := url.Parse(storage.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, errors.Errorf("invalid object storage endpoint '%s'", storage.Endpoint)
+	}
+
+	flags := fmt.Sprintf("--config-dir %s", mcConfigDir)
+	if storage.Insecure {
+		flags += " --insecure"
+	}
+	script := fmt.Sprintf(`mc %s alias set %s "$ENDPOINT" "$ACCESS_KEY_ID" "$SECRET_ACCESS_KEY" --api S3v4 >/dev/null && exec mc %s "$@"`, flags, mcAlias, flags)
+
+	mcArgs := append([]string{command}, args...)
+
+	return &corev1.Container{
+		Name:            UploadContainer,
+		Image:           image.Format(images.UploadImage, images.UploadTag),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		// The first argument after the script is $0, the arguments of the mc command follow
+		Command: []string{"sh", "-c", script, "mc"},
+		Args:    mcArgs,
+		Env: []corev1.EnvVar{
+			{
+				Name: "ACCESS_KEY_ID",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: storage.CredentialsSecret},
+						Key:                  "accessKeyID",
+					},
+				},
+			},
+			{
+				Name: "SECRET_ACCESS_KEY",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: storage.CredentialsSecret},
+						Key:                  "secretAccessKey",
+					},
+				},
+			},
+			{
+				Name:  "ENDPOINT",
+				Value: fmt.Sprintf("%s://%s%s", endpoint.Scheme, endpoint.Host, strings.TrimSuffix(endpoint.Path, "/")),
+			},
+		},
+	}, nil
+}
+
+// images returns the images of the archive jobs, images that are not set in the
+// spec default to the images of the operator configuration
+func (b *Backup) images(instance *current.IBPBackup) current.BackupImages {
+	images := b.Images
+	if instance.Spec.Images != nil {
+		if instance.Spec.Images.ArchiveImage != "" {
+			images.ArchiveImage = instance.Spec.Images.ArchiveImage
+			images.ArchiveTag = instance.Spec.Images.ArchiveTag
+		}
+		if instance.Spec.Images.UploadImage != "" {
+			images.UploadImage = instance.Spec.Images.UploadImage
+			images.UploadTag = instance.Spec.Images.UploadTag
+		}
+	}
+	return images
+}
@@ -0,0 +1,246 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util/image"
+	"github.com/pkg/errors"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// SnapshotsDir is the root directory of the ledger snapshots of the peer, it is set
+	// as the snapshots root directory of the peer in the peer deployment
+	SnapshotsDir = "/data/peer/snapshots"
+
+	// JoinBySnapshotDir is the directory ledger snapshots are downloaded to before the
+	// peer joins their channel
+	JoinBySnapshotDir = "/data/peer/joinbysnapshot"
+)
+
+// SnapshotLocation returns the object key of the archive of a ledger snapshot of the
+// channel of the peer
+func SnapshotLocation(storage *current.BackupObjectStorage, instance metav1.Object, channel string, now time.Time) string {
+	return path.Join(storage.Bucket, storage.Prefix, instance.GetNamespace(), instance.GetName(), "snapshots", channel, now.UTC().Format("20060102T150405Z")+".tar.gz")
+}
+
+// SnapshotJobName returns the name of the job that uploads the ledger snapshot of the
+// peer requested at the time
+func SnapshotJobName(name string, now time.Time) string {
+	suffix := fmt.Sprintf("-snapshot-%d", now.Unix())
+	if len(name)+len(suffix) > maxNameLength {
+		name = name[:maxNameLength-len(suffix)]
+	}
+	return name + suffix
+}
+
+// JoinBySnapshotPath returns the directory on the file system of the peer the ledger
+// snapshot of the channel is downloaded to
+func JoinBySnapshotPath(channel string) string {
+	return path.Join(JoinBySnapshotDir, channel)
+}
+
+// UploadSnapshot archives the ledger snapshot from the volume of the peer and uploads it
+// to the snapshot storage, and returns the phase of the upload. A snapshot requested at
+// block number 0 is the latest snapshot of the channel.
+func (b *Backup) UploadSnapshot(instance *current.IBPPeer, pvcName string, snapshot *current.PeerSnapshotStatus) (current.BackupPhase, error) {
+	storage := instance.Spec.SnapshotStorage
+	if storage == nil {
+		return current.BackupFailed, errors.New("snapshot storage is required to upload snapshots")
+	}
+
+	return b.runJob(instance, snapshot.Name, func() (*batchv1.Job, error) {
+		upload, err := mcContainer(storage, b.Images, "cp", archiveFile, fmt.Sprintf("%s/%s", mcAlias, snapshot.Location))
+		if err != nil {
+			return nil, err
+		}
+		upload.VolumeMounts = []corev1.VolumeMount{
+			{
+				Name:      "archive",
+				MountPath: "/archive",
+			},
+		}
+
+		snapshotDir := `"$(ls | sort -n | tail -1)"`
+		if snapshot.BlockNumber != 0 {
+			snapshotDir = fmt.Sprintf("%d", snapshot.BlockNumber)
+		}
+
+		archive := b.peerJobContainer(ArchiveContainer, fmt.Sprintf("cd %s && tar -czf %s -C %s .", path.Join(SnapshotsDir, "completed", snapshot.Channel), archiveFile, snapshotDir))
+		job := b.peerJob(instance, snapshot.Name, pvcName)
+		job.Labels["channel"] = snapshot.Channel
+		job.Spec.Template.Spec.InitContainers = []corev1.Container{archive}
+		job.Spec.Template.Spec.Containers = []corev1.Container{*upload}
+		return job, nil
+	})
+}
+
+// DownloadSnapshot downloads the archive of a ledger snapshot from the snapshot storage
+// and extracts it into the directory the peer joins the channel from, and returns the
+// phase of the download
+func (b *Backup) DownloadSnapshot(instance *current.IBPPeer, pvcName, channel, location string) (current.BackupPhase, error) {
+	storage := instance.Spec.SnapshotStorage
+	if storage == nil {
+		return current.BackupFailed, errors.New("snapshot storage is required to download snapshots")
+	}
+
+	name := fmt.Sprintf("%s-join-%s", instance.GetName(), channel)
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+
+	return b.runJob(instance, name, func() (*batchv1.Job, error) {
+		download, err := mcContainer(storage, b.Images, "cp", fmt.Sprintf("%s/%s", mcAlias, location), archiveFile)
+		if err != nil {
+			return nil, err
+		}
+		download.Name = DownloadContainer
+		download.VolumeMounts = []corev1.VolumeMount{
+			{
+				Name:      "archive",
+				MountPath: "/archive",
+			},
+		}
+
+		// The peer runs as 7051:1000, the files are extracted by root
+		dir := JoinBySnapshotPath(channel)
+		extract := b.peerJobContainer(RestoreContainer, fmt.Sprintf("rm -rf %[1]s && mkdir -p %[1]s && tar -xzf %[2]s -C %[1]s && chown -R 7051:1000 %[3]s", dir, archiveFile, JoinBySnapshotDir))
+		job := b.peerJob(instance, name, pvcName)
+		job.Labels["channel"] = channel
+		job.Spec.Template.Spec.InitContainers = []corev1.Container{*download}
+		job.Spec.Template.Spec.Containers = []corev1.Container{extract}
+		return job, nil
+	})
+}
+
+// runJob creates the job if it does not exist and returns the phase of the job
+func (b *Backup) runJob(instance metav1.Object, name string, newJob func() (*batchv1.Job, error)) (current.BackupPhase, error) {
+	job := &batchv1.Job{}
+	err := b.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.GetNamespace()}, job)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return current.BackupPending, err
+		}
+
+		job, err = newJob()
+		if err != nil {
+			return current.BackupFailed, err
+		}
+
+		log.Info(fmt.Sprintf("Starting job '%s' of '%s'", name, instance.GetName()))
+		err = b.Client.Create(context.TODO(), job, k8sclient.CreateOption{
+			Owner:  instance,
+			Scheme: b.Scheme,
+		})
+		if err != nil {
+			return current.BackupPending, errors.Wrapf(err, "failed to create job '%s'", name)
+		}
+		return current.BackupPending, nil
+	}
+
+	phase := jobPhase(job)
+	if phase == current.BackupFailed {
+		return phase, errors.Errorf("job '%s' failed", name)
+	}
+
+	return phase, nil
+}
+
+// peerJob returns a job that mounts the volume of the peer the way the peer does, the
+// job is scheduled on the node of the peer to be able to mount the volume
+func (b *Backup) peerJob(instance *current.IBPPeer, name, pvcName string) *batchv1.Job {
+	job := newJob(instance.GetNamespace(), name, "peer", instance.GetName(), instance.Spec.ImagePullSecrets, int32(2))
+	job.Spec.Template.Spec.Volumes = []corev1.Volume{
+		{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: pvcName,
+				},
+			},
+		},
+		{
+			Name: "archive",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	job.Spec.Template.Spec.Affinity = &corev1.Affinity{
+		PodAffinity: &corev1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"app": instance.GetName(),
+						},
+					},
+					TopologyKey: "kubernetes.io/hostname",
+				},
+			},
+		},
+	}
+
+	return job
+}
+
+// peerJobContainer returns a container of the archive image running the command as root
+// with the volume of the peer mounted
+func (b *Backup) peerJobContainer(name, command string) corev1.Container {
+	user := int64(0)
+	f := false
+
+	return corev1.Container{
+		Name:            name,
+		Image:           image.Format(b.Images.ArchiveImage, b.Images.ArchiveTag),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command: []string{
+			"sh",
+			"-c",
+			command,
+		},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:    &user,
+			RunAsNonRoot: &f,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "data",
+				MountPath: "/data",
+				SubPath:   "data",
+			},
+			{
+				Name:      "archive",
+				MountPath: "/archive",
+			},
+		},
+	}
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/backup"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ledger snapshots", func() {
+	var (
+		b          *backup.Backup
+		instance   *current.IBPPeer
+		snapshot   *current.PeerSnapshotStatus
+		mockClient *mocks.Client
+		jobs       map[string]*batchv1.Job
+	)
+
+	BeforeEach(func() {
+		jobs = map[string]*batchv1.Job{}
+
+		mockClient = &mocks.Client{}
+		mockClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+			if o, ok := obj.(*batchv1.Job); ok {
+				job, found := jobs[nn.Name]
+				if !found {
+					return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+				}
+				job.DeepCopyInto(o)
+			}
+			return nil
+		}
+		mockClient.CreateStub = func(ctx context.Context, obj client.Object, opts ...k8sclient.CreateOption) error {
+			if job, ok := obj.(*batchv1.Job); ok {
+				jobs[job.Name] = job
+			}
+			return nil
+		}
+
+		instance = &current.IBPPeer{
+			Spec: current.IBPPeerSpec{
+				SnapshotStorage: &current.BackupObjectStorage{
+					Endpoint:          "https://minio.example.com:9000",
+					Bucket:            "fabric",
+					Prefix:            "snapshots",
+					CredentialsSecret: "minio-creds",
+				},
+			},
+		}
+		instance.Name = "peer1"
+		instance.Namespace = "test"
+
+		snapshot = &current.PeerSnapshotStatus{
+			Name:        "peer1-snapshot-1615341600",
+			Channel:     "channel1",
+			BlockNumber: 100,
+			Location:    "fabric/snapshots/test/peer1/snapshots/channel1/20210310T020000Z.tar.gz",
+		}
+
+		b = backup.New(mockClient, &runtime.Scheme{}, current.BackupImages{
+			ArchiveImage: "ubi",
+			ArchiveTag:   "latest",
+			UploadImage:  "mc",
+			UploadTag:    "latest",
+		})
+	})
+
+	It("returns the location of a snapshot", func() {
+		now := time.Date(2021, time.March, 10, 2, 0, 0, 0, time.UTC)
+		Expect(backup.SnapshotLocation(instance.Spec.SnapshotStorage, instance, "channel1", now)).To(Equal(snapshot.Location))
+		Expect(backup.SnapshotJobName("peer1", now)).To(Equal(snapshot.Name))
+	})
+
+	It("truncates long job names", func() {
+		name := backup.SnapshotJobName(strings.Repeat("p", 70), time.Now())
+		Expect(len(name)).To(BeNumerically("<=", 63))
+		Expect(name).To(ContainSubstring("-snapshot-"))
+	})
+
+	Context("upload", func() {
+		It("returns an error if snapshot storage is not set", func() {
+			instance.Spec.SnapshotStorage = nil
+			phase, err := b.UploadSnapshot(instance, "peer1-pvc", snapshot)
+			Expect(err).To(HaveOccurred())
+			Expect(phase).To(Equal(current.BackupFailed))
+		})
+
+		It("creates a job archiving the snapshot at the block number", func() {
+			phase, err := b.UploadSnapshot(instance, "peer1-pvc", snapshot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phase).To(Equal(current.BackupPending))
+
+			job := jobs["peer1-snapshot-1615341600"]
+			Expect(job).NotTo(BeNil())
+			Expect(job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("peer1-pvc"))
+			Expect(job.Spec.Template.Spec.InitContainers[0].Command[2]).To(ContainSubstring("cd /data/peer/snapshots/completed/channel1 && tar -czf /archive/backup.tar.gz -C 100 ."))
+			Expect(job.Spec.Template.Spec.Containers[0].Args).To(ContainElement("backup/fabric/snapshots/test/peer1/snapshots/channel1/20210310T020000Z.tar.gz"))
+		})
+
+		It("archives the latest snapshot if no block number is set", func() {
+			snapshot.BlockNumber = 0
+			_, err := b.UploadSnapshot(instance, "peer1-pvc", snapshot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobs["peer1-snapshot-1615341600"].Spec.Template.Spec.InitContainers[0].Command[2]).To(ContainSubstring("tail -1"))
+		})
+
+		It("returns completed once the job completed", func() {
+			jobs["peer1-snapshot-1615341600"] = &batchv1.Job{
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+					},
+				},
+			}
+			phase, err := b.UploadSnapshot(instance, "peer1-pvc", snapshot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phase).To(Equal(current.BackupCompleted))
+			Expect(mockClient.CreateCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if the job failed", func() {
+			jobs["peer1-snapshot-1615341600"] = &batchv1.Job{
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+					},
+				},
+			}
+			phase, err := b.UploadSnapshot(instance, "peer1-pvc", snapshot)
+			Expect(err).To(MatchError(ContainSubstring("failed")))
+			Expect(phase).To(Equal(current.BackupFailed))
+		})
+	})
+
+	Context("download", func() {
+		It("creates a job extracting the snapshot into the join by snapshot directory", func() {
+			phase, err := b.DownloadSnapshot(instance, "peer1-pvc", "channel1", snapshot.Location)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phase).To(Equal(current.BackupPending))
+
+			job := jobs["peer1-join-channel1"]
+			Expect(job).NotTo(BeNil())
+			Expect(job.Spec.Template.Spec.InitContainers[0].Args).To(ContainElement("backup/" + snapshot.Location))
+			Expect(job.Spec.Template.Spec.Containers[0].Command[2]).To(ContainSubstring("tar -xzf /archive/backup.tar.gz -C /data/peer/joinbysnapshot/channel1"))
+		})
+	})
+})
@@ -0,0 +1,221 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util/image"
+	"github.com/pkg/errors"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// DownloadContainer is the name of the container that downloads the archive
+	DownloadContainer = "download"
+	// RestoreContainer is the name of the container that extracts the archive into the volume
+	RestoreContainer = "restore"
+)
+
+// RestoreRecord returns the backup a volume is restored from, which is the latest
+// completed backup of the volume that started before the restore time
+func (b *Backup) RestoreRecord(namespace string, restore *current.RestoreFrom, volume string) (*current.IBPBackup, *current.BackupRecord, error) {
+	instance := &current.IBPBackup{}
+	err := b.Client.Get(context.TODO(), types.NamespacedName{Name: restore.Backup, Namespace: namespace}, instance)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get backup '%s'", restore.Backup)
+	}
+
+	record := instance.Status.Latest(volume, restore.Before)
+	if record == nil {
+		return nil, nil, errors.Errorf("no completed backup of volume '%s' found in backup '%s'", volume, restore.Backup)
+	}
+
+	return instance, record, nil
+}
+
+// SourceVolume returns the name of the backed up volume that a volume of the component
+// is restored from. Volumes are prefixed with the name of their component, so the prefix
+// is replaced with the name of the component that was backed up.
+func SourceVolume(volume, name string, restore *current.RestoreFrom) string {
+	component := restore.GetComponent(name)
+	if component == name || !strings.HasPrefix(volume, name) {
+		return volume
+	}
+	return component + strings.TrimPrefix(volume, name)
+}
+
+// RestorePVC provisions the persistent volume claim from the VolumeSnapshot of the
+// backup the volume is restored from. Archived volumes are restored by RestoreVolume
+// once the claim exists.
+func (b *Backup) RestorePVC(instance v1.Object, restore *current.RestoreFrom, pvc *corev1.PersistentVolumeClaim) error {
+	_, record, err := b.RestoreRecord(instance.GetNamespace(), restore, SourceVolume(pvc.GetName(), instance.GetName(), restore))
+	if err != nil {
+		return err
+	}
+
+	if record.Method != current.BackupSnapshot {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("Provisioning pvc '%s' from snapshot '%s'", pvc.GetName(), record.Location))
+	apiGroup := VolumeSnapshotGVK.Group
+	pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: &apiGroup,
+		Kind:     VolumeSnapshotGVK.Kind,
+		Name:     record.Location,
+	}
+
+	return nil
+}
+
+// RestoreVolume extracts the archive of the backup the volume is restored from into the
+// persistent volume claim, and returns true once the volume is restored. Volumes that are
+// provisioned from a snapshot are restored as soon as the claim is created.
+func (b *Backup) RestoreVolume(instance v1.Object, restore *current.RestoreFrom, pvcName string) (bool, error) {
+	backup, record, err := b.RestoreRecord(instance.GetNamespace(), restore, SourceVolume(pvcName, instance.GetName(), restore))
+	if err != nil {
+		return false, err
+	}
+
+	if record.Method != current.BackupArchive {
+		return true, nil
+	}
+
+	name := restoreJobName(pvcName)
+	job := &batchv1.Job{}
+	err = b.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.GetNamespace()}, job)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+
+		job, err = b.restoreJob(backup, instance, record, name, pvcName)
+		if err != nil {
+			return false, err
+		}
+
+		log.Info(fmt.Sprintf("Restoring pvc '%s' from backup '%s'", pvcName, record.Name))
+		err = b.Client.Create(context.TODO(), job, k8sclient.CreateOption{
+			Owner:  instance,
+			Scheme: b.Scheme,
+		})
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to create restore job '%s'", name)
+		}
+		return false, nil
+	}
+
+	switch jobPhase(job) {
+	case current.BackupCompleted:
+		return true, nil
+	case current.BackupFailed:
+		return false, errors.Errorf("restore job '%s' of pvc '%s' failed", name, pvcName)
+	}
+
+	return false, nil
+}
+
+// restoreJob returns the job that downloads the archive from the object storage and
+// extracts it into the volume
+func (b *Backup) restoreJob(backup *current.IBPBackup, instance v1.Object, record *current.BackupRecord, name, pvcName string) (*batchv1.Job, error) {
+	download, err := b.uploadContainer(backup, "cp", fmt.Sprintf("%s/%s", mcAlias, record.Location), archiveFile)
+	if err != nil {
+		return nil, err
+	}
+	download.Name = DownloadContainer
+	download.VolumeMounts = []corev1.VolumeMount{
+		{
+			Name:      "archive",
+			MountPath: "/archive",
+		},
+	}
+
+	images := b.images(backup)
+	user := int64(0)
+	f := false
+
+	job := b.job(backup, name, int32(2))
+	job.Labels["restore"] = instance.GetName()
+	job.Labels["volume"] = pvcName
+	job.Spec.Template.Spec.InitContainers = []corev1.Container{*download}
+	job.Spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Name:            RestoreContainer,
+			Image:           image.Format(images.ArchiveImage, images.ArchiveTag),
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command: []string{
+				"sh",
+				"-c",
+				fmt.Sprintf("tar -xzf %s -C /data", archiveFile),
+			},
+			SecurityContext: &corev1.SecurityContext{
+				RunAsUser:    &user,
+				RunAsNonRoot: &f,
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "data",
+					MountPath: "/data",
+				},
+				{
+					Name:      "archive",
+					MountPath: "/archive",
+				},
+			},
+		},
+	}
+	job.Spec.Template.Spec.Volumes = []corev1.Volume{
+		{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: pvcName,
+				},
+			},
+		},
+		{
+			Name: "archive",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+
+	return job, nil
+}
+
+// restoreJobName returns the name of the job that restores the volume
+func restoreJobName(pvcName string) string {
+	name := pvcName
+	suffix := "-restore"
+	if len(name)+len(suffix) > maxNameLength {
+		name = name[:maxNameLength-len(suffix)]
+	}
+	return name + suffix
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/backup"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("restore", func() {
+	var (
+		b          *backup.Backup
+		instance   *current.IBPPeer
+		restore    *current.RestoreFrom
+		ibpbackup  *current.IBPBackup
+		mockClient *mocks.Client
+		jobs       map[string]*batchv1.Job
+	)
+
+	record := func(volume string, method current.BackupMethod, location string, day int) current.BackupRecord {
+		return current.BackupRecord{
+			Name:      location,
+			Volume:    volume,
+			Method:    method,
+			Location:  location,
+			Phase:     current.BackupCompleted,
+			StartTime: metav1.Time{Time: time.Date(2021, time.March, day, 2, 0, 0, 0, time.UTC)},
+		}
+	}
+
+	BeforeEach(func() {
+		jobs = map[string]*batchv1.Job{}
+
+		ibpbackup = &current.IBPBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nightly",
+				Namespace: "test",
+			},
+			Spec: current.IBPBackupSpec{
+				ObjectStorage: &current.BackupObjectStorage{
+					Endpoint:          "https://minio.example.com:9000",
+					Bucket:            "fabric",
+					CredentialsSecret: "minio-creds",
+				},
+			},
+			Status: current.IBPBackupStatus{
+				Backups: []current.BackupRecord{
+					record("peer1-pvc", current.BackupArchive, "peer1-pvc-9.tar.gz", 9),
+					record("peer1-pvc", current.BackupArchive, "peer1-pvc-10.tar.gz", 10),
+					record("peer1-statedb-pvc", current.BackupSnapshot, "peer1-statedb-pvc-10", 10),
+				},
+			},
+		}
+
+		mockClient = &mocks.Client{}
+		mockClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+			switch o := obj.(type) {
+			case *current.IBPBackup:
+				ibpbackup.DeepCopyInto(o)
+			case *batchv1.Job:
+				job, found := jobs[nn.Name]
+				if !found {
+					return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+				}
+				job.DeepCopyInto(o)
+			}
+			return nil
+		}
+		mockClient.CreateStub = func(ctx context.Context, obj client.Object, opts ...k8sclient.CreateOption) error {
+			if job, ok := obj.(*batchv1.Job); ok {
+				jobs[job.Name] = job
+			}
+			return nil
+		}
+
+		instance = &current.IBPPeer{}
+		instance.Name = "peer1"
+		instance.Namespace = "test"
+		restore = &current.RestoreFrom{Backup: "nightly"}
+
+		b = backup.New(mockClient, &runtime.Scheme{}, current.BackupImages{
+			ArchiveImage: "ubi",
+			ArchiveTag:   "latest",
+			UploadImage:  "mc",
+			UploadTag:    "latest",
+		})
+	})
+
+	Context("source volume", func() {
+		It("returns the volume if restoring the same component", func() {
+			Expect(backup.SourceVolume("peer1-pvc", "peer1", restore)).To(Equal("peer1-pvc"))
+		})
+
+		It("replaces the component prefix if restoring another component", func() {
+			restore.Component = "peer0"
+			Expect(backup.SourceVolume("peer1-statedb-pvc", "peer1", restore)).To(Equal("peer0-statedb-pvc"))
+		})
+	})
+
+	Context("restore record", func() {
+		It("returns the latest completed backup of the volume", func() {
+			_, r, err := b.RestoreRecord("test", restore, "peer1-pvc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Location).To(Equal("peer1-pvc-10.tar.gz"))
+		})
+
+		It("returns the latest completed backup started before the restore time", func() {
+			restore.Before = &metav1.Time{Time: time.Date(2021, time.March, 10, 0, 0, 0, 0, time.UTC)}
+			_, r, err := b.RestoreRecord("test", restore, "peer1-pvc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Location).To(Equal("peer1-pvc-9.tar.gz"))
+		})
+
+		It("returns an error if the volume was never backed up", func() {
+			_, _, err := b.RestoreRecord("test", restore, "peer2-pvc")
+			Expect(err).To(MatchError("no completed backup of volume 'peer2-pvc' found in backup 'nightly'"))
+		})
+	})
+
+	Context("restore pvc", func() {
+		It("provisions the claim from the snapshot", func() {
+			pvc := &corev1.PersistentVolumeClaim{}
+			pvc.Name = "peer1-statedb-pvc"
+			Expect(b.RestorePVC(instance, restore, pvc)).To(Succeed())
+			Expect(pvc.Spec.DataSource).NotTo(BeNil())
+			Expect(pvc.Spec.DataSource.Kind).To(Equal("VolumeSnapshot"))
+			Expect(pvc.Spec.DataSource.Name).To(Equal("peer1-statedb-pvc-10"))
+		})
+
+		It("does not set a data source for archived volumes", func() {
+			pvc := &corev1.PersistentVolumeClaim{}
+			pvc.Name = "peer1-pvc"
+			Expect(b.RestorePVC(instance, restore, pvc)).To(Succeed())
+			Expect(pvc.Spec.DataSource).To(BeNil())
+		})
+	})
+
+	Context("restore volume", func() {
+		It("is restored right away if the volume was provisioned from a snapshot", func() {
+			restored, err := b.RestoreVolume(instance, restore, "peer1-statedb-pvc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(Equal(true))
+			Expect(mockClient.CreateCallCount()).To(Equal(0))
+		})
+
+		It("starts a job that extracts the archive into the volume", func() {
+			restored, err := b.RestoreVolume(instance, restore, "peer1-pvc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(Equal(false))
+
+			job := jobs["peer1-pvc-restore"]
+			Expect(job).NotTo(BeNil())
+			Expect(job.Labels["restore"]).To(Equal("peer1"))
+			Expect(job.Spec.Template.Spec.InitContainers[0].Name).To(Equal(backup.DownloadContainer))
+			Expect(job.Spec.Template.Spec.InitContainers[0].Args).To(ContainElement(ContainSubstring("peer1-pvc-10.tar.gz")))
+			Expect(job.Spec.Template.Spec.Containers[0].Name).To(Equal(backup.RestoreContainer))
+			Expect(job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("peer1-pvc"))
+		})
+
+		It("is restored once the job completes", func() {
+			_, err := b.RestoreVolume(instance, restore, "peer1-pvc")
+			Expect(err).NotTo(HaveOccurred())
+
+			jobs["peer1-pvc-restore"].Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			}
+			restored, err := b.RestoreVolume(instance, restore, "peer1-pvc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(Equal(true))
+		})
+
+		It("returns an error if the job fails", func() {
+			_, err := b.RestoreVolume(instance, restore, "peer1-pvc")
+			Expect(err).NotTo(HaveOccurred())
+
+			jobs["peer1-pvc-restore"].Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+			}
+			_, err = b.RestoreVolume(instance, restore, "peer1-pvc")
+			Expect(err).To(MatchError("restore job 'peer1-pvc-restore' of pvc 'peer1-pvc' failed"))
+		})
+	})
+})
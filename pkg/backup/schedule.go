@@ -0,0 +1,201 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Schedule is a parsed cron schedule with the standard five fields:
+// minute, hour, day of month, month and day of week. Schedules are matched
+// the way Kubernetes CronJobs match them.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar record if the day of month and day of week fields
+	// contain an unstepped '*' or '?'. When neither does, both fields restrict
+	// the days and a day matches if it matches either of them.
+	domStar, dowStar bool
+}
+
+type bounds struct {
+	min, max int
+}
+
+var (
+	minuteBounds = bounds{0, 59}
+	hourBounds   = bounds{0, 23}
+	domBounds    = bounds{1, 31}
+	monthBounds  = bounds{1, 12}
+	dowBounds    = bounds{0, 7}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule parses a schedule in cron format
+func ParseSchedule(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if descriptor, found := descriptors[spec]; found {
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("invalid schedule '%s': expected 5 fields, found %d", spec, len(fields))
+	}
+
+	var err error
+	s := &Schedule{}
+	if s.minute, _, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, errors.Wrapf(err, "invalid minute in schedule '%s'", spec)
+	}
+	if s.hour, _, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, errors.Wrapf(err, "invalid hour in schedule '%s'", spec)
+	}
+	if s.dom, s.domStar, err = parseField(fields[2], domBounds); err != nil {
+		return nil, errors.Wrapf(err, "invalid day of month in schedule '%s'", spec)
+	}
+	if s.month, _, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, errors.Wrapf(err, "invalid month in schedule '%s'", spec)
+	}
+	if s.dow, s.dowStar, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, errors.Wrapf(err, "invalid day of week in schedule '%s'", spec)
+	}
+	// Sunday can be specified as 0 or 7
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	return s, nil
+}
+
+// parseField parses a comma separated list of values, ranges (1-5) and
+// steps (*/15, 1-30/2) into a bit set, and returns whether the field contains
+// an unstepped '*' or '?'
+func parseField(field string, b bounds) (uint64, bool, error) {
+	var bits uint64
+	star := false
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, false, errors.Errorf("invalid step '%s'", part[i+1:])
+			}
+			part = part[:i]
+		}
+
+		start, end := b.min, b.max
+		switch {
+		case part == "*" || part == "?":
+			if step == 1 {
+				star = true
+			}
+		case strings.Contains(part, "-"):
+			r := strings.SplitN(part, "-", 2)
+			var err error
+			if start, err = parseValue(r[0], b); err != nil {
+				return 0, false, err
+			}
+			if end, err = parseValue(r[1], b); err != nil {
+				return 0, false, err
+			}
+			if start > end {
+				return 0, false, errors.Errorf("invalid range '%s'", part)
+			}
+		default:
+			value, err := parseValue(part, b)
+			if err != nil {
+				return 0, false, err
+			}
+			start = value
+			if step == 1 {
+				end = value
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, star, nil
+}
+
+func parseValue(value string, b bounds) (int, error) {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.Errorf("invalid value '%s'", value)
+	}
+	if i < b.min || i > b.max {
+		return 0, errors.Errorf("value %d out of range [%d, %d]", i, b.min, b.max)
+	}
+	return i, nil
+}
+
+// Next returns the first time after t that matches the schedule, or the zero
+// time if no time matches within the next five years
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM-Blockchain/fabric-operator/pkg/backup"
+)
+
+var _ = Describe("schedule", func() {
+	var (
+		start = time.Date(2021, time.March, 10, 14, 35, 20, 0, time.UTC)
+	)
+
+	DescribeTable("returns the next time matching the schedule",
+		func(spec string, expected time.Time) {
+			schedule, err := backup.ParseSchedule(spec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Next(start)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2021, time.March, 10, 14, 36, 0, 0, time.UTC)),
+		Entry("every 15 minutes", "*/15 * * * *", time.Date(2021, time.March, 10, 14, 45, 0, 0, time.UTC)),
+		Entry("daily", "@daily", time.Date(2021, time.March, 11, 0, 0, 0, 0, time.UTC)),
+		Entry("at 2am", "0 2 * * *", time.Date(2021, time.March, 11, 2, 0, 0, 0, time.UTC)),
+		Entry("on weekdays", "30 1 * * 1-5", time.Date(2021, time.March, 11, 1, 30, 0, 0, time.UTC)),
+		Entry("on sundays specified as 7", "0 0 * * 7", time.Date(2021, time.March, 14, 0, 0, 0, 0, time.UTC)),
+		Entry("on a list of hours", "0 9,18 * * *", time.Date(2021, time.March, 10, 18, 0, 0, 0, time.UTC)),
+		Entry("monthly", "@monthly", time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("day of month or day of week", "0 0 1 * 5", time.Date(2021, time.March, 12, 0, 0, 0, 0, time.UTC)),
+		Entry("stepped day of month or day of week", "0 0 */2 * 1", time.Date(2021, time.March, 11, 0, 0, 0, 0, time.UTC)),
+		Entry("stepped day of week or day of month", "0 0 20 * */3", time.Date(2021, time.March, 13, 0, 0, 0, 0, time.UTC)),
+		Entry("day of week with any day of month", "0 0 ? * 1", time.Date(2021, time.March, 15, 0, 0, 0, 0, time.UTC)),
+	)
+
+	DescribeTable("returns an error for an invalid schedule",
+		func(spec string) {
+			_, err := backup.ParseSchedule(spec)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("missing fields", "0 2 * *"),
+		Entry("value out of range", "0 24 * * *"),
+		Entry("invalid step", "*/0 * * * *"),
+		Entry("invalid range", "0 5-2 * * *"),
+		Entry("not a number", "0 two * * *"),
+	)
+
+	It("returns the zero time if the schedule never matches", func() {
+		schedule, err := backup.ParseSchedule("0 0 31 2 *")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schedule.Next(start).IsZero()).To(Equal(true))
+	})
+})
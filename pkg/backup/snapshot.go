@@ -0,0 +1,76 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// VolumeSnapshotGVK is the group version kind of the VolumeSnapshots of the
+// CSI external snapshotter, the operator does not depend on its client library
+var VolumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshot",
+}
+
+func newVolumeSnapshot(namespace, name string) *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	snapshot.SetNamespace(namespace)
+	snapshot.SetName(name)
+	return snapshot
+}
+
+// volumeSnapshot returns the VolumeSnapshot of the volume of the backup
+func volumeSnapshot(instance *current.IBPBackup, record current.BackupRecord) *unstructured.Unstructured {
+	snapshot := newVolumeSnapshot(instance.GetNamespace(), record.Location)
+	snapshot.SetLabels(map[string]string{
+		"backup": instance.GetName(),
+		"volume": record.Volume,
+	})
+
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": record.Volume,
+		},
+	}
+	if instance.Spec.VolumeSnapshotClassName != "" {
+		spec["volumeSnapshotClassName"] = instance.Spec.VolumeSnapshotClassName
+	}
+	snapshot.Object["spec"] = spec
+
+	return snapshot
+}
+
+// snapshotPhase returns the phase of the backup from the status of the VolumeSnapshot
+func snapshotPhase(snapshot *unstructured.Unstructured) current.BackupPhase {
+	if message, found, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message"); found && message != "" {
+		return current.BackupFailed
+	}
+
+	if ready, found, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse"); found && ready {
+		return current.BackupCompleted
+	}
+
+	return current.BackupPending
+}
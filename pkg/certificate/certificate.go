@@ -30,6 +30,7 @@ import (
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/config"
 	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/metrics"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -106,7 +107,13 @@ func (c *CertificateManager) CertificateExpiring(certType common.SecretType, ins
 		return false, time.Time{}, err
 	}
 
-	return c.Expires(cert, numSecondsBeforeExpire)
+	expireDate, err = c.GetExpireDate(cert)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	metrics.SetCertificateExpiry(instance.GetNamespace(), instance.GetName(), string(certType), expireDate)
+
+	return expires(expireDate, numSecondsBeforeExpire)
 }
 
 func (c *CertificateManager) Expires(cert []byte, numSecondsBeforeExpire int64) (expiring bool, expireDate time.Time, err error) {
@@ -115,6 +122,10 @@ func (c *CertificateManager) Expires(cert []byte, numSecondsBeforeExpire int64)
 		return false, time.Time{}, err
 	}
 
+	return expires(expireDate, numSecondsBeforeExpire)
+}
+
+func expires(expireDate time.Time, numSecondsBeforeExpire int64) (bool, time.Time, error) {
 	// Checks if the duration between time.Now() and the expiration date is less than or equal to the numSecondsBeforeExpire
 	if expireDate.Sub(time.Now()) <= time.Duration(numSecondsBeforeExpire)*time.Second {
 		return true, expireDate, nil
@@ -123,6 +134,39 @@ func (c *CertificateManager) Expires(cert []byte, numSecondsBeforeExpire int64)
 	return false, time.Time{}, nil
 }
 
+// GetMissingHosts returns the hosts that are not covered by the subject alternative
+// names of the instance's current certificate of the given type
+func (c *CertificateManager) GetMissingHosts(certType common.SecretType, instance v1.Object, hosts []string) ([]string, error) {
+	certName := fmt.Sprintf("%s-%s-signcert", certType, instance.GetName())
+	cert, err := c.GetSignCert(certName, instance.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	return c.MissingHosts(cert, hosts)
+}
+
+func (c *CertificateManager) MissingHosts(pemBytes []byte, hosts []string) ([]string, error) {
+	cert, err := util.GetCertificateFromPEMBytes(pemBytes)
+	if err != nil {
+		return nil, errors.New("failed to get certificate from bytes")
+	}
+
+	missing := []string{}
+	for _, host := range hosts {
+		if host == "" {
+			continue
+		}
+		// VerifyHostname checks IP addresses against the IP SANs and
+		// hostnames against the DNS SANs, including wildcards
+		if err := cert.VerifyHostname(host); err != nil {
+			missing = append(missing, host)
+		}
+	}
+
+	return missing, nil
+}
+
 func (c *CertificateManager) CheckCertificatesForExpire(instance v1.Object, numSecondsBeforeExpire int64) (statusType current.IBPCRStatusType, message string, err error) {
 	tlsExpiring, tlsExpireDate, err := c.CertificateExpiring(common.TLS, instance, numSecondsBeforeExpire)
 	if err != nil {
@@ -309,6 +353,27 @@ func (c *CertificateManager) RenewCert(certType common.SecretType, instance Inst
 	return nil
 }
 
+// ReenrollTLSCert reenrolls the TLS certificate of the instance and returns the new certificate
+// and key, without updating the secrets of the instance
+func (c *CertificateManager) ReenrollTLSCert(instance v1.Object, spec *current.EnrollmentSpec, storagePath string, newKey bool) ([]byte, []byte, error) {
+	cert, key, err := c.GetSignCertAndKey(common.TLS, instance, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certReenroller, err := c.GetReenroller(common.TLS, spec, nil, storagePath, cert, key, false, newKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := certReenroller.Reenroll()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to reenroll TLS certificate for instance '%s'", instance.GetName())
+	}
+
+	return resp.SignCert, resp.Keystore, nil
+}
+
 func (c *CertificateManager) UpdateSignCert(name string, cert []byte, instance v1.Object) error {
 	// Cert might not be returned from reenroll call, for example if the reenroll happens in a job which handles
 	// updating the secret when using HSM (non-proxy)
@@ -27,6 +27,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"math/big"
+	"net"
 	"time"
 
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
@@ -167,6 +168,31 @@ var _ = Describe("Certificate", func() {
 		})
 	})
 
+	Context("get missing hosts", func() {
+		BeforeEach(func() {
+			certBytes = createCertWithHosts(time.Now().Add(time.Hour*24*30), []string{"peer.domain.com", "*.apps.domain.com"}, []net.IP{net.ParseIP("127.0.0.1")})
+		})
+
+		It("returns error if fails to read certificate", func() {
+			certBytes = []byte("invalid")
+			_, err := certificateManager.GetMissingHosts(common.TLS, instance, []string{"peer.domain.com"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to get certificate from bytes"))
+		})
+
+		It("returns no hosts if all hosts are in the certificate", func() {
+			missing, err := certificateManager.GetMissingHosts(common.TLS, instance, []string{"peer.domain.com", "console.apps.domain.com", "127.0.0.1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(BeEmpty())
+		})
+
+		It("returns hosts that are not in the certificate", func() {
+			missing, err := certificateManager.GetMissingHosts(common.TLS, instance, []string{"peer.domain.com", "peer.new-domain.com", "10.0.0.1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(Equal([]string{"peer.new-domain.com", "10.0.0.1"}))
+		})
+	})
+
 	Context("check certificates for expire", func() {
 		var (
 			expiredCert []byte
@@ -342,9 +368,15 @@ var _ = Describe("Certificate", func() {
 })
 
 func createCert(expireDate time.Time) []byte {
+	return createCertWithHosts(expireDate, nil, nil)
+}
+
+func createCertWithHosts(expireDate time.Time, dnsNames []string, ipAddresses []net.IP) []byte {
 	certtemplate := x509.Certificate{
 		SerialNumber: big.NewInt(1),
 		NotAfter:     expireDate,
+		DNSNames:     dnsNames,
+		IPAddresses:  ipAddresses,
 	}
 
 	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
@@ -0,0 +1,140 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ccaas
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/pkg/errors"
+)
+
+// Type is the type of the chaincode package handled by the ccaas external
+// builder shipped with the peer image
+const Type = "ccaas"
+
+type metadata struct {
+	Type  string `json:"type"`
+	Label string `json:"label"`
+}
+
+type connection struct {
+	Address     string `json:"address"`
+	DialTimeout string `json:"dial_timeout"`
+	TLSRequired bool   `json:"tls_required"`
+}
+
+// Address returns the address the peers use to connect to the chaincode server
+func Address(instance *current.IBPChaincode) string {
+	return fmt.Sprintf("%s.%s.svc:%d", instance.GetName(), instance.GetNamespace(), instance.GetPort())
+}
+
+// NewPackage returns the chaincode package of the chaincode server and its package ID
+func NewPackage(instance *current.IBPChaincode) ([]byte, string, error) {
+	pkg, err := Package(instance.GetLabel(), Address(instance))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return pkg, PackageID(instance.GetLabel(), pkg), nil
+}
+
+// Package returns the chaincode package pointing the peers at the chaincode server
+// listening on address. The package is reproducible, so the same label and address
+// always result in the same package ID.
+func Package(label, address string) ([]byte, error) {
+	connectionBytes, err := json.Marshal(&connection{
+		Address:     address,
+		DialTimeout: "10s",
+		TLSRequired: false,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal connection.json")
+	}
+
+	code, err := targz(file{"connection.json", connectionBytes})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create code.tar.gz")
+	}
+
+	metadataBytes, err := json.Marshal(&metadata{
+		Type:  Type,
+		Label: label,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal metadata.json")
+	}
+
+	pkg, err := targz(file{"metadata.json", metadataBytes}, file{"code.tar.gz", code})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create chaincode package")
+	}
+
+	return pkg, nil
+}
+
+// PackageID returns the package ID the peer assigns to the chaincode package on install
+func PackageID(label string, pkg []byte) string {
+	hash := sha256.Sum256(pkg)
+	return fmt.Sprintf("%s:%s", label, hex.EncodeToString(hash[:]))
+}
+
+type file struct {
+	name    string
+	content []byte
+}
+
+// targz leaves modification times unset so the archive only depends on its content
+func targz(files ...file) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+
+	for _, f := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name: f.name,
+			Mode: 0644,
+			Size: int64(len(f.content)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		_, err = tw.Write(f.content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := tw.Close()
+	if err != nil {
+		return nil, err
+	}
+	err = gw.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
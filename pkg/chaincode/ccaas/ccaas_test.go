@@ -0,0 +1,98 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ccaas_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/chaincode/ccaas"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CCaaS package", func() {
+	var instance *current.IBPChaincode
+
+	BeforeEach(func() {
+		instance = &current.IBPChaincode{
+			Spec: current.IBPChaincodeSpec{
+				Version: "1.0",
+			},
+		}
+		instance.Name = "basic"
+		instance.Namespace = "org1"
+	})
+
+	It("returns the address of the chaincode service", func() {
+		Expect(ccaas.Address(instance)).To(Equal("basic.org1.svc:9999"))
+	})
+
+	It("creates a ccaas package with the connection to the chaincode server", func() {
+		pkg, packageID, err := ccaas.NewPackage(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.HasPrefix(packageID, "basic_1.0:")).To(Equal(true))
+
+		files := untargz(pkg)
+		Expect(files).To(HaveKey("metadata.json"))
+		Expect(string(files["metadata.json"])).To(Equal(`{"type":"ccaas","label":"basic_1.0"}`))
+
+		code := untargz(files["code.tar.gz"])
+		Expect(string(code["connection.json"])).To(Equal(`{"address":"basic.org1.svc:9999","dial_timeout":"10s","tls_required":false}`))
+	})
+
+	It("creates the same package for the same label and address", func() {
+		pkg1, err := ccaas.Package("basic_1.0", "basic.org1.svc:9999")
+		Expect(err).NotTo(HaveOccurred())
+		pkg2, err := ccaas.Package("basic_1.0", "basic.org1.svc:9999")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ccaas.PackageID("basic_1.0", pkg1)).To(Equal(ccaas.PackageID("basic_1.0", pkg2)))
+	})
+
+	It("creates a different package if the address changes", func() {
+		pkg1, err := ccaas.Package("basic_1.0", "basic.org1.svc:9999")
+		Expect(err).NotTo(HaveOccurred())
+		pkg2, err := ccaas.Package("basic_1.0", "basic.org1.svc:7052")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ccaas.PackageID("basic_1.0", pkg1)).NotTo(Equal(ccaas.PackageID("basic_1.0", pkg2)))
+	})
+})
+
+func untargz(data []byte) map[string][]byte {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	Expect(err).NotTo(HaveOccurred())
+	tr := tar.NewReader(gr)
+
+	files := map[string][]byte{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		Expect(err).NotTo(HaveOccurred())
+		content, err := io.ReadAll(tr)
+		Expect(err).NotTo(HaveOccurred())
+		files[header.Name] = content
+	}
+	return files
+}
@@ -0,0 +1,351 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chaincode
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/chaincode/ccaas"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/chaincode/override"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel/peeradmin"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	resourcemanager "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/manager"
+	basepeer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("chaincode")
+
+const (
+	defaultDeployment = "./definitions/chaincode/deployment.yaml"
+	defaultService    = "./definitions/chaincode/service.yaml"
+
+	defaultTimeout = 30 * time.Second
+)
+
+//go:generate counterfeiter -o mocks/lifecycle.go -fake-name Lifecycle . Lifecycle
+
+type Lifecycle interface {
+	InstallChaincode(peer *peeradmin.Endpoint, signer *peeradmin.Signer, pkg []byte) (string, error)
+	QueryInstalledChaincodes(peer *peeradmin.Endpoint, signer *peeradmin.Signer) ([]string, error)
+	CheckCommitReadiness(peer *peeradmin.Endpoint, signer *peeradmin.Signer, channelID string, def *peeradmin.ChaincodeDefinition) (map[string]bool, error)
+	QueryCommittedSequence(peer *peeradmin.Endpoint, signer *peeradmin.Signer, channelID, name string) (int64, error)
+	ApproveChaincodeDefinition(peer, orderer *peeradmin.Endpoint, signer *peeradmin.Signer, channelID string, def *peeradmin.ChaincodeDefinition) error
+	CommitChaincodeDefinition(peers []*peeradmin.Endpoint, orderer *peeradmin.Endpoint, signer *peeradmin.Signer, channelID string, def *peeradmin.ChaincodeDefinition) error
+}
+
+// Chaincode deploys chaincode servers and drives the chaincode lifecycle on the peers
+type Chaincode struct {
+	Client    k8sclient.Client
+	Scheme    *runtime.Scheme
+	Lifecycle Lifecycle
+
+	DeploymentManager resources.Manager
+	ServiceManager    resources.Manager
+}
+
+func New(client k8sclient.Client, scheme *runtime.Scheme) *Chaincode {
+	o := &override.Override{}
+	resourceManager := resourcemanager.New(client, scheme)
+
+	c := &Chaincode{
+		Client:    client,
+		Scheme:    scheme,
+		Lifecycle: peeradmin.New(defaultTimeout),
+	}
+	c.DeploymentManager = resourceManager.CreateDeploymentManager("", o.Deployment, c.GetLabels, defaultDeployment)
+	c.ServiceManager = resourceManager.CreateServiceManager("", o.Service, c.GetLabels, defaultService)
+
+	return c
+}
+
+// chaincodePeer is a peer the chaincode is installed on
+type chaincodePeer struct {
+	name     string
+	endpoint *peeradmin.Endpoint
+	signer   *peeradmin.Signer
+	orderer  *peeradmin.Endpoint
+}
+
+// Reconcile deploys the chaincode server, installs the chaincode package on every peer, approves
+// the chaincode definition for the organization of every peer and commits it once every
+// organization approved. Failures of individual peers and organizations are reported in the
+// returned status and retried on the next reconcile.
+func (c *Chaincode) Reconcile(instance *current.IBPChaincode) (*current.IBPChaincodeStatus, error) {
+	err := c.ValidateSpec(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.DeploymentManager.Reconcile(instance, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to reconcile chaincode deployment")
+	}
+
+	err = c.ServiceManager.Reconcile(instance, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to reconcile chaincode service")
+	}
+
+	pkg, packageID, err := ccaas.NewPackage(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	validationParameter, err := GetValidationParameter(instance.Spec.EndorsementPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	def := &peeradmin.ChaincodeDefinition{
+		Name:                instance.GetChaincodeName(),
+		Version:             instance.Spec.Version,
+		Sequence:            instance.Spec.Sequence,
+		PackageID:           packageID,
+		ValidationParameter: validationParameter,
+		InitRequired:        instance.Spec.InitRequired,
+	}
+
+	status := &current.IBPChaincodeStatus{
+		PackageID: packageID,
+	}
+
+	// Install the package on every peer, the peers of an organization that have the
+	// package installed are used to approve and commit the definition
+	orgs := map[string][]*chaincodePeer{}
+	for _, name := range instance.Spec.Peers {
+		peerStatus := current.ChaincodePeerStatus{Name: name}
+
+		mspID, peer, err := c.getPeer(instance, name)
+		if err == nil {
+			err = c.install(peer, pkg, packageID)
+		}
+		if err != nil {
+			log.Error(err, fmt.Sprintf("Failed to install chaincode '%s' on peer '%s'", instance.GetName(), name))
+			peerStatus.Message = err.Error()
+			status.Peers = append(status.Peers, peerStatus)
+			continue
+		}
+
+		peerStatus.Installed = true
+		status.Peers = append(status.Peers, peerStatus)
+		orgs[mspID] = append(orgs[mspID], peer)
+	}
+
+	if len(orgs) == 0 {
+		return status, nil
+	}
+
+	mspIDs := []string{}
+	for mspID := range orgs {
+		mspIDs = append(mspIDs, mspID)
+	}
+	sort.Strings(mspIDs)
+	first := orgs[mspIDs[0]][0]
+
+	status.CommittedSequence, err = c.Lifecycle.QueryCommittedSequence(first.endpoint, first.signer, instance.Spec.Channel, def.Name)
+	if err != nil {
+		return nil, err
+	}
+	if status.CommittedSequence >= def.Sequence {
+		for _, mspID := range mspIDs {
+			status.Organizations = append(status.Organizations, current.ChaincodeOrganizationStatus{MSPID: mspID, Approved: true})
+		}
+		return status, nil
+	}
+
+	approvals, err := c.Lifecycle.CheckCommitReadiness(first.endpoint, first.signer, instance.Spec.Channel, def)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mspID := range mspIDs {
+		orgStatus := current.ChaincodeOrganizationStatus{
+			MSPID:    mspID,
+			Approved: approvals[mspID],
+		}
+
+		if !orgStatus.Approved {
+			peer := orgs[mspID][0]
+			log.Info(fmt.Sprintf("Approving chaincode '%s' sequence %d for organization '%s'", def.Name, def.Sequence, mspID))
+			err = c.Lifecycle.ApproveChaincodeDefinition(peer.endpoint, peer.orderer, peer.signer, instance.Spec.Channel, def)
+			if err != nil {
+				log.Error(err, fmt.Sprintf("Failed to approve chaincode '%s' for organization '%s'", def.Name, mspID))
+				orgStatus.Message = err.Error()
+			}
+		}
+
+		status.Organizations = append(status.Organizations, orgStatus)
+	}
+
+	if !status.AllApproved() {
+		// Approvals are committed asynchronously, the definition is committed on a later reconcile
+		return status, nil
+	}
+
+	endorsers := []*peeradmin.Endpoint{}
+	for _, mspID := range mspIDs {
+		endorsers = append(endorsers, orgs[mspID][0].endpoint)
+	}
+
+	log.Info(fmt.Sprintf("Committing chaincode '%s' sequence %d on channel '%s'", def.Name, def.Sequence, instance.Spec.Channel))
+	err = c.Lifecycle.CommitChaincodeDefinition(endorsers, first.orderer, first.signer, instance.Spec.Channel, def)
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (c *Chaincode) install(peer *chaincodePeer, pkg []byte, packageID string) error {
+	installed, err := c.Lifecycle.QueryInstalledChaincodes(peer.endpoint, peer.signer)
+	if err != nil {
+		return err
+	}
+
+	if util.ContainsValue(packageID, installed) {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("Installing chaincode package '%s' on peer '%s'", packageID, peer.name))
+	_, err = c.Lifecycle.InstallChaincode(peer.endpoint, peer.signer, pkg)
+	return err
+}
+
+// ValidateSpec checks that the fields required to define the chaincode are set
+func (c *Chaincode) ValidateSpec(instance *current.IBPChaincode) error {
+	if instance.Spec.Version == "" {
+		return errors.New("version must be set")
+	}
+	if instance.Spec.Sequence < 1 {
+		return errors.New("sequence must be greater than 0")
+	}
+	if instance.Spec.Channel == "" {
+		return errors.New("channel must be set")
+	}
+	if len(instance.Spec.Peers) == 0 {
+		return errors.New("at least one peer must be listed")
+	}
+
+	return nil
+}
+
+// getPeer returns the MSP ID of the peer and the access to the peer and the orderer of the
+// channel of the chaincode, using the admin identity of the organization of the peer
+func (c *Chaincode) getPeer(instance *current.IBPChaincode, name string) (string, *chaincodePeer, error) {
+	peer := &current.IBPPeer{}
+	err := c.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.GetNamespace()}, peer)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to get peer '%s'", name)
+	}
+
+	if peer.Spec.AdminSecret == "" {
+		return "", nil, errors.Errorf("peer '%s' does not set an adminSecret", name)
+	}
+
+	var channel *current.PeerChannel
+	for i := range peer.Spec.Channels {
+		if peer.Spec.Channels[i].Name == instance.Spec.Channel {
+			channel = &peer.Spec.Channels[i]
+		}
+	}
+	if channel == nil {
+		return "", nil, errors.Errorf("peer '%s' does not list channel '%s'", name, instance.Spec.Channel)
+	}
+	if status := peer.Status.GetChannel(channel.Name); status == nil || status.Status != current.NodeJoined {
+		return "", nil, errors.Errorf("peer '%s' has not joined channel '%s'", name, channel.Name)
+	}
+
+	orderer, err := basepeer.GetOrdererEndpoint(*channel)
+	if err != nil {
+		return "", nil, err
+	}
+
+	signer, err := basepeer.GetAdminSigner(c.Client, peer)
+	if err != nil {
+		return "", nil, err
+	}
+
+	endpoint, err := basepeer.GetPeerAdminEndpoint(c.Client, peer)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return peer.Spec.MSPID, &chaincodePeer{
+		name:     name,
+		endpoint: endpoint,
+		signer:   signer,
+		orderer:  orderer,
+	}, nil
+}
+
+// GetValidationParameter returns the validation parameter of the chaincode definition for
+// the signature policy, or nil to use the endorsement policy of the channel
+func GetValidationParameter(policy string) ([]byte, error) {
+	if policy == "" {
+		return nil, nil
+	}
+
+	signaturePolicy, err := policydsl.FromString(policy)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid endorsement policy '%s'", policy)
+	}
+
+	validationParameter, err := proto.Marshal(&pb.ApplicationPolicy{
+		Type: &pb.ApplicationPolicy_SignaturePolicy{
+			SignaturePolicy: signaturePolicy,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal endorsement policy")
+	}
+
+	return validationParameter, nil
+}
+
+func (c *Chaincode) GetLabels(instance v1.Object) map[string]string {
+	label := os.Getenv("OPERATOR_LABEL_PREFIX")
+	if label == "" {
+		label = "fabric"
+	}
+
+	return map[string]string{
+		"app":                          instance.GetName(),
+		"creator":                      label,
+		"release":                      "operator",
+		"helm.sh/chart":                "ibm-" + label,
+		"app.kubernetes.io/name":       label,
+		"app.kubernetes.io/instance":   label + "chaincode",
+		"app.kubernetes.io/managed-by": label + "-operator",
+	}
+}
@@ -0,0 +1,286 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chaincode_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	controllermocks "github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/chaincode"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/chaincode/mocks"
+	managermocks "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("chaincode", func() {
+	var (
+		cc            *chaincode.Chaincode
+		mockClient    *controllermocks.Client
+		mockLifecycle *mocks.Lifecycle
+		deploymentMgr *managermocks.ResourceManager
+		serviceMgr    *managermocks.ResourceManager
+		instance      *current.IBPChaincode
+		peers         map[string]*current.IBPPeer
+	)
+
+	BeforeEach(func() {
+		mockClient = &controllermocks.Client{}
+		mockLifecycle = &mocks.Lifecycle{}
+		deploymentMgr = &managermocks.ResourceManager{}
+		serviceMgr = &managermocks.ResourceManager{}
+
+		instance = &current.IBPChaincode{
+			Spec: current.IBPChaincodeSpec{
+				Version:  "1.0",
+				Sequence: 1,
+				Channel:  "mychannel",
+				Image:    "chaincode-image",
+				Peers:    []string{"org1peer1", "org2peer1"},
+			},
+		}
+		instance.Name = "basic"
+		instance.Namespace = "namespace"
+
+		peers = map[string]*current.IBPPeer{
+			"org1peer1": newPeer("org1peer1", "Org1MSP"),
+			"org2peer1": newPeer("org2peer1", "Org2MSP"),
+		}
+
+		cert, key := generateIdentity()
+		mockClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+			switch o := obj.(type) {
+			case *current.IBPPeer:
+				peer, found := peers[nn.Name]
+				if !found {
+					return errors.New("not found")
+				}
+				peer.DeepCopyInto(o)
+			case *corev1.Secret:
+				o.Name = nn.Name
+				o.Data = map[string][]byte{
+					"cert.pem":     cert,
+					"key.pem":      key,
+					"cacert-0.pem": cert,
+				}
+			}
+			return nil
+		}
+
+		mockLifecycle.QueryInstalledChaincodesReturns([]string{}, nil)
+		mockLifecycle.CheckCommitReadinessReturns(map[string]bool{"Org1MSP": false, "Org2MSP": false}, nil)
+
+		cc = &chaincode.Chaincode{
+			Client:            mockClient,
+			Scheme:            &runtime.Scheme{},
+			Lifecycle:         mockLifecycle,
+			DeploymentManager: deploymentMgr,
+			ServiceManager:    serviceMgr,
+		}
+	})
+
+	Context("validate spec", func() {
+		It("returns an error if version is not set", func() {
+			instance.Spec.Version = ""
+			_, err := cc.Reconcile(instance)
+			Expect(err).To(MatchError("version must be set"))
+		})
+
+		It("returns an error if sequence is not set", func() {
+			instance.Spec.Sequence = 0
+			_, err := cc.Reconcile(instance)
+			Expect(err).To(MatchError("sequence must be greater than 0"))
+		})
+
+		It("returns an error if no peer is listed", func() {
+			instance.Spec.Peers = nil
+			_, err := cc.Reconcile(instance)
+			Expect(err).To(MatchError("at least one peer must be listed"))
+		})
+
+		It("returns an error if the endorsement policy is invalid", func() {
+			instance.Spec.EndorsementPolicy = "NOT('Org1MSP.peer')"
+			_, err := cc.Reconcile(instance)
+			Expect(err).To(MatchError(ContainSubstring("invalid endorsement policy")))
+		})
+	})
+
+	Context("reconcile", func() {
+		It("returns an error if it fails to reconcile the deployment", func() {
+			deploymentMgr.ReconcileReturns(errors.New("deployment error"))
+			_, err := cc.Reconcile(instance)
+			Expect(err).To(MatchError(ContainSubstring("failed to reconcile chaincode deployment")))
+		})
+
+		It("installs the package on every peer", func() {
+			status, err := cc.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockLifecycle.InstallChaincodeCallCount()).To(Equal(2))
+			Expect(status.PackageID).To(HavePrefix("basic_1.0:"))
+			Expect(status.Peers).To(Equal([]current.ChaincodePeerStatus{
+				{Name: "org1peer1", Installed: true},
+				{Name: "org2peer1", Installed: true},
+			}))
+		})
+
+		It("does not install the package if already installed", func() {
+			status, err := cc.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+
+			mockLifecycle.QueryInstalledChaincodesReturns([]string{status.PackageID}, nil)
+			_, err = cc.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockLifecycle.InstallChaincodeCallCount()).To(Equal(2))
+		})
+
+		It("reports peers that have not joined the channel", func() {
+			peers["org2peer1"].Status.Channels = nil
+			status, err := cc.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Peers[1].Installed).To(Equal(false))
+			Expect(status.Peers[1].Message).To(Equal("peer 'org2peer1' has not joined channel 'mychannel'"))
+			Expect(status.Organizations).To(HaveLen(1))
+		})
+
+		It("reports peers without admin secret", func() {
+			peers["org2peer1"].Spec.AdminSecret = ""
+			status, err := cc.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Peers[1].Message).To(Equal("peer 'org2peer1' does not set an adminSecret"))
+		})
+
+		It("approves the definition for every organization that has not approved", func() {
+			mockLifecycle.CheckCommitReadinessReturns(map[string]bool{"Org1MSP": true, "Org2MSP": false}, nil)
+			status, err := cc.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mockLifecycle.ApproveChaincodeDefinitionCallCount()).To(Equal(1))
+			_, orderer, signer, channelID, def := mockLifecycle.ApproveChaincodeDefinitionArgsForCall(0)
+			Expect(orderer.Address).To(Equal("orderer:7050"))
+			Expect(signer.MSPID).To(Equal("Org2MSP"))
+			Expect(channelID).To(Equal("mychannel"))
+			Expect(def.PackageID).To(Equal(status.PackageID))
+
+			Expect(status.Organizations).To(Equal([]current.ChaincodeOrganizationStatus{
+				{MSPID: "Org1MSP", Approved: true},
+				{MSPID: "Org2MSP", Approved: false},
+			}))
+			Expect(mockLifecycle.CommitChaincodeDefinitionCallCount()).To(Equal(0))
+		})
+
+		It("reports failed approvals", func() {
+			mockLifecycle.ApproveChaincodeDefinitionReturns(errors.New("orderer rejected envelope"))
+			status, err := cc.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Organizations[0].Message).To(ContainSubstring("orderer rejected envelope"))
+		})
+
+		It("commits the definition once every organization approved", func() {
+			mockLifecycle.CheckCommitReadinessReturns(map[string]bool{"Org1MSP": true, "Org2MSP": true}, nil)
+			_, err := cc.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mockLifecycle.ApproveChaincodeDefinitionCallCount()).To(Equal(0))
+			Expect(mockLifecycle.CommitChaincodeDefinitionCallCount()).To(Equal(1))
+			endorsers, _, _, _, def := mockLifecycle.CommitChaincodeDefinitionArgsForCall(0)
+			Expect(endorsers).To(HaveLen(2))
+			Expect(def.Sequence).To(Equal(int64(1)))
+		})
+
+		It("does nothing once the sequence is committed", func() {
+			mockLifecycle.QueryCommittedSequenceReturns(1, nil)
+			status, err := cc.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.CommittedSequence).To(Equal(int64(1)))
+			Expect(status.AllApproved()).To(Equal(true))
+			Expect(mockLifecycle.CheckCommitReadinessCallCount()).To(Equal(0))
+			Expect(mockLifecycle.CommitChaincodeDefinitionCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("validation parameter", func() {
+		It("returns nil if no endorsement policy is set", func() {
+			param, err := chaincode.GetValidationParameter("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(param).To(BeNil())
+		})
+
+		It("returns the signature policy", func() {
+			param, err := chaincode.GetValidationParameter("OR('Org1MSP.peer','Org2MSP.peer')")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(param).NotTo(BeEmpty())
+		})
+	})
+})
+
+func newPeer(name, mspID string) *current.IBPPeer {
+	peer := &current.IBPPeer{
+		Spec: current.IBPPeerSpec{
+			MSPID:       mspID,
+			Domain:      "domain",
+			AdminSecret: name + "-admin",
+			Channels: []current.PeerChannel{
+				{
+					Name:            "mychannel",
+					OrdererEndpoint: "orderer:7050",
+				},
+			},
+		},
+		Status: current.IBPPeerStatus{
+			Channels: []current.PeerChannelStatus{
+				{Name: "mychannel", Status: current.NodeJoined},
+			},
+		},
+	}
+	peer.Name = name
+	peer.Namespace = "namespace"
+	return peer
+}
+
+func generateIdentity() ([]byte, []byte) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	Expect(err).NotTo(HaveOccurred())
+
+	key, err := x509.MarshalPKCS8PrivateKey(priv)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key})
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package override
+
+import (
+	"fmt"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/chaincode/ccaas"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	dep "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/deployment"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Container names
+const (
+	CHAINCODE = "chaincode"
+)
+
+func (o *Override) Deployment(object v1.Object, deployment *appsv1.Deployment, action resources.Action) error {
+	instance := object.(*current.IBPChaincode)
+	switch action {
+	case resources.Create:
+		return o.CreateDeployment(instance, deployment)
+	case resources.Update:
+		return o.UpdateDeployment(instance, deployment)
+	}
+
+	return nil
+}
+
+func (o *Override) CreateDeployment(instance *current.IBPChaincode, k8sDep *appsv1.Deployment) error {
+	deployment := dep.New(k8sDep)
+
+	_, err := deployment.GetContainer(CHAINCODE)
+	if err != nil {
+		return errors.New("chaincode container not found in deployment spec")
+	}
+
+	return o.CommonDeployment(instance, deployment)
+}
+
+func (o *Override) UpdateDeployment(instance *current.IBPChaincode, k8sDep *appsv1.Deployment) error {
+	deployment := dep.New(k8sDep)
+	return o.CommonDeployment(instance, deployment)
+}
+
+func (o *Override) CommonDeployment(instance *current.IBPChaincode, deployment *dep.Deployment) error {
+	if instance.Spec.Image == "" {
+		return errors.New("chaincode image must be set")
+	}
+
+	_, packageID, err := ccaas.NewPackage(instance)
+	if err != nil {
+		return err
+	}
+
+	chaincode := deployment.MustGetContainer(CHAINCODE)
+	chaincode.Image = instance.Spec.Image
+	chaincode.Ports[0].ContainerPort = instance.GetPort()
+	chaincode.AppendEnvIfMissingOverrideIfPresent("CHAINCODE_SERVER_ADDRESS", fmt.Sprintf("0.0.0.0:%d", instance.GetPort()))
+	// CHAINCODE_ID is read by the chaincode samples, the go shim reads CORE_CHAINCODE_ID_NAME
+	chaincode.AppendEnvIfMissingOverrideIfPresent("CHAINCODE_ID", packageID)
+	chaincode.AppendEnvIfMissingOverrideIfPresent("CORE_CHAINCODE_ID_NAME", packageID)
+
+	if instance.Spec.Resources != nil {
+		err = chaincode.UpdateResources(instance.Spec.Resources)
+		if err != nil {
+			return errors.Wrap(err, "resource update for chaincode failed")
+		}
+	}
+
+	deployment.SetImagePullSecrets(instance.Spec.ImagePullSecrets)
+
+	replicas := instance.GetReplicas()
+	deployment.SetReplicas(&replicas)
+
+	return nil
+}
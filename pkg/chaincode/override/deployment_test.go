@@ -0,0 +1,124 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package override_test
+
+import (
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/chaincode/ccaas"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/chaincode/override"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var _ = Describe("Chaincode Deployment Overrides", func() {
+	var (
+		overrider  *override.Override
+		instance   *current.IBPChaincode
+		deployment *appsv1.Deployment
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		deployment, err = util.GetDeploymentFromFile("../../../definitions/chaincode/deployment.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		replicas := int32(2)
+		overrider = &override.Override{}
+		instance = &current.IBPChaincode{
+			Spec: current.IBPChaincodeSpec{
+				Version:          "1.0",
+				Image:            "ghcr.io/hyperledger-labs/fabric-samples/asset-transfer-basic:latest",
+				ImagePullSecrets: []string{"pullsecret"},
+				Port:             7052,
+				Replicas:         &replicas,
+				Resources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1"),
+					},
+				},
+			},
+		}
+		instance.Name = "basic"
+		instance.Namespace = "org1"
+	})
+
+	Context("create", func() {
+		It("returns an error if image is not set", func() {
+			instance.Spec.Image = ""
+			err := overrider.Deployment(instance, deployment, resources.Create)
+			Expect(err).To(MatchError("chaincode image must be set"))
+		})
+
+		It("overrides values based on spec", func() {
+			err := overrider.Deployment(instance, deployment, resources.Create)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, packageID, err := ccaas.NewPackage(instance)
+			Expect(err).NotTo(HaveOccurred())
+
+			container := deployment.Spec.Template.Spec.Containers[0]
+			By("setting image", func() {
+				Expect(container.Image).To(Equal(instance.Spec.Image))
+			})
+
+			By("setting port", func() {
+				Expect(container.Ports[0].ContainerPort).To(Equal(int32(7052)))
+				Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "CHAINCODE_SERVER_ADDRESS", Value: "0.0.0.0:7052"}))
+			})
+
+			By("setting chaincode id", func() {
+				Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "CHAINCODE_ID", Value: packageID}))
+				Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "CORE_CHAINCODE_ID_NAME", Value: packageID}))
+			})
+
+			By("setting resources", func() {
+				Expect(container.Resources.Limits[corev1.ResourceCPU]).To(Equal(resource.MustParse("1")))
+			})
+
+			By("setting image pull secrets", func() {
+				Expect(deployment.Spec.Template.Spec.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "pullsecret"}}))
+			})
+
+			By("setting replicas", func() {
+				Expect(*deployment.Spec.Replicas).To(Equal(int32(2)))
+			})
+		})
+	})
+
+	Context("update", func() {
+		It("updates the chaincode id if the package changes", func() {
+			err := overrider.Deployment(instance, deployment, resources.Create)
+			Expect(err).NotTo(HaveOccurred())
+
+			instance.Spec.Version = "2.0"
+			err = overrider.Deployment(instance, deployment, resources.Update)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, packageID, err := ccaas.NewPackage(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "CHAINCODE_ID", Value: packageID}))
+		})
+	})
+})
@@ -0,0 +1,64 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package override_test
+
+import (
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/chaincode/override"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("Chaincode Service Overrides", func() {
+	var (
+		overrider *override.Override
+		instance  *current.IBPChaincode
+		service   *corev1.Service
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		service, err = util.GetServiceFromFile("../../../definitions/chaincode/service.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		overrider = &override.Override{}
+		instance = &current.IBPChaincode{}
+	})
+
+	Context("create", func() {
+		It("uses the default port", func() {
+			err := overrider.Service(instance, service, resources.Create)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service.Spec.Ports[0].Port).To(Equal(current.DefaultChaincodePort))
+		})
+
+		It("overrides the port based on spec", func() {
+			instance.Spec.Port = 7052
+			err := overrider.Service(instance, service, resources.Create)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service.Spec.Ports[0].Port).To(Equal(int32(7052)))
+			Expect(service.Spec.Ports[0].TargetPort).To(Equal(intstr.FromInt(7052)))
+		})
+	})
+})
@@ -0,0 +1,501 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel/participation"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/orderer/configtx"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("channel")
+
+const (
+	// BlockKey is the key of the channel genesis block in the genesis secret
+	BlockKey = "channel.block"
+
+	// SpecHashAnnotation is the annotation of the genesis secret that holds the hash of the
+	// channel spec the genesis block was generated from
+	SpecHashAnnotation = "ibp.com/channel-spec-hash"
+
+	defaultTimeout = 30 * time.Second
+)
+
+//go:generate counterfeiter -o mocks/participation.go -fake-name Participation . Participation
+
+type Participation interface {
+	Join(adminURL string, creds *participation.Credentials, block []byte) (*participation.ChannelInfo, error)
+	GetChannel(adminURL string, creds *participation.Credentials, channelID string) (*participation.ChannelInfo, error)
+}
+
+// Channel creates application channels on orderer nodes through the channel participation API
+type Channel struct {
+	Client        k8sclient.Client
+	Scheme        *runtime.Scheme
+	Participation Participation
+}
+
+func New(client k8sclient.Client, scheme *runtime.Scheme) *Channel {
+	return &Channel{
+		Client:        client,
+		Scheme:        scheme,
+		Participation: participation.New(defaultTimeout),
+	}
+}
+
+// Reconcile joins every orderer node of the channel that is not a member of the channel yet,
+// and returns the join status of every node
+func (c *Channel) Reconcile(instance *current.IBPChannel) ([]current.ChannelNodeStatus, error) {
+	parent, nodes, err := c.GetOrdererNodes(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]current.ChannelNodeStatus, len(nodes))
+	credentials := make([]*participation.Credentials, len(nodes))
+	adminURLs := make([]string, len(nodes))
+	pending := false
+	joined := false
+	for i, node := range nodes {
+		statuses[i] = current.ChannelNodeStatus{
+			Name:   node.Name,
+			Status: current.NodePending,
+		}
+
+		adminURLs[i], credentials[i], err = c.GetAdminAccess(&node)
+		if err != nil {
+			statuses[i].Status = current.NodeFailed
+			statuses[i].Message = err.Error()
+			continue
+		}
+
+		info, err := c.Participation.GetChannel(adminURLs[i], credentials[i], instance.GetChannelName())
+		if err != nil {
+			if err != participation.ErrChannelNotFound {
+				statuses[i].Status = current.NodeFailed
+				statuses[i].Message = err.Error()
+				continue
+			}
+			pending = true
+			continue
+		}
+		setJoined(&statuses[i], info)
+		joined = true
+	}
+
+	if !pending {
+		return statuses, nil
+	}
+
+	block, err := c.GetConfigBlock(instance, parent, nodes, joined)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range nodes {
+		if statuses[i].Status != current.NodePending {
+			continue
+		}
+
+		log.Info(fmt.Sprintf("Joining orderer node '%s' to channel '%s'", nodes[i].Name, instance.GetChannelName()))
+		info, err := c.Participation.Join(adminURLs[i], credentials[i], block)
+		if err != nil {
+			if err == participation.ErrChannelExists {
+				setJoined(&statuses[i], nil)
+				continue
+			}
+			statuses[i].Status = current.NodeFailed
+			statuses[i].Message = err.Error()
+			continue
+		}
+		setJoined(&statuses[i], info)
+	}
+
+	return statuses, nil
+}
+
+func setJoined(status *current.ChannelNodeStatus, info *participation.ChannelInfo) {
+	status.Status = current.NodeJoined
+	status.Message = ""
+	if info != nil {
+		status.ConsensusRelation = info.ConsensusRelation
+		status.Height = info.Height
+	}
+}
+
+// GetOrdererNodes returns the parent orderer of the channel and the orderer nodes that serve the
+// channel, sorted by name
+func (c *Channel) GetOrdererNodes(instance *current.IBPChannel) (*current.IBPOrderer, []current.IBPOrderer, error) {
+	parent := &current.IBPOrderer{}
+	err := c.Client.Get(context.TODO(), types.NamespacedName{Name: instance.Spec.Orderer.ClusterName, Namespace: instance.Namespace}, parent)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get orderer '%s'", instance.Spec.Orderer.ClusterName)
+	}
+
+	labelSelector, err := labels.Parse(fmt.Sprintf("parent=%s", parent.Name))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse selector for parent name")
+	}
+
+	ordererList := &current.IBPOrdererList{}
+	err = c.Client.List(context.TODO(), ordererList, &client.ListOptions{
+		LabelSelector: labelSelector,
+		Namespace:     instance.Namespace,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to list nodes of orderer '%s'", parent.Name)
+	}
+
+	nodes := []current.IBPOrderer{}
+	for _, node := range ordererList.Items {
+		if len(instance.Spec.Orderer.Nodes) > 0 && !util.ContainsValue(node.Name, instance.Spec.Orderer.Nodes) {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 0 {
+		return nil, nil, errors.Errorf("no orderer nodes found for orderer '%s'", parent.Name)
+	}
+
+	for _, name := range instance.Spec.Orderer.Nodes {
+		found := false
+		for _, node := range nodes {
+			if node.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, nil, errors.Errorf("orderer node '%s' is not a node of orderer '%s'", name, parent.Name)
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	return parent, nodes, nil
+}
+
+// GetAdminAccess returns the admin endpoint of the orderer node, as published in its connection
+// profile, and the credentials used to call it
+func (c *Channel) GetAdminAccess(node *current.IBPOrderer) (string, *participation.Credentials, error) {
+	cm := &corev1.ConfigMap{}
+	err := c.Client.Get(context.TODO(), types.NamespacedName{Name: node.Name + "-connection-profile", Namespace: node.Namespace}, cm)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to get connection profile of orderer node '%s'", node.Name)
+	}
+
+	profile := &current.OrdererConnectionProfile{}
+	err = json.Unmarshal(cm.BinaryData["profile.json"], profile)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to unmarshal connection profile of orderer node '%s'", node.Name)
+	}
+
+	if profile.Endpoints.Admin == "" {
+		return "", nil, errors.Errorf("orderer node '%s' does not expose an admin endpoint, channel participation requires fabric v2.4 or later", node.Name)
+	}
+
+	cert, err := common.GetTLSSignCertBytes(c.Client, node)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to get tls signcert of orderer node '%s'", node.Name)
+	}
+
+	key, err := common.GetTLSKeystoreBytes(c.Client, node)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to get tls keystore of orderer node '%s'", node.Name)
+	}
+
+	rootCAs, err := common.GetTLSCACertBytes(c.Client, node)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to get tls cacerts of orderer node '%s'", node.Name)
+	}
+
+	intermediateCAs, err := common.GetTLSIntercertBytes(c.Client, node)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to get tls intercerts of orderer node '%s'", node.Name)
+	}
+
+	return profile.Endpoints.Admin, &participation.Credentials{
+		Cert:    cert,
+		Key:     key,
+		RootCAs: append(rootCAs, intermediateCAs...),
+	}, nil
+}
+
+// GetConfigBlock returns the genesis block of the channel. The block is stored in a secret, as
+// every orderer node must be joined with the same genesis block. The block is generated again
+// when the spec of the channel changed, as long as no orderer node has joined the channel yet.
+// Once a node joined, the configuration of the channel can only be changed by config updates.
+func (c *Channel) GetConfigBlock(instance *current.IBPChannel, parent *current.IBPOrderer, nodes []current.IBPOrderer, joined bool) ([]byte, error) {
+	hash, err := GetSpecHash(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	err = c.Client.Get(context.TODO(), types.NamespacedName{Name: GetGenesisSecretName(instance), Namespace: instance.Namespace}, secret)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, errors.Wrap(err, "failed to get genesis secret")
+		}
+
+		log.Info(fmt.Sprintf("Generating genesis block for channel '%s'", instance.GetChannelName()))
+		block, err := c.GenerateConfigBlock(instance, parent, nodes)
+		if err != nil {
+			return nil, err
+		}
+
+		secret = &corev1.Secret{
+			Data: map[string][]byte{
+				BlockKey: block,
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+		secret.Name = GetGenesisSecretName(instance)
+		secret.Namespace = instance.Namespace
+		secret.Labels = map[string]string{
+			"app": instance.Name,
+		}
+		secret.Annotations = map[string]string{
+			SpecHashAnnotation: hash,
+		}
+
+		err = c.Client.Create(context.TODO(), secret, k8sclient.CreateOption{Owner: instance, Scheme: c.Scheme})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create genesis secret")
+		}
+
+		return block, nil
+	}
+
+	if len(secret.Data[BlockKey]) == 0 {
+		return nil, errors.Errorf("genesis secret '%s' does not contain a block", secret.Name)
+	}
+
+	if secret.Annotations[SpecHashAnnotation] == hash {
+		return secret.Data[BlockKey], nil
+	}
+
+	if joined {
+		return nil, errors.Errorf("spec of channel '%s' changed after orderer nodes joined the channel, the genesis block can no longer be changed and the channel configuration must be changed with a config update", instance.GetChannelName())
+	}
+
+	log.Info(fmt.Sprintf("Spec of channel '%s' changed, generating genesis block again", instance.GetChannelName()))
+	block, err := c.GenerateConfigBlock(instance, parent, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Data[BlockKey] = block
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[SpecHashAnnotation] = hash
+
+	err = c.Client.Update(context.TODO(), secret)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update genesis secret")
+	}
+
+	return block, nil
+}
+
+// GenerateConfigBlock generates the genesis block of the channel from the spec
+func (c *Channel) GenerateConfigBlock(instance *current.IBPChannel, parent *current.IBPOrderer, nodes []current.IBPOrderer) ([]byte, error) {
+	configTx := configtx.New()
+	profile, err := configTx.GetProfile(configtx.ApplicationChannelProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	if parent.Spec.IsBFT() {
+		profile.SetOrdererType(configtx.ConsensusTypeBFT)
+		profile.Capabilities = map[string]bool{
+			"V3_0": true,
+		}
+	}
+	setCapabilities(profile, instance.Spec.Capabilities)
+
+	for name, policy := range instance.Spec.Policies {
+		profile.Application.Policies[name] = &configtx.Policy{
+			Type: policy.Type,
+			Rule: policy.Rule,
+		}
+	}
+
+	mspConfigs := map[string]*msp.MSPConfig{}
+
+	ordererOrg := &configtx.Organization{
+		Name:           parent.Spec.OrgName,
+		ID:             parent.Spec.MSPID,
+		MSPType:        "bccsp",
+		AdminPrincipal: "Role.MEMBER",
+	}
+	for _, node := range nodes {
+		err = c.addConsenter(profile, ordererOrg, &node)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = profile.AddOrgToOrderer(ordererOrg)
+	if err != nil {
+		return nil, err
+	}
+	mspConfigs[ordererOrg.Name], err = GetMSPConfig(c.Client, &nodes[0], ordererOrg.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, org := range instance.Spec.ApplicationOrganizations {
+		peer := &current.IBPPeer{}
+		err = c.Client.Get(context.TODO(), types.NamespacedName{Name: org.PeerName, Namespace: instance.Namespace}, peer)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get peer '%s'", org.PeerName)
+		}
+
+		appOrg := &configtx.Organization{
+			Name:           org.GetName(peer.Spec.MSPID),
+			ID:             peer.Spec.MSPID,
+			MSPType:        "bccsp",
+			AdminPrincipal: "Role.MEMBER",
+			Policies:       configtx.DefaultApplicationOrgPolicies(peer.Spec.MSPID),
+		}
+		if _, found := mspConfigs[appOrg.Name]; found {
+			return nil, errors.Errorf("organization '%s' is defined more than once in channel", appOrg.Name)
+		}
+
+		err = profile.AddOrgToApplication(appOrg)
+		if err != nil {
+			return nil, err
+		}
+		mspConfigs[appOrg.Name], err = GetMSPConfig(c.Client, peer, appOrg.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = configTx.CompleteProfileInitialization(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return profile.GenerateApplicationChannelBlock(instance.GetChannelName(), mspConfigs)
+}
+
+func (c *Channel) addConsenter(profile *configtx.Profile, ordererOrg *configtx.Organization, node *current.IBPOrderer) error {
+	host, port := common.GetOrdererAddress(node)
+	ordererOrg.OrdererEndpoints = append(ordererOrg.OrdererEndpoints, fmt.Sprintf("%s:%d", host, port))
+
+	tlsCert, err := common.GetTLSSignCertBytes(c.Client, node)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get tls signcert of orderer node '%s'", node.Name)
+	}
+
+	if profile.Orderer.OrdererType != configtx.ConsensusTypeBFT {
+		return profile.AddRaftConsentingNode(&etcdraft.Consenter{
+			Host:          host,
+			Port:          uint32(port), // #nosec G115
+			ClientTlsCert: tlsCert,
+			ServerTlsCert: tlsCert,
+		})
+	}
+
+	ecert, err := common.GetEcertSignCertBytes(c.Client, node)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get ecert signcert of orderer node '%s'", node.Name)
+	}
+
+	id, err := common.GetConsenterID(node)
+	if err != nil {
+		return err
+	}
+
+	return profile.AddBFTConsentingNode(&cb.Consenter{
+		Id:            id,
+		Host:          host,
+		Port:          uint32(port), // #nosec G115
+		MspId:         node.Spec.MSPID,
+		Identity:      ecert,
+		ClientTlsCert: tlsCert,
+		ServerTlsCert: tlsCert,
+	})
+}
+
+func setCapabilities(profile *configtx.Profile, capabilities *current.ChannelCapabilities) {
+	if capabilities == nil {
+		return
+	}
+
+	if len(capabilities.Channel) > 0 {
+		profile.Capabilities = toCapabilities(capabilities.Channel)
+	}
+	if len(capabilities.Orderer) > 0 {
+		profile.SetCapabilitiesForOrderer(toCapabilities(capabilities.Orderer))
+	}
+	if len(capabilities.Application) > 0 {
+		profile.Application.Capabilities = toCapabilities(capabilities.Application)
+	}
+}
+
+func toCapabilities(capabilities []string) map[string]bool {
+	m := map[string]bool{}
+	for _, c := range capabilities {
+		m[c] = true
+	}
+	return m
+}
+
+func GetGenesisSecretName(instance *current.IBPChannel) string {
+	return fmt.Sprintf("%s-channel-genesis", instance.Name)
+}
+
+// GetSpecHash returns the hash of the channel spec the genesis block is generated from
+func GetSpecHash(instance *current.IBPChannel) (string, error) {
+	bytes, err := json.Marshal(instance.Spec)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal channel spec")
+	}
+
+	hash := sha256.Sum256(bytes)
+	return hex.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,249 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	controllermocks "github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel/participation"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("channel", func() {
+	var (
+		ch                *channel.Channel
+		mockClient        *controllermocks.Client
+		mockParticipation *mocks.Participation
+		instance          *current.IBPChannel
+		block             []byte
+		specHash          string
+	)
+
+	BeforeEach(func() {
+		mockClient = &controllermocks.Client{}
+		mockParticipation = &mocks.Participation{}
+		block = []byte("genesisblock")
+
+		instance = &current.IBPChannel{
+			Spec: current.IBPChannelSpec{
+				ChannelName: "mychannel",
+				Orderer: current.ChannelOrderer{
+					ClusterName: "orderer",
+				},
+			},
+		}
+		instance.Name = "channel1"
+		instance.Namespace = "namespace"
+
+		var err error
+		specHash, err = channel.GetSpecHash(instance)
+		Expect(err).NotTo(HaveOccurred())
+
+		mockClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+			switch o := obj.(type) {
+			case *current.IBPOrderer:
+				o.Name = nn.Name
+				o.Namespace = nn.Namespace
+			case *corev1.ConfigMap:
+				node := strings.TrimSuffix(nn.Name, "-connection-profile")
+				profile := &current.OrdererConnectionProfile{
+					Endpoints: current.OrdererEndpoints{
+						Admin: "https://" + node + "-admin",
+					},
+				}
+				bytes, _ := json.Marshal(profile)
+				o.BinaryData = map[string][]byte{
+					"profile.json": bytes,
+				}
+			case *corev1.Secret:
+				o.Name = nn.Name
+				o.Data = map[string][]byte{
+					"cert.pem":       []byte("cert"),
+					"key.pem":        []byte("key"),
+					"cacert-0.pem":   []byte("cacert"),
+					channel.BlockKey: block,
+				}
+				o.Annotations = map[string]string{
+					channel.SpecHashAnnotation: specHash,
+				}
+			}
+			return nil
+		}
+
+		mockClient.ListStub = func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+			switch o := obj.(type) {
+			case *current.IBPOrdererList:
+				node1 := current.IBPOrderer{}
+				node1.Name = "orderernode2"
+				node1.Namespace = "namespace"
+				node2 := current.IBPOrderer{}
+				node2.Name = "orderernode1"
+				node2.Namespace = "namespace"
+				o.Items = []current.IBPOrderer{node1, node2}
+			}
+			return nil
+		}
+
+		mockParticipation.GetChannelReturns(nil, participation.ErrChannelNotFound)
+		mockParticipation.JoinReturns(&participation.ChannelInfo{
+			Name:              "mychannel",
+			ConsensusRelation: "consenter",
+			Height:            1,
+		}, nil)
+
+		ch = &channel.Channel{
+			Client:        mockClient,
+			Scheme:        &runtime.Scheme{},
+			Participation: mockParticipation,
+		}
+	})
+
+	Context("get orderer nodes", func() {
+		It("returns every node of the cluster sorted by name", func() {
+			_, nodes, err := ch.GetOrdererNodes(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(HaveLen(2))
+			Expect(nodes[0].Name).To(Equal("orderernode1"))
+			Expect(nodes[1].Name).To(Equal("orderernode2"))
+		})
+
+		It("returns only the listed nodes", func() {
+			instance.Spec.Orderer.Nodes = []string{"orderernode2"}
+			_, nodes, err := ch.GetOrdererNodes(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(HaveLen(1))
+			Expect(nodes[0].Name).To(Equal("orderernode2"))
+		})
+
+		It("returns an error if a listed node is not part of the cluster", func() {
+			instance.Spec.Orderer.Nodes = []string{"orderernode1", "orderernode3"}
+			_, _, err := ch.GetOrdererNodes(instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("orderer node 'orderernode3' is not a node of orderer 'orderer'"))
+		})
+	})
+
+	Context("get admin access", func() {
+		It("returns an error if the node does not expose an admin endpoint", func() {
+			mockClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+				cm := obj.(*corev1.ConfigMap)
+				cm.BinaryData = map[string][]byte{
+					"profile.json": []byte(`{"endpoints":{"api":"grpcs://orderer"}}`),
+				}
+				return nil
+			}
+			node := &current.IBPOrderer{}
+			node.Name = "orderernode1"
+			_, _, err := ch.GetAdminAccess(node)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not expose an admin endpoint"))
+		})
+
+		It("returns the admin endpoint and the tls credentials of the node", func() {
+			node := &current.IBPOrderer{}
+			node.Name = "orderernode1"
+			url, creds, err := ch.GetAdminAccess(node)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(url).To(Equal("https://orderernode1-admin"))
+			Expect(creds.Cert).To(Equal([]byte("cert")))
+			Expect(creds.Key).To(Equal([]byte("key")))
+		})
+	})
+
+	Context("reconcile", func() {
+		It("joins every node that is not a member of the channel", func() {
+			statuses, err := ch.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockParticipation.JoinCallCount()).To(Equal(2))
+
+			url, _, joinBlock := mockParticipation.JoinArgsForCall(0)
+			Expect(url).To(Equal("https://orderernode1-admin"))
+			Expect(joinBlock).To(Equal(block))
+
+			Expect(statuses).To(HaveLen(2))
+			for _, status := range statuses {
+				Expect(status.Status).To(Equal(current.NodeJoined))
+				Expect(status.ConsensusRelation).To(Equal("consenter"))
+			}
+		})
+
+		It("does not join nodes that are already members of the channel", func() {
+			mockParticipation.GetChannelReturnsOnCall(0, &participation.ChannelInfo{Name: "mychannel", Height: 5}, nil)
+			statuses, err := ch.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockParticipation.JoinCallCount()).To(Equal(1))
+			Expect(statuses[0].Status).To(Equal(current.NodeJoined))
+			Expect(statuses[0].Height).To(Equal(uint64(5)))
+		})
+
+		It("does not generate a block if every node is a member of the channel", func() {
+			mockParticipation.GetChannelReturns(&participation.ChannelInfo{Name: "mychannel"}, nil)
+			_, err := ch.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockParticipation.JoinCallCount()).To(Equal(0))
+		})
+
+		It("reports nodes that failed to join", func() {
+			mockParticipation.JoinReturnsOnCall(1, nil, errors.New("connection refused"))
+			statuses, err := ch.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statuses[0].Status).To(Equal(current.NodeJoined))
+			Expect(statuses[1].Status).To(Equal(current.NodeFailed))
+			Expect(statuses[1].Message).To(Equal("connection refused"))
+		})
+
+		It("treats an existing channel on join as joined", func() {
+			mockParticipation.JoinReturns(nil, participation.ErrChannelExists)
+			statuses, err := ch.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statuses[0].Status).To(Equal(current.NodeJoined))
+		})
+
+		It("joins nodes with the stored genesis block if the spec did not change", func() {
+			_, err := ch.Reconcile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockClient.CreateCallCount()).To(Equal(0))
+			Expect(mockClient.UpdateCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if the spec changed after nodes joined the channel", func() {
+			mockParticipation.GetChannelReturnsOnCall(0, &participation.ChannelInfo{Name: "mychannel", Height: 5}, nil)
+			instance.Spec.Capabilities = &current.ChannelCapabilities{
+				Application: []string{"V2_5"},
+			}
+			_, err := ch.Reconcile(instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("changed after orderer nodes joined the channel"))
+			Expect(mockParticipation.JoinCallCount()).To(Equal(0))
+			Expect(mockClient.UpdateCallCount()).To(Equal(0))
+		})
+	})
+})
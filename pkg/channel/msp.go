@@ -0,0 +1,107 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+import (
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
+	"github.com/gogo/protobuf/proto"
+	"github.com/hyperledger/fabric-lib-go/bccsp"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	fmsp "github.com/hyperledger/fabric/msp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetMSPConfig builds the verifying MSP definition of an organization from the
+// ecert and tls crypto secrets of one of its components
+func GetMSPConfig(client k8sclient.Client, instance v1.Object, mspID string) (*msp.MSPConfig, error) {
+	cacerts, err := common.GetEcertCACertBytes(client, instance)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get ecert cacerts of '%s'", instance.GetName())
+	}
+	if len(cacerts) == 0 {
+		return nil, errors.Errorf("no ecert cacerts found for '%s'", instance.GetName())
+	}
+
+	admincerts, err := common.GetEcertAdmincertBytes(client, instance)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get ecert admincerts of '%s'", instance.GetName())
+	}
+
+	intermediateCerts, err := common.GetEcertIntercertBytes(client, instance)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get ecert intercerts of '%s'", instance.GetName())
+	}
+
+	tlsCACerts, err := common.GetTLSCACertBytes(client, instance)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get tls cacerts of '%s'", instance.GetName())
+	}
+
+	tlsIntermediateCerts, err := common.GetTLSIntercertBytes(client, instance)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get tls intercerts of '%s'", instance.GetName())
+	}
+
+	// Node OUs are identified by the issuing CA, which is the intermediate CA if one is used
+	ouCert := cacerts[0]
+	if len(intermediateCerts) > 0 {
+		ouCert = intermediateCerts[0]
+	}
+
+	fmspconf := &msp.FabricMSPConfig{
+		Admins:            admincerts,
+		RootCerts:         cacerts,
+		IntermediateCerts: intermediateCerts,
+		Name:              mspID,
+		CryptoConfig: &msp.FabricCryptoConfig{
+			SignatureHashFamily:            bccsp.SHA2,
+			IdentityIdentifierHashFunction: bccsp.SHA256,
+		},
+		TlsRootCerts:         tlsCACerts,
+		TlsIntermediateCerts: tlsIntermediateCerts,
+		FabricNodeOus: &msp.FabricNodeOUs{
+			Enable: true,
+			ClientOuIdentifier: &msp.FabricOUIdentifier{
+				OrganizationalUnitIdentifier: "client",
+				Certificate:                  ouCert,
+			},
+			PeerOuIdentifier: &msp.FabricOUIdentifier{
+				OrganizationalUnitIdentifier: "peer",
+				Certificate:                  ouCert,
+			},
+			AdminOuIdentifier: &msp.FabricOUIdentifier{
+				OrganizationalUnitIdentifier: "admin",
+				Certificate:                  ouCert,
+			},
+			OrdererOuIdentifier: &msp.FabricOUIdentifier{
+				OrganizationalUnitIdentifier: "orderer",
+				Certificate:                  ouCert,
+			},
+		},
+	}
+
+	fmspbytes, err := proto.Marshal(fmspconf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &msp.MSPConfig{Config: fmspbytes, Type: int32(fmsp.FABRIC)}, nil
+}
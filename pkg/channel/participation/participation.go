@@ -0,0 +1,265 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package participation
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ChannelsPath is the path of the channel participation API on the orderer's admin endpoint
+	ChannelsPath = "/participation/v1/channels"
+
+	// ConfigBlockField is the name of the multipart form field carrying the config block on join
+	ConfigBlockField = "config-block"
+)
+
+var (
+	// ErrChannelExists is returned on join if the orderer is already a member of the channel
+	ErrChannelExists = errors.New("channel already exists")
+
+	// ErrChannelNotFound is returned if the orderer is not a member of the channel
+	ErrChannelNotFound = errors.New("channel not found")
+)
+
+// ChannelInfo is the information about a channel as reported by the
+// channel participation API of an orderer
+type ChannelInfo struct {
+	Name              string `json:"name"`
+	URL               string `json:"url"`
+	ConsensusRelation string `json:"consensusRelation"`
+	Status            string `json:"status"`
+	Height            uint64 `json:"height"`
+}
+
+// ChannelList is the list of channels the orderer is a member of, as reported by the
+// channel participation API
+type ChannelList struct {
+	SystemChannel *ChannelInfo   `json:"systemChannel"`
+	Channels      []*ChannelInfo `json:"channels"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Credentials is the TLS crypto used to authenticate to the admin endpoint
+// of an orderer, which requires mutual TLS
+type Credentials struct {
+	Cert    []byte
+	Key     []byte
+	RootCAs [][]byte
+}
+
+// Client performs osnadmin-style calls against the channel participation API
+type Client struct {
+	Timeout time.Duration
+}
+
+func New(timeout time.Duration) *Client {
+	return &Client{
+		Timeout: timeout,
+	}
+}
+
+// Join joins the orderer serving the admin endpoint to the channel defined by the
+// config block
+func (c *Client) Join(adminURL string, creds *Credentials, block []byte) (*ChannelInfo, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(ConfigBlockField, "config.block")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create multipart form")
+	}
+	_, err = part.Write(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to write config block to multipart form")
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to close multipart form")
+	}
+
+	resp, err := c.do(http.MethodPost, channelsURL(adminURL), writer.FormDataContentType(), body, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return decodeChannelInfo(resp.Body)
+	case http.StatusMethodNotAllowed:
+		return nil, ErrChannelExists
+	default:
+		return nil, errors.Errorf("failed to join channel: %s", decodeError(resp.StatusCode, resp.Body))
+	}
+}
+
+// GetChannel returns the information about the channel from the orderer serving the
+// admin endpoint
+func (c *Client) GetChannel(adminURL string, creds *Credentials, channelID string) (*ChannelInfo, error) {
+	resp, err := c.do(http.MethodGet, channelsURL(adminURL)+"/"+channelID, "", nil, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return decodeChannelInfo(resp.Body)
+	case http.StatusNotFound:
+		return nil, ErrChannelNotFound
+	default:
+		return nil, errors.Errorf("failed to get channel '%s': %s", channelID, decodeError(resp.StatusCode, resp.Body))
+	}
+}
+
+// ListChannels returns the names of the channels, including the system channel, that the
+// orderer serving the admin endpoint is a member of
+func (c *Client) ListChannels(adminURL string, creds *Credentials) ([]string, error) {
+	resp, err := c.do(http.MethodGet, channelsURL(adminURL), "", nil, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to list channels: %s", decodeError(resp.StatusCode, resp.Body))
+	}
+
+	list := &ChannelList{}
+	err = json.Unmarshal(resp.Body, list)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal channel list")
+	}
+
+	channels := []string{}
+	if list.SystemChannel != nil {
+		channels = append(channels, list.SystemChannel.Name)
+	}
+	for _, channel := range list.Channels {
+		channels = append(channels, channel.Name)
+	}
+
+	return channels, nil
+}
+
+type response struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (c *Client) do(method, url, contentType string, body *bytes.Buffer, creds *Credentials) (*response, error) {
+	httpClient, err := c.httpClient(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	var req *http.Request
+	if body != nil {
+		req, err = http.NewRequestWithContext(ctx, method, url, body)
+	} else {
+		req, err = http.NewRequestWithContext(ctx, method, url, nil)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid http request")
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "request to '%s' failed", url)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+
+	return &response{
+		StatusCode: resp.StatusCode,
+		Body:       respBody,
+	}, nil
+}
+
+func (c *Client) httpClient(creds *Credentials) (*http.Client, error) {
+	if creds == nil {
+		return nil, errors.New("credentials are required to call the channel participation API")
+	}
+
+	cert, err := tls.X509KeyPair(creds.Cert, creds.Key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load client TLS key pair")
+	}
+
+	rootCertPool := x509.NewCertPool()
+	for _, ca := range creds.RootCAs {
+		rootCertPool.AppendCertsFromPEM(ca)
+	}
+
+	return &http.Client{
+		Timeout: c.Timeout,
+		Transport: &http.Transport{
+			TLSHandshakeTimeout: c.Timeout / 2,
+			TLSClientConfig: &tls.Config{
+				RootCAs:      rootCertPool,
+				Certificates: []tls.Certificate{cert},
+				MinVersion:   tls.VersionTLS12, // TLS 1.2 recommended, TLS 1.3 (current latest version) encouraged
+			},
+		},
+	}, nil
+}
+
+func channelsURL(adminURL string) string {
+	return strings.TrimSuffix(adminURL, "/") + ChannelsPath
+}
+
+func decodeChannelInfo(body []byte) (*ChannelInfo, error) {
+	info := &ChannelInfo{}
+	err := json.Unmarshal(body, info)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal channel info")
+	}
+	return info, nil
+}
+
+func decodeError(statusCode int, body []byte) string {
+	resp := &errorResponse{}
+	err := json.Unmarshal(body, resp)
+	if err != nil || resp.Error == "" {
+		return fmt.Sprintf("status code %d", statusCode)
+	}
+	return fmt.Sprintf("status code %d: %s", statusCode, resp.Error)
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package participation_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel/participation"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("participation client", func() {
+	var (
+		server  *httptest.Server
+		client  *participation.Client
+		creds   *participation.Credentials
+		handler http.HandlerFunc
+	)
+
+	BeforeEach(func() {
+		certPEM, keyPEM := generateCert()
+		creds = &participation.Credentials{
+			Cert:    certPEM,
+			Key:     keyPEM,
+			RootCAs: [][]byte{certPEM},
+		}
+
+		serverCert, err := tls.X509KeyPair(certPEM, keyPEM)
+		Expect(err).NotTo(HaveOccurred())
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(certPEM)
+
+		server = httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			handler(rw, req)
+		}))
+		server.TLS = &tls.Config{
+			Certificates: []tls.Certificate{serverCert},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    pool,
+			MinVersion:   tls.VersionTLS12,
+		}
+		server.StartTLS()
+
+		client = participation.New(5 * time.Second)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("join", func() {
+		It("posts the config block and returns the channel info", func() {
+			handler = func(rw http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+				Expect(req.Method).To(Equal(http.MethodPost))
+				Expect(req.URL.Path).To(Equal(participation.ChannelsPath))
+
+				file, _, err := req.FormFile(participation.ConfigBlockField)
+				Expect(err).NotTo(HaveOccurred())
+				block, err := ioutil.ReadAll(file)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(block)).To(Equal("configblock"))
+
+				rw.WriteHeader(http.StatusCreated)
+				rw.Write([]byte(`{"name":"channel1","consensusRelation":"consenter","status":"onboarding","height":1}`))
+			}
+
+			info, err := client.Join(server.URL, creds, []byte("configblock"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Name).To(Equal("channel1"))
+			Expect(info.ConsensusRelation).To(Equal("consenter"))
+			Expect(info.Height).To(Equal(uint64(1)))
+		})
+
+		It("returns channel exists error if orderer is already a member of the channel", func() {
+			handler = func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusMethodNotAllowed)
+				rw.Write([]byte(`{"error":"cannot join: channel already exists"}`))
+			}
+
+			_, err := client.Join(server.URL, creds, []byte("configblock"))
+			Expect(err).To(Equal(participation.ErrChannelExists))
+		})
+
+		It("returns the error reported by the orderer", func() {
+			handler = func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusBadRequest)
+				rw.Write([]byte(`{"error":"invalid join block"}`))
+			}
+
+			_, err := client.Join(server.URL, creds, []byte("configblock"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("failed to join channel: status code 400: invalid join block"))
+		})
+
+		It("returns an error if credentials are not provided", func() {
+			_, err := client.Join(server.URL, nil, []byte("configblock"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("credentials are required"))
+		})
+	})
+
+	Context("get channel", func() {
+		It("returns the channel info", func() {
+			handler = func(rw http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+				Expect(req.Method).To(Equal(http.MethodGet))
+				Expect(req.URL.Path).To(Equal(participation.ChannelsPath + "/channel1"))
+
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"name":"channel1","consensusRelation":"consenter","status":"active","height":5}`))
+			}
+
+			info, err := client.GetChannel(server.URL, creds, "channel1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Status).To(Equal("active"))
+			Expect(info.Height).To(Equal(uint64(5)))
+		})
+
+		It("returns channel not found error if orderer is not a member of the channel", func() {
+			handler = func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusNotFound)
+			}
+
+			_, err := client.GetChannel(server.URL, creds, "channel1")
+			Expect(err).To(Equal(participation.ErrChannelNotFound))
+		})
+	})
+
+	Context("list channels", func() {
+		It("returns the names of the channels including the system channel", func() {
+			handler = func(rw http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+				Expect(req.Method).To(Equal(http.MethodGet))
+				Expect(req.URL.Path).To(Equal(participation.ChannelsPath))
+
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"systemChannel":{"name":"testchainid"},"channels":[{"name":"channel1"},{"name":"channel2"}]}`))
+			}
+
+			channels, err := client.ListChannels(server.URL, creds)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(channels).To(Equal([]string{"testchainid", "channel1", "channel2"}))
+		})
+
+		It("returns no channels if the orderer is not a member of any channel", func() {
+			handler = func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"systemChannel":null,"channels":null}`))
+			}
+
+			channels, err := client.ListChannels(server.URL, creds)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(channels).To(BeEmpty())
+		})
+	})
+})
+
+func generateCert() ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:              []string{"localhost"},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
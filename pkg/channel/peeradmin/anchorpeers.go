@@ -0,0 +1,196 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package peeradmin
+
+import (
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+const (
+	applicationGroupKey = "Application"
+	anchorPeersKey      = "AnchorPeers"
+	mspKey              = "MSP"
+	adminsPolicyKey     = "Admins"
+)
+
+// GetApplicationOrgName returns the name of the application organization group of the
+// channel configuration that is defined by the MSP ID
+func GetApplicationOrgName(config *cb.Config, mspID string) (string, error) {
+	application, err := getApplicationGroup(config)
+	if err != nil {
+		return "", err
+	}
+
+	name, err := getOrgName(application, mspID)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", errors.Errorf("no application organization with msp id '%s' found in channel", mspID)
+	}
+
+	return name, nil
+}
+
+// getOrgName returns the name of the organization group of the application or orderer group
+// that is defined by the MSP ID, or an empty name if there is none
+func getOrgName(group *cb.ConfigGroup, mspID string) (string, error) {
+	for name, org := range group.Groups {
+		value, found := org.Values[mspKey]
+		if !found {
+			continue
+		}
+
+		mspConfig := &msp.MSPConfig{}
+		err := proto.Unmarshal(value.Value, mspConfig)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to unmarshal msp of organization '%s'", name)
+		}
+
+		fabricMSPConfig := &msp.FabricMSPConfig{}
+		err = proto.Unmarshal(mspConfig.Config, fabricMSPConfig)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to unmarshal msp of organization '%s'", name)
+		}
+
+		if fabricMSPConfig.Name == mspID {
+			return name, nil
+		}
+	}
+
+	return "", nil
+}
+
+// AnchorPeersUpdate returns the config update that adds the anchor peer to the application
+// organization defined by the MSP ID, or nil if the anchor peer is already set
+func AnchorPeersUpdate(config *cb.Config, channelID, mspID string, anchorPeer *pb.AnchorPeer) (*cb.ConfigUpdate, error) {
+	orgName, err := GetApplicationOrgName(config, mspID)
+	if err != nil {
+		return nil, err
+	}
+
+	application, _ := getApplicationGroup(config)
+	org := application.Groups[orgName]
+
+	anchorPeers := &pb.AnchorPeers{}
+	current, exists := org.Values[anchorPeersKey]
+	if exists {
+		err = proto.Unmarshal(current.Value, anchorPeers)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal anchor peers")
+		}
+	}
+
+	for _, peer := range anchorPeers.AnchorPeers {
+		if peer.Host == anchorPeer.Host && peer.Port == anchorPeer.Port {
+			return nil, nil
+		}
+	}
+	anchorPeers.AnchorPeers = append(anchorPeers.AnchorPeers, anchorPeer)
+
+	anchorPeersBytes, err := proto.Marshal(anchorPeers)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal anchor peers")
+	}
+
+	orgReadSet := &cb.ConfigGroup{
+		Version: org.Version,
+	}
+	var orgWriteSet *cb.ConfigGroup
+	if exists {
+		// Modifying an existing value only bumps the version of the value
+		orgWriteSet = &cb.ConfigGroup{
+			Version: org.Version,
+			Values: map[string]*cb.ConfigValue{
+				anchorPeersKey: {
+					Version:   current.Version + 1,
+					ModPolicy: current.ModPolicy,
+					Value:     anchorPeersBytes,
+				},
+			},
+		}
+	} else {
+		// Adding a value changes the membership of the group, which bumps the version
+		// of the group and requires every existing member in the write set
+		orgWriteSet = &cb.ConfigGroup{
+			Version:   org.Version + 1,
+			ModPolicy: org.ModPolicy,
+			Groups:    map[string]*cb.ConfigGroup{},
+			Values: map[string]*cb.ConfigValue{
+				anchorPeersKey: {
+					ModPolicy: adminsPolicyKey,
+					Value:     anchorPeersBytes,
+				},
+			},
+			Policies: map[string]*cb.ConfigPolicy{},
+		}
+		for name, group := range org.Groups {
+			orgWriteSet.Groups[name] = &cb.ConfigGroup{Version: group.Version}
+		}
+		for name, value := range org.Values {
+			orgWriteSet.Values[name] = &cb.ConfigValue{Version: value.Version}
+		}
+		for name, policy := range org.Policies {
+			orgWriteSet.Policies[name] = &cb.ConfigPolicy{Version: policy.Version}
+		}
+	}
+
+	return &cb.ConfigUpdate{
+		ChannelId: channelID,
+		ReadSet: &cb.ConfigGroup{
+			Version: config.ChannelGroup.Version,
+			Groups: map[string]*cb.ConfigGroup{
+				applicationGroupKey: {
+					Version: application.Version,
+					Groups: map[string]*cb.ConfigGroup{
+						orgName: orgReadSet,
+					},
+				},
+			},
+		},
+		WriteSet: &cb.ConfigGroup{
+			Version: config.ChannelGroup.Version,
+			Groups: map[string]*cb.ConfigGroup{
+				applicationGroupKey: {
+					Version: application.Version,
+					Groups: map[string]*cb.ConfigGroup{
+						orgName: orgWriteSet,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func getApplicationGroup(config *cb.Config) (*cb.ConfigGroup, error) {
+	if config == nil || config.ChannelGroup == nil {
+		return nil, errors.New("channel config is empty")
+	}
+
+	application, found := config.ChannelGroup.Groups[applicationGroupKey]
+	if !found {
+		return nil, errors.New("channel config does not contain an application group")
+	}
+
+	return application, nil
+}
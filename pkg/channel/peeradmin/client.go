@@ -0,0 +1,382 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package peeradmin
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	// CSCC is the name of the configuration system chaincode of the peer
+	CSCC = "cscc"
+
+	joinChain   = "JoinChain"
+	getChannels = "GetChannels"
+)
+
+// Endpoint is a gRPC endpoint of a peer or an orderer
+type Endpoint struct {
+	// Address is the host:port of the endpoint, a grpcs:// prefix is ignored
+	Address string
+
+	// TLSCACerts are the PEM encoded certificates used to verify the endpoint
+	TLSCACerts [][]byte
+
+	// ClientCert and ClientKey (Optional) are presented if the endpoint
+	// requires mutual TLS
+	ClientCert []byte
+	ClientKey  []byte
+}
+
+// Client performs the peer CLI calls needed to administer peers and their channels
+type Client struct {
+	Timeout time.Duration
+}
+
+func New(timeout time.Duration) *Client {
+	return &Client{
+		Timeout: timeout,
+	}
+}
+
+// FetchGenesisBlock returns the genesis block of the channel from the orderer
+func (c *Client) FetchGenesisBlock(orderer *Endpoint, signer *Signer, channelID string) (*cb.Block, error) {
+	return c.fetchBlock(orderer, signer, channelID, &ab.SeekPosition{
+		Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}},
+	})
+}
+
+// FetchConfigBlock returns the last config block of the channel from the orderer
+func (c *Client) FetchConfigBlock(orderer *Endpoint, signer *Signer, channelID string) (*cb.Block, error) {
+	newest, err := c.fetchBlock(orderer, signer, channelID, &ab.SeekPosition{
+		Type: &ab.SeekPosition_Newest{Newest: &ab.SeekNewest{}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := protoutil.GetLastConfigIndexFromBlock(newest)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get last config index")
+	}
+
+	return c.fetchBlock(orderer, signer, channelID, &ab.SeekPosition{
+		Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: index}},
+	})
+}
+
+// FetchConfig returns the current configuration of the channel from the orderer
+func (c *Client) FetchConfig(orderer *Endpoint, signer *Signer, channelID string) (*cb.Config, error) {
+	block, err := c.FetchConfigBlock(orderer, signer, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := protoutil.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to extract envelope from config block")
+	}
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal config block payload")
+	}
+	configEnv := &cb.ConfigEnvelope{}
+	err = proto.Unmarshal(payload.Data, configEnv)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal config envelope")
+	}
+
+	return configEnv.Config, nil
+}
+
+// JoinChannel joins the peer to the channel defined by the genesis block, the signer
+// must be an admin of the peer
+func (c *Client) JoinChannel(peer *Endpoint, signer *Signer, block *cb.Block) error {
+	blockBytes, err := proto.Marshal(block)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal genesis block")
+	}
+
+	_, err = c.invokeCSCC(peer, signer, cb.HeaderType_CONFIG, [][]byte{[]byte(joinChain), blockBytes})
+	if err != nil {
+		return errors.Wrap(err, "failed to join channel")
+	}
+
+	return nil
+}
+
+// GetChannels returns the names of the channels the peer has joined
+func (c *Client) GetChannels(peer *Endpoint, signer *Signer) ([]string, error) {
+	payload, err := c.invokeCSCC(peer, signer, cb.HeaderType_ENDORSER_TRANSACTION, [][]byte{[]byte(getChannels)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get channels")
+	}
+
+	resp := &pb.ChannelQueryResponse{}
+	err = proto.Unmarshal(payload, resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal channel query response")
+	}
+
+	channels := []string{}
+	for _, channel := range resp.Channels {
+		channels = append(channels, channel.ChannelId)
+	}
+
+	return channels, nil
+}
+
+// UpdateConfig signs the config update with the signer and submits it to the orderer
+func (c *Client) UpdateConfig(orderer *Endpoint, signer *Signer, update *cb.ConfigUpdate) error {
+	updateBytes, err := proto.Marshal(update)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal config update")
+	}
+
+	sigHeader, err := protoutil.NewSignatureHeader(signer)
+	if err != nil {
+		return errors.Wrap(err, "failed to create signature header")
+	}
+	sigHeaderBytes, err := proto.Marshal(sigHeader)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal signature header")
+	}
+
+	signature, err := signer.Sign(append(append([]byte{}, sigHeaderBytes...), updateBytes...))
+	if err != nil {
+		return err
+	}
+
+	updateEnv := &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: updateBytes,
+		Signatures: []*cb.ConfigSignature{
+			{
+				SignatureHeader: sigHeaderBytes,
+				Signature:       signature,
+			},
+		},
+	}
+
+	env, err := protoutil.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, update.ChannelId, signer, updateEnv, 0, 0)
+	if err != nil {
+		return errors.Wrap(err, "failed to create config update envelope")
+	}
+
+	return c.broadcast(orderer, env)
+}
+
+func (c *Client) fetchBlock(orderer *Endpoint, signer *Signer, channelID string, position *ab.SeekPosition) (*cb.Block, error) {
+	seekInfo := &ab.SeekInfo{
+		Start:    position,
+		Stop:     position,
+		Behavior: ab.SeekInfo_FAIL_IF_NOT_READY,
+	}
+
+	env, err := protoutil.CreateSignedEnvelope(cb.HeaderType_DELIVER_SEEK_INFO, channelID, signer, seekInfo, 0, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create seek envelope")
+	}
+
+	conn, err := c.dial(orderer)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	stream, err := ab.NewAtomicBroadcastClient(conn).Deliver(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open deliver stream")
+	}
+	defer stream.CloseSend()
+
+	err = stream.Send(env)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send seek envelope")
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to receive block")
+	}
+
+	switch t := resp.Type.(type) {
+	case *ab.DeliverResponse_Block:
+		return t.Block, nil
+	case *ab.DeliverResponse_Status:
+		return nil, errors.Errorf("failed to fetch block of channel '%s': status %s", channelID, t.Status)
+	default:
+		return nil, errors.Errorf("unexpected deliver response type %T", t)
+	}
+}
+
+func (c *Client) broadcast(orderer *Endpoint, env *cb.Envelope) error {
+	conn, err := c.dial(orderer)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	stream, err := ab.NewAtomicBroadcastClient(conn).Broadcast(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to open broadcast stream")
+	}
+	defer stream.CloseSend()
+
+	err = stream.Send(env)
+	if err != nil {
+		return errors.Wrap(err, "failed to send envelope")
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		return errors.Wrap(err, "failed to receive broadcast response")
+	}
+
+	if resp.Status != cb.Status_SUCCESS {
+		return errors.Errorf("orderer rejected envelope: status %s: %s", resp.Status, resp.Info)
+	}
+
+	return nil
+}
+
+func (c *Client) invokeCSCC(peer *Endpoint, signer *Signer, headerType cb.HeaderType, args [][]byte) ([]byte, error) {
+	_, signedProp, err := c.newProposal(signer, headerType, "", CSCC, args)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.processProposal(peer, signedProp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Response.Payload, nil
+}
+
+func (c *Client) newProposal(signer *Signer, headerType cb.HeaderType, channelID, chaincode string, args [][]byte) (*pb.Proposal, *pb.SignedProposal, error) {
+	creator, err := signer.Serialize()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to serialize signer")
+	}
+
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_GOLANG,
+			ChaincodeId: &pb.ChaincodeID{Name: chaincode},
+			Input:       &pb.ChaincodeInput{Args: args},
+		},
+	}
+
+	prop, _, err := protoutil.CreateProposalFromCIS(headerType, channelID, cis, creator)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create proposal")
+	}
+
+	signedProp, err := protoutil.GetSignedProposal(prop, signer)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign proposal")
+	}
+
+	return prop, signedProp, nil
+}
+
+func (c *Client) processProposal(peer *Endpoint, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	conn, err := c.dial(peer)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	resp, err := pb.NewEndorserClient(conn).ProcessProposal(ctx, signedProp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to process proposal")
+	}
+
+	if resp.Response == nil || resp.Response.Status != 200 {
+		return nil, errors.Errorf("proposal failed: %s", responseMessage(resp.Response))
+	}
+
+	return resp, nil
+}
+
+func (c *Client) dial(endpoint *Endpoint) (*grpc.ClientConn, error) {
+	address := strings.TrimPrefix(endpoint.Address, "grpcs://")
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid address '%s'", address)
+	}
+
+	rootCertPool := x509.NewCertPool()
+	for _, ca := range endpoint.TLSCACerts {
+		rootCertPool.AppendCertsFromPEM(ca)
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs:    rootCertPool,
+		ServerName: host,
+		MinVersion: tls.VersionTLS12, // TLS 1.2 recommended, TLS 1.3 (current latest version) encouraged
+	}
+	if len(endpoint.ClientCert) > 0 {
+		cert, err := tls.X509KeyPair(endpoint.ClientCert, endpoint.ClientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load client TLS key pair")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), grpc.WithBlock())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to '%s'", address)
+	}
+
+	return conn, nil
+}
+
+func responseMessage(resp *pb.Response) string {
+	if resp == nil {
+		return "empty response"
+	}
+	return resp.Message
+}
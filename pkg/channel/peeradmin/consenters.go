@@ -0,0 +1,565 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package peeradmin
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/orderer/configtx"
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/pkg/errors"
+)
+
+const (
+	ordererGroupKey     = "Orderer"
+	consensusTypeKey    = "ConsensusType"
+	orderersKey         = "Orderers"
+	endpointsKey        = "Endpoints"
+	ordererAddressesKey = "OrdererAddresses"
+
+	consensusTypeEtcdRaft = "etcdraft"
+	consensusTypeBFT      = "BFT"
+)
+
+// Consenters returns the host:port of the consenters of the channel, and whether the
+// channel is ordered by BFT consenters
+func Consenters(config *cb.Config) ([]string, bool, error) {
+	ordererGroup, err := getOrdererGroup(config)
+	if err != nil {
+		return nil, false, err
+	}
+
+	consensusType, err := getConsensusType(ordererGroup)
+	if err != nil {
+		return nil, false, err
+	}
+
+	consenters := []string{}
+	switch consensusType.Type {
+	case consensusTypeEtcdRaft:
+		metadata := &etcdraft.ConfigMetadata{}
+		err = proto.Unmarshal(consensusType.Metadata, metadata)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "failed to unmarshal etcdraft metadata")
+		}
+		for _, consenter := range metadata.Consenters {
+			consenters = append(consenters, fmt.Sprintf("%s:%d", consenter.Host, consenter.Port))
+		}
+	case consensusTypeBFT:
+		orderers, err := getOrderers(ordererGroup)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, consenter := range orderers.ConsenterMapping {
+			consenters = append(consenters, fmt.Sprintf("%s:%d", consenter.Host, consenter.Port))
+		}
+	default:
+		return nil, false, errors.Errorf("consensus type '%s' is not supported", consensusType.Type)
+	}
+
+	return consenters, consensusType.Type == consensusTypeBFT, nil
+}
+
+// RemoveConsenterUpdate returns the config update that removes the orderer at the host and
+// port from the consenters and the orderer endpoints of the channel, or nil if the orderer
+// is neither a consenter nor an orderer endpoint of the channel
+func RemoveConsenterUpdate(config *cb.Config, channelID, host string, port uint32) (*cb.ConfigUpdate, error) {
+	ordererGroup, err := getOrdererGroup(config)
+	if err != nil {
+		return nil, err
+	}
+
+	consensusType, err := getConsensusType(ordererGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	// Modifying existing values only bumps the version of the values, the groups are
+	// written at their current version
+	ordererReadSet := &cb.ConfigGroup{
+		Version: ordererGroup.Version,
+		Groups:  map[string]*cb.ConfigGroup{},
+	}
+	ordererWriteSet := &cb.ConfigGroup{
+		Version: ordererGroup.Version,
+		Groups:  map[string]*cb.ConfigGroup{},
+		Values:  map[string]*cb.ConfigValue{},
+	}
+	channelWriteValues := map[string]*cb.ConfigValue{}
+
+	switch consensusType.Type {
+	case consensusTypeEtcdRaft:
+		metadata := &etcdraft.ConfigMetadata{}
+		err = proto.Unmarshal(consensusType.Metadata, metadata)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal etcdraft metadata")
+		}
+
+		consenters := []*etcdraft.Consenter{}
+		for _, consenter := range metadata.Consenters {
+			if consenter.Host != host || consenter.Port != port {
+				consenters = append(consenters, consenter)
+			}
+		}
+		if len(consenters) != len(metadata.Consenters) {
+			metadata.Consenters = consenters
+			consensusType.Metadata, err = proto.Marshal(metadata)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to marshal etcdraft metadata")
+			}
+			ordererWriteSet.Values[consensusTypeKey], err = modifiedValue(ordererGroup.Values[consensusTypeKey], consensusType)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case consensusTypeBFT:
+		orderers, err := getOrderers(ordererGroup)
+		if err != nil {
+			return nil, err
+		}
+
+		consenters := []*cb.Consenter{}
+		for _, consenter := range orderers.ConsenterMapping {
+			if consenter.Host != host || consenter.Port != port {
+				consenters = append(consenters, consenter)
+			}
+		}
+		if len(consenters) != len(orderers.ConsenterMapping) {
+			orderers.ConsenterMapping = consenters
+			ordererWriteSet.Values[orderersKey], err = modifiedValue(ordererGroup.Values[orderersKey], orderers)
+			if err != nil {
+				return nil, err
+			}
+			policy, err := modifiedBlockValidationPolicy(ordererGroup, consenters)
+			if err != nil {
+				return nil, err
+			}
+			ordererWriteSet.Policies = map[string]*cb.ConfigPolicy{
+				configtx.BlockValidationPolicyKey: policy,
+			}
+		}
+	default:
+		return nil, errors.Errorf("consensus type '%s' is not supported", consensusType.Type)
+	}
+
+	address := fmt.Sprintf("%s:%d", host, port)
+	for name, org := range ordererGroup.Groups {
+		value, err := removeAddress(org.Values[endpointsKey], address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to remove endpoint from organization '%s'", name)
+		}
+		if value == nil {
+			continue
+		}
+
+		ordererReadSet.Groups[name] = &cb.ConfigGroup{
+			Version: org.Version,
+		}
+		ordererWriteSet.Groups[name] = &cb.ConfigGroup{
+			Version: org.Version,
+			Values: map[string]*cb.ConfigValue{
+				endpointsKey: value,
+			},
+		}
+	}
+
+	// Channels created from a system channel list the orderer addresses globally
+	value, err := removeAddress(config.ChannelGroup.Values[ordererAddressesKey], address)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to remove orderer address from channel")
+	}
+	if value != nil {
+		channelWriteValues[ordererAddressesKey] = value
+	}
+
+	if len(ordererWriteSet.Values) == 0 && len(ordererWriteSet.Groups) == 0 && len(channelWriteValues) == 0 {
+		return nil, nil
+	}
+
+	return &cb.ConfigUpdate{
+		ChannelId: channelID,
+		ReadSet: &cb.ConfigGroup{
+			Version: config.ChannelGroup.Version,
+			Groups: map[string]*cb.ConfigGroup{
+				ordererGroupKey: ordererReadSet,
+			},
+		},
+		WriteSet: &cb.ConfigGroup{
+			Version: config.ChannelGroup.Version,
+			Groups: map[string]*cb.ConfigGroup{
+				ordererGroupKey: ordererWriteSet,
+			},
+			Values: channelWriteValues,
+		},
+	}, nil
+}
+
+// AddConsenterUpdate returns the config update that adds the orderer to the consenters of the
+// channel and to the orderer endpoints of the orderer organization defined by the MSP ID of the
+// consenter, or nil if the orderer is already both. The TLS certificates of the consenter are
+// used for raft channels, BFT channels additionally require its id and identity.
+func AddConsenterUpdate(config *cb.Config, channelID string, consenter *cb.Consenter) (*cb.ConfigUpdate, error) {
+	ordererGroup, err := getOrdererGroup(config)
+	if err != nil {
+		return nil, err
+	}
+
+	consensusType, err := getConsensusType(ordererGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	ordererReadSet := &cb.ConfigGroup{
+		Version: ordererGroup.Version,
+		Groups:  map[string]*cb.ConfigGroup{},
+	}
+	ordererWriteSet := &cb.ConfigGroup{
+		Version: ordererGroup.Version,
+		Groups:  map[string]*cb.ConfigGroup{},
+		Values:  map[string]*cb.ConfigValue{},
+	}
+
+	switch consensusType.Type {
+	case consensusTypeEtcdRaft:
+		metadata := &etcdraft.ConfigMetadata{}
+		err = proto.Unmarshal(consensusType.Metadata, metadata)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal etcdraft metadata")
+		}
+
+		found := false
+		for _, c := range metadata.Consenters {
+			if c.Host == consenter.Host && c.Port == consenter.Port {
+				found = true
+				break
+			}
+		}
+		if !found {
+			metadata.Consenters = append(metadata.Consenters, &etcdraft.Consenter{
+				Host:          consenter.Host,
+				Port:          consenter.Port,
+				ClientTlsCert: consenter.ClientTlsCert,
+				ServerTlsCert: consenter.ServerTlsCert,
+			})
+			consensusType.Metadata, err = proto.Marshal(metadata)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to marshal etcdraft metadata")
+			}
+			ordererWriteSet.Values[consensusTypeKey], err = modifiedValue(ordererGroup.Values[consensusTypeKey], consensusType)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case consensusTypeBFT:
+		orderers, err := getOrderers(ordererGroup)
+		if err != nil {
+			return nil, err
+		}
+
+		found := false
+		for _, c := range orderers.ConsenterMapping {
+			if c.Host == consenter.Host && c.Port == consenter.Port {
+				found = true
+				break
+			}
+			if c.Id == consenter.Id {
+				return nil, errors.Errorf("consenter id %d is already used by '%s:%d'", c.Id, c.Host, c.Port)
+			}
+		}
+		if !found {
+			orderers.ConsenterMapping = append(orderers.ConsenterMapping, consenter)
+			ordererWriteSet.Values[orderersKey], err = modifiedValue(ordererGroup.Values[orderersKey], orderers)
+			if err != nil {
+				return nil, err
+			}
+			policy, err := modifiedBlockValidationPolicy(ordererGroup, orderers.ConsenterMapping)
+			if err != nil {
+				return nil, err
+			}
+			ordererWriteSet.Policies = map[string]*cb.ConfigPolicy{
+				configtx.BlockValidationPolicyKey: policy,
+			}
+		}
+	default:
+		return nil, errors.Errorf("consensus type '%s' is not supported", consensusType.Type)
+	}
+
+	orgName, err := getOrgName(ordererGroup, consenter.MspId)
+	if err != nil {
+		return nil, err
+	}
+	if orgName == "" {
+		return nil, errors.Errorf("no orderer organization with msp id '%s' found in channel", consenter.MspId)
+	}
+
+	org := ordererGroup.Groups[orgName]
+	value, err := addAddress(org.Values[endpointsKey], fmt.Sprintf("%s:%d", consenter.Host, consenter.Port))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to add endpoint to organization '%s'", orgName)
+	}
+	if value != nil {
+		ordererReadSet.Groups[orgName] = &cb.ConfigGroup{
+			Version: org.Version,
+		}
+		ordererWriteSet.Groups[orgName] = &cb.ConfigGroup{
+			Version: org.Version,
+			Values: map[string]*cb.ConfigValue{
+				endpointsKey: value,
+			},
+		}
+	}
+
+	if len(ordererWriteSet.Values) == 0 && len(ordererWriteSet.Groups) == 0 {
+		return nil, nil
+	}
+
+	return &cb.ConfigUpdate{
+		ChannelId: channelID,
+		ReadSet: &cb.ConfigGroup{
+			Version: config.ChannelGroup.Version,
+			Groups: map[string]*cb.ConfigGroup{
+				ordererGroupKey: ordererReadSet,
+			},
+		},
+		WriteSet: &cb.ConfigGroup{
+			Version: config.ChannelGroup.Version,
+			Groups: map[string]*cb.ConfigGroup{
+				ordererGroupKey: ordererWriteSet,
+			},
+		},
+	}, nil
+}
+
+// UpdateConsenterCertUpdate returns the config update that replaces the TLS certificate of the
+// consenter at the host and port with the certificate, or nil if the orderer is not a consenter
+// of the channel or already uses the certificate
+func UpdateConsenterCertUpdate(config *cb.Config, channelID, host string, port uint32, tlsCert []byte) (*cb.ConfigUpdate, error) {
+	ordererGroup, err := getOrdererGroup(config)
+	if err != nil {
+		return nil, err
+	}
+
+	consensusType, err := getConsensusType(ordererGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	ordererWriteSet := &cb.ConfigGroup{
+		Version: ordererGroup.Version,
+		Values:  map[string]*cb.ConfigValue{},
+	}
+
+	switch consensusType.Type {
+	case consensusTypeEtcdRaft:
+		metadata := &etcdraft.ConfigMetadata{}
+		err = proto.Unmarshal(consensusType.Metadata, metadata)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal etcdraft metadata")
+		}
+
+		updated := false
+		for _, consenter := range metadata.Consenters {
+			if consenter.Host == host && consenter.Port == port && (!bytes.Equal(consenter.ClientTlsCert, tlsCert) || !bytes.Equal(consenter.ServerTlsCert, tlsCert)) {
+				consenter.ClientTlsCert = tlsCert
+				consenter.ServerTlsCert = tlsCert
+				updated = true
+			}
+		}
+		if updated {
+			consensusType.Metadata, err = proto.Marshal(metadata)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to marshal etcdraft metadata")
+			}
+			ordererWriteSet.Values[consensusTypeKey], err = modifiedValue(ordererGroup.Values[consensusTypeKey], consensusType)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case consensusTypeBFT:
+		orderers, err := getOrderers(ordererGroup)
+		if err != nil {
+			return nil, err
+		}
+
+		updated := false
+		for _, consenter := range orderers.ConsenterMapping {
+			if consenter.Host == host && consenter.Port == port && (!bytes.Equal(consenter.ClientTlsCert, tlsCert) || !bytes.Equal(consenter.ServerTlsCert, tlsCert)) {
+				consenter.ClientTlsCert = tlsCert
+				consenter.ServerTlsCert = tlsCert
+				updated = true
+			}
+		}
+		if updated {
+			ordererWriteSet.Values[orderersKey], err = modifiedValue(ordererGroup.Values[orderersKey], orderers)
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, errors.Errorf("consensus type '%s' is not supported", consensusType.Type)
+	}
+
+	if len(ordererWriteSet.Values) == 0 {
+		return nil, nil
+	}
+
+	return &cb.ConfigUpdate{
+		ChannelId: channelID,
+		ReadSet: &cb.ConfigGroup{
+			Version: config.ChannelGroup.Version,
+			Groups: map[string]*cb.ConfigGroup{
+				ordererGroupKey: {
+					Version: ordererGroup.Version,
+				},
+			},
+		},
+		WriteSet: &cb.ConfigGroup{
+			Version: config.ChannelGroup.Version,
+			Groups: map[string]*cb.ConfigGroup{
+				ordererGroupKey: ordererWriteSet,
+			},
+		},
+	}, nil
+}
+
+// addAddress returns the modified orderer addresses value with the address, or nil if the
+// value already contains the address
+func addAddress(current *cb.ConfigValue, address string) (*cb.ConfigValue, error) {
+	if current == nil {
+		// Organizations without endpoints rely on the global orderer addresses
+		return nil, nil
+	}
+
+	addresses := &cb.OrdererAddresses{}
+	err := proto.Unmarshal(current.Value, addresses)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal orderer addresses")
+	}
+
+	for _, a := range addresses.Addresses {
+		if a == address {
+			return nil, nil
+		}
+	}
+
+	addresses.Addresses = append(addresses.Addresses, address)
+	return modifiedValue(current, addresses)
+}
+
+// removeAddress returns the modified orderer addresses value without the address, or nil
+// if the value does not contain the address
+func removeAddress(current *cb.ConfigValue, address string) (*cb.ConfigValue, error) {
+	if current == nil {
+		return nil, nil
+	}
+
+	addresses := &cb.OrdererAddresses{}
+	err := proto.Unmarshal(current.Value, addresses)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal orderer addresses")
+	}
+
+	remaining := []string{}
+	for _, a := range addresses.Addresses {
+		if a != address {
+			remaining = append(remaining, a)
+		}
+	}
+	if len(remaining) == len(addresses.Addresses) {
+		return nil, nil
+	}
+
+	addresses.Addresses = remaining
+	return modifiedValue(current, addresses)
+}
+
+func modifiedValue(current *cb.ConfigValue, msg proto.Message) (*cb.ConfigValue, error) {
+	value, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal config value")
+	}
+
+	return &cb.ConfigValue{
+		Version:   current.Version + 1,
+		ModPolicy: current.ModPolicy,
+		Value:     value,
+	}, nil
+}
+
+// modifiedBlockValidationPolicy returns the block validation policy of a BFT channel ordered by
+// the consenters, as the quorum of consenters that must sign blocks changes with the consenters
+func modifiedBlockValidationPolicy(ordererGroup *cb.ConfigGroup, consenters []*cb.Consenter) (*cb.ConfigPolicy, error) {
+	current, found := ordererGroup.Policies[configtx.BlockValidationPolicyKey]
+	if !found {
+		return nil, errors.New("channel config does not contain a block validation policy")
+	}
+
+	return &cb.ConfigPolicy{
+		Version:   current.Version + 1,
+		ModPolicy: current.ModPolicy,
+		Policy:    configtx.BFTBlockValidationPolicy(consenters),
+	}, nil
+}
+
+func getOrdererGroup(config *cb.Config) (*cb.ConfigGroup, error) {
+	if config == nil || config.ChannelGroup == nil {
+		return nil, errors.New("channel config is empty")
+	}
+
+	orderer, found := config.ChannelGroup.Groups[ordererGroupKey]
+	if !found {
+		return nil, errors.New("channel config does not contain an orderer group")
+	}
+
+	return orderer, nil
+}
+
+func getConsensusType(ordererGroup *cb.ConfigGroup) (*ab.ConsensusType, error) {
+	value, found := ordererGroup.Values[consensusTypeKey]
+	if !found {
+		return nil, errors.New("channel config does not contain a consensus type")
+	}
+
+	consensusType := &ab.ConsensusType{}
+	err := proto.Unmarshal(value.Value, consensusType)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal consensus type")
+	}
+
+	return consensusType, nil
+}
+
+func getOrderers(ordererGroup *cb.ConfigGroup) (*cb.Orderers, error) {
+	value, found := ordererGroup.Values[orderersKey]
+	if !found {
+		return nil, errors.New("channel config does not contain BFT consenters")
+	}
+
+	orderers := &cb.Orderers{}
+	err := proto.Unmarshal(value.Value, orderers)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal BFT consenters")
+	}
+
+	return orderers, nil
+}
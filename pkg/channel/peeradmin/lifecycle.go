@@ -0,0 +1,217 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package peeradmin
+
+import (
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Lifecycle is the name of the chaincode lifecycle system chaincode of the peer
+	Lifecycle = "_lifecycle"
+
+	installChaincode           = "InstallChaincode"
+	queryInstalledChaincodes   = "QueryInstalledChaincodes"
+	approveChaincodeDefinition = "ApproveChaincodeDefinitionForMyOrg"
+	checkCommitReadiness       = "CheckCommitReadiness"
+	commitChaincodeDefinition  = "CommitChaincodeDefinition"
+	queryChaincodeDefinitions  = "QueryChaincodeDefinitions"
+)
+
+// ChaincodeDefinition is the definition of a chaincode that organizations approve
+// and commit on a channel
+type ChaincodeDefinition struct {
+	Name                string
+	Version             string
+	Sequence            int64
+	PackageID           string
+	ValidationParameter []byte
+	InitRequired        bool
+}
+
+// InstallChaincode installs the chaincode package on the peer and returns the package ID
+func (c *Client) InstallChaincode(peer *Endpoint, signer *Signer, pkg []byte) (string, error) {
+	result := &lb.InstallChaincodeResult{}
+	err := c.invokeLifecycle(peer, signer, installChaincode, &lb.InstallChaincodeArgs{ChaincodeInstallPackage: pkg}, result)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to install chaincode")
+	}
+
+	return result.PackageId, nil
+}
+
+// QueryInstalledChaincodes returns the package IDs of the chaincodes installed on the peer
+func (c *Client) QueryInstalledChaincodes(peer *Endpoint, signer *Signer) ([]string, error) {
+	result := &lb.QueryInstalledChaincodesResult{}
+	err := c.invokeLifecycle(peer, signer, queryInstalledChaincodes, &lb.QueryInstalledChaincodesArgs{}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query installed chaincodes")
+	}
+
+	packageIDs := []string{}
+	for _, installed := range result.InstalledChaincodes {
+		packageIDs = append(packageIDs, installed.PackageId)
+	}
+
+	return packageIDs, nil
+}
+
+// CheckCommitReadiness returns the approval of every organization of the channel for the
+// chaincode definition, keyed by MSP ID
+func (c *Client) CheckCommitReadiness(peer *Endpoint, signer *Signer, channelID string, def *ChaincodeDefinition) (map[string]bool, error) {
+	args := &lb.CheckCommitReadinessArgs{
+		Name:                def.Name,
+		Version:             def.Version,
+		Sequence:            def.Sequence,
+		ValidationParameter: def.ValidationParameter,
+		InitRequired:        def.InitRequired,
+	}
+
+	result := &lb.CheckCommitReadinessResult{}
+	err := c.invokeLifecycleOnChannel(peer, signer, channelID, checkCommitReadiness, args, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check commit readiness")
+	}
+
+	return result.Approvals, nil
+}
+
+// QueryCommittedSequence returns the sequence of the chaincode definition committed on
+// the channel, or 0 if the chaincode has not been committed
+func (c *Client) QueryCommittedSequence(peer *Endpoint, signer *Signer, channelID, name string) (int64, error) {
+	result := &lb.QueryChaincodeDefinitionsResult{}
+	err := c.invokeLifecycleOnChannel(peer, signer, channelID, queryChaincodeDefinitions, &lb.QueryChaincodeDefinitionsArgs{}, result)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to query committed chaincodes")
+	}
+
+	for _, def := range result.ChaincodeDefinitions {
+		if def.Name == name {
+			return def.Sequence, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// ApproveChaincodeDefinition approves the chaincode definition for the organization of the
+// signer, the approval is endorsed by the peer and submitted to the orderer
+func (c *Client) ApproveChaincodeDefinition(peer, orderer *Endpoint, signer *Signer, channelID string, def *ChaincodeDefinition) error {
+	args := &lb.ApproveChaincodeDefinitionForMyOrgArgs{
+		Name:                def.Name,
+		Version:             def.Version,
+		Sequence:            def.Sequence,
+		ValidationParameter: def.ValidationParameter,
+		InitRequired:        def.InitRequired,
+		Source: &lb.ChaincodeSource{
+			Type: &lb.ChaincodeSource_LocalPackage{
+				LocalPackage: &lb.ChaincodeSource_Local{
+					PackageId: def.PackageID,
+				},
+			},
+		},
+	}
+
+	err := c.submitLifecycle([]*Endpoint{peer}, orderer, signer, channelID, approveChaincodeDefinition, args)
+	if err != nil {
+		return errors.Wrap(err, "failed to approve chaincode definition")
+	}
+
+	return nil
+}
+
+// CommitChaincodeDefinition commits the chaincode definition on the channel, the commit is
+// endorsed by every peer and submitted to the orderer
+func (c *Client) CommitChaincodeDefinition(peers []*Endpoint, orderer *Endpoint, signer *Signer, channelID string, def *ChaincodeDefinition) error {
+	args := &lb.CommitChaincodeDefinitionArgs{
+		Name:                def.Name,
+		Version:             def.Version,
+		Sequence:            def.Sequence,
+		ValidationParameter: def.ValidationParameter,
+		InitRequired:        def.InitRequired,
+	}
+
+	err := c.submitLifecycle(peers, orderer, signer, channelID, commitChaincodeDefinition, args)
+	if err != nil {
+		return errors.Wrap(err, "failed to commit chaincode definition")
+	}
+
+	return nil
+}
+
+func (c *Client) invokeLifecycle(peer *Endpoint, signer *Signer, function string, args, result proto.Message) error {
+	return c.invokeLifecycleOnChannel(peer, signer, "", function, args, result)
+}
+
+func (c *Client) invokeLifecycleOnChannel(peer *Endpoint, signer *Signer, channelID, function string, args, result proto.Message) error {
+	argsBytes, err := proto.Marshal(args)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal args")
+	}
+
+	_, signedProp, err := c.newProposal(signer, cb.HeaderType_ENDORSER_TRANSACTION, channelID, Lifecycle, [][]byte{[]byte(function), argsBytes})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.processProposal(peer, signedProp)
+	if err != nil {
+		return err
+	}
+
+	err = proto.Unmarshal(resp.Response.Payload, result)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal result")
+	}
+
+	return nil
+}
+
+func (c *Client) submitLifecycle(peers []*Endpoint, orderer *Endpoint, signer *Signer, channelID, function string, args proto.Message) error {
+	argsBytes, err := proto.Marshal(args)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal args")
+	}
+
+	prop, signedProp, err := c.newProposal(signer, cb.HeaderType_ENDORSER_TRANSACTION, channelID, Lifecycle, [][]byte{[]byte(function), argsBytes})
+	if err != nil {
+		return err
+	}
+
+	resps := []*pb.ProposalResponse{}
+	for _, peer := range peers {
+		resp, err := c.processProposal(peer, signedProp)
+		if err != nil {
+			return errors.Wrapf(err, "endorsement failed on peer '%s'", peer.Address)
+		}
+		resps = append(resps, resp)
+	}
+
+	env, err := protoutil.CreateSignedTx(prop, signer, resps...)
+	if err != nil {
+		return errors.Wrap(err, "failed to create transaction")
+	}
+
+	return c.broadcast(orderer, env)
+}
@@ -0,0 +1,497 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package peeradmin_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel/peeradmin"
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("peer admin", func() {
+	Context("signer", func() {
+		var (
+			certPEM []byte
+			keyPEM  []byte
+		)
+
+		BeforeEach(func() {
+			certPEM, keyPEM = generateCert()
+		})
+
+		It("returns an error for an invalid certificate", func() {
+			_, err := peeradmin.NewSigner("org1msp", []byte("invalid"), keyPEM)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("failed to decode certificate PEM"))
+		})
+
+		It("returns an error for an invalid key", func() {
+			_, err := peeradmin.NewSigner("org1msp", certPEM, []byte("invalid"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("failed to decode private key PEM"))
+		})
+
+		It("serializes the identity with the msp id", func() {
+			signer, err := peeradmin.NewSigner("org1msp", certPEM, keyPEM)
+			Expect(err).NotTo(HaveOccurred())
+
+			bytes, err := signer.Serialize()
+			Expect(err).NotTo(HaveOccurred())
+
+			id := &msp.SerializedIdentity{}
+			Expect(proto.Unmarshal(bytes, id)).To(Succeed())
+			Expect(id.Mspid).To(Equal("org1msp"))
+			Expect(id.IdBytes).To(Equal(certPEM))
+		})
+
+		It("signs messages with a verifiable signature", func() {
+			signer, err := peeradmin.NewSigner("org1msp", certPEM, keyPEM)
+			Expect(err).NotTo(HaveOccurred())
+
+			sig, err := signer.Sign([]byte("message"))
+			Expect(err).NotTo(HaveOccurred())
+
+			digest := sha256.Sum256([]byte("message"))
+			Expect(ecdsa.VerifyASN1(&signer.Key.PublicKey, digest[:], sig)).To(BeTrue())
+		})
+	})
+
+	Context("anchor peers update", func() {
+		var (
+			config     *cb.Config
+			anchorPeer *pb.AnchorPeer
+		)
+
+		BeforeEach(func() {
+			fabricMSPConfig, err := proto.Marshal(&msp.FabricMSPConfig{Name: "org1msp"})
+			Expect(err).NotTo(HaveOccurred())
+			mspConfig, err := proto.Marshal(&msp.MSPConfig{Config: fabricMSPConfig})
+			Expect(err).NotTo(HaveOccurred())
+
+			config = &cb.Config{
+				ChannelGroup: &cb.ConfigGroup{
+					Version: 1,
+					Groups: map[string]*cb.ConfigGroup{
+						"Application": {
+							Version: 2,
+							Groups: map[string]*cb.ConfigGroup{
+								"org1": {
+									Version:   3,
+									ModPolicy: "Admins",
+									Values: map[string]*cb.ConfigValue{
+										"MSP": {Version: 4, Value: mspConfig},
+									},
+									Policies: map[string]*cb.ConfigPolicy{
+										"Admins": {Version: 5},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			anchorPeer = &pb.AnchorPeer{Host: "peer1.example.com", Port: 443}
+		})
+
+		It("returns an error if the organization is not a member of the channel", func() {
+			_, err := peeradmin.AnchorPeersUpdate(config, "channel1", "org2msp", anchorPeer)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("no application organization with msp id 'org2msp' found in channel"))
+		})
+
+		It("adds the anchor peers value to the organization", func() {
+			update, err := peeradmin.AnchorPeersUpdate(config, "channel1", "org1msp", anchorPeer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(update.ChannelId).To(Equal("channel1"))
+
+			readOrg := update.ReadSet.Groups["Application"].Groups["org1"]
+			Expect(readOrg.Version).To(Equal(uint64(3)))
+
+			writeOrg := update.WriteSet.Groups["Application"].Groups["org1"]
+			Expect(writeOrg.Version).To(Equal(uint64(4)))
+			Expect(writeOrg.Values["MSP"].Version).To(Equal(uint64(4)))
+			Expect(writeOrg.Policies["Admins"].Version).To(Equal(uint64(5)))
+
+			anchorPeers := &pb.AnchorPeers{}
+			Expect(proto.Unmarshal(writeOrg.Values["AnchorPeers"].Value, anchorPeers)).To(Succeed())
+			Expect(anchorPeers.AnchorPeers).To(HaveLen(1))
+			Expect(anchorPeers.AnchorPeers[0].Host).To(Equal("peer1.example.com"))
+		})
+
+		It("bumps the version of existing anchor peers", func() {
+			existing, err := proto.Marshal(&pb.AnchorPeers{AnchorPeers: []*pb.AnchorPeer{{Host: "peer0.example.com", Port: 443}}})
+			Expect(err).NotTo(HaveOccurred())
+			config.ChannelGroup.Groups["Application"].Groups["org1"].Values["AnchorPeers"] = &cb.ConfigValue{Version: 1, ModPolicy: "Admins", Value: existing}
+
+			update, err := peeradmin.AnchorPeersUpdate(config, "channel1", "org1msp", anchorPeer)
+			Expect(err).NotTo(HaveOccurred())
+
+			writeOrg := update.WriteSet.Groups["Application"].Groups["org1"]
+			Expect(writeOrg.Version).To(Equal(uint64(3)))
+			Expect(writeOrg.Values).To(HaveLen(1))
+			Expect(writeOrg.Values["AnchorPeers"].Version).To(Equal(uint64(2)))
+
+			anchorPeers := &pb.AnchorPeers{}
+			Expect(proto.Unmarshal(writeOrg.Values["AnchorPeers"].Value, anchorPeers)).To(Succeed())
+			Expect(anchorPeers.AnchorPeers).To(HaveLen(2))
+		})
+
+		It("returns no update if the anchor peer is already set", func() {
+			existing, err := proto.Marshal(&pb.AnchorPeers{AnchorPeers: []*pb.AnchorPeer{anchorPeer}})
+			Expect(err).NotTo(HaveOccurred())
+			config.ChannelGroup.Groups["Application"].Groups["org1"].Values["AnchorPeers"] = &cb.ConfigValue{Version: 1, Value: existing}
+
+			update, err := peeradmin.AnchorPeersUpdate(config, "channel1", "org1msp", anchorPeer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(update).To(BeNil())
+		})
+	})
+
+	Context("remove consenter update", func() {
+		var config *cb.Config
+
+		marshal := func(msg proto.Message) []byte {
+			b, err := proto.Marshal(msg)
+			Expect(err).NotTo(HaveOccurred())
+			return b
+		}
+
+		BeforeEach(func() {
+			metadata := marshal(&etcdraft.ConfigMetadata{
+				Consenters: []*etcdraft.Consenter{
+					{Host: "orderer1.example.com", Port: 443},
+					{Host: "orderer2.example.com", Port: 443},
+					{Host: "orderer3.example.com", Port: 443},
+				},
+			})
+
+			config = &cb.Config{
+				ChannelGroup: &cb.ConfigGroup{
+					Version: 1,
+					Groups: map[string]*cb.ConfigGroup{
+						"Orderer": {
+							Version: 2,
+							Groups: map[string]*cb.ConfigGroup{
+								"OrdererOrg": {
+									Version: 3,
+									Values: map[string]*cb.ConfigValue{
+										"Endpoints": {
+											Version:   4,
+											ModPolicy: "Admins",
+											Value: marshal(&cb.OrdererAddresses{
+												Addresses: []string{"orderer1.example.com:443", "orderer2.example.com:443", "orderer3.example.com:443"},
+											}),
+										},
+									},
+								},
+							},
+							Values: map[string]*cb.ConfigValue{
+								"ConsensusType": {
+									Version:   5,
+									ModPolicy: "Admins",
+									Value:     marshal(&ab.ConsensusType{Type: "etcdraft", Metadata: metadata}),
+								},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("returns the consenters of the channel", func() {
+			consenters, bft, err := peeradmin.Consenters(config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bft).To(Equal(false))
+			Expect(consenters).To(Equal([]string{"orderer1.example.com:443", "orderer2.example.com:443", "orderer3.example.com:443"}))
+		})
+
+		It("removes the raft consenter and its endpoint", func() {
+			update, err := peeradmin.RemoveConsenterUpdate(config, "channel1", "orderer3.example.com", 443)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(update.ChannelId).To(Equal("channel1"))
+			Expect(update.ReadSet.Groups["Orderer"].Version).To(Equal(uint64(2)))
+
+			writeOrderer := update.WriteSet.Groups["Orderer"]
+			Expect(writeOrderer.Version).To(Equal(uint64(2)))
+			Expect(writeOrderer.Values["ConsensusType"].Version).To(Equal(uint64(6)))
+			Expect(writeOrderer.Values["ConsensusType"].ModPolicy).To(Equal("Admins"))
+
+			consensusType := &ab.ConsensusType{}
+			Expect(proto.Unmarshal(writeOrderer.Values["ConsensusType"].Value, consensusType)).To(Succeed())
+			metadata := &etcdraft.ConfigMetadata{}
+			Expect(proto.Unmarshal(consensusType.Metadata, metadata)).To(Succeed())
+			Expect(metadata.Consenters).To(HaveLen(2))
+
+			writeOrg := writeOrderer.Groups["OrdererOrg"]
+			Expect(writeOrg.Version).To(Equal(uint64(3)))
+			Expect(writeOrg.Values["Endpoints"].Version).To(Equal(uint64(5)))
+			addresses := &cb.OrdererAddresses{}
+			Expect(proto.Unmarshal(writeOrg.Values["Endpoints"].Value, addresses)).To(Succeed())
+			Expect(addresses.Addresses).To(Equal([]string{"orderer1.example.com:443", "orderer2.example.com:443"}))
+		})
+
+		It("removes the BFT consenter", func() {
+			config.ChannelGroup.Groups["Orderer"].Values["ConsensusType"].Value = marshal(&ab.ConsensusType{Type: "BFT"})
+			config.ChannelGroup.Groups["Orderer"].Policies = map[string]*cb.ConfigPolicy{
+				"BlockValidation": {Version: 3, ModPolicy: "/Channel/Orderer/Admins"},
+			}
+			config.ChannelGroup.Groups["Orderer"].Values["Orderers"] = &cb.ConfigValue{
+				Version:   1,
+				ModPolicy: "/Channel/Orderer/Admins",
+				Value: marshal(&cb.Orderers{
+					ConsenterMapping: []*cb.Consenter{
+						{Id: 1, Host: "orderer1.example.com", Port: 443},
+						{Id: 2, Host: "orderer2.example.com", Port: 443},
+					},
+				}),
+			}
+
+			consenters, bft, err := peeradmin.Consenters(config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bft).To(Equal(true))
+			Expect(consenters).To(HaveLen(2))
+
+			update, err := peeradmin.RemoveConsenterUpdate(config, "channel1", "orderer2.example.com", 443)
+			Expect(err).NotTo(HaveOccurred())
+
+			writeOrderer := update.WriteSet.Groups["Orderer"]
+			Expect(writeOrderer.Values).NotTo(HaveKey("ConsensusType"))
+			Expect(writeOrderer.Values["Orderers"].Version).To(Equal(uint64(2)))
+			orderers := &cb.Orderers{}
+			Expect(proto.Unmarshal(writeOrderer.Values["Orderers"].Value, orderers)).To(Succeed())
+			Expect(orderers.ConsenterMapping).To(HaveLen(1))
+			Expect(orderers.ConsenterMapping[0].Id).To(Equal(uint32(1)))
+
+			policy := writeOrderer.Policies["BlockValidation"]
+			Expect(policy.Version).To(Equal(uint64(4)))
+			Expect(policy.ModPolicy).To(Equal("/Channel/Orderer/Admins"))
+			envelope := &cb.SignaturePolicyEnvelope{}
+			Expect(proto.Unmarshal(policy.Policy.Value, envelope)).To(Succeed())
+			Expect(envelope.Identities).To(HaveLen(1))
+			Expect(envelope.Rule.GetNOutOf().N).To(Equal(int32(1)))
+		})
+
+		It("removes the orderer address of channels created from a system channel", func() {
+			config.ChannelGroup.Values = map[string]*cb.ConfigValue{
+				"OrdererAddresses": {
+					Version: 1,
+					Value:   marshal(&cb.OrdererAddresses{Addresses: []string{"orderer1.example.com:443", "orderer3.example.com:443"}}),
+				},
+			}
+
+			update, err := peeradmin.RemoveConsenterUpdate(config, "channel1", "orderer3.example.com", 443)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(update.WriteSet.Values["OrdererAddresses"].Version).To(Equal(uint64(2)))
+		})
+
+		It("returns no update if the orderer is not a consenter", func() {
+			update, err := peeradmin.RemoveConsenterUpdate(config, "channel1", "orderer4.example.com", 443)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(update).To(BeNil())
+		})
+
+		Context("update consenter cert update", func() {
+			It("replaces the TLS certificate of the raft consenter", func() {
+				update, err := peeradmin.UpdateConsenterCertUpdate(config, "channel1", "orderer2.example.com", 443, []byte("newcert"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(update.ReadSet.Groups["Orderer"].Values).To(BeEmpty())
+
+				writeOrderer := update.WriteSet.Groups["Orderer"]
+				Expect(writeOrderer.Groups).To(BeEmpty())
+				Expect(writeOrderer.Values["ConsensusType"].Version).To(Equal(uint64(6)))
+				consensusType := &ab.ConsensusType{}
+				Expect(proto.Unmarshal(writeOrderer.Values["ConsensusType"].Value, consensusType)).To(Succeed())
+				metadata := &etcdraft.ConfigMetadata{}
+				Expect(proto.Unmarshal(consensusType.Metadata, metadata)).To(Succeed())
+				Expect(metadata.Consenters).To(HaveLen(3))
+				Expect(metadata.Consenters[0].ServerTlsCert).To(BeNil())
+				Expect(metadata.Consenters[1].ClientTlsCert).To(Equal([]byte("newcert")))
+				Expect(metadata.Consenters[1].ServerTlsCert).To(Equal([]byte("newcert")))
+			})
+
+			It("replaces the TLS certificate of the BFT consenter", func() {
+				config.ChannelGroup.Groups["Orderer"].Values["ConsensusType"].Value = marshal(&ab.ConsensusType{Type: "BFT"})
+				config.ChannelGroup.Groups["Orderer"].Values["Orderers"] = &cb.ConfigValue{
+					Value: marshal(&cb.Orderers{
+						ConsenterMapping: []*cb.Consenter{
+							{Id: 1, Host: "orderer1.example.com", Port: 443, Identity: []byte("ecert")},
+						},
+					}),
+				}
+
+				update, err := peeradmin.UpdateConsenterCertUpdate(config, "channel1", "orderer1.example.com", 443, []byte("newcert"))
+				Expect(err).NotTo(HaveOccurred())
+				orderers := &cb.Orderers{}
+				Expect(proto.Unmarshal(update.WriteSet.Groups["Orderer"].Values["Orderers"].Value, orderers)).To(Succeed())
+				Expect(orderers.ConsenterMapping[0].ServerTlsCert).To(Equal([]byte("newcert")))
+				Expect(orderers.ConsenterMapping[0].Identity).To(Equal([]byte("ecert")))
+			})
+
+			It("returns no update if the consenter already uses the certificate", func() {
+				update, err := peeradmin.UpdateConsenterCertUpdate(config, "channel1", "orderer2.example.com", 443, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(update).To(BeNil())
+			})
+
+			It("returns no update if the orderer is not a consenter", func() {
+				update, err := peeradmin.UpdateConsenterCertUpdate(config, "channel1", "orderer4.example.com", 443, []byte("newcert"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(update).To(BeNil())
+			})
+		})
+
+		Context("add consenter update", func() {
+			var consenter *cb.Consenter
+
+			BeforeEach(func() {
+				config.ChannelGroup.Groups["Orderer"].Groups["OrdererOrg"].Values["MSP"] = &cb.ConfigValue{
+					Value: marshal(&msp.MSPConfig{
+						Config: marshal(&msp.FabricMSPConfig{Name: "OrdererMSP"}),
+					}),
+				}
+
+				consenter = &cb.Consenter{
+					Id:            4,
+					Host:          "orderer4.example.com",
+					Port:          443,
+					MspId:         "OrdererMSP",
+					ClientTlsCert: []byte("tlscert"),
+					ServerTlsCert: []byte("tlscert"),
+				}
+			})
+
+			It("adds the raft consenter and its endpoint", func() {
+				update, err := peeradmin.AddConsenterUpdate(config, "channel1", consenter)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(update.ChannelId).To(Equal("channel1"))
+
+				writeOrderer := update.WriteSet.Groups["Orderer"]
+				Expect(writeOrderer.Values["ConsensusType"].Version).To(Equal(uint64(6)))
+				consensusType := &ab.ConsensusType{}
+				Expect(proto.Unmarshal(writeOrderer.Values["ConsensusType"].Value, consensusType)).To(Succeed())
+				metadata := &etcdraft.ConfigMetadata{}
+				Expect(proto.Unmarshal(consensusType.Metadata, metadata)).To(Succeed())
+				Expect(metadata.Consenters).To(HaveLen(4))
+				Expect(metadata.Consenters[3].Host).To(Equal("orderer4.example.com"))
+				Expect(metadata.Consenters[3].ServerTlsCert).To(Equal([]byte("tlscert")))
+
+				writeOrg := writeOrderer.Groups["OrdererOrg"]
+				Expect(writeOrg.Values["Endpoints"].Version).To(Equal(uint64(5)))
+				addresses := &cb.OrdererAddresses{}
+				Expect(proto.Unmarshal(writeOrg.Values["Endpoints"].Value, addresses)).To(Succeed())
+				Expect(addresses.Addresses).To(ContainElement("orderer4.example.com:443"))
+			})
+
+			It("adds the BFT consenter", func() {
+				config.ChannelGroup.Groups["Orderer"].Values["ConsensusType"].Value = marshal(&ab.ConsensusType{Type: "BFT"})
+				config.ChannelGroup.Groups["Orderer"].Policies = map[string]*cb.ConfigPolicy{
+					"BlockValidation": {Version: 1, ModPolicy: "/Channel/Orderer/Admins"},
+				}
+				config.ChannelGroup.Groups["Orderer"].Values["Orderers"] = &cb.ConfigValue{
+					Version: 1,
+					Value: marshal(&cb.Orderers{
+						ConsenterMapping: []*cb.Consenter{
+							{Id: 1, Host: "orderer1.example.com", Port: 443},
+						},
+					}),
+				}
+
+				update, err := peeradmin.AddConsenterUpdate(config, "channel1", consenter)
+				Expect(err).NotTo(HaveOccurred())
+				orderers := &cb.Orderers{}
+				Expect(proto.Unmarshal(update.WriteSet.Groups["Orderer"].Values["Orderers"].Value, orderers)).To(Succeed())
+				Expect(orderers.ConsenterMapping).To(HaveLen(2))
+				Expect(orderers.ConsenterMapping[1].Id).To(Equal(uint32(4)))
+
+				policy := update.WriteSet.Groups["Orderer"].Policies["BlockValidation"]
+				Expect(policy.Version).To(Equal(uint64(2)))
+				envelope := &cb.SignaturePolicyEnvelope{}
+				Expect(proto.Unmarshal(policy.Policy.Value, envelope)).To(Succeed())
+				Expect(envelope.Identities).To(HaveLen(2))
+				Expect(envelope.Rule.GetNOutOf().N).To(Equal(int32(2)))
+			})
+
+			It("returns an error if the BFT consenter id is taken", func() {
+				consenter.Id = 1
+				config.ChannelGroup.Groups["Orderer"].Values["ConsensusType"].Value = marshal(&ab.ConsensusType{Type: "BFT"})
+				config.ChannelGroup.Groups["Orderer"].Values["Orderers"] = &cb.ConfigValue{
+					Value: marshal(&cb.Orderers{
+						ConsenterMapping: []*cb.Consenter{
+							{Id: 1, Host: "orderer1.example.com", Port: 443},
+						},
+					}),
+				}
+
+				_, err := peeradmin.AddConsenterUpdate(config, "channel1", consenter)
+				Expect(err).To(MatchError("consenter id 1 is already used by 'orderer1.example.com:443'"))
+			})
+
+			It("returns no update if the orderer is already a consenter", func() {
+				consenter.Host = "orderer3.example.com"
+				update, err := peeradmin.AddConsenterUpdate(config, "channel1", consenter)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(update).To(BeNil())
+			})
+
+			It("returns an error if the orderer organization is not in the channel", func() {
+				consenter.MspId = "OtherMSP"
+				_, err := peeradmin.AddConsenterUpdate(config, "channel1", consenter)
+				Expect(err).To(MatchError("no orderer organization with msp id 'OtherMSP' found in channel"))
+			})
+		})
+	})
+})
+
+func generateCert() ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "admin"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package peeradmin
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-lib-go/bccsp/utils"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/pkg/errors"
+)
+
+// Signer is an identity of an organization that signs the requests sent to
+// peers and orderers
+type Signer struct {
+	MSPID string
+	Cert  []byte
+	Key   *ecdsa.PrivateKey
+}
+
+// NewSigner returns a signer for the PEM encoded certificate and ECDSA private key
+func NewSigner(mspID string, certPEM, keyPEM []byte) (*Signer, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("failed to decode certificate PEM")
+	}
+	_, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse certificate")
+	}
+
+	block, _ = pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("failed to decode private key PEM")
+	}
+
+	var key *ecdsa.PrivateKey
+	pkcs8Key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err == nil {
+		var ok bool
+		key, ok = pkcs8Key.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("private key is not an ECDSA key")
+		}
+	} else {
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse private key")
+		}
+	}
+
+	return &Signer{
+		MSPID: mspID,
+		Cert:  certPEM,
+		Key:   key,
+	}, nil
+}
+
+// Serialize returns the serialized identity of the signer
+func (s *Signer) Serialize() ([]byte, error) {
+	return proto.Marshal(&msp.SerializedIdentity{
+		Mspid:   s.MSPID,
+		IdBytes: s.Cert,
+	})
+}
+
+// Sign signs the SHA-256 digest of the message, the signature is normalized
+// to low-S as required by fabric
+func (s *Signer) Sign(msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	r, sig, err := ecdsa.Sign(rand.Reader, s.Key, digest[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign message")
+	}
+
+	sig, err = utils.ToLowS(&s.Key.PublicKey, sig)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.MarshalECDSASignature(r, sig)
+}
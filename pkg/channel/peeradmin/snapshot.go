@@ -0,0 +1,147 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package peeradmin
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	joinChainBySnapshot  = "JoinChainBySnapshot"
+	joinBySnapshotStatus = "JoinBySnapshotStatus"
+)
+
+// GenerateSnapshot requests a snapshot of the ledger of the channel at the block number,
+// a block number of 0 is the last committed block. The peer generates the snapshot
+// asynchronously once the block is committed.
+func (c *Client) GenerateSnapshot(peer *Endpoint, signer *Signer, channelID string, blockNumber uint64) error {
+	header, err := protoutil.NewSignatureHeader(signer)
+	if err != nil {
+		return errors.Wrap(err, "failed to create signature header")
+	}
+
+	request, err := signSnapshotRequest(signer, &pb.SnapshotRequest{
+		SignatureHeader: header,
+		ChannelId:       channelID,
+		BlockNumber:     blockNumber,
+	})
+	if err != nil {
+		return err
+	}
+
+	conn, err := c.dial(peer)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	_, err = pb.NewSnapshotClient(conn).Generate(ctx, request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to generate snapshot of channel '%s'", channelID)
+	}
+
+	return nil
+}
+
+// PendingSnapshots returns the block numbers of the snapshots of the channel that the
+// peer has not generated yet
+func (c *Client) PendingSnapshots(peer *Endpoint, signer *Signer, channelID string) ([]uint64, error) {
+	header, err := protoutil.NewSignatureHeader(signer)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create signature header")
+	}
+
+	request, err := signSnapshotRequest(signer, &pb.SnapshotQuery{
+		SignatureHeader: header,
+		ChannelId:       channelID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := c.dial(peer)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	resp, err := pb.NewSnapshotClient(conn).QueryPendings(ctx, request)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query pending snapshots of channel '%s'", channelID)
+	}
+
+	return resp.BlockNumbers, nil
+}
+
+// JoinChannelBySnapshot joins the peer to the channel of the snapshot stored in the
+// directory on the file system of the peer. The peer builds the ledger from the snapshot
+// asynchronously, JoinBySnapshotInProgress reports when it is done.
+func (c *Client) JoinChannelBySnapshot(peer *Endpoint, signer *Signer, snapshotDir string) error {
+	_, err := c.invokeCSCC(peer, signer, cb.HeaderType_CONFIG, [][]byte{[]byte(joinChainBySnapshot), []byte(snapshotDir)})
+	if err != nil {
+		return errors.Wrap(err, "failed to join channel by snapshot")
+	}
+
+	return nil
+}
+
+// JoinBySnapshotInProgress returns true if the peer is joining a channel from a snapshot
+func (c *Client) JoinBySnapshotInProgress(peer *Endpoint, signer *Signer) (bool, error) {
+	payload, err := c.invokeCSCC(peer, signer, cb.HeaderType_ENDORSER_TRANSACTION, [][]byte{[]byte(joinBySnapshotStatus)})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get join by snapshot status")
+	}
+
+	status := &pb.JoinBySnapshotStatus{}
+	err = proto.Unmarshal(payload, status)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to unmarshal join by snapshot status")
+	}
+
+	return status.InProgress, nil
+}
+
+func signSnapshotRequest(signer *Signer, request proto.Message) (*pb.SignedSnapshotRequest, error) {
+	requestBytes, err := proto.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal snapshot request")
+	}
+
+	signature, err := signer.Sign(requestBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SignedSnapshotRequest{
+		Request:   requestBytes,
+		Signature: signature,
+	}, nil
+}
@@ -45,6 +45,7 @@ import (
 	oconfig "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/migrator"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/webhook"
 	openshiftv1 "github.com/openshift/api/config/v1"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -243,6 +244,15 @@ func OperatorWithSignal(operatorCfg *oconfig.Config, signalHandler context.Conte
 		return err
 	}
 
+	// Webhooks require the serving certificate of the webhook server to be mounted,
+	// they are only registered when enabled
+	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+		if err := webhook.AddToManager(mgr, operatorCfg); err != nil {
+			log.Error(err, "Unable to register webhooks")
+			return err
+		}
+	}
+
 	log.Info("Starting the Cmd.")
 
 	// Start the Cmd
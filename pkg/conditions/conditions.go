@@ -0,0 +1,117 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conditions
+
+import (
+	"fmt"
+	"strings"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Reasons of the conditions set on the status of the custom resources
+const (
+	NotReconciled        = "NotReconciled"
+	AllPodsRunning       = "AllPodsRunning"
+	WaitingForPods       = "WaitingForPods"
+	CertificatesValid    = "CertificatesValid"
+	CertificatesExpiring = "CertificatesExpiring"
+	CertificatesExpired  = "CertificatesExpired"
+	RestartRequested     = "RestartRequested"
+	NoRestartRequested   = "NoRestartRequested"
+	Migrated             = "Migrated"
+	MigrationPending     = "MigrationPending"
+)
+
+// SetReady sets the Ready condition from the type of the status. A component is
+// ready when it is deployed, including when it is deployed with a warning.
+func SetReady(status *current.CRStatus, generation int64) {
+	reason := string(status.Type)
+	if reason == "" {
+		reason = NotReconciled
+	}
+
+	ready := status.Type == current.Deployed || status.Type == current.Warning
+	set(status, generation, current.ReadyCondition, ready, reason, status.Message)
+}
+
+// SetDeploymentAvailable sets the DeploymentAvailable condition
+func SetDeploymentAvailable(status *current.CRStatus, generation int64, available bool) {
+	if available {
+		set(status, generation, current.DeploymentAvailableCondition, true, AllPodsRunning, "All pods running")
+		return
+	}
+
+	set(status, generation, current.DeploymentAvailableCondition, false, WaitingForPods, "Waiting for pods")
+}
+
+// SetCertificatesValid sets the CertificatesValid condition from the status returned
+// by the certificate expiry check of the component. Statuses that are not the
+// result of the check, e.g. Initializing, leave the condition unchanged.
+func SetCertificatesValid(status *current.CRStatus, generation int64, certStatus *current.CRStatus) {
+	switch certStatus.Type {
+	case current.Warning:
+		set(status, generation, current.CertificatesValidCondition, false, CertificatesExpiring, certStatus.Message)
+	case current.Error:
+		set(status, generation, current.CertificatesValidCondition, false, CertificatesExpired, certStatus.Message)
+	case current.Deployed:
+		set(status, generation, current.CertificatesValidCondition, true, CertificatesValid, "")
+	}
+}
+
+// SetRestartPending sets the RestartPending condition from the reasons of the
+// restart requests that have not been completed yet
+func SetRestartPending(status *current.CRStatus, generation int64, reasons []string) {
+	if len(reasons) > 0 {
+		message := fmt.Sprintf("Restart requested for: %s", strings.Join(reasons, ", "))
+		set(status, generation, current.RestartPendingCondition, true, RestartRequested, message)
+		return
+	}
+
+	set(status, generation, current.RestartPendingCondition, false, NoRestartRequested, "")
+}
+
+// SetMigrationComplete sets the MigrationComplete condition
+func SetMigrationComplete(status *current.CRStatus, generation int64, version string, migrated bool) {
+	if migrated {
+		set(status, generation, current.MigrationCompleteCondition, true, Migrated, fmt.Sprintf("Migrated to operator version %s", version))
+		return
+	}
+
+	set(status, generation, current.MigrationCompleteCondition, false, MigrationPending, fmt.Sprintf("Waiting for migration to operator version %s", version))
+}
+
+// set sets the condition on the status, the last transition time of the condition
+// is only updated if the status of the condition changed
+func set(status *current.CRStatus, generation int64, conditionType string, value bool, reason, message string) {
+	conditionStatus := metav1.ConditionFalse
+	if value {
+		conditionStatus = metav1.ConditionTrue
+	}
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
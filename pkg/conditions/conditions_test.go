@@ -0,0 +1,125 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conditions_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/conditions"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("conditions", func() {
+	var (
+		status *current.CRStatus
+	)
+
+	BeforeEach(func() {
+		status = &current.CRStatus{}
+	})
+
+	Context("ready", func() {
+		It("is not ready if the component has not been reconciled", func() {
+			conditions.SetReady(status, 1)
+
+			condition := meta.FindStatusCondition(status.Conditions, current.ReadyCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(conditions.NotReconciled))
+			Expect(condition.ObservedGeneration).To(Equal(int64(1)))
+		})
+
+		It("is ready if the component is deployed with a warning", func() {
+			status.Type = current.Warning
+			status.Message = "certificate expires soon"
+			conditions.SetReady(status, 1)
+
+			condition := meta.FindStatusCondition(status.Conditions, current.ReadyCondition)
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(string(current.Warning)))
+			Expect(condition.Message).To(Equal("certificate expires soon"))
+		})
+
+		It("does not update the transition time if the status did not change", func() {
+			status.Type = current.Deployed
+			conditions.SetReady(status, 1)
+			transitionTime := metav1.Now().Rfc3339Copy()
+			status.Conditions[0].LastTransitionTime = transitionTime
+
+			conditions.SetReady(status, 2)
+			Expect(len(status.Conditions)).To(Equal(1))
+			Expect(status.Conditions[0].LastTransitionTime).To(Equal(transitionTime))
+			Expect(status.Conditions[0].ObservedGeneration).To(Equal(int64(2)))
+		})
+	})
+
+	Context("certificates valid", func() {
+		It("is false if a certificate is expiring", func() {
+			conditions.SetCertificatesValid(status, 1, &current.CRStatus{Type: current.Warning, Message: "expiring"})
+
+			condition := meta.FindStatusCondition(status.Conditions, current.CertificatesValidCondition)
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(conditions.CertificatesExpiring))
+		})
+
+		It("is false if a certificate has expired", func() {
+			conditions.SetCertificatesValid(status, 1, &current.CRStatus{Type: current.Error, Message: "expired"})
+
+			condition := meta.FindStatusCondition(status.Conditions, current.CertificatesValidCondition)
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(conditions.CertificatesExpired))
+		})
+
+		It("ignores statuses that are not the result of the certificate check", func() {
+			conditions.SetCertificatesValid(status, 1, &current.CRStatus{Type: current.Deployed})
+			conditions.SetCertificatesValid(status, 1, &current.CRStatus{Type: current.Initializing})
+
+			Expect(meta.IsStatusConditionTrue(status.Conditions, current.CertificatesValidCondition)).To(Equal(true))
+		})
+	})
+
+	Context("restart pending", func() {
+		It("lists the reasons of the pending restart", func() {
+			conditions.SetRestartPending(status, 1, []string{"adminCert", "ecertUpdate"})
+
+			condition := meta.FindStatusCondition(status.Conditions, current.RestartPendingCondition)
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(Equal("Restart requested for: adminCert, ecertUpdate"))
+		})
+
+		It("is false if no restart is pending", func() {
+			conditions.SetRestartPending(status, 1, nil)
+
+			Expect(meta.IsStatusConditionFalse(status.Conditions, current.RestartPendingCondition)).To(Equal(true))
+		})
+	})
+
+	Context("migration complete", func() {
+		It("is false if the component has not been migrated", func() {
+			conditions.SetMigrationComplete(status, 1, "1.0.4", false)
+
+			condition := meta.FindStatusCondition(status.Conditions, current.MigrationCompleteCondition)
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(conditions.MigrationPending))
+		})
+	})
+})
@@ -0,0 +1,419 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connectionprofile
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	controllerclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+var log = logf.Log.WithName("connectionprofile")
+
+const (
+	// ComponentSuffix is the suffix of the connection profile configmaps of the components
+	ComponentSuffix = "-connection-profile"
+
+	// LabelKey and LabelValue are set on the connection profile configmaps of the organizations
+	LabelKey   = "app.kubernetes.io/component"
+	LabelValue = "connection-profile"
+
+	// JSONKey and YAMLKey are the keys of the connection profile in the configmap of an organization
+	JSONKey = "connection.json"
+	YAMLKey = "connection.yaml"
+)
+
+var invalidNameChars = regexp.MustCompile("[^a-z0-9-]+")
+
+// GetConfigMapName returns the name of the connection profile configmap of an organization
+func GetConfigMapName(mspID string) string {
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(mspID), "-"), "-")
+	return fmt.Sprintf("connection-%s", name)
+}
+
+// ConnectionProfile aggregates the connection profiles of the peers, orderers and CAs
+// of a namespace into a common connection profile per organization
+type ConnectionProfile struct {
+	Client controllerclient.Client
+}
+
+func New(client controllerclient.Client) *ConnectionProfile {
+	return &ConnectionProfile{
+		Client: client,
+	}
+}
+
+// Reconcile writes the connection profile of every organization in the namespace to
+// a configmap and deletes the configmaps of organizations that no longer have components
+func (c *ConnectionProfile) Reconcile(namespace string) error {
+	profiles, err := c.Build(namespace)
+	if err != nil {
+		return err
+	}
+
+	for mspID, profile := range profiles {
+		err = c.updateConfigMap(namespace, mspID, profile)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update connection profile of organization '%s'", mspID)
+		}
+	}
+
+	return c.deleteStaleConfigMaps(namespace, profiles)
+}
+
+// Build returns the connection profiles of the organizations in the namespace by MSP ID.
+// Components that have not written their own connection profile yet are left out.
+func (c *ConnectionProfile) Build(namespace string) (map[string]*Profile, error) {
+	profiles := map[string]*Profile{}
+	caHosts := map[string]map[string]string{}
+
+	getProfile := func(mspID string) *Profile {
+		if profiles[mspID] == nil {
+			profiles[mspID] = &Profile{
+				Name:    fmt.Sprintf("%s-%s", namespace, GetConfigMapName(mspID)),
+				Version: "1.0.0",
+				Client: Client{
+					Organization: mspID,
+				},
+				Organizations: map[string]*Organization{
+					mspID: {MSPID: mspID},
+				},
+			}
+			caHosts[mspID] = map[string]string{}
+		}
+		return profiles[mspID]
+	}
+
+	addCAHost := func(mspID string, enrollment *current.EnrollmentSpec) {
+		if enrollment == nil {
+			return
+		}
+		for _, e := range []*current.Enrollment{enrollment.Component, enrollment.TLS} {
+			if e != nil && e.CAHost != "" {
+				if _, found := caHosts[mspID][e.CAHost]; !found {
+					caHosts[mspID][e.CAHost] = e.CAName
+				}
+			}
+		}
+	}
+
+	peerList := &current.IBPPeerList{}
+	err := c.Client.List(context.TODO(), peerList, k8sclient.InNamespace(namespace))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list peers")
+	}
+
+	for _, peer := range peerList.Items {
+		if peer.Spec.MSPID == "" || peer.Spec.HasReplicas() {
+			continue
+		}
+
+		peerProfile := &current.PeerConnectionProfile{}
+		found, err := c.getComponentProfile(namespace, peer.GetName(), peerProfile)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		node, err := newNode(peerProfile.Endpoints.API, peerProfile.TLS)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid connection profile of peer '%s'", peer.GetName())
+		}
+
+		profile := getProfile(peer.Spec.MSPID)
+		if profile.Peers == nil {
+			profile.Peers = map[string]*Node{}
+		}
+		profile.Peers[peer.GetName()] = node
+		org := profile.Organizations[peer.Spec.MSPID]
+		org.Peers = append(org.Peers, peer.GetName())
+
+		if peer.Spec.Secret != nil {
+			addCAHost(peer.Spec.MSPID, peer.Spec.Secret.Enrollment)
+		}
+	}
+
+	ordererList := &current.IBPOrdererList{}
+	err = c.Client.List(context.TODO(), ordererList, k8sclient.InNamespace(namespace))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list orderers")
+	}
+
+	for _, orderer := range ordererList.Items {
+		if orderer.Spec.MSPID == "" {
+			continue
+		}
+
+		// The parent of an orderer cluster has no connection profile, its nodes are
+		// added to the profile
+		ordererProfile := &current.OrdererConnectionProfile{}
+		found, err := c.getComponentProfile(namespace, orderer.GetName(), ordererProfile)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		node, err := newNode(ordererProfile.Endpoints.API, ordererProfile.TLS)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid connection profile of orderer '%s'", orderer.GetName())
+		}
+
+		profile := getProfile(orderer.Spec.MSPID)
+		if profile.Orderers == nil {
+			profile.Orderers = map[string]*Node{}
+		}
+		profile.Orderers[orderer.GetName()] = node
+		org := profile.Organizations[orderer.Spec.MSPID]
+		org.Orderers = append(org.Orderers, orderer.GetName())
+
+		if orderer.Spec.Secret != nil {
+			addCAHost(orderer.Spec.MSPID, orderer.Spec.Secret.Enrollment)
+		}
+	}
+
+	caList := &current.IBPCAList{}
+	err = c.Client.List(context.TODO(), caList, k8sclient.InNamespace(namespace))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list CAs")
+	}
+
+	for _, ca := range caList.Items {
+		caProfile := &current.CAConnectionProfile{}
+		found, err := c.getComponentProfile(namespace, ca.GetName(), caProfile)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		caURL, err := url.Parse(caProfile.Endpoints.API)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid endpoint of CA '%s'", ca.GetName())
+		}
+
+		// A CA belongs to the organizations whose peers and orderers enroll with it
+		for mspID, hosts := range caHosts {
+			caName, found := hosts[caURL.Hostname()]
+			if !found {
+				continue
+			}
+
+			certificateAuthority, err := newCertificateAuthority(caProfile, caName)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid connection profile of CA '%s'", ca.GetName())
+			}
+
+			profile := profiles[mspID]
+			if profile.CertificateAuthorities == nil {
+				profile.CertificateAuthorities = map[string]*CertificateAuthority{}
+			}
+			profile.CertificateAuthorities[ca.GetName()] = certificateAuthority
+			org := profile.Organizations[mspID]
+			org.CertificateAuthorities = append(org.CertificateAuthorities, ca.GetName())
+		}
+	}
+
+	for mspID, profile := range profiles {
+		org := profile.Organizations[mspID]
+		sort.Strings(org.Peers)
+		sort.Strings(org.Orderers)
+		sort.Strings(org.CertificateAuthorities)
+	}
+
+	return profiles, nil
+}
+
+func (c *ConnectionProfile) getComponentProfile(namespace, name string, into interface{}) (bool, error) {
+	cm := &corev1.ConfigMap{}
+	err := c.Client.Get(context.TODO(), types.NamespacedName{Name: name + ComponentSuffix, Namespace: namespace}, cm)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get connection profile of '%s'", name)
+	}
+
+	bytes, found := cm.BinaryData["profile.json"]
+	if !found {
+		return false, nil
+	}
+
+	err = json.Unmarshal(bytes, into)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal connection profile of '%s'", name)
+	}
+
+	return true, nil
+}
+
+func (c *ConnectionProfile) updateConfigMap(namespace, mspID string, profile *Profile) error {
+	jsonBytes, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal connection profile")
+	}
+
+	yamlBytes, err := yaml.JSONToYAML(jsonBytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert connection profile to yaml")
+	}
+
+	data := map[string]string{
+		JSONKey: string(jsonBytes),
+		YAMLKey: string(yamlBytes),
+	}
+
+	name := GetConfigMapName(mspID)
+	existing := &corev1.ConfigMap{}
+	err = c.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, existing)
+	if err == nil && reflect.DeepEqual(existing.Data, data) {
+		return nil
+	}
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Updating connection profile '%s' of organization '%s'", name, mspID))
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				LabelKey:  LabelValue,
+				"orgname": mspID,
+			},
+		},
+		Data: data,
+	}
+
+	return c.Client.CreateOrUpdate(context.TODO(), cm)
+}
+
+func (c *ConnectionProfile) deleteStaleConfigMaps(namespace string, profiles map[string]*Profile) error {
+	labelSelector, err := labels.Parse(fmt.Sprintf("%s=%s", LabelKey, LabelValue))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse selector for connection profiles")
+	}
+
+	cmList := &corev1.ConfigMapList{}
+	err = c.Client.List(context.TODO(), cmList, &k8sclient.ListOptions{
+		LabelSelector: labelSelector,
+		Namespace:     namespace,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list connection profiles")
+	}
+
+	for _, cm := range cmList.Items {
+		if _, found := profiles[cm.Labels["orgname"]]; found {
+			continue
+		}
+
+		log.Info(fmt.Sprintf("Deleting connection profile '%s' of organization without components", cm.GetName()))
+		cmRef := cm
+		err := c.Client.Delete(context.TODO(), &cmRef)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete connection profile '%s'", cm.GetName())
+		}
+	}
+
+	return nil
+}
+
+func newNode(endpoint string, tls *current.MSP) (*Node, error) {
+	nodeURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid endpoint '%s'", endpoint)
+	}
+
+	node := &Node{
+		URL: endpoint,
+		GRPCOptions: map[string]string{
+			"ssl-target-name-override": nodeURL.Hostname(),
+			"hostnameOverride":         nodeURL.Hostname(),
+		},
+	}
+
+	if tls != nil {
+		node.TLSCACerts.PEM, err = decodeCerts(tls.CACerts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return node, nil
+}
+
+func newCertificateAuthority(profile *current.CAConnectionProfile, caName string) (*CertificateAuthority, error) {
+	certificateAuthority := &CertificateAuthority{
+		URL:    profile.Endpoints.API,
+		CAName: caName,
+		HTTPOptions: map[string]bool{
+			"verify": true,
+		},
+	}
+
+	if profile.TLS != nil {
+		pems, err := decodeCerts(profile.TLS.Cert)
+		if err != nil {
+			return nil, err
+		}
+		certificateAuthority.TLSCACerts.PEM = pems
+	}
+
+	return certificateAuthority, nil
+}
+
+// decodeCerts decodes the base64 encoded certificates of a component connection profile
+func decodeCerts(certs ...string) ([]string, error) {
+	pems := []string{}
+	for _, cert := range certs {
+		if cert == "" {
+			continue
+		}
+		pem, err := base64.StdEncoding.DecodeString(cert)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode certificate")
+		}
+		pems = append(pems, string(pem))
+	}
+	return pems, nil
+}
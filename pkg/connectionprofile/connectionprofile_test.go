@@ -0,0 +1,244 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connectionprofile_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/connectionprofile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("Connection profile", func() {
+	var (
+		mockKubeClient *mocks.Client
+		cp             *connectionprofile.ConnectionProfile
+		configMaps     map[string]*corev1.ConfigMap
+		peers          []current.IBPPeer
+		orderers       []current.IBPOrderer
+		cas            []current.IBPCA
+		orgConfigMaps  []corev1.ConfigMap
+	)
+
+	encode := func(cert string) string {
+		return base64.StdEncoding.EncodeToString([]byte(cert))
+	}
+
+	addProfile := func(name string, profile interface{}) {
+		bytes, err := json.Marshal(profile)
+		Expect(err).NotTo(HaveOccurred())
+		configMaps[name+connectionprofile.ComponentSuffix] = &corev1.ConfigMap{
+			BinaryData: map[string][]byte{"profile.json": bytes},
+		}
+	}
+
+	enrollment := func(caHost string) *current.SecretSpec {
+		return &current.SecretSpec{
+			Enrollment: &current.EnrollmentSpec{
+				Component: &current.Enrollment{
+					CAHost: caHost,
+					CAName: "ca",
+				},
+				TLS: &current.Enrollment{
+					CAHost: caHost,
+					CAName: "tlsca",
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		configMaps = map[string]*corev1.ConfigMap{}
+		orgConfigMaps = nil
+
+		peer1 := current.IBPPeer{Spec: current.IBPPeerSpec{MSPID: "Org1MSP", Secret: enrollment("ns-org1ca-ca.domain")}}
+		peer1.Name = "peer1"
+		peer2 := current.IBPPeer{Spec: current.IBPPeerSpec{MSPID: "Org1MSP"}}
+		peer2.Name = "peer2"
+		peers = []current.IBPPeer{peer1, peer2}
+
+		orderer1 := current.IBPOrderer{Spec: current.IBPOrdererSpec{MSPID: "OrdererMSP", Secret: enrollment("ns-ordererca-ca.domain")}}
+		orderer1.Name = "orderernode1"
+		orderer := current.IBPOrderer{Spec: current.IBPOrdererSpec{MSPID: "OrdererMSP"}}
+		orderer.Name = "orderer"
+		orderers = []current.IBPOrderer{orderer, orderer1}
+
+		org1ca := current.IBPCA{}
+		org1ca.Name = "org1ca"
+		ordererca := current.IBPCA{}
+		ordererca.Name = "ordererca"
+		cas = []current.IBPCA{org1ca, ordererca}
+
+		addProfile("peer1", &current.PeerConnectionProfile{
+			Endpoints: current.PeerEndpoints{API: "grpcs://ns-peer1-peer.domain:443"},
+			TLS:       &current.MSP{CACerts: []string{encode("peer1tlsca")}},
+		})
+		addProfile("peer2", &current.PeerConnectionProfile{
+			Endpoints: current.PeerEndpoints{API: "grpcs://ns-peer2-peer.domain:443"},
+			TLS:       &current.MSP{CACerts: []string{encode("peer2tlsca")}},
+		})
+		addProfile("orderernode1", &current.OrdererConnectionProfile{
+			Endpoints: current.OrdererEndpoints{API: "grpcs://ns-orderernode1-orderer.domain:443"},
+			TLS:       &current.MSP{CACerts: []string{encode("orderertlsca")}},
+		})
+		addProfile("org1ca", &current.CAConnectionProfile{
+			Endpoints: current.CAEndpoints{API: "https://ns-org1ca-ca.domain:443"},
+			TLS:       &current.ConnectionProfileTLS{Cert: encode("org1catls")},
+		})
+		addProfile("ordererca", &current.CAConnectionProfile{
+			Endpoints: current.CAEndpoints{API: "https://ns-ordererca-ca.domain:443"},
+			TLS:       &current.ConnectionProfileTLS{Cert: encode("orderercatls")},
+		})
+
+		mockKubeClient = &mocks.Client{}
+		mockKubeClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+			switch obj := obj.(type) {
+			case *corev1.ConfigMap:
+				cm, found := configMaps[nn.Name]
+				if !found {
+					return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+				}
+				cm.DeepCopyInto(obj)
+			}
+			return nil
+		}
+		mockKubeClient.ListStub = func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+			switch obj := obj.(type) {
+			case *current.IBPPeerList:
+				obj.Items = peers
+			case *current.IBPOrdererList:
+				obj.Items = orderers
+			case *current.IBPCAList:
+				obj.Items = cas
+			case *corev1.ConfigMapList:
+				obj.Items = orgConfigMaps
+			}
+			return nil
+		}
+
+		cp = connectionprofile.New(mockKubeClient)
+	})
+
+	Context("build", func() {
+		It("builds a connection profile per organization", func() {
+			profiles, err := cp.Build("ns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profiles).To(HaveLen(2))
+
+			org1 := profiles["Org1MSP"]
+			Expect(org1.Client.Organization).To(Equal("Org1MSP"))
+			Expect(org1.Organizations["Org1MSP"].Peers).To(Equal([]string{"peer1", "peer2"}))
+			Expect(org1.Peers["peer1"].URL).To(Equal("grpcs://ns-peer1-peer.domain:443"))
+			Expect(org1.Peers["peer1"].TLSCACerts.PEM).To(Equal([]string{"peer1tlsca"}))
+			Expect(org1.Peers["peer1"].GRPCOptions).To(HaveKeyWithValue("ssl-target-name-override", "ns-peer1-peer.domain"))
+			Expect(org1.Orderers).To(BeEmpty())
+		})
+
+		It("adds the CAs the components enroll with", func() {
+			profiles, err := cp.Build("ns")
+			Expect(err).NotTo(HaveOccurred())
+
+			org1 := profiles["Org1MSP"]
+			Expect(org1.Organizations["Org1MSP"].CertificateAuthorities).To(Equal([]string{"org1ca"}))
+			Expect(org1.CertificateAuthorities["org1ca"].CAName).To(Equal("ca"))
+			Expect(org1.CertificateAuthorities["org1ca"].TLSCACerts.PEM).To(Equal([]string{"org1catls"}))
+			Expect(org1.CertificateAuthorities["org1ca"].HTTPOptions).To(Equal(map[string]bool{"verify": true}))
+
+			ordererOrg := profiles["OrdererMSP"]
+			Expect(ordererOrg.Organizations["OrdererMSP"].Orderers).To(Equal([]string{"orderernode1"}))
+			Expect(ordererOrg.Organizations["OrdererMSP"].CertificateAuthorities).To(Equal([]string{"ordererca"}))
+		})
+
+		It("leaves out components without a connection profile", func() {
+			delete(configMaps, "peer2"+connectionprofile.ComponentSuffix)
+			profiles, err := cp.Build("ns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profiles["Org1MSP"].Organizations["Org1MSP"].Peers).To(Equal([]string{"peer1"}))
+		})
+	})
+
+	Context("reconcile", func() {
+		It("writes the connection profiles of the organizations as json and yaml", func() {
+			err := cp.Reconcile("ns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.CreateOrUpdateCallCount()).To(Equal(2))
+
+			written := map[string]*corev1.ConfigMap{}
+			for i := 0; i < 2; i++ {
+				_, obj, _ := mockKubeClient.CreateOrUpdateArgsForCall(i)
+				written[obj.GetName()] = obj.(*corev1.ConfigMap)
+			}
+			cm := written["connection-org1msp"]
+			Expect(cm).NotTo(BeNil())
+			Expect(cm.Labels).To(HaveKeyWithValue(connectionprofile.LabelKey, connectionprofile.LabelValue))
+
+			profile := &connectionprofile.Profile{}
+			err = json.Unmarshal([]byte(cm.Data[connectionprofile.JSONKey]), profile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profile.Peers).To(HaveKey("peer1"))
+
+			yamlProfile := &connectionprofile.Profile{}
+			err = yaml.Unmarshal([]byte(cm.Data[connectionprofile.YAMLKey]), yamlProfile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(yamlProfile).To(Equal(profile))
+		})
+
+		It("does not update connection profiles that did not change", func() {
+			err := cp.Reconcile("ns")
+			Expect(err).NotTo(HaveOccurred())
+			for i := 0; i < 2; i++ {
+				_, obj, _ := mockKubeClient.CreateOrUpdateArgsForCall(i)
+				configMaps[obj.GetName()] = obj.(*corev1.ConfigMap)
+			}
+
+			err = cp.Reconcile("ns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.CreateOrUpdateCallCount()).To(Equal(2))
+		})
+
+		It("deletes the connection profiles of organizations without components", func() {
+			orgConfigMaps = []corev1.ConfigMap{
+				{ObjectMeta: metav1.ObjectMeta{Name: "connection-org1msp", Labels: map[string]string{"orgname": "Org1MSP"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "connection-org2msp", Labels: map[string]string{"orgname": "Org2MSP"}}},
+			}
+
+			err := cp.Reconcile("ns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.DeleteCallCount()).To(Equal(1))
+			_, obj, _ := mockKubeClient.DeleteArgsForCall(0)
+			Expect(obj.GetName()).To(Equal("connection-org2msp"))
+		})
+	})
+
+	It("derives the configmap name from the MSP ID", func() {
+		Expect(connectionprofile.GetConfigMapName("Org1_MSP")).To(Equal("connection-org1-msp"))
+	})
+})
@@ -0,0 +1,64 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connectionprofile
+
+// Profile is a Fabric common connection profile of an organization, as used by the
+// Fabric Gateway client SDKs
+type Profile struct {
+	Name                   string                           `json:"name"`
+	Version                string                           `json:"version"`
+	Client                 Client                           `json:"client"`
+	Organizations          map[string]*Organization         `json:"organizations"`
+	Peers                  map[string]*Node                 `json:"peers,omitempty"`
+	Orderers               map[string]*Node                 `json:"orderers,omitempty"`
+	CertificateAuthorities map[string]*CertificateAuthority `json:"certificateAuthorities,omitempty"`
+}
+
+// Client is the client section of a connection profile
+type Client struct {
+	Organization string `json:"organization"`
+}
+
+// Organization lists the components of an organization by name
+type Organization struct {
+	MSPID                  string   `json:"mspid"`
+	Peers                  []string `json:"peers,omitempty"`
+	Orderers               []string `json:"orderers,omitempty"`
+	CertificateAuthorities []string `json:"certificateAuthorities,omitempty"`
+}
+
+// Node is a peer or orderer of a connection profile
+type Node struct {
+	URL         string            `json:"url"`
+	TLSCACerts  PEMs              `json:"tlsCACerts"`
+	GRPCOptions map[string]string `json:"grpcOptions,omitempty"`
+}
+
+// CertificateAuthority is a CA of a connection profile
+type CertificateAuthority struct {
+	URL         string          `json:"url"`
+	CAName      string          `json:"caName,omitempty"`
+	TLSCACerts  PEMs            `json:"tlsCACerts"`
+	HTTPOptions map[string]bool `json:"httpOptions,omitempty"`
+}
+
+// PEMs holds PEM encoded certificates
+type PEMs struct {
+	PEM []string `json:"pem"`
+}
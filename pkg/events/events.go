@@ -0,0 +1,117 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"fmt"
+
+	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// Reasons of the events recorded on the custom resources
+const (
+	Initialized              = "Initialized"
+	Enrolled                 = "Enrolled"
+	EnrollmentFailed         = "EnrollmentFailed"
+	CertificateRenewed       = "CertificateRenewed"
+	CertificateRenewalFailed = "CertificateRenewalFailed"
+	RestartRequested         = "RestartRequested"
+	RestartQueued            = "RestartQueued"
+	Migrated                 = "Migrated"
+	ReconcileFailed          = "ReconcileFailed"
+)
+
+// ErrorReasons maps operator error codes to the reason of the event recorded
+// for the error
+var ErrorReasons = map[int]string{
+	operatorerrors.InvalidDeploymentCreateRequest:     "InvalidDeploymentCreateRequest",
+	operatorerrors.InvalidDeploymentUpdateRequest:     "InvalidDeploymentUpdateRequest",
+	operatorerrors.InvalidServiceCreateRequest:        "InvalidServiceCreateRequest",
+	operatorerrors.InvalidServiceUpdateRequest:        "InvalidServiceUpdateRequest",
+	operatorerrors.InvalidPVCCreateRequest:            "InvalidPVCCreateRequest",
+	operatorerrors.InvalidPVCUpdateRequest:            "InvalidPVCUpdateRequest",
+	operatorerrors.InvalidConfigMapCreateRequest:      "InvalidConfigMapCreateRequest",
+	operatorerrors.InvalidConfigMapUpdateRequest:      "InvalidConfigMapUpdateRequest",
+	operatorerrors.InvalidServiceAccountCreateRequest: "InvalidServiceAccountCreateRequest",
+	operatorerrors.InvalidServiceAccountUpdateRequest: "InvalidServiceAccountUpdateRequest",
+	operatorerrors.InvalidRoleCreateRequest:           "InvalidRoleCreateRequest",
+	operatorerrors.InvalidRoleUpdateRequest:           "InvalidRoleUpdateRequest",
+	operatorerrors.InvalidRoleBindingCreateRequest:    "InvalidRoleBindingCreateRequest",
+	operatorerrors.InvalidRoleBindingUpdateRequest:    "InvalidRoleBindingUpdateRequest",
+	operatorerrors.InvalidPeerInitSpec:                "InvalidPeerInitSpec",
+	operatorerrors.InvalidOrdererType:                 "InvalidOrdererType",
+	operatorerrors.InvalidOrdererNodeCreateRequest:    "InvalidOrdererNodeCreateRequest",
+	operatorerrors.InvalidOrdererNodeUpdateRequest:    "InvalidOrdererNodeUpdateRequest",
+	operatorerrors.InvalidOrdererInitSpec:             "InvalidOrdererInitSpec",
+	operatorerrors.CAInitilizationFailed:              "CAInitializationFailed",
+	operatorerrors.OrdererInitilizationFailed:         "OrdererInitializationFailed",
+	operatorerrors.PeerInitilizationFailed:            "PeerInitializationFailed",
+	operatorerrors.MigrationFailed:                    "MigrationFailed",
+	operatorerrors.FabricPeerMigrationFailed:          "FabricPeerMigrationFailed",
+	operatorerrors.FabricOrdererMigrationFailed:       "FabricOrdererMigrationFailed",
+	operatorerrors.InvalidCustomResourceCreateRequest: "InvalidCustomResourceCreateRequest",
+	operatorerrors.FabricCAMigrationFailed:            "FabricCAMigrationFailed",
+}
+
+// Normal records an event of type Normal on the instance
+func Normal(recorder record.EventRecorder, instance v1.Object, reason, messageFmt string, args ...interface{}) {
+	recordEvent(recorder, instance, corev1.EventTypeNormal, reason, messageFmt, args...)
+}
+
+// Warning records an event of type Warning on the instance
+func Warning(recorder record.EventRecorder, instance v1.Object, reason, messageFmt string, args ...interface{}) {
+	recordEvent(recorder, instance, corev1.EventTypeWarning, reason, messageFmt, args...)
+}
+
+// Error records a Warning event for an error returned by the reconcile of the instance,
+// operator errors are recorded with the reason matching their error code
+func Error(recorder record.EventRecorder, instance v1.Object, err error) {
+	Warning(recorder, instance, ErrorReason(err), "%s", err.Error())
+}
+
+// ErrorReason returns the reason of the event recorded for the error
+func ErrorReason(err error) string {
+	code := operatorerrors.GetErrorCode(err)
+	if reason, found := ErrorReasons[code]; found {
+		return reason
+	}
+	if code != 0 {
+		return fmt.Sprintf("OperatorError%d", code)
+	}
+	return ReconcileFailed
+}
+
+func recordEvent(recorder record.EventRecorder, instance v1.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	// Offerings and managers constructed without a recorder, such as in unit tests,
+	// do not record events
+	if recorder == nil {
+		return
+	}
+
+	obj, ok := instance.(runtime.Object)
+	if !ok {
+		return
+	}
+
+	recorder.Eventf(obj, eventtype, reason, messageFmt, args...)
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events_test
+
+import (
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/events"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("Events", func() {
+	var (
+		recorder *record.FakeRecorder
+		instance *current.IBPPeer
+	)
+
+	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+		instance = &current.IBPPeer{}
+	})
+
+	It("records a normal event", func() {
+		events.Normal(recorder, instance, events.Enrolled, "Enrolled for %s", "ecert")
+		Expect(recorder.Events).To(Receive(Equal("Normal Enrolled Enrolled for ecert")))
+	})
+
+	It("records a warning event", func() {
+		events.Warning(recorder, instance, events.EnrollmentFailed, "Failed to enroll for %s", "tls")
+		Expect(recorder.Events).To(Receive(Equal("Warning EnrollmentFailed Failed to enroll for tls")))
+	})
+
+	It("does not record events without a recorder", func() {
+		Expect(func() { events.Normal(nil, instance, events.Enrolled, "Enrolled") }).NotTo(Panic())
+	})
+
+	Context("error", func() {
+		It("records operator errors with the reason of the error code", func() {
+			events.Error(recorder, instance, operatorerrors.New(operatorerrors.PeerInitilizationFailed, "init failed"))
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning PeerInitializationFailed ")))
+		})
+
+		It("records other errors as reconcile failures", func() {
+			events.Error(recorder, instance, errors.New("100% failed"))
+			Expect(recorder.Events).To(Receive(Equal("Warning ReconcileFailed 100% failed")))
+		})
+	})
+})
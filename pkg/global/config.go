@@ -23,6 +23,7 @@ import (
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/container"
 	ibpdep "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/deployment"
 	ibpjob "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/job"
+	ibpsts "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/statefulset"
 
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
@@ -54,6 +55,8 @@ func (cs *ConfigSetter) UpdateSecurityContextForAllContainers(obj runtime.Object
 	switch obj.(type) {
 	case *appsv1.Deployment:
 		resource = ibpdep.New(obj.(*appsv1.Deployment))
+	case *appsv1.StatefulSet:
+		resource = ibpsts.New(obj.(*appsv1.StatefulSet))
 	case *batchv1.Job:
 		resource = ibpjob.NewWithDefaults(obj.(*batchv1.Job))
 	default:
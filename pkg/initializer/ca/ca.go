@@ -26,6 +26,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"time"
 
 	_ "github.com/lib/pq"
@@ -41,6 +42,10 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+// mysqlAddress matches the address of the server in a mysql datasource, e.g.
+// user:password@tcp(host:3306)/dbname
+var mysqlAddress = regexp.MustCompile(`@tcp\(([^)]+)\)`)
+
 //go:generate counterfeiter -o mocks/config.go -fake-name CAConfig . CAConfig
 
 type CAConfig interface {
@@ -181,6 +186,12 @@ func (ca *CA) Init() (err error) {
 		}
 	}
 
+	if cfg.CAcfg.DB.Type == "mysql" {
+		if !ca.IsMySQLReachable(cfg.CAcfg.DB) {
+			return errors.New("Cannot initialize CA. MySQL is not reachable")
+		}
+	}
+
 	parentURL := cfg.CAcfg.Intermediate.ParentServer.URL
 	if parentURL != "" {
 		log.Info(fmt.Sprintf("Request received to enroll with parent server: %s", parentURL))
@@ -248,6 +259,19 @@ func (ca *CA) IsPostgresReachable(db lib.CAConfigDB) bool {
 	return true
 }
 
+// IsMySQLReachable checks that the address of the MySQL server in the datasource accepts
+// connections. Unlike for postgres the connection is not pinged with the driver, the TLS
+// configuration of the connection is registered with the mysql driver by fabric-ca when
+// the CA starts, the credentials and TLS settings are verified by fabric-ca at that point.
+func (ca *CA) IsMySQLReachable(db lib.CAConfigDB) bool {
+	matches := mysqlAddress.FindStringSubmatch(db.Datasource)
+	if len(matches) < 2 {
+		return false
+	}
+
+	return util.IsTCPReachable(matches[1])
+}
+
 // ViperUnmarshal as this is what fabric-ca uses when it reads it's configuration
 // file
 func (ca *CA) ViperUnmarshal(configFile string) (*lib.ServerConfig, error) {
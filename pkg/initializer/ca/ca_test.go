@@ -19,6 +19,8 @@
 package initializer_test
 
 import (
+	"fmt"
+	"net"
 	"path/filepath"
 
 	v1 "github.com/IBM-Blockchain/fabric-operator/pkg/apis/ca/v1"
@@ -26,6 +28,7 @@ import (
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/ca/config"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/ca/mocks"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/util/pointer"
+	"github.com/hyperledger/fabric-ca/lib"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -188,6 +191,41 @@ var _ = Describe("IBPCA", func() {
 			})
 		})
 
+		Context("mysql reachability", func() {
+			It("returns true if the server accepts connections", func() {
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+				Expect(err).NotTo(HaveOccurred())
+				defer listener.Close()
+
+				db := lib.CAConfigDB{
+					Type:       "mysql",
+					Datasource: fmt.Sprintf("root:password@tcp(%s)/fabric_ca?parseTime=true", listener.Addr().String()),
+				}
+				Expect(ca.IsMySQLReachable(db)).To(Equal(true))
+			})
+
+			It("returns false if the server doesn't accept connections", func() {
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+				Expect(err).NotTo(HaveOccurred())
+				address := listener.Addr().String()
+				listener.Close()
+
+				db := lib.CAConfigDB{
+					Type:       "mysql",
+					Datasource: fmt.Sprintf("root:password@tcp(%s)/fabric_ca", address),
+				}
+				Expect(ca.IsMySQLReachable(db)).To(Equal(false))
+			})
+
+			It("returns false if the datasource has no address", func() {
+				db := lib.CAConfigDB{
+					Type:       "mysql",
+					Datasource: "root:password@/fabric_ca",
+				}
+				Expect(ca.IsMySQLReachable(db)).To(Equal(false))
+			})
+		})
+
 		Context("viper unmarshal", func() {
 			It("returns an error if fails to find file", func() {
 				_, err := ca.ViperUnmarshal("../../../defaultconfig/ca/foo.yaml")
@@ -341,6 +341,13 @@ func IsValidPostgressDatasource(datasourceStr string) bool {
 	return true
 }
 
+// IsValidMySQLDatasource checks that the datasource is in the format expected by
+// the mysql driver used by fabric-ca, e.g. user:password@tcp(host:3306)/dbname
+func IsValidMySQLDatasource(datasourceStr string) bool {
+	re := regexp.MustCompile(`^[^:@\s]+(:\S*)?@tcp\(\S+:\d+\)/[^?\s]+(\?\S*)?$`)
+	return re.MatchString(datasourceStr)
+}
+
 func ValidCryptoInput(certFile, keyFile string) error {
 	if certFile == "" && keyFile != "" {
 		return errors.New("Key file specified but no corresponding certificate file specified, both must be passed")
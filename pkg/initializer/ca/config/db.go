@@ -69,46 +69,72 @@ func (c *Config) ParseDBBlock() (map[string][]byte, error) {
 			return nil, errors.Errorf("datasource for postgres is not valid")
 		}
 
-		if c.dbCrypto == nil {
-			c.dbCrypto = map[string][]byte{}
+		log.Info("Parsing DB block for Postgres database")
+		return c.parseDBTLS()
+	case MySQL:
+		datasource := c.ServerConfig.CAConfig.DB.Datasource
+		if datasource == "" {
+			return nil, errors.Errorf("no datasource string specified for mysql")
 		}
 
-		log.Info("Parsing DB block for Postgres database")
-		certFiles := c.ServerConfig.CAConfig.DB.TLS.CertFiles
-		for index, certFile := range certFiles {
-			err := c.HandleCertInput(certFile, fmt.Sprintf("db-certfile%d.pem", index), c.dbCrypto)
-			if err != nil {
-				return nil, err
-			}
-			certFiles[index] = filepath.Join(c.HomeDir, fmt.Sprintf("db-certfile%d.pem", index))
+		if !IsValidMySQLDatasource(datasource) {
+			return nil, errors.Errorf("datasource for mysql is not valid")
 		}
-		c.ServerConfig.CAConfig.DB.TLS.CertFiles = certFiles
-
-		certFile := c.ServerConfig.CAConfig.DB.TLS.Client.CertFile
-		keyFile := c.ServerConfig.CAConfig.DB.TLS.Client.KeyFile
-		if certFile != "" && keyFile != "" {
-			log.Info("Client authentication information provided for database connection")
-			err := c.HandleCertInput(certFile, "db-cert.pem", c.dbCrypto)
-			if err != nil {
-				return nil, err
-			}
-			c.ServerConfig.CAConfig.DB.TLS.Client.CertFile = filepath.Join(c.HomeDir, "db-cert.pem")
-
-			err = c.HandleKeyInput(keyFile, "db-key.pem", c.dbCrypto)
-			if err != nil {
-				return nil, err
-			}
-			c.ServerConfig.CAConfig.DB.TLS.Client.KeyFile = filepath.Join(c.HomeDir, "db-key.pem")
+
+		if !c.ServerConfig.CAConfig.DB.TLS.IsEnabled() {
+			return nil, nil
 		}
 
-		return c.dbCrypto, nil
-	case MySQL:
-		return nil, errors.New("MySQL is not supported")
+		// fabric-ca registers the TLS configuration of the database connection
+		// with the mysql driver under the name 'custom'
+		if !strings.Contains(datasource, "tls=custom") {
+			return nil, errors.Errorf("datasource for mysql must set 'tls=custom' when TLS is enabled")
+		}
+
+		log.Info("Parsing DB block for MySQL database")
+		return c.parseDBTLS()
 	}
 
 	return nil, errors.Errorf("database type '%s' is not supported", dbType)
 }
 
+// parseDBTLS stores the TLS crypto material for the database connection and
+// updates the DB block to point to the stored files
+func (c *Config) parseDBTLS() (map[string][]byte, error) {
+	if c.dbCrypto == nil {
+		c.dbCrypto = map[string][]byte{}
+	}
+
+	certFiles := c.ServerConfig.CAConfig.DB.TLS.CertFiles
+	for index, certFile := range certFiles {
+		err := c.HandleCertInput(certFile, fmt.Sprintf("db-certfile%d.pem", index), c.dbCrypto)
+		if err != nil {
+			return nil, err
+		}
+		certFiles[index] = filepath.Join(c.HomeDir, fmt.Sprintf("db-certfile%d.pem", index))
+	}
+	c.ServerConfig.CAConfig.DB.TLS.CertFiles = certFiles
+
+	certFile := c.ServerConfig.CAConfig.DB.TLS.Client.CertFile
+	keyFile := c.ServerConfig.CAConfig.DB.TLS.Client.KeyFile
+	if certFile != "" && keyFile != "" {
+		log.Info("Client authentication information provided for database connection")
+		err := c.HandleCertInput(certFile, "db-cert.pem", c.dbCrypto)
+		if err != nil {
+			return nil, err
+		}
+		c.ServerConfig.CAConfig.DB.TLS.Client.CertFile = filepath.Join(c.HomeDir, "db-cert.pem")
+
+		err = c.HandleKeyInput(keyFile, "db-key.pem", c.dbCrypto)
+		if err != nil {
+			return nil, err
+		}
+		c.ServerConfig.CAConfig.DB.TLS.Client.KeyFile = filepath.Join(c.HomeDir, "db-key.pem")
+	}
+
+	return c.dbCrypto, nil
+}
+
 func (c *Config) DBMountPath() {
 	certFile := c.ServerConfig.CAConfig.DB.TLS.Client.CertFile
 	keyFile := c.ServerConfig.CAConfig.DB.TLS.Client.KeyFile
@@ -76,13 +76,6 @@ var _ = Describe("DB config", func() {
 			Expect(err.Error()).To(Equal("database type 'couchdb' is not supported"))
 		})
 
-		It("returns an error if mysql database type specified", func() {
-			cfg.ServerConfig.CAConfig.DB.Type = string(config.MySQL)
-			_, err := cfg.ParseDBBlock()
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(Equal("MySQL is not supported"))
-		})
-
 		It("returns no error and an empty map if TLS disabled", func() {
 			cfg.ServerConfig.CAConfig.DB.TLS.Enabled = pointer.False()
 			crypto, err := cfg.ParseDBBlock()
@@ -124,6 +117,54 @@ var _ = Describe("DB config", func() {
 			Expect(cfg.ServerConfig.CAConfig.DB.TLS.CertFiles[0]).To(Equal(filepath.Join(cfg.HomeDir, "db-certfile0.pem")))
 		})
 
+		Context("mysql", func() {
+			BeforeEach(func() {
+				cfg.ServerConfig.CAConfig.DB.Type = string(config.MySQL)
+				cfg.ServerConfig.CAConfig.DB.Datasource = "db:db@tcp(0.0.0.0:3306)/fabric?parseTime=true&tls=custom"
+			})
+
+			It("returns an error if missing datasource", func() {
+				cfg.ServerConfig.CAConfig.DB.Datasource = ""
+				_, err := cfg.ParseDBBlock()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("no datasource string specified for mysql"))
+			})
+
+			It("returns an error if datasource is unexpected format", func() {
+				cfg.ServerConfig.CAConfig.DB.Datasource = "host=0.0.0.0 port=3306 dbname=fabric"
+				_, err := cfg.ParseDBBlock()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("datasource for mysql is not valid"))
+			})
+
+			It("returns an error if TLS enabled but datasource does not use the custom TLS config", func() {
+				cfg.ServerConfig.CAConfig.DB.Datasource = "db:db@tcp(0.0.0.0:3306)/fabric?parseTime=true"
+				_, err := cfg.ParseDBBlock()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("datasource for mysql must set 'tls=custom' when TLS is enabled"))
+			})
+
+			It("returns no error and an empty map if TLS disabled", func() {
+				cfg.ServerConfig.CAConfig.DB.TLS.Enabled = pointer.False()
+				cfg.ServerConfig.CAConfig.DB.Datasource = "db:db@tcp(0.0.0.0:3306)/fabric?parseTime=true"
+				crypto, err := cfg.ParseDBBlock()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(crypto).To(BeNil())
+			})
+
+			It("parses config and returns a map containing all db crypto and updated paths to crypto material", func() {
+				crypto, err := cfg.ParseDBBlock()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(crypto["db-cert.pem"]).NotTo(BeNil())
+				Expect(cfg.ServerConfig.CAConfig.DB.TLS.Client.CertFile).To(Equal(filepath.Join(cfg.HomeDir, "db-cert.pem")))
+				Expect(crypto["db-key.pem"]).NotTo(BeNil())
+				Expect(cfg.ServerConfig.CAConfig.DB.TLS.Client.KeyFile).To(Equal(filepath.Join(cfg.HomeDir, "db-key.pem")))
+				Expect(crypto["db-certfile0.pem"]).NotTo(BeNil())
+				Expect(cfg.ServerConfig.CAConfig.DB.TLS.CertFiles[0]).To(Equal(filepath.Join(cfg.HomeDir, "db-certfile0.pem")))
+			})
+		})
+
 		It("creates SQLLite database and returns empty crypto map", func() {
 			cfg.ServerConfig.CAConfig.DB.Type = string(config.SQLLite)
 			crypto, err := cfg.ParseDBBlock()
@@ -518,34 +518,58 @@ func initHSMCAJob(instance *current.IBPCA, hsmConfig *config.HSMConfig, dbConfig
 		return job
 	}
 
-	// If using postgres with TLS enabled need to mount trusted root TLS certificate for databae server
-	if strings.ToLower(dbConfig.Type) == "postgres" {
-		if dbConfig.TLS.IsEnabled() {
-			job.Spec.Template.Spec.Containers[0].VolumeMounts = append(job.Spec.Template.Spec.Containers[0].VolumeMounts,
-				corev1.VolumeMount{
-					Name:      "cacrypto",
-					MountPath: fmt.Sprintf("/crypto/%s/db-certfile0.pem", typ),
-					SubPath:   "db-certfile0.pem",
-				})
-
-			job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes,
-				corev1.Volume{
-					Name: "cacrypto",
-					VolumeSource: corev1.VolumeSource{
-						Secret: &corev1.SecretVolumeSource{
-							SecretName: fmt.Sprintf("%s-%s-crypto", instance.GetName(), typ),
-							Items: []corev1.KeyToPath{
-								corev1.KeyToPath{
-									Key:  "db-certfile0.pem",
-									Path: "db-certfile0.pem",
-								},
-							},
-						},
-					},
-				},
-			)
-		}
+	// If using postgres or mysql with TLS enabled need to mount trusted root TLS certificate
+	// and client certificate for database server
+	volume, volumeMounts := dbTLSVolume(instance, dbConfig, typ)
+	if volume != nil {
+		job.Spec.Template.Spec.Containers[0].VolumeMounts = append(job.Spec.Template.Spec.Containers[0].VolumeMounts, volumeMounts...)
+		job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, *volume)
 	}
 
 	return job
 }
+
+// dbTLSVolume returns the volume and volume mounts for the TLS crypto material used to
+// connect to a postgres or mysql database, nil is returned if TLS is not enabled
+func dbTLSVolume(instance *current.IBPCA, dbConfig *v1.CAConfigDB, typ string) (*corev1.Volume, []corev1.VolumeMount) {
+	switch caconfig.DBType(strings.ToLower(dbConfig.Type)) {
+	case caconfig.Postgres, caconfig.MySQL:
+	default:
+		return nil, nil
+	}
+
+	if !dbConfig.TLS.IsEnabled() {
+		return nil, nil
+	}
+
+	files := []string{"db-certfile0.pem"}
+	if dbConfig.TLS.Client.CertFile != "" && dbConfig.TLS.Client.KeyFile != "" {
+		files = append(files, "db-cert.pem", "db-key.pem")
+	}
+
+	volumeMounts := []corev1.VolumeMount{}
+	items := []corev1.KeyToPath{}
+	for _, file := range files {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "cacrypto",
+			MountPath: fmt.Sprintf("/crypto/%s/%s", typ, file),
+			SubPath:   file,
+		})
+		items = append(items, corev1.KeyToPath{
+			Key:  file,
+			Path: file,
+		})
+	}
+
+	volume := &corev1.Volume{
+		Name: "cacrypto",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: fmt.Sprintf("%s-%s-crypto", instance.GetName(), typ),
+				Items:      items,
+			},
+		},
+	}
+
+	return volume, volumeMounts
+}
@@ -33,10 +33,13 @@ import (
 	caconfig "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/ca/config"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/ca/mocks"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/config"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util/pointer"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -318,5 +321,51 @@ var _ = Describe("HSM CA initializer", func() {
 				Expect(client.UpdateCallCount()).To(Equal(1))
 			})
 		})
+
+		It("mounts the database TLS crypto material if using mysql with TLS enabled", func() {
+			jm, err := util.ConvertToJsonMessage(&v1.ServerConfig{
+				CAConfig: v1.CAConfig{
+					DB: &v1.CAConfigDB{
+						Type:       "mysql",
+						Datasource: "db:db@tcp(0.0.0.0:3306)/fabric?parseTime=true&tls=custom",
+						TLS: v1.ClientTLSConfig{
+							Enabled:   pointer.True(),
+							CertFiles: []string{"ca.pem"},
+							Client: v1.KeyCertFiles{
+								CertFile: "cert.pem",
+								KeyFile:  "key.pem",
+							},
+						},
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			instance.Spec.ConfigOverride = &current.ConfigOverride{
+				CA: &runtime.RawExtension{Raw: *jm},
+			}
+
+			_, err = hsmca.Create(instance, &v1.ServerConfig{}, ca)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, obj, _ := client.CreateArgsForCall(2)
+			job := obj.(*batchv1.Job)
+			Expect(job.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElements([]corev1.VolumeMount{
+				{
+					Name:      "cacrypto",
+					MountPath: "/crypto/ca/db-certfile0.pem",
+					SubPath:   "db-certfile0.pem",
+				},
+				{
+					Name:      "cacrypto",
+					MountPath: "/crypto/ca/db-cert.pem",
+					SubPath:   "db-cert.pem",
+				},
+				{
+					Name:      "cacrypto",
+					MountPath: "/crypto/ca/db-key.pem",
+					SubPath:   "db-key.pem",
+				},
+			}))
+		})
 	})
 })
@@ -22,7 +22,6 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
-	"strings"
 
 	"github.com/pkg/errors"
 
@@ -267,33 +266,12 @@ func (h *HSMDaemon) initHSMCAJob(instance *current.IBPCA, dbConfig *v1.CAConfigD
 	job.Spec.Template.Spec.Containers[0].VolumeMounts = append(job.Spec.Template.Spec.Containers[0].VolumeMounts, h.Config.GetVolumeMounts()...)
 
 	if dbConfig != nil {
-		// If using postgres with TLS enabled need to mount trusted root TLS certificate for database server
-		if strings.ToLower(dbConfig.Type) == "postgres" {
-			if dbConfig.TLS.IsEnabled() {
-				job.Spec.Template.Spec.Containers[0].VolumeMounts = append(job.Spec.Template.Spec.Containers[0].VolumeMounts,
-					corev1.VolumeMount{
-						Name:      "cacrypto",
-						MountPath: fmt.Sprintf("/crypto/%s/db-certfile0.pem", typ),
-						SubPath:   "db-certfile0.pem",
-					})
-
-				job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes,
-					corev1.Volume{
-						Name: "cacrypto",
-						VolumeSource: corev1.VolumeSource{
-							Secret: &corev1.SecretVolumeSource{
-								SecretName: fmt.Sprintf("%s-%s-crypto", instance.GetName(), typ),
-								Items: []corev1.KeyToPath{
-									corev1.KeyToPath{
-										Key:  "db-certfile0.pem",
-										Path: "db-certfile0.pem",
-									},
-								},
-							},
-						},
-					},
-				)
-			}
+		// If using postgres or mysql with TLS enabled need to mount trusted root TLS certificate
+		// and client certificate for database server
+		volume, volumeMounts := dbTLSVolume(instance, dbConfig, typ)
+		if volume != nil {
+			job.Spec.Template.Spec.Containers[0].VolumeMounts = append(job.Spec.Template.Spec.Containers[0].VolumeMounts, volumeMounts...)
+			job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, *volume)
 		}
 	}
 
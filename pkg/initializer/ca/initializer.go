@@ -47,7 +47,9 @@ type Config struct {
 	RoleBindingFile         string
 	ConfigMapFile           string
 	IngressFile             string
-	Ingressv1beta1File      string
+	TLSRouteFile            string
+	HTTPRouteFile           string
+	BackendTLSPolicyFile    string
 	RouteFile               string
 }
 
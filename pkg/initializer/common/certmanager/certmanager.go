@@ -0,0 +1,337 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package certmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"reflect"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/config"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("init_certmanager")
+
+const (
+	// GroupName is the API group of cert-manager
+	GroupName = "cert-manager.io"
+
+	// CertificateKind is the kind of the resource that requests a certificate from an issuer
+	CertificateKind = "Certificate"
+
+	// IssuerKind is the default kind of the issuer of a certificate
+	IssuerKind = "Issuer"
+
+	// RequeueInterval is the interval at which instances waiting for cert-manager to issue
+	// their TLS certificate are reconciled again
+	RequeueInterval = 10 * time.Second
+)
+
+// CertificateGVK is the group, version and kind of cert-manager Certificates. Certificates are
+// unstructured objects, the cert-manager types are not registered with the scheme of the operator.
+var CertificateGVK = schema.GroupVersionKind{Group: GroupName, Version: "v1", Kind: CertificateKind}
+
+// CertManager requests the TLS certificate of an instance from a cert-manager issuer. It creates
+// a Certificate with the CSR hosts of the enrollment as subject alternative names, and reads the
+// crypto from the secret cert-manager writes the issued certificate to. Renewal of the certificate
+// is driven by cert-manager.
+type CertManager struct {
+	Client   k8sclient.Client
+	Scheme   *runtime.Scheme
+	Instance v1.Object
+	Config   *current.Enrollment
+}
+
+func New(client k8sclient.Client, scheme *runtime.Scheme, instance v1.Object, cfg *current.Enrollment) *CertManager {
+	return &CertManager{
+		Client:   client,
+		Scheme:   scheme,
+		Instance: instance,
+		Config:   cfg,
+	}
+}
+
+// GetCertificateName returns the name of the Certificate of the TLS certificate of the instance
+func GetCertificateName(instance v1.Object) string {
+	return fmt.Sprintf("%s-tls", instance.GetName())
+}
+
+// GetSecretName returns the name of the secret cert-manager writes the TLS certificate of the
+// instance to, its content is converted into the tls-<instance name>-* secrets of the operator
+func GetSecretName(instance v1.Object) string {
+	return fmt.Sprintf("tls-%s-certmanager", instance.GetName())
+}
+
+// GetCrypto returns the crypto issued by cert-manager, it returns an error if the certificate
+// has not been issued yet. Reconcilers check IsIssued and requeue until it has been issued.
+func (c *CertManager) GetCrypto() (*config.Response, error) {
+	err := c.ReconcileCertificate()
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := c.GetSecret()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get secret '%s'", GetSecretName(c.Instance))
+	}
+	if !IsSecretReady(secret) {
+		return nil, errors.Errorf("cert-manager has not issued certificate to secret '%s' yet", secret.GetName())
+	}
+
+	return ParseSecret(secret)
+}
+
+// IsIssued returns true if cert-manager wrote the issued certificate to the secret of the
+// instance. The Certificate of the instance is reconciled if it has not been issued yet.
+func (c *CertManager) IsIssued() (bool, error) {
+	secret, err := c.GetSecret()
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return false, errors.Wrapf(err, "failed to get secret '%s'", GetSecretName(c.Instance))
+	}
+	if err == nil && IsSecretReady(secret) {
+		return true, nil
+	}
+
+	return false, c.ReconcileCertificate()
+}
+
+// cert-manager issues certificates asynchronously, ping CA is a no-op
+func (c *CertManager) PingCA() error {
+	// no-op
+	return nil
+}
+
+func (c *CertManager) Validate() error {
+	if c.Config.CertManager == nil || c.Config.CertManager.Name == "" {
+		return errors.New("unable to request certificate from cert-manager, issuer name not specified")
+	}
+
+	if c.Config.CSR == nil || len(c.Config.CSR.Hosts) == 0 {
+		return errors.New("unable to request certificate from cert-manager, CSR hosts not specified")
+	}
+
+	return nil
+}
+
+// ReconcileCertificate creates the Certificate of the instance, or updates it if the CSR hosts or
+// the issuer changed. cert-manager reissues the certificate when the Certificate is updated.
+func (c *CertManager) ReconcileCertificate() error {
+	desired := c.GetCertificate()
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(CertificateGVK)
+	err := c.Client.Get(context.TODO(), types.NamespacedName{Name: desired.GetName(), Namespace: desired.GetNamespace()}, existing)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Info(fmt.Sprintf("Creating certificate '%s'", desired.GetName()))
+			err = c.Client.Create(context.TODO(), desired, k8sclient.CreateOption{Owner: c.Instance, Scheme: c.Scheme})
+			if err != nil {
+				return errors.Wrapf(err, "failed to create certificate '%s'", desired.GetName())
+			}
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get certificate '%s'", desired.GetName())
+	}
+
+	spec, _, err := unstructured.NestedMap(existing.Object, "spec")
+	if err != nil {
+		return errors.Wrapf(err, "invalid spec of certificate '%s'", desired.GetName())
+	}
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+
+	// Only the fields set by the operator are compared, cert-manager may default other fields
+	updated := false
+	for key, value := range desired.Object["spec"].(map[string]interface{}) {
+		if !reflect.DeepEqual(spec[key], value) {
+			spec[key] = value
+			updated = true
+		}
+	}
+	if !updated {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("Updating certificate '%s'", desired.GetName()))
+	existing.Object["spec"] = spec
+	err = c.Client.Update(context.TODO(), existing, k8sclient.UpdateOption{Owner: c.Instance, Scheme: c.Scheme})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update certificate '%s'", desired.GetName())
+	}
+
+	return nil
+}
+
+// GetCertificate returns the Certificate that requests the TLS certificate of the instance
+func (c *CertManager) GetCertificate() *unstructured.Unstructured {
+	dnsNames := []interface{}{}
+	ipAddresses := []interface{}{}
+	if c.Config.CSR != nil {
+		for _, host := range c.Config.CSR.Hosts {
+			if net.ParseIP(host) != nil {
+				ipAddresses = append(ipAddresses, host)
+			} else if host != "" {
+				dnsNames = append(dnsNames, host)
+			}
+		}
+	}
+
+	issuerRef := map[string]interface{}{
+		"name":  c.Config.CertManager.Name,
+		"kind":  IssuerKind,
+		"group": GroupName,
+	}
+	if c.Config.CertManager.Kind != "" {
+		issuerRef["kind"] = c.Config.CertManager.Kind
+	}
+	if c.Config.CertManager.Group != "" {
+		issuerRef["group"] = c.Config.CertManager.Group
+	}
+
+	spec := map[string]interface{}{
+		"secretName":  GetSecretName(c.Instance),
+		"dnsNames":    dnsNames,
+		"ipAddresses": ipAddresses,
+		"issuerRef":   issuerRef,
+		// Fabric nodes use their TLS certificate for both ends of mutual TLS connections
+		"usages": []interface{}{"digital signature", "key encipherment", "server auth", "client auth"},
+		// Renewed certificates keep the private key, Raft orderers match consenters by the
+		// public key of their TLS certificate and would otherwise need a channel config update
+		"privateKey": map[string]interface{}{
+			"algorithm":      "ECDSA",
+			"encoding":       "PKCS8",
+			"size":           int64(256),
+			"rotationPolicy": "Never",
+		},
+	}
+
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(CertificateGVK)
+	cert.SetName(GetCertificateName(c.Instance))
+	cert.SetNamespace(c.Instance.GetNamespace())
+	cert.Object["spec"] = spec
+
+	return cert
+}
+
+// GetSecret returns the secret cert-manager writes the issued certificate to
+func (c *CertManager) GetSecret() (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := c.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      GetSecretName(c.Instance),
+		Namespace: c.Instance.GetNamespace(),
+	}, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// GetRenewedCrypto returns the crypto in the secret of the Certificate if cert-manager issued a
+// certificate other than the current one, returns nil if the secret is not ready or unchanged
+func (c *CertManager) GetRenewedCrypto(currentCert []byte) (*config.Response, error) {
+	secret, err := c.GetSecret()
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if !IsSecretReady(secret) {
+		return nil, nil
+	}
+
+	resp, err := ParseSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(resp.SignCert, currentCert) {
+		return nil, nil
+	}
+
+	return resp, nil
+}
+
+// IsSecretReady returns true if the secret contains an issued certificate and its key
+func IsSecretReady(secret *corev1.Secret) bool {
+	return len(secret.Data[corev1.TLSCertKey]) > 0 && len(secret.Data[corev1.TLSPrivateKeyKey]) > 0
+}
+
+// ParseSecret converts a cert-manager secret into crypto. The first certificate of tls.crt is
+// the signcert, the rest of the chain are intermediate certificates, and ca.crt contains the
+// CA certificate. Issuers that do not set ca.crt, such as ACME issuers, must include the CA
+// in tls.crt, the last certificate of the chain is then used as the CA certificate.
+func ParseSecret(secret *corev1.Secret) (*config.Response, error) {
+	resp := &config.Response{
+		Keystore: secret.Data[corev1.TLSPrivateKeyKey],
+	}
+
+	rest := secret.Data[corev1.TLSCertKey]
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if resp.SignCert == nil {
+			resp.SignCert = pem.EncodeToMemory(block)
+		} else {
+			resp.IntermediateCerts = append(resp.IntermediateCerts, pem.EncodeToMemory(block))
+		}
+	}
+	if resp.SignCert == nil {
+		return nil, errors.Errorf("secret '%s' does not contain a certificate", secret.GetName())
+	}
+
+	caCert := secret.Data["ca.crt"]
+	if len(caCert) == 0 {
+		if len(resp.IntermediateCerts) == 0 {
+			return nil, errors.Errorf("secret '%s' does not contain a CA certificate, issuer must provide ca.crt or a chain in tls.crt", secret.GetName())
+		}
+		last := len(resp.IntermediateCerts) - 1
+		caCert = resp.IntermediateCerts[last]
+		resp.IntermediateCerts = resp.IntermediateCerts[:last]
+		if len(resp.IntermediateCerts) == 0 {
+			resp.IntermediateCerts = nil
+		}
+	}
+	resp.CACerts = [][]byte{caCert}
+
+	return resp, nil
+}
@@ -0,0 +1,312 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package certmanager_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/certmanager"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("cert-manager", func() {
+	var (
+		mockKubeClient *mocks.Client
+		certManager    *certmanager.CertManager
+		instance       *current.IBPPeer
+		enrollment     *current.Enrollment
+
+		secret      *corev1.Secret
+		certificate *unstructured.Unstructured
+		signCert    []byte
+		interCert   []byte
+	)
+
+	BeforeEach(func() {
+		mockKubeClient = &mocks.Client{}
+		instance = &current.IBPPeer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "peer1",
+				Namespace: "peer-namespace",
+			},
+		}
+		enrollment = &current.Enrollment{
+			CSR: &current.CSR{
+				Hosts: []string{"peer-namespace-peer1-peer.domain.com", "127.0.0.1"},
+			},
+			CertManager: &current.CertManagerIssuer{
+				Name: "fabric-issuer",
+			},
+		}
+
+		signCert = createCert()
+		interCert = createCert()
+		secret = &corev1.Secret{
+			Data: map[string][]byte{
+				"tls.crt": append(append([]byte{}, signCert...), interCert...),
+				"tls.key": []byte("key"),
+				"ca.crt":  []byte("cacert"),
+			},
+		}
+		certificate = nil
+
+		mockKubeClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+			switch o := obj.(type) {
+			case *corev1.Secret:
+				if secret == nil {
+					return k8serror.NewNotFound(schema.GroupResource{}, nn.Name)
+				}
+				secret.DeepCopyInto(o)
+				o.Name = nn.Name
+			case *unstructured.Unstructured:
+				if certificate == nil {
+					return k8serror.NewNotFound(schema.GroupResource{}, nn.Name)
+				}
+				o.Object = certificate.DeepCopy().Object
+			}
+			return nil
+		}
+
+		certManager = certmanager.New(mockKubeClient, nil, instance, enrollment)
+	})
+
+	Context("validate", func() {
+		It("returns error if issuer name is not specified", func() {
+			enrollment.CertManager.Name = ""
+			err := certManager.Validate()
+			Expect(err).To(MatchError("unable to request certificate from cert-manager, issuer name not specified"))
+		})
+
+		It("returns error if CSR hosts are not specified", func() {
+			enrollment.CSR = nil
+			err := certManager.Validate()
+			Expect(err).To(MatchError("unable to request certificate from cert-manager, CSR hosts not specified"))
+		})
+
+		It("succeeds if issuer and hosts are specified", func() {
+			Expect(certManager.Validate()).To(Succeed())
+		})
+	})
+
+	Context("get certificate", func() {
+		It("requests the CSR hosts from the issuer", func() {
+			cert := certManager.GetCertificate()
+			Expect(cert.GroupVersionKind()).To(Equal(certmanager.CertificateGVK))
+			Expect(cert.GetName()).To(Equal("peer1-tls"))
+			Expect(cert.GetNamespace()).To(Equal("peer-namespace"))
+
+			secretName, _, _ := unstructured.NestedString(cert.Object, "spec", "secretName")
+			Expect(secretName).To(Equal("tls-peer1-certmanager"))
+
+			dnsNames, _, _ := unstructured.NestedStringSlice(cert.Object, "spec", "dnsNames")
+			Expect(dnsNames).To(Equal([]string{"peer-namespace-peer1-peer.domain.com"}))
+
+			ipAddresses, _, _ := unstructured.NestedStringSlice(cert.Object, "spec", "ipAddresses")
+			Expect(ipAddresses).To(Equal([]string{"127.0.0.1"}))
+
+			issuerRef, _, _ := unstructured.NestedStringMap(cert.Object, "spec", "issuerRef")
+			Expect(issuerRef).To(Equal(map[string]string{
+				"name":  "fabric-issuer",
+				"kind":  "Issuer",
+				"group": "cert-manager.io",
+			}))
+		})
+
+		It("keeps the private key when the certificate is renewed", func() {
+			cert := certManager.GetCertificate()
+			rotationPolicy, _, _ := unstructured.NestedString(cert.Object, "spec", "privateKey", "rotationPolicy")
+			Expect(rotationPolicy).To(Equal("Never"))
+		})
+
+		It("references a cluster issuer", func() {
+			enrollment.CertManager.Kind = "ClusterIssuer"
+			cert := certManager.GetCertificate()
+			kind, _, _ := unstructured.NestedString(cert.Object, "spec", "issuerRef", "kind")
+			Expect(kind).To(Equal("ClusterIssuer"))
+		})
+	})
+
+	Context("reconcile certificate", func() {
+		It("returns error if fails to get certificate", func() {
+			mockKubeClient.GetReturns(errors.New("get error"))
+			mockKubeClient.GetStub = nil
+			err := certManager.ReconcileCertificate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("get error"))
+		})
+
+		It("creates certificate if not found", func() {
+			err := certManager.ReconcileCertificate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.CreateCallCount()).To(Equal(1))
+			_, obj, _ := mockKubeClient.CreateArgsForCall(0)
+			Expect(obj.GetName()).To(Equal("peer1-tls"))
+		})
+
+		It("does not update certificate if unchanged", func() {
+			certificate = certManager.GetCertificate()
+			unstructured.SetNestedField(certificate.Object, "60d", "spec", "renewBefore")
+			err := certManager.ReconcileCertificate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.CreateCallCount()).To(Equal(0))
+			Expect(mockKubeClient.UpdateCallCount()).To(Equal(0))
+		})
+
+		It("updates hosts of certificate if CSR hosts changed", func() {
+			certificate = certManager.GetCertificate()
+			unstructured.SetNestedField(certificate.Object, "60d", "spec", "renewBefore")
+			enrollment.CSR.Hosts = append(enrollment.CSR.Hosts, "peer-namespace-peer1-peer.new-domain.com")
+
+			err := certManager.ReconcileCertificate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.UpdateCallCount()).To(Equal(1))
+			_, obj, _ := mockKubeClient.UpdateArgsForCall(0)
+			updated := obj.(*unstructured.Unstructured)
+			dnsNames, _, _ := unstructured.NestedStringSlice(updated.Object, "spec", "dnsNames")
+			Expect(dnsNames).To(ContainElement("peer-namespace-peer1-peer.new-domain.com"))
+			renewBefore, _, _ := unstructured.NestedString(updated.Object, "spec", "renewBefore")
+			Expect(renewBefore).To(Equal("60d"))
+		})
+	})
+
+	Context("is issued", func() {
+		It("returns true if the secret contains the issued certificate", func() {
+			issued, err := certManager.IsIssued()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issued).To(Equal(true))
+			Expect(mockKubeClient.CreateCallCount()).To(Equal(0))
+		})
+
+		It("creates the certificate and returns false if the secret is not found", func() {
+			secret = nil
+			issued, err := certManager.IsIssued()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issued).To(Equal(false))
+			Expect(mockKubeClient.CreateCallCount()).To(Equal(1))
+		})
+
+		It("returns false if the certificate has not been written to the secret yet", func() {
+			delete(secret.Data, "tls.crt")
+			issued, err := certManager.IsIssued()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issued).To(Equal(false))
+		})
+	})
+
+	Context("get crypto", func() {
+		It("returns error if certificate has not been issued", func() {
+			delete(secret.Data, "tls.key")
+			_, err := certManager.GetCrypto()
+			Expect(err).To(MatchError("cert-manager has not issued certificate to secret 'tls-peer1-certmanager' yet"))
+		})
+
+		It("returns crypto from the secret of the certificate", func() {
+			resp, err := certManager.GetCrypto()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.SignCert).To(Equal(signCert))
+			Expect(resp.IntermediateCerts).To(Equal([][]byte{interCert}))
+			Expect(resp.Keystore).To(Equal([]byte("key")))
+			Expect(resp.CACerts).To(Equal([][]byte{[]byte("cacert")}))
+		})
+	})
+
+	Context("parse secret", func() {
+		It("uses the last certificate of the chain if the secret does not contain ca.crt", func() {
+			delete(secret.Data, "ca.crt")
+			resp, err := certmanager.ParseSecret(secret)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.SignCert).To(Equal(signCert))
+			Expect(resp.IntermediateCerts).To(BeNil())
+			Expect(resp.CACerts).To(Equal([][]byte{interCert}))
+		})
+
+		It("returns error if secret does not contain a CA certificate", func() {
+			delete(secret.Data, "ca.crt")
+			secret.Data["tls.crt"] = signCert
+			_, err := certmanager.ParseSecret(secret)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not contain a CA certificate"))
+		})
+
+		It("returns error if secret does not contain a certificate", func() {
+			secret.Data["tls.crt"] = []byte("invalid")
+			_, err := certmanager.ParseSecret(secret)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not contain a certificate"))
+		})
+	})
+
+	Context("get renewed crypto", func() {
+		It("returns nil if secret is not found", func() {
+			secret = nil
+			resp, err := certManager.GetRenewedCrypto(signCert)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(BeNil())
+		})
+
+		It("returns nil if certificate did not change", func() {
+			resp, err := certManager.GetRenewedCrypto(signCert)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(BeNil())
+		})
+
+		It("returns crypto if cert-manager renewed the certificate", func() {
+			resp, err := certManager.GetRenewedCrypto(createCert())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).NotTo(BeNil())
+			Expect(resp.SignCert).To(Equal(signCert))
+		})
+	})
+})
+
+func createCert() []byte {
+	certtemplate := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	cert, err := x509.CreateCertificate(rand.Reader, &certtemplate, &certtemplate, &priv.PublicKey, priv)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: cert,
+	})
+}
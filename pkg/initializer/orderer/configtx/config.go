@@ -21,7 +21,9 @@ package configtx
 import (
 	"time"
 
+	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric-protos-go/orderer/smartbft"
 )
 
 const (
@@ -29,12 +31,24 @@ const (
 	ConsensusTypeSolo = "solo"
 	// ConsensusTypeKafka identifies the Kafka-based consensus implementation.
 	ConsensusTypeKafka = "kafka"
-	// ConsensusTypeKafka identifies the Kafka-based consensus implementation.
+	// ConsensusTypeEtcdRaft identifies the Raft-based consensus implementation.
 	ConsensusTypeEtcdRaft = "etcdraft"
+	// ConsensusTypeBFT identifies the SmartBFT-based consensus implementation.
+	ConsensusTypeBFT = "BFT"
+
+	// ApplicationChannelProfile is the name of the default profile used to generate
+	// the genesis block of application channels
+	ApplicationChannelProfile = "ApplicationChannel"
+
+	// OrderersKey is the key of the config value holding the BFT consenter mapping
+	OrderersKey = "Orderers"
 
 	// BlockValidationPolicyKey
 	BlockValidationPolicyKey = "BlockValidation"
 
+	// EndorsementPolicyKey is the key of the endorsement policy of application organizations
+	EndorsementPolicyKey = "Endorsement"
+
 	// OrdererAdminsPolicy is the absolute path to the orderer admins policy
 	OrdererAdminsPolicy = "/Channel/Orderer/Admins"
 
@@ -132,16 +146,18 @@ type AnchorPeer struct {
 
 // Orderer contains configuration associated to a channel.
 type Orderer struct {
-	OrdererType   string                   `yaml:"OrdererType"`
-	Addresses     []string                 `yaml:"Addresses"`
-	BatchTimeout  time.Duration            `yaml:"BatchTimeout"`
-	BatchSize     BatchSize                `yaml:"BatchSize"`
-	Kafka         Kafka                    `yaml:"Kafka"`
-	EtcdRaft      *etcdraft.ConfigMetadata `yaml:"EtcdRaft"`
-	Organizations []*Organization          `yaml:"Organizations"`
-	MaxChannels   uint64                   `yaml:"MaxChannels"`
-	Capabilities  map[string]bool          `yaml:"Capabilities"`
-	Policies      map[string]*Policy       `yaml:"Policies"`
+	OrdererType      string                   `yaml:"OrdererType"`
+	Addresses        []string                 `yaml:"Addresses"`
+	BatchTimeout     time.Duration            `yaml:"BatchTimeout"`
+	BatchSize        BatchSize                `yaml:"BatchSize"`
+	Kafka            Kafka                    `yaml:"Kafka"`
+	EtcdRaft         *etcdraft.ConfigMetadata `yaml:"EtcdRaft"`
+	SmartBFT         *smartbft.Options        `yaml:"SmartBFT"`
+	ConsenterMapping []*cb.Consenter          `yaml:"ConsenterMapping"`
+	Organizations    []*Organization          `yaml:"Organizations"`
+	MaxChannels      uint64                   `yaml:"MaxChannels"`
+	Capabilities     map[string]bool          `yaml:"Capabilities"`
+	Policies         map[string]*Policy       `yaml:"Policies"`
 }
 
 // BatchSize contains configuration affecting the size of batches.
@@ -22,7 +22,9 @@ import (
 	"path/filepath"
 	"time"
 
+	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric-protos-go/orderer/smartbft"
 	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/pkg/errors"
 )
@@ -32,28 +34,35 @@ func GetGenesisDefaults() *TopLevel {
 	return &TopLevel{
 		Profiles: map[string]*Profile{
 			"Initial": &Profile{
-				Orderer: &Orderer{
-					Organizations: []*Organization{},
-					OrdererType:   "etcdraft",
-					Addresses:     []string{},
-					BatchTimeout:  2 * time.Second,
-					BatchSize: BatchSize{
-						MaxMessageCount:   500,
-						AbsoluteMaxBytes:  10 * 1024 * 1024,
-						PreferredMaxBytes: 2 * 1024 * 1024,
+				Orderer: defaultOrderer(),
+
+				Consortiums: map[string]*Consortium{
+					"SampleConsortium": &Consortium{},
+				},
+				Capabilities: map[string]bool{
+					"V1_4_3": true,
+				},
+				Policies: map[string]*Policy{
+					"Readers": &Policy{
+						Type: "ImplicitMeta",
+						Rule: "ANY Readers",
 					},
-					EtcdRaft: &etcdraft.ConfigMetadata{
-						Consenters: []*etcdraft.Consenter{},
-						Options: &etcdraft.Options{
-							TickInterval:         "500ms",
-							ElectionTick:         10,
-							HeartbeatTick:        1,
-							MaxInflightBlocks:    5,
-							SnapshotIntervalSize: 20 * 1024 * 1024, // 20 MB
-						},
+					"Writers": &Policy{
+						Type: "ImplicitMeta",
+						Rule: "ANY Writers",
+					},
+					"Admins": &Policy{
+						Type: "ImplicitMeta",
+						Rule: "MAJORITY Admins",
 					},
+				},
+			},
+			ApplicationChannelProfile: &Profile{
+				Orderer: defaultOrderer(),
+				Application: &Application{
+					Organizations: []*Organization{},
 					Capabilities: map[string]bool{
-						"V1_4_2": true,
+						"V2_0": true,
 					},
 					Policies: map[string]*Policy{
 						"Readers": &Policy{
@@ -66,20 +75,20 @@ func GetGenesisDefaults() *TopLevel {
 						},
 						"Admins": &Policy{
 							Type: "ImplicitMeta",
-							Rule: "ANY Admins",
+							Rule: "MAJORITY Admins",
 						},
-						"BlockValidation": &Policy{
+						"LifecycleEndorsement": &Policy{
 							Type: "ImplicitMeta",
-							Rule: "ANY Writers",
+							Rule: "MAJORITY Endorsement",
+						},
+						"Endorsement": &Policy{
+							Type: "ImplicitMeta",
+							Rule: "MAJORITY Endorsement",
 						},
 					},
 				},
-
-				Consortiums: map[string]*Consortium{
-					"SampleConsortium": &Consortium{},
-				},
 				Capabilities: map[string]bool{
-					"V1_4_3": true,
+					"V2_0": true,
 				},
 				Policies: map[string]*Policy{
 					"Readers": &Policy{
@@ -100,6 +109,71 @@ func GetGenesisDefaults() *TopLevel {
 	}
 }
 
+// defaultOrderer returns the orderer section shared by the default profiles
+func defaultOrderer() *Orderer {
+	return &Orderer{
+		Organizations: []*Organization{},
+		OrdererType:   "etcdraft",
+		Addresses:     []string{},
+		BatchTimeout:  2 * time.Second,
+		BatchSize: BatchSize{
+			MaxMessageCount:   500,
+			AbsoluteMaxBytes:  10 * 1024 * 1024,
+			PreferredMaxBytes: 2 * 1024 * 1024,
+		},
+		EtcdRaft: &etcdraft.ConfigMetadata{
+			Consenters: []*etcdraft.Consenter{},
+			Options: &etcdraft.Options{
+				TickInterval:         "500ms",
+				ElectionTick:         10,
+				HeartbeatTick:        1,
+				MaxInflightBlocks:    5,
+				SnapshotIntervalSize: 20 * 1024 * 1024, // 20 MB
+			},
+		},
+		SmartBFT: &smartbft.Options{
+			RequestBatchMaxCount:      100,
+			RequestBatchMaxBytes:      10 * 1024 * 1024,
+			RequestBatchMaxInterval:   "50ms",
+			IncomingMessageBufferSize: 200,
+			RequestPoolSize:           100000,
+			RequestForwardTimeout:     "2s",
+			RequestComplainTimeout:    "20s",
+			RequestAutoRemoveTimeout:  "3m0s",
+			ViewChangeResendInterval:  "5s",
+			ViewChangeTimeout:         "20s",
+			LeaderHeartbeatTimeout:    "1m0s",
+			LeaderHeartbeatCount:      10,
+			CollectTimeout:            "1s",
+			SyncOnStart:               true,
+			LeaderRotation:            smartbft.Options_ROTATION_ON,
+			DecisionsPerLeader:        3,
+		},
+		ConsenterMapping: []*cb.Consenter{},
+		Capabilities: map[string]bool{
+			"V1_4_2": true,
+		},
+		Policies: map[string]*Policy{
+			"Readers": &Policy{
+				Type: "ImplicitMeta",
+				Rule: "ANY Readers",
+			},
+			"Writers": &Policy{
+				Type: "ImplicitMeta",
+				Rule: "ANY Writers",
+			},
+			"Admins": &Policy{
+				Type: "ImplicitMeta",
+				Rule: "ANY Admins",
+			},
+			"BlockValidation": &Policy{
+				Type: "ImplicitMeta",
+				Rule: "ANY Writers",
+			},
+		},
+	}
+}
+
 func LoadTopLevelConfig(configFile string) (*TopLevel, error) {
 	config := viperutil.New()
 	configDir, err := filepath.Abs(filepath.Dir(configFile))
@@ -189,9 +263,56 @@ func (c *ConfigTx) CompleteOrdererInitialization(ord *Orderer) error {
 				return errors.Errorf("consenter info in %s configuration did not specify server TLS cert", ConsensusTypeEtcdRaft)
 			}
 		}
+	case ConsensusTypeBFT:
+		if ord.SmartBFT == nil {
+			return errors.Errorf("%s configuration did not specify SmartBFT options", ConsensusTypeBFT)
+		}
+
+		for _, d := range []string{
+			ord.SmartBFT.RequestBatchMaxInterval,
+			ord.SmartBFT.RequestForwardTimeout,
+			ord.SmartBFT.RequestComplainTimeout,
+			ord.SmartBFT.RequestAutoRemoveTimeout,
+			ord.SmartBFT.ViewChangeResendInterval,
+			ord.SmartBFT.ViewChangeTimeout,
+			ord.SmartBFT.LeaderHeartbeatTimeout,
+			ord.SmartBFT.CollectTimeout,
+		} {
+			if _, err := time.ParseDuration(d); err != nil {
+				return errors.Errorf("SmartBFT option (%s) must be in time duration format", d)
+			}
+		}
+
+		for _, c := range ord.ConsenterMapping {
+			if err := ValidateBFTConsenter(c); err != nil {
+				return err
+			}
+		}
 	default:
 		return errors.Errorf("unknown orderer type: %s", ord.OrdererType)
 	}
 
 	return nil
 }
+
+func ValidateBFTConsenter(c *cb.Consenter) error {
+	if c.Host == "" {
+		return errors.Errorf("consenter info in %s configuration did not specify host", ConsensusTypeBFT)
+	}
+	if c.Port == 0 {
+		return errors.Errorf("consenter info in %s configuration did not specify port", ConsensusTypeBFT)
+	}
+	if c.MspId == "" {
+		return errors.Errorf("consenter info in %s configuration did not specify MSP ID", ConsensusTypeBFT)
+	}
+	if c.Identity == nil {
+		return errors.Errorf("consenter info in %s configuration did not specify identity", ConsensusTypeBFT)
+	}
+	if c.ClientTlsCert == nil {
+		return errors.Errorf("consenter info in %s configuration did not specify client TLS cert", ConsensusTypeBFT)
+	}
+	if c.ServerTlsCert == nil {
+		return errors.Errorf("consenter info in %s configuration did not specify server TLS cert", ConsensusTypeBFT)
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ package configtx
 
 import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	mspprotos "github.com/hyperledger/fabric-protos-go/msp"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/policies"
@@ -32,6 +33,13 @@ import (
 // NewApplicationGroup returns the application component of the channel configuration.  It defines the organizations which are involved
 // in application logic like chaincodes, and how these members may interact with the orderer.  It sets the mod_policy of all elements to "Admins".
 func NewApplicationGroup(conf *Application) (*cb.ConfigGroup, error) {
+	return NewApplicationGroupWithMSPConfigs(conf, nil)
+}
+
+// NewApplicationGroupWithMSPConfigs returns the application component of the channel configuration. The MSP
+// definition of an organization is taken from mspConfigs by organization name, organizations without an entry
+// load their MSP definition from their MSPDir.
+func NewApplicationGroupWithMSPConfigs(conf *Application, mspConfigs map[string]*mspprotos.MSPConfig) (*cb.ConfigGroup, error) {
 	applicationGroup := protoutil.NewConfigGroup()
 	if err := AddPolicies(applicationGroup, conf.Policies, channelconfig.AdminsPolicyKey); err != nil {
 		return nil, errors.Wrapf(err, "error adding policies to application group")
@@ -47,7 +55,7 @@ func NewApplicationGroup(conf *Application) (*cb.ConfigGroup, error) {
 
 	for _, org := range conf.Organizations {
 		var err error
-		applicationGroup.Groups[org.Name], err = NewApplicationOrgGroup(org)
+		applicationGroup.Groups[org.Name], err = newApplicationOrgGroup(org, mspConfigs[org.Name])
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create application org")
 		}
@@ -60,6 +68,10 @@ func NewApplicationGroup(conf *Application) (*cb.ConfigGroup, error) {
 // NewApplicationOrgGroup returns an application org component of the channel configuration.  It defines the crypto material for the organization
 // (its MSP) as well as its anchor peers for use by the gossip network.  It sets the mod_policy of all elements to "Admins".
 func NewApplicationOrgGroup(conf *Organization) (*cb.ConfigGroup, error) {
+	return newApplicationOrgGroup(conf, nil)
+}
+
+func newApplicationOrgGroup(conf *Organization, mspConfig *mspprotos.MSPConfig) (*cb.ConfigGroup, error) {
 	applicationOrgGroup := protoutil.NewConfigGroup()
 	applicationOrgGroup.ModPolicy = channelconfig.AdminsPolicyKey
 
@@ -67,9 +79,12 @@ func NewApplicationOrgGroup(conf *Organization) (*cb.ConfigGroup, error) {
 		return applicationOrgGroup, nil
 	}
 
-	mspConfig, err := msp.GetVerifyingMspConfig(conf.MSPDir, conf.ID, conf.MSPType)
-	if err != nil {
-		return nil, errors.Wrapf(err, "1 - Error loading MSP configuration for org %s", conf.Name)
+	if mspConfig == nil {
+		var err error
+		mspConfig, err = msp.GetVerifyingMspConfig(conf.MSPDir, conf.ID, conf.MSPType)
+		if err != nil {
+			return nil, errors.Wrapf(err, "1 - Error loading MSP configuration for org %s", conf.Name)
+		}
 	}
 
 	if err := AddPolicies(applicationOrgGroup, conf.Policies, channelconfig.AdminsPolicyKey); err != nil {
@@ -19,6 +19,7 @@
 package configtx
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/gogo/protobuf/proto"
@@ -57,6 +58,20 @@ func (p *Profile) AddRaftConsentingNode(consenter *etcdraft.Consenter) error {
 	return nil
 }
 
+func (p *Profile) AddBFTConsentingNode(consenter *cb.Consenter) error {
+	if p.Orderer.OrdererType != ConsensusTypeBFT {
+		return errors.Errorf("can only add BFT consenting node if orderer type is '%s'", ConsensusTypeBFT)
+	}
+
+	for _, c := range p.Orderer.ConsenterMapping {
+		if c.Id == consenter.Id {
+			return errors.Errorf("consenter with id '%d' already exists in consenter mapping", consenter.Id)
+		}
+	}
+	p.Orderer.ConsenterMapping = append(p.Orderer.ConsenterMapping, consenter)
+	return nil
+}
+
 func (p *Profile) AddConsortium(name string, consortium *Consortium) error {
 	for _, org := range consortium.Organizations {
 		err := ValidateOrg(org)
@@ -86,6 +101,18 @@ func (p *Profile) AddOrgToOrderer(org *Organization) error {
 	return nil
 }
 
+func (p *Profile) AddOrgToApplication(org *Organization) error {
+	if p.Application == nil {
+		return errors.New("can only add application org if profile has an application section")
+	}
+	err := ValidateOrg(org)
+	if err != nil {
+		return err
+	}
+	p.Application.Organizations = append(p.Application.Organizations, org)
+	return nil
+}
+
 func (p *Profile) SetMaxChannel(max uint64) {
 	p.Orderer.MaxChannels = max
 }
@@ -118,6 +145,35 @@ func (p *Profile) GenerateBlock(channelID string, mspConfigs map[string]*msp.MSP
 
 }
 
+// GenerateApplicationChannelBlock generates the genesis block of an application channel, which is
+// used to join orderers to the channel through the channel participation API
+func (p *Profile) GenerateApplicationChannelBlock(channelID string, mspConfigs map[string]*msp.MSPConfig) ([]byte, error) {
+	if p.Orderer == nil {
+		return nil, errors.Errorf("refusing to generate block which is missing orderer section")
+	}
+
+	if p.Application == nil {
+		return nil, errors.Errorf("refusing to generate application channel block which is missing application section")
+	}
+
+	if p.Consortiums != nil {
+		return nil, errors.New("application channel block must not contain a consortiums group definition")
+	}
+
+	cg, err := p.NewChannelConfigGroup(mspConfigs)
+	if err != nil {
+		return nil, err
+	}
+
+	genesisBlock := p.Block(channelID, cg)
+	gBlockBytes, err := utils.Marshal(genesisBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling application channel genesis block")
+	}
+
+	return gBlockBytes, nil
+}
+
 func (p *Profile) Block(channelID string, channelGroup *cb.ConfigGroup) *cb.Block {
 	payloadChannelHeader := utils.MakeChannelHeader(cb.HeaderType_CONFIG, int32(1), channelID, 0)
 	payloadSignatureHeader := utils.MakeSignatureHeader(nil, utils.CreateNonceOrPanic())
@@ -168,7 +224,7 @@ func (p *Profile) NewChannelConfigGroup(mspConfigs map[string]*msp.MSPConfig) (*
 	}
 
 	if p.Application != nil {
-		channelGroup.Groups[channelconfig.ApplicationGroupKey], err = NewApplicationGroup(p.Application)
+		channelGroup.Groups[channelconfig.ApplicationGroupKey], err = NewApplicationGroupWithMSPConfigs(p.Application, mspConfigs)
 		if err != nil {
 			return nil, errors.Wrap(err, "could not create application group")
 		}
@@ -222,6 +278,18 @@ func (p *Profile) NewOrdererGroup(conf *Orderer, mspConfigs map[string]*msp.MSPC
 			return nil, err
 		}
 		consensusMetadata = cm
+	case ConsensusTypeBFT:
+		cm, err := proto.Marshal(p.Orderer.SmartBFT)
+		if err != nil {
+			return nil, err
+		}
+		consensusMetadata = cm
+		addOrderersValue(ordererGroup, conf.ConsenterMapping, channelconfig.AdminsPolicyKey)
+		// Blocks of BFT channels must be signed by a quorum of the consenters
+		ordererGroup.Policies[BlockValidationPolicyKey] = &cb.ConfigPolicy{
+			Policy:    BFTBlockValidationPolicy(conf.ConsenterMapping),
+			ModPolicy: channelconfig.AdminsPolicyKey,
+		}
 	default:
 		return nil, errors.Errorf("unknown orderer type: %s", conf.OrdererType)
 	}
@@ -252,6 +320,29 @@ func ValidateOrg(org *Organization) error {
 	return nil
 }
 
+// DefaultApplicationOrgPolicies returns the signature policies of an application organization
+// that uses node OUs to classify its identities
+func DefaultApplicationOrgPolicies(mspID string) map[string]*Policy {
+	return map[string]*Policy{
+		channelconfig.ReadersPolicyKey: &Policy{
+			Type: SignaturePolicyType,
+			Rule: fmt.Sprintf("OR('%[1]s.admin', '%[1]s.peer', '%[1]s.client')", mspID),
+		},
+		channelconfig.WritersPolicyKey: &Policy{
+			Type: SignaturePolicyType,
+			Rule: fmt.Sprintf("OR('%[1]s.admin', '%[1]s.client')", mspID),
+		},
+		channelconfig.AdminsPolicyKey: &Policy{
+			Type: SignaturePolicyType,
+			Rule: fmt.Sprintf("OR('%s.admin')", mspID),
+		},
+		EndorsementPolicyKey: &Policy{
+			Type: SignaturePolicyType,
+			Rule: fmt.Sprintf("OR('%s.peer')", mspID),
+		},
+	}
+}
+
 // NewOrdererOrgGroup returns an orderer org component of the channel configuration.  It defines the crypto material for the
 // organization (its MSP).  It sets the mod_policy of all elements to "Admins".
 func NewOrdererOrgGroup(conf *Organization, mspConfig *msp.MSPConfig) (*cb.ConfigGroup, error) {
@@ -282,6 +373,45 @@ func addValue(cg *cb.ConfigGroup, value channelconfig.ConfigValue, modPolicy str
 	}
 }
 
+// addOrderersValue sets the BFT consenter mapping on the orderer group, the channelconfig
+// package vendored by the operator predates BFT and does not provide a helper for it
+func addOrderersValue(cg *cb.ConfigGroup, consenters []*cb.Consenter, modPolicy string) {
+	cg.Values[OrderersKey] = &cb.ConfigValue{
+		Value:     utils.MarshalOrPanic(&cb.Orderers{ConsenterMapping: consenters}),
+		ModPolicy: modPolicy,
+	}
+}
+
+// BFTBlockValidationPolicy returns the block validation policy of a BFT channel, which requires
+// the signatures of a quorum of the consenters. For n consenters, of which f = (n-1)/3 may be
+// faulty, the quorum is ceil((n+f+1)/2).
+func BFTBlockValidationPolicy(consenters []*cb.Consenter) *cb.Policy {
+	n := len(consenters)
+	f := (n - 1) / 3
+	quorum := (n + f + 2) / 2
+
+	identities := []*msp.MSPPrincipal{}
+	signers := []*cb.SignaturePolicy{}
+	for i, consenter := range consenters {
+		identities = append(identities, &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_IDENTITY,
+			Principal: utils.MarshalOrPanic(&msp.SerializedIdentity{
+				Mspid:   consenter.MspId,
+				IdBytes: consenter.Identity,
+			}),
+		})
+		signers = append(signers, policydsl.SignedBy(int32(i))) // #nosec G115
+	}
+
+	return &cb.Policy{
+		Type: int32(cb.Policy_SIGNATURE),
+		Value: utils.MarshalOrPanic(&cb.SignaturePolicyEnvelope{
+			Rule:       policydsl.NOutOf(int32(quorum), signers), // #nosec G115
+			Identities: identities,
+		}),
+	}
+}
+
 func addPolicy(cg *cb.ConfigGroup, policy policies.ConfigPolicy, modPolicy string) {
 	cg.Policies[policy.Key()] = &cb.ConfigPolicy{
 		Policy:    policy.Value(),
@@ -19,7 +19,11 @@
 package configtx_test
 
 import (
+	"os"
+
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/orderer/configtx"
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -32,6 +36,7 @@ var _ = Describe("profile", func() {
 		err       error
 		profile   *configtx.Profile
 		mspConfig map[string]*msp.MSPConfig
+		cert      []byte
 	)
 
 	BeforeEach(func() {
@@ -42,6 +47,9 @@ var _ = Describe("profile", func() {
 		mspConfig = map[string]*msp.MSPConfig{
 			"testorg3": &msp.MSPConfig{},
 		}
+
+		cert, err = os.ReadFile("../../../../testdata/tls/tls.crt")
+		Expect(err).NotTo(HaveOccurred())
 	})
 
 	Context("profile configuration updates", func() {
@@ -69,8 +77,8 @@ var _ = Describe("profile", func() {
 			consenter := &etcdraft.Consenter{
 				Host:          "testrafthost",
 				Port:          7050,
-				ClientTlsCert: []byte("../../../../testdata/tls/tls.crt"),
-				ServerTlsCert: []byte("../../../../testdata/tls/tls.crt"),
+				ClientTlsCert: cert,
+				ServerTlsCert: cert,
 			}
 
 			profile.SetOrdererType("etcdraft")
@@ -83,6 +91,81 @@ var _ = Describe("profile", func() {
 			Expect(string(blockBytes)).To(ContainSubstring("testrafthost"))
 		})
 
+		It("adds BFT consenting node", func() {
+			consenter := &cb.Consenter{
+				Id:            1,
+				Host:          "testbfthost",
+				Port:          7050,
+				MspId:         "testorg3",
+				Identity:      cert,
+				ClientTlsCert: cert,
+				ServerTlsCert: cert,
+			}
+
+			profile.SetOrdererType(configtx.ConsensusTypeBFT)
+			err := profile.AddBFTConsentingNode(consenter)
+			Expect(err).NotTo(HaveOccurred())
+
+			blockBytes, err := profile.GenerateBlock("channel1", mspConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(blockBytes)).To(ContainSubstring("testbfthost"))
+			Expect(string(blockBytes)).To(ContainSubstring(configtx.OrderersKey))
+		})
+
+		It("requires a quorum of BFT consenters to sign blocks", func() {
+			consenters := []*cb.Consenter{}
+			for i := uint32(1); i <= 4; i++ {
+				consenters = append(consenters, &cb.Consenter{
+					Id:            i,
+					Host:          "testbfthost",
+					Port:          7050 + i,
+					MspId:         "testorg3",
+					Identity:      cert,
+					ClientTlsCert: cert,
+					ServerTlsCert: cert,
+				})
+			}
+
+			policy := configtx.BFTBlockValidationPolicy(consenters)
+			Expect(policy.Type).To(Equal(int32(cb.Policy_SIGNATURE)))
+
+			envelope := &cb.SignaturePolicyEnvelope{}
+			err := proto.Unmarshal(policy.Value, envelope)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(envelope.Identities).To(HaveLen(4))
+			Expect(envelope.Rule.GetNOutOf().N).To(Equal(int32(3)))
+			Expect(envelope.Rule.GetNOutOf().Rules).To(HaveLen(4))
+
+			identity := &msp.SerializedIdentity{}
+			err = proto.Unmarshal(envelope.Identities[0].Principal, identity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(identity.Mspid).To(Equal("testorg3"))
+			Expect(identity.IdBytes).To(Equal(cert))
+		})
+
+		It("fails to add BFT consenting node with duplicate id", func() {
+			consenter := &cb.Consenter{
+				Id:   1,
+				Host: "testbfthost",
+				Port: 7050,
+			}
+
+			profile.SetOrdererType(configtx.ConsensusTypeBFT)
+			err := profile.AddBFTConsentingNode(consenter)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = profile.AddBFTConsentingNode(consenter)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("already exists in consenter mapping"))
+		})
+
+		It("fails to add BFT consenting node if orderer type is etcdraft", func() {
+			profile.SetOrdererType("etcdraft")
+			err := profile.AddBFTConsentingNode(&cb.Consenter{Id: 1})
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("adds consortium", func() {
 			profile.Policies = map[string]*configtx.Policy{
 				channelconfig.AdminsPolicyKey: &configtx.Policy{
@@ -185,4 +268,47 @@ var _ = Describe("profile", func() {
 
 		Expect(string(blockBytes)).To(ContainSubstring("testorg3"))
 	})
+
+	Context("application channel", func() {
+		var appProfile *configtx.Profile
+
+		BeforeEach(func() {
+			configTx := configtx.New()
+			appProfile, err = configTx.GetProfile(configtx.ApplicationChannelProfile)
+			Expect(err).NotTo(HaveOccurred())
+
+			org := &configtx.Organization{
+				Name:           "peerorg1",
+				ID:             "peerorg1msp",
+				MSPType:        "bccsp",
+				AdminPrincipal: "Role.MEMBER",
+				Policies:       configtx.DefaultApplicationOrgPolicies("peerorg1msp"),
+			}
+			err = appProfile.AddOrgToApplication(org)
+			Expect(err).NotTo(HaveOccurred())
+
+			mspConfig["peerorg1"] = &msp.MSPConfig{}
+		})
+
+		It("generates application channel block using supplied msp configs", func() {
+			blockBytes, err := appProfile.GenerateApplicationChannelBlock("channel1", mspConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(blockBytes)).To(ContainSubstring("peerorg1"))
+			Expect(string(blockBytes)).To(ContainSubstring(configtx.EndorsementPolicyKey))
+			Expect(string(blockBytes)).NotTo(ContainSubstring("SampleConsortium"))
+		})
+
+		It("returns an error if profile contains consortiums", func() {
+			appProfile.Consortiums = map[string]*configtx.Consortium{}
+			_, err := appProfile.GenerateApplicationChannelBlock("channel1", mspConfig)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must not contain a consortiums group"))
+		})
+
+		It("returns an error if adding application org to profile without application section", func() {
+			err := profile.AddOrgToApplication(&configtx.Organization{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
@@ -28,6 +28,7 @@ import (
 
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/certmanager"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/config"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/enroller"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/secretmanager"
@@ -51,24 +52,27 @@ import (
 var log = logf.Log.WithName("orderer_initializer")
 
 type Config struct {
-	ConfigTxFile       string
-	OrdererFile        string
-	OrdererV2File      string
-	OrdererV24File     string
-	OrdererV25File     string
-	OUFile             string
-	InterOUFile        string
-	DeploymentFile     string
-	PVCFile            string
-	ServiceFile        string
-	CMFile             string
-	RoleFile           string
-	ServiceAccountFile string
-	RoleBindingFile    string
-	IngressFile        string
-	Ingressv1beta1File string
-	RouteFile          string
-	StoragePath        string
+	ConfigTxFile         string
+	OrdererFile          string
+	OrdererV2File        string
+	OrdererV24File       string
+	OrdererV25File       string
+	OUFile               string
+	InterOUFile          string
+	DeploymentFile       string
+	StatefulSetFile      string
+	PVCFile              string
+	ServiceFile          string
+	CMFile               string
+	RoleFile             string
+	ServiceAccountFile   string
+	RoleBindingFile      string
+	IngressFile          string
+	TLSRouteFile         string
+	HTTPRouteFile        string
+	BackendTLSPolicyFile string
+	RouteFile            string
+	StoragePath          string
 }
 
 type Response struct {
@@ -179,6 +183,12 @@ func (i *Initializer) GetEnrollers(cryptos *config.Cryptos, instance *current.IB
 		}
 	}
 
+	// The TLS certificate is requested from cert-manager instead of a Fabric CA if the
+	// TLS enrollment references a cert-manager issuer
+	if enrollmentSpec.TLS.UsesCertManager() && cryptos.TLS == nil {
+		cryptos.TLS = certmanager.New(i.Client, i.Scheme, instance, enrollmentSpec.TLS)
+	}
+
 	// err := common.GetSWEnrollers(cryptos, enrollmentSpec, storagePath)
 	err := common.GetCommonEnrollers(cryptos, enrollmentSpec, storagePath)
 	if err != nil {
@@ -27,6 +27,7 @@ import (
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
 	commonapi "github.com/IBM-Blockchain/fabric-operator/pkg/apis/common"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/certmanager"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/config"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/enroller"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/secretmanager"
@@ -49,6 +50,7 @@ type Config struct {
 	CorePeerV2File         string
 	CorePeerV25File        string
 	DeploymentFile         string
+	StatefulSetFile        string
 	PVCFile                string
 	CouchDBPVCFile         string
 	ServiceFile            string
@@ -58,7 +60,9 @@ type Config struct {
 	CouchContainerFile     string
 	CouchInitContainerFile string
 	IngressFile            string
-	Ingressv1beta1File     string
+	TLSRouteFile           string
+	HTTPRouteFile          string
+	BackendTLSPolicyFile   string
 	CCLauncherFile         string
 	RouteFile              string
 	StoragePath            string
@@ -190,6 +194,12 @@ func (i *Initializer) GetEnrollers(cryptos *config.Cryptos, instance *current.IB
 		}
 	}
 
+	// The TLS certificate is requested from cert-manager instead of a Fabric CA if the
+	// TLS enrollment references a cert-manager issuer
+	if enrollmentSpec.TLS.UsesCertManager() && cryptos.TLS == nil {
+		cryptos.TLS = certmanager.New(i.Client, i.Scheme, instance, enrollmentSpec.TLS)
+	}
+
 	// Common enrollers get software based enrollers for TLS and clientauth crypto,
 	// these types are not supported for HSM
 	err := common.GetCommonEnrollers(cryptos, enrollmentSpec, storagePath)
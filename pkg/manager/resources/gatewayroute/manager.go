@@ -0,0 +1,538 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gatewayroute
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"fmt"
+	"sort"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("gatewayroute_manager")
+
+const (
+	// GroupName is the API group of the Gateway API
+	GroupName = "gateway.networking.k8s.io"
+
+	// TLSRouteKind routes TLS connections by SNI to the endpoint without terminating TLS
+	TLSRouteKind = "TLSRoute"
+
+	// HTTPRouteKind routes HTTP requests to the endpoint, the Gateway terminates TLS and
+	// opens a new TLS connection to the endpoint as configured by a BackendTLSPolicy
+	HTTPRouteKind = "HTTPRoute"
+
+	// BackendTLSPolicyKind configures how the Gateway verifies the TLS certificate of the
+	// endpoint behind an HTTPRoute
+	BackendTLSPolicyKind = "BackendTLSPolicy"
+
+	// CACertsKey is the key of the config map that holds the certificates a BackendTLSPolicy
+	// verifies the TLS certificate of the endpoint with
+	CACertsKey = "ca.crt"
+)
+
+// Route is a Gateway API route to an endpoint of a component
+type Route struct {
+	// Suffix is appended to the name of the instance to name the route
+	Suffix string
+
+	// Kind is either TLSRoute or HTTPRoute
+	Kind string
+
+	Hostnames []string
+	Gateway   current.GatewayReference
+
+	// Service and Port are the backend of the route
+	Service string
+	Port    int32
+
+	// BackendTLS is required by HTTPRoutes, every endpoint of the components serves TLS
+	BackendTLS *BackendTLS
+}
+
+// BackendTLS is how the Gateway verifies the TLS certificate of the endpoint behind an HTTPRoute
+type BackendTLS struct {
+	// SectionName is the name of the service port of the endpoint
+	SectionName string
+
+	// Hostname is the name the TLS certificate of the endpoint is verified against
+	Hostname string
+
+	// CACertsSecrets are the secrets holding the certificates of the CAs that issued the TLS
+	// certificate of the endpoint. Secrets that do not exist and keys that do not hold
+	// certificates, like private keys, are skipped.
+	CACertsSecrets []string
+}
+
+// Manager reconciles the Gateway API routes of a component. Routes are unstructured objects,
+// the Gateway API types are not registered with the scheme of the operator.
+type Manager struct {
+	Client               k8sclient.Client
+	Scheme               *runtime.Scheme
+	TLSRouteFile         string
+	HTTPRouteFile        string
+	BackendTLSPolicyFile string
+
+	LabelsFunc func(v1.Object) map[string]string
+	RoutesFunc func(v1.Object) ([]Route, error)
+}
+
+func (m *Manager) Reconcile(instance v1.Object, update bool) error {
+	routes, err := m.RoutesFunc(instance)
+	if err != nil {
+		return err
+	}
+
+	for _, route := range routes {
+		err = ValidateGateway(route.Gateway)
+		if err != nil {
+			return err
+		}
+
+		if route.Kind == HTTPRouteKind && route.BackendTLS == nil {
+			return fmt.Errorf("%s '%s' has no backend TLS configuration", HTTPRouteKind, m.routeName(instance, route))
+		}
+
+		err = m.reconcileRoute(instance, route, update)
+		if err != nil {
+			return errors.Wrapf(err, "failed to reconcile %s '%s'", route.Kind, m.routeName(instance, route))
+		}
+
+		if route.BackendTLS != nil {
+			err = m.reconcileBackendTLS(instance, route, update)
+			if err != nil {
+				return errors.Wrapf(err, "failed to reconcile %s '%s'", BackendTLSPolicyKind, m.routeName(instance, route))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) reconcileRoute(instance v1.Object, route Route, update bool) error {
+	desired, err := m.GetRouteBasedOnCRFromFile(instance, route)
+	if err != nil {
+		return err
+	}
+
+	return m.reconcileObject(instance, desired, update)
+}
+
+func (m *Manager) reconcileBackendTLS(instance v1.Object, route Route, update bool) error {
+	err := m.reconcileCACerts(instance, route)
+	if err != nil {
+		return err
+	}
+
+	desired, err := m.GetBackendTLSPolicyBasedOnCRFromFile(instance, route)
+	if err != nil {
+		return err
+	}
+
+	return m.reconcileObject(instance, desired, update)
+}
+
+func (m *Manager) reconcileObject(instance v1.Object, desired *unstructured.Unstructured, update bool) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(desired.GroupVersionKind())
+	err := m.Client.Get(context.TODO(), types.NamespacedName{Name: desired.GetName(), Namespace: desired.GetNamespace()}, existing)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Info(fmt.Sprintf("Creating %s '%s'", desired.GetKind(), desired.GetName()))
+			return m.Client.Create(context.TODO(), desired, k8sclient.CreateOption{Owner: instance, Scheme: m.Scheme})
+		}
+		return err
+	}
+
+	if update {
+		log.Info(fmt.Sprintf("Updating %s '%s'", desired.GetKind(), desired.GetName()))
+		existing.Object["spec"] = desired.Object["spec"]
+		existing.SetLabels(desired.GetLabels())
+		return m.Client.Update(context.TODO(), existing, k8sclient.UpdateOption{Owner: instance, Scheme: m.Scheme})
+	}
+
+	return nil
+}
+
+// reconcileCACerts keeps the certificates a BackendTLSPolicy verifies the endpoint with up to
+// date on every reconcile, as they change when the certificates of the component are renewed
+// rather than with its spec
+func (m *Manager) reconcileCACerts(instance v1.Object, route Route) error {
+	certs, err := m.getCACerts(instance.GetNamespace(), route.BackendTLS.CACertsSecrets)
+	if err != nil {
+		return err
+	}
+
+	name := m.caCertsName(instance, route)
+	cm := &corev1.ConfigMap{}
+	err = m.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.GetNamespace()}, cm)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Info(fmt.Sprintf("Creating config map '%s'", name))
+			cm = &corev1.ConfigMap{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      name,
+					Namespace: instance.GetNamespace(),
+					Labels:    m.LabelsFunc(instance),
+				},
+				Data: map[string]string{
+					CACertsKey: string(certs),
+				},
+			}
+			return m.Client.Create(context.TODO(), cm, k8sclient.CreateOption{Owner: instance, Scheme: m.Scheme})
+		}
+		return err
+	}
+
+	if cm.Data[CACertsKey] == string(certs) {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("Updating config map '%s' with the CA certificates of the endpoint", name))
+	cm.Data = map[string]string{
+		CACertsKey: string(certs),
+	}
+	return m.Client.Update(context.TODO(), cm, k8sclient.UpdateOption{Owner: instance, Scheme: m.Scheme})
+}
+
+// getCACerts returns the PEM encoded certificates held by the secrets, without duplicates
+func (m *Manager) getCACerts(namespace string, secrets []string) ([]byte, error) {
+	certs := []byte{}
+	for _, name := range secrets {
+		secret := &corev1.Secret{}
+		err := m.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, secret)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		keys := []string{}
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			for _, block := range certificateBlocks(secret.Data[key]) {
+				cert := pem.EncodeToMemory(block)
+				if !bytes.Contains(certs, cert) {
+					certs = append(certs, cert...)
+				}
+			}
+		}
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no CA certificates found in secrets %v", secrets)
+	}
+
+	return certs, nil
+}
+
+// certificateBlocks returns the PEM blocks of data, or nil if any of them is not a certificate
+func certificateBlocks(data []byte) []*pem.Block {
+	blocks := []*pem.Block{}
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			return blocks
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil
+		}
+		blocks = append(blocks, block)
+		data = rest
+	}
+}
+
+// GetRouteBasedOnCRFromFile renders a route from the definition of its kind
+func (m *Manager) GetRouteBasedOnCRFromFile(instance v1.Object, route Route) (*unstructured.Unstructured, error) {
+	file := m.TLSRouteFile
+	if route.Kind == HTTPRouteKind {
+		file = m.HTTPRouteFile
+	}
+
+	obj, err := util.GetUnstructuredFromFile(file)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("Error reading %s configuration file: %s", route.Kind, file))
+		return nil, err
+	}
+
+	return m.BasedOnCR(instance, route, obj)
+}
+
+func (m *Manager) BasedOnCR(instance v1.Object, route Route, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if obj.GetKind() != route.Kind {
+		return nil, fmt.Errorf("definition of kind '%s' does not match route of kind '%s'", obj.GetKind(), route.Kind)
+	}
+
+	parentRef := map[string]interface{}{
+		"name": route.Gateway.Name,
+	}
+	if route.Gateway.Namespace != "" {
+		parentRef["namespace"] = route.Gateway.Namespace
+	}
+
+	hostnames := []interface{}{}
+	for _, hostname := range route.Hostnames {
+		hostnames = append(hostnames, hostname)
+	}
+
+	spec, _, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid route spec")
+	}
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+	spec["parentRefs"] = []interface{}{parentRef}
+	spec["hostnames"] = hostnames
+	spec["rules"] = []interface{}{
+		map[string]interface{}{
+			"backendRefs": []interface{}{
+				map[string]interface{}{
+					"name": route.Service,
+					"port": int64(route.Port),
+				},
+			},
+		},
+	}
+	obj.Object["spec"] = spec
+
+	obj.SetName(m.routeName(instance, route))
+	obj.SetNamespace(instance.GetNamespace())
+	obj.SetLabels(m.LabelsFunc(instance))
+
+	return obj, nil
+}
+
+// GetBackendTLSPolicyBasedOnCRFromFile renders the BackendTLSPolicy of an HTTPRoute from its definition
+func (m *Manager) GetBackendTLSPolicyBasedOnCRFromFile(instance v1.Object, route Route) (*unstructured.Unstructured, error) {
+	obj, err := util.GetUnstructuredFromFile(m.BackendTLSPolicyFile)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("Error reading %s configuration file: %s", BackendTLSPolicyKind, m.BackendTLSPolicyFile))
+		return nil, err
+	}
+
+	return m.BackendTLSPolicyBasedOnCR(instance, route, obj)
+}
+
+func (m *Manager) BackendTLSPolicyBasedOnCR(instance v1.Object, route Route, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if obj.GetKind() != BackendTLSPolicyKind {
+		return nil, fmt.Errorf("definition of kind '%s' does not match policy of kind '%s'", obj.GetKind(), BackendTLSPolicyKind)
+	}
+
+	targetRef := map[string]interface{}{
+		"group": "",
+		"kind":  "Service",
+		"name":  route.Service,
+	}
+	if route.BackendTLS.SectionName != "" {
+		targetRef["sectionName"] = route.BackendTLS.SectionName
+	}
+
+	spec, _, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid policy spec")
+	}
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+	spec["targetRefs"] = []interface{}{targetRef}
+	spec["validation"] = map[string]interface{}{
+		"caCertificateRefs": []interface{}{
+			map[string]interface{}{
+				"group": "",
+				"kind":  "ConfigMap",
+				"name":  m.caCertsName(instance, route),
+			},
+		},
+		"hostname": route.BackendTLS.Hostname,
+	}
+	obj.Object["spec"] = spec
+
+	obj.SetName(m.routeName(instance, route))
+	obj.SetNamespace(instance.GetNamespace())
+	obj.SetLabels(m.LabelsFunc(instance))
+
+	return obj, nil
+}
+
+func (m *Manager) routeName(instance v1.Object, route Route) string {
+	if route.Suffix != "" {
+		return fmt.Sprintf("%s-%s", instance.GetName(), route.Suffix)
+	}
+	return instance.GetName()
+}
+
+func (m *Manager) caCertsName(instance v1.Object, route Route) string {
+	return fmt.Sprintf("%s-backend-ca", m.routeName(instance, route))
+}
+
+func (m *Manager) Exists(instance v1.Object) bool {
+	if instance == nil {
+		return false // Instance has not been reconciled yet
+	}
+
+	_, err := m.Get(instance)
+	return err == nil
+}
+
+// Get returns the first route of the instance
+func (m *Manager) Get(instance v1.Object) (client.Object, error) {
+	if instance == nil {
+		return nil, nil // Instance has not been reconciled yet
+	}
+
+	routes, err := m.RoutesFunc(instance)
+	if err != nil {
+		return nil, err
+	}
+	if len(routes) == 0 {
+		return nil, k8serrors.NewNotFound(schema.GroupResource{Group: GroupName, Resource: "routes"}, instance.GetName())
+	}
+
+	return m.getRoute(instance, routes[0])
+}
+
+func (m *Manager) getRoute(instance v1.Object, route Route) (*unstructured.Unstructured, error) {
+	desired, err := m.GetRouteBasedOnCRFromFile(instance, route)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(desired.GroupVersionKind())
+	err = m.Client.Get(context.TODO(), types.NamespacedName{Name: desired.GetName(), Namespace: desired.GetNamespace()}, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// Delete deletes all routes of the instance and the backend TLS configuration of its
+// HTTPRoutes, it is a no-op on clusters that do not serve the Gateway API
+func (m *Manager) Delete(instance v1.Object) error {
+	routes, err := m.RoutesFunc(instance)
+	if err != nil {
+		return err
+	}
+
+	for _, route := range routes {
+		obj, err := m.GetRouteBasedOnCRFromFile(instance, route)
+		if err != nil {
+			return err
+		}
+		objs := []client.Object{obj}
+
+		if route.BackendTLS != nil {
+			policy, err := m.GetBackendTLSPolicyBasedOnCRFromFile(instance, route)
+			if err != nil {
+				return err
+			}
+			cm := &corev1.ConfigMap{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      m.caCertsName(instance, route),
+					Namespace: instance.GetNamespace(),
+				},
+			}
+			objs = append(objs, policy, cm)
+		}
+
+		for _, obj := range objs {
+			err = m.delete(obj)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) delete(obj client.Object) error {
+	err := m.Client.Get(context.TODO(), types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, obj)
+	if err != nil {
+		if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+
+	err = m.Client.Delete(context.TODO(), obj)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Manager) GetName(instance v1.Object) string {
+	return instance.GetName()
+}
+
+func (m *Manager) CheckState(instance v1.Object) error {
+	// NO-OP
+	return nil
+}
+
+func (m *Manager) RestoreState(instance v1.Object) error {
+	// NO-OP
+	return nil
+}
+
+func (m *Manager) SetCustomName(name string) {
+	// NO-OP
+}
+
+// GetGateway returns the Gateway the routes of a component attach to, it is empty if
+// the component does not set one
+func GetGateway(ingress current.Ingress) current.GatewayReference {
+	if ingress.Gateway == nil {
+		return current.GatewayReference{}
+	}
+	return *ingress.Gateway
+}
+
+// ValidateGateway returns an error if routes have no Gateway to attach to
+func ValidateGateway(gateway current.GatewayReference) error {
+	if gateway.Name == "" {
+		return errors.New("ingress.gateway.name must be set when ingress.mode is GatewayAPI")
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gatewayroute_test
+
+import (
+	"context"
+	"encoding/pem"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Gateway route manager", func() {
+	var (
+		mockKubeClient *mocks.Client
+		manager        *gatewayroute.Manager
+		instance       metav1.Object
+		notFoundErr    error
+		exists         bool
+		caCerts        string
+		cmCerts        string
+	)
+
+	BeforeEach(func() {
+		mockKubeClient = &mocks.Client{}
+		notFoundErr = &k8serror.StatusError{
+			ErrStatus: metav1.Status{
+				Reason: metav1.StatusReasonNotFound,
+			},
+		}
+
+		rootCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("root")})
+		interCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("intermediate")})
+		caCerts = string(rootCert) + string(interCert)
+		cmCerts = caCerts
+		exists = false
+
+		mockKubeClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+			switch o := obj.(type) {
+			case *corev1.Secret:
+				switch nn.Name {
+				case "tls-peer1-cacerts":
+					o.Data = map[string][]byte{
+						"cacert-0.pem": rootCert,
+						"key.pem":      pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}),
+					}
+					return nil
+				case "tls-peer1-intercerts":
+					o.Data = map[string][]byte{
+						"intercert-0.pem": interCert,
+						"cacert-0.pem":    rootCert,
+					}
+					return nil
+				}
+				return notFoundErr
+			case *corev1.ConfigMap:
+				if !exists {
+					return notFoundErr
+				}
+				o.Name = nn.Name
+				o.Data = map[string]string{gatewayroute.CACertsKey: cmCerts}
+				return nil
+			}
+			if !exists {
+				return notFoundErr
+			}
+			obj.SetName(nn.Name)
+			return nil
+		}
+
+		instance = &metav1.ObjectMeta{Name: "peer1", Namespace: "namespace"}
+
+		manager = &gatewayroute.Manager{
+			Client:               mockKubeClient,
+			TLSRouteFile:         "../../../../definitions/peer/tlsroute.yaml",
+			HTTPRouteFile:        "../../../../definitions/peer/httproute.yaml",
+			BackendTLSPolicyFile: "../../../../definitions/peer/backendtlspolicy.yaml",
+			LabelsFunc: func(metav1.Object) map[string]string {
+				return map[string]string{"app": "peer1"}
+			},
+			RoutesFunc: func(metav1.Object) ([]gatewayroute.Route, error) {
+				return []gatewayroute.Route{
+					{
+						Kind:      gatewayroute.TLSRouteKind,
+						Hostnames: []string{"namespace-peer1-peer.domain"},
+						Gateway:   current.GatewayReference{Name: "gateway", Namespace: "gateway-ns"},
+						Service:   "peer1",
+						Port:      7051,
+					},
+					{
+						Suffix:    "operations",
+						Kind:      gatewayroute.HTTPRouteKind,
+						Hostnames: []string{"namespace-peer1-operations.domain"},
+						Gateway:   current.GatewayReference{Name: "gateway"},
+						Service:   "peer1",
+						Port:      9443,
+						BackendTLS: &gatewayroute.BackendTLS{
+							SectionName:    "operations",
+							Hostname:       "namespace-peer1-operations.domain",
+							CACertsSecrets: []string{"tls-peer1-cacerts", "tls-peer1-intercerts", "tls-peer1-othercerts"},
+						},
+					},
+				}, nil
+			},
+		}
+	})
+
+	Context("reconciles the routes", func() {
+		It("does not try to create routes if the get request returns an error other than 'not found'", func() {
+			mockKubeClient.GetStub = nil
+			mockKubeClient.GetReturns(errors.New("connection refused"))
+			err := manager.Reconcile(instance, false)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("connection refused"))
+			Expect(mockKubeClient.CreateCallCount()).To(Equal(0))
+		})
+
+		When("routes do not exist", func() {
+			It("creates a route per endpoint", func() {
+				err := manager.Reconcile(instance, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockKubeClient.CreateCallCount()).To(Equal(4))
+
+				_, obj, _ := mockKubeClient.CreateArgsForCall(0)
+				route := obj.(*unstructured.Unstructured)
+				Expect(route.GetKind()).To(Equal(gatewayroute.TLSRouteKind))
+				Expect(route.GetName()).To(Equal("peer1"))
+				Expect(route.GetNamespace()).To(Equal("namespace"))
+				Expect(route.GetLabels()).To(Equal(map[string]string{"app": "peer1"}))
+
+				parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+				Expect(parentRefs).To(Equal([]interface{}{map[string]interface{}{"name": "gateway", "namespace": "gateway-ns"}}))
+				hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+				Expect(hostnames).To(Equal([]string{"namespace-peer1-peer.domain"}))
+				rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+				Expect(rules).To(Equal([]interface{}{
+					map[string]interface{}{
+						"backendRefs": []interface{}{
+							map[string]interface{}{"name": "peer1", "port": int64(7051)},
+						},
+					},
+				}))
+
+				_, obj, _ = mockKubeClient.CreateArgsForCall(1)
+				route = obj.(*unstructured.Unstructured)
+				Expect(route.GetKind()).To(Equal(gatewayroute.HTTPRouteKind))
+				Expect(route.GetName()).To(Equal("peer1-operations"))
+			})
+
+			It("creates a backend TLS policy that verifies the endpoint of an HTTPRoute", func() {
+				err := manager.Reconcile(instance, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, obj, _ := mockKubeClient.CreateArgsForCall(2)
+				cm := obj.(*corev1.ConfigMap)
+				Expect(cm.GetName()).To(Equal("peer1-operations-backend-ca"))
+				Expect(cm.GetNamespace()).To(Equal("namespace"))
+				Expect(cm.Data).To(Equal(map[string]string{gatewayroute.CACertsKey: caCerts}))
+
+				_, obj, _ = mockKubeClient.CreateArgsForCall(3)
+				policy := obj.(*unstructured.Unstructured)
+				Expect(policy.GetKind()).To(Equal(gatewayroute.BackendTLSPolicyKind))
+				Expect(policy.GetName()).To(Equal("peer1-operations"))
+				Expect(policy.GetLabels()).To(Equal(map[string]string{"app": "peer1"}))
+
+				targetRefs, _, _ := unstructured.NestedSlice(policy.Object, "spec", "targetRefs")
+				Expect(targetRefs).To(Equal([]interface{}{
+					map[string]interface{}{"group": "", "kind": "Service", "name": "peer1", "sectionName": "operations"},
+				}))
+				validation, _, _ := unstructured.NestedMap(policy.Object, "spec", "validation")
+				Expect(validation).To(Equal(map[string]interface{}{
+					"caCertificateRefs": []interface{}{
+						map[string]interface{}{"group": "", "kind": "ConfigMap", "name": "peer1-operations-backend-ca"},
+					},
+					"hostname": "namespace-peer1-operations.domain",
+				}))
+			})
+
+			It("returns an error if the CA certificates of an HTTPRoute's endpoint are not found", func() {
+				mockKubeClient.GetStub = nil
+				mockKubeClient.GetReturns(notFoundErr)
+				err := manager.Reconcile(instance, false)
+				Expect(err).To(MatchError(ContainSubstring("no CA certificates found")))
+				Expect(mockKubeClient.CreateCallCount()).To(Equal(2))
+			})
+		})
+
+		When("routes exist", func() {
+			BeforeEach(func() {
+				exists = true
+			})
+
+			It("updates the routes on spec updates", func() {
+				err := manager.Reconcile(instance, true)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockKubeClient.CreateCallCount()).To(Equal(0))
+				Expect(mockKubeClient.UpdateCallCount()).To(Equal(3))
+			})
+
+			It("does not update the routes without spec updates", func() {
+				err := manager.Reconcile(instance, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockKubeClient.UpdateCallCount()).To(Equal(0))
+			})
+
+			It("updates the CA certificates of an HTTPRoute's endpoint when they change", func() {
+				cmCerts = "old certs"
+				err := manager.Reconcile(instance, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockKubeClient.UpdateCallCount()).To(Equal(1))
+
+				_, obj, _ := mockKubeClient.UpdateArgsForCall(0)
+				cm := obj.(*corev1.ConfigMap)
+				Expect(cm.Data).To(Equal(map[string]string{gatewayroute.CACertsKey: caCerts}))
+			})
+		})
+
+		It("returns an error if a route has no gateway to attach to", func() {
+			manager.RoutesFunc = func(metav1.Object) ([]gatewayroute.Route, error) {
+				return []gatewayroute.Route{{Kind: gatewayroute.TLSRouteKind, Service: "peer1", Port: 7051}}, nil
+			}
+			err := manager.Reconcile(instance, false)
+			Expect(err).To(MatchError(ContainSubstring("ingress.gateway.name must be set")))
+			Expect(mockKubeClient.CreateCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if an HTTPRoute has no backend TLS configuration", func() {
+			manager.RoutesFunc = func(metav1.Object) ([]gatewayroute.Route, error) {
+				return []gatewayroute.Route{{Kind: gatewayroute.HTTPRouteKind, Gateway: current.GatewayReference{Name: "gateway"}, Service: "peer1", Port: 9443}}, nil
+			}
+			err := manager.Reconcile(instance, false)
+			Expect(err).To(MatchError(ContainSubstring("has no backend TLS configuration")))
+			Expect(mockKubeClient.CreateCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if the definition does not match the kind of the route", func() {
+			manager.HTTPRouteFile = manager.TLSRouteFile
+			err := manager.Reconcile(instance, false)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not match route of kind 'HTTPRoute'"))
+		})
+	})
+
+	Context("deletes the routes", func() {
+		It("deletes every route of the instance and the backend TLS configuration of its HTTPRoutes", func() {
+			exists = true
+			err := manager.Delete(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.DeleteCallCount()).To(Equal(4))
+
+			_, obj, _ := mockKubeClient.DeleteArgsForCall(2)
+			Expect(obj.(*unstructured.Unstructured).GetKind()).To(Equal(gatewayroute.BackendTLSPolicyKind))
+			_, obj, _ = mockKubeClient.DeleteArgsForCall(3)
+			Expect(obj.GetName()).To(Equal("peer1-operations-backend-ca"))
+		})
+
+		It("ignores routes that do not exist", func() {
+			err := manager.Delete(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.DeleteCallCount()).To(Equal(0))
+		})
+
+		It("ignores clusters that do not serve the Gateway API", func() {
+			mockKubeClient.GetStub = nil
+			mockKubeClient.GetReturns(&meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: gatewayroute.GroupName, Kind: gatewayroute.TLSRouteKind}})
+			err := manager.Delete(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.DeleteCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -24,9 +24,10 @@ import (
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/configmap"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/deployment"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/ingress"
-	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/ingressv1beta1"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/orderernode"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/poddisruptionbudget"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/pv"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/pvc"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/role"
@@ -34,11 +35,12 @@ import (
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/route"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/service"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/serviceaccount"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/statefulset"
 	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
-	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -67,6 +69,18 @@ func (m *Manager) CreateDeploymentManager(name string, oFunc func(v1.Object, *ap
 	}
 }
 
+func (m *Manager) CreateStatefulSetManager(name string, oFunc func(v1.Object, *appsv1.Deployment, resources.Action) error, claimOFuncs map[string]func(v1.Object, *corev1.PersistentVolumeClaim, resources.Action) error, labelsFunc func(v1.Object) map[string]string, statefulSetFile string) *statefulset.Manager {
+	return &statefulset.Manager{
+		Client:             m.Client,
+		Scheme:             m.Scheme,
+		StatefulSetFile:    statefulSetFile,
+		LabelsFunc:         labelsFunc,
+		Name:               name,
+		OverrideFunc:       oFunc,
+		ClaimOverrideFuncs: claimOFuncs,
+	}
+}
+
 func (m *Manager) CreateServiceManager(name string, oFunc func(v1.Object, *corev1.Service, resources.Action) error, labelsFunc func(v1.Object) map[string]string, serviceFile string) *service.Manager {
 	return &service.Manager{
 		Client:       m.Client,
@@ -166,14 +180,15 @@ func (m *Manager) CreateIngressManager(suffix string, oFunc func(v1.Object, *net
 	}
 }
 
-func (m *Manager) CreateIngressv1beta1Manager(suffix string, oFunc func(v1.Object, *networkingv1beta1.Ingress, resources.Action) error, labelsFunc func(v1.Object) map[string]string, file string) resources.Manager {
-	return &ingressv1beta1.Manager{
-		Client:       m.Client,
-		Scheme:       m.Scheme,
-		IngressFile:  file,
-		Suffix:       suffix,
-		LabelsFunc:   labelsFunc,
-		OverrideFunc: oFunc,
+func (m *Manager) CreateGatewayRouteManager(routesFunc func(v1.Object) ([]gatewayroute.Route, error), labelsFunc func(v1.Object) map[string]string, tlsRouteFile, httpRouteFile, backendTLSPolicyFile string) resources.Manager {
+	return &gatewayroute.Manager{
+		Client:               m.Client,
+		Scheme:               m.Scheme,
+		TLSRouteFile:         tlsRouteFile,
+		HTTPRouteFile:        httpRouteFile,
+		BackendTLSPolicyFile: backendTLSPolicyFile,
+		LabelsFunc:           labelsFunc,
+		RoutesFunc:           routesFunc,
 	}
 }
 
@@ -186,3 +201,13 @@ func (m *Manager) CreateOrderernodeManager(suffix string, oFunc func(v1.Object,
 		OverrideFunc:    oFunc,
 	}
 }
+
+func (m *Manager) CreatePodDisruptionBudgetManager(nameFunc func(v1.Object) string, oFunc func(v1.Object, *policyv1.PodDisruptionBudget, resources.Action) error, labelsFunc func(v1.Object) map[string]string) resources.Manager {
+	return &poddisruptionbudget.Manager{
+		Client:       m.Client,
+		Scheme:       m.Scheme,
+		NameFunc:     nameFunc,
+		LabelsFunc:   labelsFunc,
+		OverrideFunc: oFunc,
+	}
+}
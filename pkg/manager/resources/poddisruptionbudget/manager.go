@@ -0,0 +1,208 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package poddisruptionbudget
+
+import (
+	"context"
+	"fmt"
+
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	"github.com/pkg/errors"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("poddisruptionbudget_manager")
+
+// Manager reconciles the pod disruption budget shared by a group of nodes, such as the
+// nodes of an orderer cluster or the peers of an organization
+type Manager struct {
+	Client k8sclient.Client
+	Scheme *runtime.Scheme
+
+	NameFunc     func(v1.Object) string
+	LabelsFunc   func(v1.Object) map[string]string
+	OverrideFunc func(v1.Object, *policyv1.PodDisruptionBudget, resources.Action) error
+}
+
+func (m *Manager) GetName(instance v1.Object) string {
+	return m.NameFunc(instance)
+}
+
+func (m *Manager) Reconcile(instance v1.Object, update bool) error {
+	name := m.GetName(instance)
+	pdb := &policyv1.PodDisruptionBudget{}
+	err := m.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.GetNamespace()}, pdb)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Info(fmt.Sprintf("Creating pod disruption budget '%s'", name))
+			pdb, err = m.GetPodDisruptionBudgetFromTemplate(instance)
+			if err != nil {
+				return err
+			}
+
+			_, err = m.setOwnerReference(instance, pdb)
+			if err != nil {
+				return err
+			}
+
+			err = m.Client.Create(context.TODO(), pdb)
+			if err != nil {
+				return err
+			}
+			return nil
+		}
+		return err
+	}
+
+	// The budget depends on the size of the group of nodes, which changes without the spec
+	// of the instance being updated
+	desired := pdb.DeepCopy()
+	if m.OverrideFunc != nil {
+		err = m.OverrideFunc(instance, desired, resources.Update)
+		if err != nil {
+			return operatorerrors.New(operatorerrors.InvalidPDBUpdateRequest, err.Error())
+		}
+	}
+
+	ownerAdded, err := m.setOwnerReference(instance, desired)
+	if err != nil {
+		return err
+	}
+
+	if ownerAdded || !equality.Semantic.DeepEqual(pdb.Spec, desired.Spec) {
+		log.Info(fmt.Sprintf("Updating pod disruption budget '%s'", name))
+		err = m.Client.Update(context.TODO(), desired)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setOwnerReference adds the instance to the owners of the budget. Every member of the group
+// owns the budget without being its controller, so that the budget is garbage collected once
+// the last member is deleted rather than with the member that created it. Returns true if the
+// owner references changed.
+func (m *Manager) setOwnerReference(instance v1.Object, pdb *policyv1.PodDisruptionBudget) (bool, error) {
+	if m.Scheme == nil {
+		return false, nil
+	}
+
+	owners := append([]v1.OwnerReference{}, pdb.GetOwnerReferences()...)
+	err := controllerutil.SetOwnerReference(instance, pdb, m.Scheme)
+	if err != nil {
+		return false, errors.Wrap(err, "owner reference error")
+	}
+
+	return !equality.Semantic.DeepEqual(owners, pdb.GetOwnerReferences()), nil
+}
+
+func (m *Manager) GetPodDisruptionBudgetFromTemplate(instance v1.Object) (*policyv1.PodDisruptionBudget, error) {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      m.GetName(instance),
+			Namespace: instance.GetNamespace(),
+			Labels:    m.LabelsFunc(instance),
+		},
+	}
+
+	return m.BasedOnCR(instance, pdb)
+}
+
+func (m *Manager) BasedOnCR(instance v1.Object, pdb *policyv1.PodDisruptionBudget) (*policyv1.PodDisruptionBudget, error) {
+	if m.OverrideFunc != nil {
+		err := m.OverrideFunc(instance, pdb, resources.Create)
+		if err != nil {
+			return nil, operatorerrors.New(operatorerrors.InvalidPDBCreateRequest, err.Error())
+		}
+	}
+
+	return pdb, nil
+}
+
+func (m *Manager) Get(instance v1.Object) (client.Object, error) {
+	if instance == nil {
+		return nil, nil // Instance has not been reconciled yet
+	}
+
+	name := m.GetName(instance)
+	pdb := &policyv1.PodDisruptionBudget{}
+	err := m.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.GetNamespace()}, pdb)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdb, nil
+}
+
+func (m *Manager) Exists(instance v1.Object) bool {
+	_, err := m.Get(instance)
+	if err != nil {
+		return false
+	}
+
+	return true
+}
+
+func (m *Manager) Delete(instance v1.Object) error {
+	pdb, err := m.Get(instance)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if pdb == nil {
+		return nil
+	}
+
+	err = m.Client.Delete(context.TODO(), pdb)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) CheckState(instance v1.Object) error {
+	// NO-OP
+	return nil
+}
+
+func (m *Manager) RestoreState(instance v1.Object) error {
+	// NO-OP
+	return nil
+}
+
+func (m *Manager) SetCustomName(name string) {
+	// NO-OP
+}
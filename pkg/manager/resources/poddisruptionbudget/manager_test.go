@@ -0,0 +1,225 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package poddisruptionbudget_test
+
+import (
+	"context"
+
+	"github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/poddisruptionbudget"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("PodDisruptionBudget manager", func() {
+	var (
+		mockKubeClient *mocks.Client
+		manager        *poddisruptionbudget.Manager
+		instance       metav1.Object
+		maxUnavailable int
+		scheme         *runtime.Scheme
+	)
+
+	owner := func(name string, uid types.UID) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "namespace",
+				UID:       uid,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		// The scheme knows the type of the owners used to test owner references
+		scheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		maxUnavailable = 1
+		mockKubeClient = &mocks.Client{}
+		manager = &poddisruptionbudget.Manager{
+			Client: mockKubeClient,
+			NameFunc: func(metav1.Object) string {
+				return "cluster1-pdb"
+			},
+			LabelsFunc: func(metav1.Object) map[string]string {
+				return map[string]string{"orderingservice": "cluster1"}
+			},
+			OverrideFunc: func(_ metav1.Object, pdb *policyv1.PodDisruptionBudget, _ resources.Action) error {
+				max := intstr.FromInt(maxUnavailable)
+				pdb.Spec.MaxUnavailable = &max
+				pdb.Spec.Selector = &metav1.LabelSelector{
+					MatchLabels: map[string]string{"orderingservice": "cluster1"},
+				}
+				return nil
+			},
+		}
+
+		instance = &metav1.ObjectMeta{
+			Name:      "cluster1node1",
+			Namespace: "namespace",
+		}
+	})
+
+	Context("reconciles the pod disruption budget", func() {
+		It("returns an error if the get request returns an error other than 'not found'", func() {
+			mockKubeClient.GetReturns(errors.New("connection refused"))
+			err := manager.Reconcile(instance, false)
+			Expect(err).To(MatchError("connection refused"))
+			Expect(mockKubeClient.CreateCallCount()).To(Equal(0))
+		})
+
+		When("pod disruption budget does not exist", func() {
+			BeforeEach(func() {
+				mockKubeClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, "not found"))
+			})
+
+			It("returns an error if override fails", func() {
+				manager.OverrideFunc = func(metav1.Object, *policyv1.PodDisruptionBudget, resources.Action) error {
+					return errors.New("override failed")
+				}
+				err := manager.Reconcile(instance, false)
+				Expect(err).To(MatchError(ContainSubstring("override failed")))
+			})
+
+			It("creates the pod disruption budget shared by the group", func() {
+				err := manager.Reconcile(instance, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockKubeClient.CreateCallCount()).To(Equal(1))
+				_, obj, _ := mockKubeClient.CreateArgsForCall(0)
+				pdb := obj.(*policyv1.PodDisruptionBudget)
+				Expect(pdb.Name).To(Equal("cluster1-pdb"))
+				Expect(pdb.Namespace).To(Equal("namespace"))
+				Expect(pdb.Labels).To(Equal(map[string]string{"orderingservice": "cluster1"}))
+				Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+			})
+
+			It("adds the instance as an owner that is not the controller of the budget", func() {
+				manager.Scheme = scheme
+				err := manager.Reconcile(owner("cluster1node1", "uid1"), false)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, obj, _ := mockKubeClient.CreateArgsForCall(0)
+				pdb := obj.(*policyv1.PodDisruptionBudget)
+				Expect(pdb.OwnerReferences).To(HaveLen(1))
+				Expect(pdb.OwnerReferences[0].Name).To(Equal("cluster1node1"))
+				Expect(pdb.OwnerReferences[0].Controller).To(BeNil())
+			})
+		})
+
+		When("pod disruption budget exists", func() {
+			BeforeEach(func() {
+				mockKubeClient.GetStub = func(ctx context.Context, key types.NamespacedName, obj client.Object) error {
+					o := obj.(*policyv1.PodDisruptionBudget)
+					max := intstr.FromInt(1)
+					o.Name = key.Name
+					o.Namespace = key.Namespace
+					o.Spec.MaxUnavailable = &max
+					o.Spec.Selector = &metav1.LabelSelector{
+						MatchLabels: map[string]string{"orderingservice": "cluster1"},
+					}
+					return nil
+				}
+			})
+
+			It("does not update the pod disruption budget if the budget is unchanged", func() {
+				err := manager.Reconcile(instance, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockKubeClient.UpdateCallCount()).To(Equal(0))
+			})
+
+			It("updates the pod disruption budget if the size of the group changed", func() {
+				maxUnavailable = 2
+				err := manager.Reconcile(instance, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockKubeClient.UpdateCallCount()).To(Equal(1))
+				_, obj, _ := mockKubeClient.UpdateArgsForCall(0)
+				pdb := obj.(*policyv1.PodDisruptionBudget)
+				Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(2))
+			})
+
+			It("adds every member of the group as an owner of the budget", func() {
+				manager.Scheme = scheme
+				getStub := mockKubeClient.GetStub
+				mockKubeClient.GetStub = func(ctx context.Context, key types.NamespacedName, obj client.Object) error {
+					err := getStub(ctx, key, obj)
+					isController := true
+					obj.SetOwnerReferences([]metav1.OwnerReference{
+						{APIVersion: "v1", Kind: "ConfigMap", Name: "cluster1node1", UID: "uid1", Controller: &isController},
+					})
+					return err
+				}
+
+				err := manager.Reconcile(owner("cluster1node2", "uid2"), false)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockKubeClient.UpdateCallCount()).To(Equal(1))
+				_, obj, _ := mockKubeClient.UpdateArgsForCall(0)
+				pdb := obj.(*policyv1.PodDisruptionBudget)
+				Expect(pdb.OwnerReferences).To(HaveLen(2))
+				Expect(pdb.OwnerReferences[1].Name).To(Equal("cluster1node2"))
+				Expect(pdb.OwnerReferences[1].Controller).To(BeNil())
+			})
+
+			It("does not update the budget if the instance already owns it", func() {
+				manager.Scheme = scheme
+				getStub := mockKubeClient.GetStub
+				mockKubeClient.GetStub = func(ctx context.Context, key types.NamespacedName, obj client.Object) error {
+					err := getStub(ctx, key, obj)
+					obj.SetOwnerReferences([]metav1.OwnerReference{
+						{APIVersion: "v1", Kind: "ConfigMap", Name: "cluster1node1", UID: "uid1"},
+					})
+					return err
+				}
+
+				err := manager.Reconcile(owner("cluster1node1", "uid1"), false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockKubeClient.UpdateCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("deletes the pod disruption budget", func() {
+		It("does not return an error if the pod disruption budget does not exist", func() {
+			mockKubeClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, "not found"))
+			err := manager.Delete(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.DeleteCallCount()).To(Equal(0))
+		})
+
+		It("deletes the pod disruption budget", func() {
+			err := manager.Delete(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.DeleteCallCount()).To(Equal(1))
+		})
+	})
+})
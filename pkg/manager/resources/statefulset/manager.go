@@ -0,0 +1,332 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package statefulset
+
+import (
+	"context"
+	"fmt"
+
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/deployment"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("statefulset_manager")
+
+type Manager struct {
+	Client          k8sclient.Client
+	Scheme          *runtime.Scheme
+	StatefulSetFile string
+	Name            string
+
+	LabelsFunc func(v1.Object) map[string]string
+	// OverrideFunc is the deployment override of the component, it is applied to the pod
+	// template and replicas of the stateful set
+	OverrideFunc func(v1.Object, *appsv1.Deployment, resources.Action) error
+	// ClaimOverrideFuncs are the overrides applied to the volume claim templates, keyed by the
+	// name of the volume claim template
+	ClaimOverrideFuncs map[string]func(v1.Object, *corev1.PersistentVolumeClaim, resources.Action) error
+}
+
+func (m *Manager) GetName(instance v1.Object) string {
+	return deployment.GetName(instance.GetName(), m.Name)
+}
+
+func (m *Manager) Reconcile(instance v1.Object, update bool) error {
+	name := m.GetName(instance)
+
+	sts := &appsv1.StatefulSet{}
+	err := m.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.GetNamespace()}, sts)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Info(fmt.Sprintf("Creating stateful set '%s'", name))
+			sts, err := m.GetStatefulSetBasedOnCRFromFile(instance)
+			if err != nil {
+				return err
+			}
+
+			err = m.BindExistingClaims(sts)
+			if err != nil {
+				return err
+			}
+
+			err = m.Client.Create(context.TODO(), sts, k8sclient.CreateOption{
+				Owner:  instance,
+				Scheme: m.Scheme,
+			})
+			if err != nil {
+				return err
+			}
+			return nil
+		}
+		return err
+	}
+
+	if update {
+		log.Info(fmt.Sprintf("Updating stateful set '%s'", name))
+		s := New(sts)
+		dep := s.PodDeployment()
+		err = m.OverrideFunc(instance, dep, resources.Update)
+		if err != nil {
+			return operatorerrors.New(operatorerrors.InvalidDeploymentUpdateRequest, err.Error())
+		}
+		s.SetFromPodDeployment(dep)
+
+		// Volume claim templates can't be updated, the pod volumes they provide
+		// must not be added back to the pod template
+		s.RemoveClaimedVolumes()
+
+		err = m.Client.Patch(context.TODO(), sts, nil, k8sclient.PatchOption{
+			Resilient: &k8sclient.ResilientPatch{
+				Retry:    3,
+				Into:     &appsv1.StatefulSet{},
+				Strategy: client.MergeFrom,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) GetStatefulSetBasedOnCRFromFile(instance v1.Object) (*appsv1.StatefulSet, error) {
+	sts, err := util.GetStatefulSetFromFile(m.StatefulSetFile)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("Error reading stateful set configuration file: %s", m.StatefulSetFile))
+		return nil, err
+	}
+
+	return m.BasedOnCR(instance, sts)
+}
+
+func (m *Manager) BasedOnCR(instance v1.Object, sts *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
+	s := New(sts)
+
+	if m.OverrideFunc != nil {
+		dep := s.PodDeployment()
+		err := m.OverrideFunc(instance, dep, resources.Create)
+		if err != nil {
+			return nil, operatorerrors.New(operatorerrors.InvalidDeploymentCreateRequest, err.Error())
+		}
+		s.SetFromPodDeployment(dep)
+	}
+
+	sts.Name = m.GetName(instance)
+	sts.Namespace = instance.GetNamespace()
+	sts.Spec.ServiceName = instance.GetName()
+
+	requiredLabels := m.LabelsFunc(instance)
+	labels := sts.Labels
+	if len(labels) == 0 {
+		labels = make(map[string]string)
+	}
+	for requiredKey, requiredElement := range requiredLabels {
+		labels[requiredKey] = requiredElement
+	}
+	s.SetLabels(labels, m.getSelectorLabels(instance))
+
+	for i := range sts.Spec.VolumeClaimTemplates {
+		claim := &sts.Spec.VolumeClaimTemplates[i]
+		if override, found := m.ClaimOverrideFuncs[claim.Name]; found {
+			err := override(instance, claim, resources.Create)
+			if err != nil {
+				return nil, operatorerrors.New(operatorerrors.InvalidPVCCreateRequest, err.Error())
+			}
+		}
+
+		if claim.Labels == nil {
+			claim.Labels = map[string]string{}
+		}
+		for requiredKey, requiredElement := range requiredLabels {
+			claim.Labels[requiredKey] = requiredElement
+		}
+	}
+
+	return sts, nil
+}
+
+// BindExistingClaims decides for every volume claim template whether the pod volume of the same
+// name or the claim template provides the volume. If the persistent volume claim referenced by the
+// pod volume exists, e.g. because the component ran as a deployment before, the pod volume is kept
+// and the claim template is dropped so that the data on the volume is not lost. Otherwise the
+// claim template creates the volume.
+func (m *Manager) BindExistingClaims(sts *appsv1.StatefulSet) error {
+	s := New(sts)
+
+	for _, name := range s.ClaimTemplateNames() {
+		volume := s.GetVolume(name)
+		if volume == nil || volume.PersistentVolumeClaim == nil {
+			s.RemoveVolume(name)
+			continue
+		}
+
+		claimName := volume.PersistentVolumeClaim.ClaimName
+		err := m.Client.Get(context.TODO(), types.NamespacedName{Name: claimName, Namespace: sts.Namespace}, &corev1.PersistentVolumeClaim{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				s.RemoveVolume(name)
+				continue
+			}
+			return errors.Wrapf(err, "failed to get persistent volume claim '%s'", claimName)
+		}
+
+		log.Info(fmt.Sprintf("Stateful set '%s' uses existing persistent volume claim '%s' for volume '%s'", sts.Name, claimName, name))
+		s.RemoveClaimTemplate(name)
+	}
+
+	return nil
+}
+
+func (m *Manager) CheckForSecretChange(instance v1.Object, secretName string, restartFunc func(string, *appsv1.Deployment) bool) error {
+	name := m.GetName(instance)
+
+	sts := &appsv1.StatefulSet{}
+	err := m.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.GetNamespace()}, sts)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	rv, err := util.GetResourceVerFromSecret(m.Client, secretName, instance.GetNamespace())
+	if err == nil && rv != "" {
+		s := New(sts)
+		dep := s.PodDeployment()
+
+		// Only if secret change is detected do we update stateful set env var with new resource version
+		changed := restartFunc(rv, dep)
+		if changed {
+			log.Info(fmt.Sprintf("Secret '%s' update detected, triggering stateful set restart for '%s'", secretName, instance.GetName()))
+			s.SetFromPodDeployment(dep)
+			err = m.Client.Update(context.TODO(), sts)
+			if err != nil {
+				return errors.Wrap(err, "failed to update stateful set with secret resource version")
+			}
+		}
+	}
+
+	return nil
+}
+
+// CheckState is a no-op, the stateful set is not compared against the expected state as
+// the pod volumes depend on the volume claims that existed when it was created
+func (m *Manager) CheckState(instance v1.Object) error {
+	return nil
+}
+
+func (m *Manager) RestoreState(instance v1.Object) error {
+	return nil
+}
+
+func (m *Manager) Get(instance v1.Object) (client.Object, error) {
+	if instance == nil {
+		return nil, nil // Instance has not been reconciled yet
+	}
+
+	name := m.GetName(instance)
+	sts := &appsv1.StatefulSet{}
+	err := m.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.GetNamespace()}, sts)
+	if err != nil {
+		return nil, err
+	}
+
+	return sts, nil
+}
+
+func (m *Manager) Exists(instance v1.Object) bool {
+	sts, err := m.Get(instance)
+	if err != nil || sts == nil {
+		return false
+	}
+
+	return true
+}
+
+func (m *Manager) Delete(instance v1.Object) error {
+	sts, err := m.Get(instance)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if sts == nil {
+		return nil
+	}
+
+	err = m.Client.Delete(context.TODO(), sts)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeploymentStatus returns the status of the stateful set in the form of a deployment status
+func (m *Manager) DeploymentStatus(instance v1.Object) (appsv1.DeploymentStatus, error) {
+	sts := &appsv1.StatefulSet{}
+	err := m.Client.Get(
+		context.TODO(),
+		types.NamespacedName{Name: m.GetName(instance), Namespace: instance.GetNamespace()},
+		sts,
+	)
+	if err != nil {
+		return appsv1.DeploymentStatus{}, err
+	}
+
+	return appsv1.DeploymentStatus{
+		ObservedGeneration:  sts.Status.ObservedGeneration,
+		Replicas:            sts.Status.Replicas,
+		UpdatedReplicas:     sts.Status.UpdatedReplicas,
+		ReadyReplicas:       sts.Status.ReadyReplicas,
+		AvailableReplicas:   sts.Status.ReadyReplicas,
+		UnavailableReplicas: sts.Status.Replicas - sts.Status.ReadyReplicas,
+		CollisionCount:      sts.Status.CollisionCount,
+	}, nil
+}
+
+func (m *Manager) SetCustomName(name string) {
+	// NO-OP
+}
+
+func (m *Manager) GetScheme() *runtime.Scheme {
+	return m.Scheme
+}
+
+func (m *Manager) getSelectorLabels(instance v1.Object) map[string]string {
+	return map[string]string{
+		"app": instance.GetName(),
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package statefulset_test
+
+import (
+	"context"
+
+	"github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/statefulset"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("StatefulSet manager", func() {
+	var (
+		mockKubeClient *mocks.Client
+		manager        *statefulset.Manager
+		instance       metav1.Object
+		existingClaims map[string]bool
+		existingSts    *appsv1.StatefulSet
+	)
+
+	BeforeEach(func() {
+		existingClaims = map[string]bool{}
+		existingSts = nil
+
+		mockKubeClient = &mocks.Client{}
+		mockKubeClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+			switch o := obj.(type) {
+			case *appsv1.StatefulSet:
+				if existingSts == nil {
+					return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+				}
+				existingSts.DeepCopyInto(o)
+			case *corev1.PersistentVolumeClaim:
+				if !existingClaims[nn.Name] {
+					return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+				}
+			}
+			return nil
+		}
+
+		manager = &statefulset.Manager{
+			StatefulSetFile: "../../../../definitions/peer/statefulset.yaml",
+			Client:          mockKubeClient,
+			OverrideFunc: func(object metav1.Object, d *appsv1.Deployment, action resources.Action) error {
+				d.Spec.Template.Spec.Volumes = append(d.Spec.Template.Spec.Volumes,
+					corev1.Volume{
+						Name: "fabric-peer-0",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "peer1-pvc"},
+						},
+					},
+					corev1.Volume{
+						Name: "db-data",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "peer1-statedb-pvc"},
+						},
+					},
+				)
+				return nil
+			},
+			ClaimOverrideFuncs: map[string]func(metav1.Object, *corev1.PersistentVolumeClaim, resources.Action) error{
+				"fabric-peer-0": func(object metav1.Object, pvc *corev1.PersistentVolumeClaim, action resources.Action) error {
+					pvc.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("10Gi")
+					return nil
+				},
+			},
+			LabelsFunc: func(metav1.Object) map[string]string {
+				return map[string]string{"app": "peer1"}
+			},
+		}
+
+		instance = &metav1.ObjectMeta{Name: "peer1", Namespace: "test"}
+	})
+
+	Context("stateful set does not exist", func() {
+		It("returns an error if fails to load default config", func() {
+			manager.StatefulSetFile = "bad.yaml"
+			err := manager.Reconcile(instance, false)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no such file or directory"))
+		})
+
+		It("returns an error if override deployment value fails", func() {
+			manager.OverrideFunc = func(metav1.Object, *appsv1.Deployment, resources.Action) error {
+				return errors.New("creation override failed")
+			}
+			err := manager.Reconcile(instance, false)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("creation override failed"))
+		})
+
+		It("creates the volumes from the claim templates", func() {
+			err := manager.Reconcile(instance, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mockKubeClient.CreateCallCount()).To(Equal(1))
+			_, obj, _ := mockKubeClient.CreateArgsForCall(0)
+			sts := obj.(*appsv1.StatefulSet)
+			Expect(sts.Name).To(Equal("peer1"))
+			Expect(sts.Spec.ServiceName).To(Equal("peer1"))
+			Expect(sts.Spec.Template.Spec.Volumes).To(BeEmpty())
+			Expect(statefulset.New(sts).ClaimTemplateNames()).To(Equal([]string{"fabric-peer-0", "db-data"}))
+
+			claim := statefulset.New(sts).GetClaimTemplate("fabric-peer-0")
+			Expect(claim.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("10Gi")))
+			Expect(claim.Labels).To(HaveKeyWithValue("app", "peer1"))
+		})
+
+		It("keeps the existing volume claims of a deployment that is migrated", func() {
+			existingClaims["peer1-pvc"] = true
+
+			err := manager.Reconcile(instance, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, obj, _ := mockKubeClient.CreateArgsForCall(0)
+			sts := obj.(*appsv1.StatefulSet)
+			Expect(len(sts.Spec.Template.Spec.Volumes)).To(Equal(1))
+			Expect(sts.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("peer1-pvc"))
+			Expect(statefulset.New(sts).ClaimTemplateNames()).To(Equal([]string{"db-data"}))
+		})
+	})
+
+	Context("stateful set exists", func() {
+		BeforeEach(func() {
+			var err error
+			existingSts, err = manager.GetStatefulSetBasedOnCRFromFile(instance)
+			Expect(err).NotTo(HaveOccurred())
+			statefulset.New(existingSts).RemoveClaimedVolumes()
+		})
+
+		It("returns an error if override deployment value fails", func() {
+			manager.OverrideFunc = func(metav1.Object, *appsv1.Deployment, resources.Action) error {
+				return errors.New("update override failed")
+			}
+			err := manager.Reconcile(instance, true)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("update override failed"))
+		})
+
+		It("does not add the volumes of the claim templates to the pod template on update", func() {
+			err := manager.Reconcile(instance, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mockKubeClient.PatchCallCount()).To(Equal(1))
+			_, obj, _, _ := mockKubeClient.PatchArgsForCall(0)
+			sts := obj.(*appsv1.StatefulSet)
+			Expect(sts.Spec.Template.Spec.Volumes).To(BeEmpty())
+		})
+
+		It("returns the status of the stateful set as a deployment status", func() {
+			existingSts.Status.Replicas = 1
+			existingSts.Status.ReadyReplicas = 0
+
+			status, err := manager.DeploymentStatus(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Replicas).To(Equal(int32(1)))
+			Expect(status.UnavailableReplicas).To(Equal(int32(1)))
+		})
+	})
+})
@@ -0,0 +1,138 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package statefulset
+
+import (
+	"fmt"
+
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/container"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func New(sts *appsv1.StatefulSet) *StatefulSet {
+	return &StatefulSet{
+		StatefulSet: sts,
+	}
+}
+
+type StatefulSet struct {
+	*appsv1.StatefulSet
+}
+
+// ClaimTemplateNames returns the names of the volume claim templates of the stateful set
+func (s *StatefulSet) ClaimTemplateNames() []string {
+	names := []string{}
+	for _, claim := range s.StatefulSet.Spec.VolumeClaimTemplates {
+		names = append(names, claim.Name)
+	}
+	return names
+}
+
+func (s *StatefulSet) GetClaimTemplate(name string) *corev1.PersistentVolumeClaim {
+	for i, claim := range s.StatefulSet.Spec.VolumeClaimTemplates {
+		if claim.Name == name {
+			return &s.StatefulSet.Spec.VolumeClaimTemplates[i]
+		}
+	}
+	return nil
+}
+
+func (s *StatefulSet) RemoveClaimTemplate(name string) {
+	claims := []corev1.PersistentVolumeClaim{}
+	for _, claim := range s.StatefulSet.Spec.VolumeClaimTemplates {
+		if claim.Name != name {
+			claims = append(claims, claim)
+		}
+	}
+	s.StatefulSet.Spec.VolumeClaimTemplates = claims
+}
+
+func (s *StatefulSet) GetVolume(name string) *corev1.Volume {
+	for i, volume := range s.StatefulSet.Spec.Template.Spec.Volumes {
+		if volume.Name == name {
+			return &s.StatefulSet.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	return nil
+}
+
+func (s *StatefulSet) RemoveVolume(name string) {
+	volumes := []corev1.Volume{}
+	for _, volume := range s.StatefulSet.Spec.Template.Spec.Volumes {
+		if volume.Name != name {
+			volumes = append(volumes, volume)
+		}
+	}
+	s.StatefulSet.Spec.Template.Spec.Volumes = volumes
+}
+
+// RemoveClaimedVolumes removes the pod volumes that are provided by the volume claim
+// templates, a pod volume with the same name as a claim template is rejected by the API
+func (s *StatefulSet) RemoveClaimedVolumes() {
+	for _, name := range s.ClaimTemplateNames() {
+		s.RemoveVolume(name)
+	}
+}
+
+// PodDeployment returns a deployment that shares the pod template and replicas of the stateful
+// set, it allows the deployment overrides of a component to be applied to the stateful set.
+// Changes made to the deployment are copied back using SetFromPodDeployment.
+func (s *StatefulSet) PodDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: *s.StatefulSet.ObjectMeta.DeepCopy(),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: s.StatefulSet.Spec.Replicas,
+			Selector: s.StatefulSet.Spec.Selector,
+			Template: *s.StatefulSet.Spec.Template.DeepCopy(),
+		},
+	}
+}
+
+func (s *StatefulSet) SetFromPodDeployment(dep *appsv1.Deployment) {
+	s.StatefulSet.Spec.Replicas = dep.Spec.Replicas
+	s.StatefulSet.Spec.Template = dep.Spec.Template
+}
+
+func (s *StatefulSet) SetLabels(labels map[string]string, selectorLabels map[string]string) {
+	s.StatefulSet.Labels = labels
+	s.StatefulSet.Spec.Template.Labels = labels
+	s.StatefulSet.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: selectorLabels,
+	}
+}
+
+// UpdateSecurityContextForAllContainers updates the security context for all containers defined
+// in the stateful set
+func (s *StatefulSet) UpdateSecurityContextForAllContainers(sc container.SecurityContext) {
+	for i := range s.Spec.Template.Spec.InitContainers {
+		container.UpdateSecurityContext(&s.Spec.Template.Spec.InitContainers[i], sc)
+	}
+
+	for i := range s.Spec.Template.Spec.Containers {
+		container.UpdateSecurityContext(&s.Spec.Template.Spec.Containers[i], sc)
+	}
+}
+
+// ClaimName returns the name of the persistent volume claim that is created from a volume
+// claim template for the pod with the given ordinal
+func ClaimName(claimTemplate, statefulSetName string, ordinal int) string {
+	return fmt.Sprintf("%s-%s-%d", claimTemplate, statefulSetName, ordinal)
+}
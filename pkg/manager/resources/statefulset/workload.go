@@ -0,0 +1,120 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package statefulset
+
+import (
+	"fmt"
+
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//go:generate counterfeiter -o mocks/workload.go -fake-name Workload . Workload
+
+// Workload defines the contract of the managers of the workload that runs a component
+type Workload interface {
+	resources.Manager
+	CheckForSecretChange(v1.Object, string, func(string, *appsv1.Deployment) bool) error
+	DeploymentStatus(v1.Object) (appsv1.DeploymentStatus, error)
+	GetScheme() *runtime.Scheme
+}
+
+// WorkloadManager manages a component that runs either as a deployment or as a stateful set.
+// A component that is switched to a stateful set has its deployment replaced, the persistent
+// volume claims used by the deployment are owned by the instance and are bound by the stateful
+// set when it gets created.
+type WorkloadManager struct {
+	Deployment  Workload
+	StatefulSet Workload
+
+	UseStatefulSet func(v1.Object) bool
+}
+
+func (w *WorkloadManager) workload(instance v1.Object) Workload {
+	if w.UseStatefulSet(instance) {
+		return w.StatefulSet
+	}
+	return w.Deployment
+}
+
+func (w *WorkloadManager) Reconcile(instance v1.Object, update bool) error {
+	if !w.UseStatefulSet(instance) {
+		if w.StatefulSet.Exists(instance) {
+			return errors.Errorf("'%s' runs as a stateful set, switching back to a deployment is not supported", instance.GetName())
+		}
+		return w.Deployment.Reconcile(instance, update)
+	}
+
+	if w.Deployment.Exists(instance) {
+		log.Info(fmt.Sprintf("Replacing deployment '%s' with stateful set", w.Deployment.GetName(instance)))
+		err := w.Deployment.Delete(instance)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete deployment")
+		}
+	}
+
+	return w.StatefulSet.Reconcile(instance, update)
+}
+
+func (w *WorkloadManager) CheckState(instance v1.Object) error {
+	return w.workload(instance).CheckState(instance)
+}
+
+func (w *WorkloadManager) RestoreState(instance v1.Object) error {
+	return w.workload(instance).RestoreState(instance)
+}
+
+func (w *WorkloadManager) Exists(instance v1.Object) bool {
+	return w.workload(instance).Exists(instance)
+}
+
+func (w *WorkloadManager) Get(instance v1.Object) (client.Object, error) {
+	return w.workload(instance).Get(instance)
+}
+
+func (w *WorkloadManager) Delete(instance v1.Object) error {
+	return w.workload(instance).Delete(instance)
+}
+
+func (w *WorkloadManager) GetName(instance v1.Object) string {
+	return w.workload(instance).GetName(instance)
+}
+
+// SetCustomName sets the custom name on both workload managers, so that it is applied
+// regardless of the workload type the instance runs as
+func (w *WorkloadManager) SetCustomName(name string) {
+	w.Deployment.SetCustomName(name)
+	w.StatefulSet.SetCustomName(name)
+}
+
+func (w *WorkloadManager) CheckForSecretChange(instance v1.Object, secretName string, restartFunc func(string, *appsv1.Deployment) bool) error {
+	return w.workload(instance).CheckForSecretChange(instance, secretName, restartFunc)
+}
+
+func (w *WorkloadManager) DeploymentStatus(instance v1.Object) (appsv1.DeploymentStatus, error) {
+	return w.workload(instance).DeploymentStatus(instance)
+}
+
+func (w *WorkloadManager) GetScheme() *runtime.Scheme {
+	return w.Deployment.GetScheme()
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package statefulset_test
+
+import (
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/statefulset"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/statefulset/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Workload manager", func() {
+	var (
+		deploymentMgr  *mocks.Workload
+		statefulSetMgr *mocks.Workload
+		manager        *statefulset.WorkloadManager
+		instance       metav1.Object
+		useSts         bool
+	)
+
+	BeforeEach(func() {
+		deploymentMgr = &mocks.Workload{}
+		statefulSetMgr = &mocks.Workload{}
+		useSts = false
+
+		manager = &statefulset.WorkloadManager{
+			Deployment:  deploymentMgr,
+			StatefulSet: statefulSetMgr,
+			UseStatefulSet: func(metav1.Object) bool {
+				return useSts
+			},
+		}
+
+		instance = &metav1.ObjectMeta{Name: "peer1", Namespace: "test"}
+	})
+
+	It("reconciles the deployment by default", func() {
+		err := manager.Reconcile(instance, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deploymentMgr.ReconcileCallCount()).To(Equal(1))
+		Expect(statefulSetMgr.ReconcileCallCount()).To(Equal(0))
+	})
+
+	It("returns an error when switching a stateful set back to a deployment", func() {
+		statefulSetMgr.ExistsReturns(true)
+		err := manager.Reconcile(instance, true)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("switching back to a deployment is not supported"))
+		Expect(deploymentMgr.ReconcileCallCount()).To(Equal(0))
+	})
+
+	Context("stateful set", func() {
+		BeforeEach(func() {
+			useSts = true
+		})
+
+		It("reconciles the stateful set", func() {
+			err := manager.Reconcile(instance, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deploymentMgr.DeleteCallCount()).To(Equal(0))
+			Expect(statefulSetMgr.ReconcileCallCount()).To(Equal(1))
+		})
+
+		It("replaces an existing deployment", func() {
+			deploymentMgr.ExistsReturns(true)
+			err := manager.Reconcile(instance, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deploymentMgr.DeleteCallCount()).To(Equal(1))
+			Expect(statefulSetMgr.ReconcileCallCount()).To(Equal(1))
+		})
+
+		It("returns an error if the deployment can't be deleted", func() {
+			deploymentMgr.ExistsReturns(true)
+			deploymentMgr.DeleteReturns(errors.New("delete error"))
+			err := manager.Reconcile(instance, false)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("failed to delete deployment: delete error"))
+			Expect(statefulSetMgr.ReconcileCallCount()).To(Equal(0))
+		})
+
+		It("uses the stateful set for status checks", func() {
+			_, _ = manager.DeploymentStatus(instance)
+			Expect(statefulSetMgr.DeploymentStatusCallCount()).To(Equal(1))
+			Expect(deploymentMgr.DeploymentStatusCallCount()).To(Equal(0))
+		})
+	})
+
+	It("sets the custom name on both workload managers", func() {
+		manager.SetCustomName("custom")
+		Expect(deploymentMgr.SetCustomNameArgsForCall(0)).To(Equal("custom"))
+		Expect(statefulSetMgr.SetCustomNameArgsForCall(0)).To(Equal("custom"))
+	})
+})
@@ -0,0 +1,111 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "ibp_operator"
+
+var (
+	// CertificateExpiryTimestamp is the time the signcert of a component expires,
+	// in seconds since the epoch
+	CertificateExpiryTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "certificate_expiry_timestamp_seconds",
+		Help:      "Unix time at which the signcert of a component expires",
+	}, []string{"namespace", "name", "type"})
+
+	// RestartRequestsTotal is the number of restarts requested for a component,
+	// labelled by the reason of the restart
+	RestartRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "restart_requests_total",
+		Help:      "Number of restarts requested for a component",
+	}, []string{"namespace", "name", "reason"})
+
+	// StaggerRestartQueueDepth is the number of components waiting in the stagger
+	// restart queue of an organization
+	StaggerRestartQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "stagger_restart_queue_depth",
+		Help:      "Number of components waiting in the stagger restart queue",
+	}, []string{"namespace", "component", "mspid"})
+
+	// ReconcileResultsTotal is the number of reconciles of the custom resources,
+	// errors are labelled by their operator error code
+	ReconcileResultsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconcile_results_total",
+		Help:      "Number of reconciles by result and operator error code",
+	}, []string{"controller", "result", "code"})
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(
+		CertificateExpiryTimestamp,
+		RestartRequestsTotal,
+		StaggerRestartQueueDepth,
+		ReconcileResultsTotal,
+	)
+}
+
+// SetCertificateExpiry records the time the certificate expires
+func SetCertificateExpiry(namespace, name, certType string, expireDate time.Time) {
+	CertificateExpiryTimestamp.WithLabelValues(namespace, name, certType).Set(float64(expireDate.Unix()))
+}
+
+// IncRestartRequests counts a restart requested for the component
+func IncRestartRequests(namespace, name, reason string) {
+	RestartRequestsTotal.WithLabelValues(namespace, name, reason).Inc()
+}
+
+// SetStaggerRestartQueueDepth records the length of the restart queue of every
+// organization, dropping queues that no longer exist
+func SetStaggerRestartQueueDepth(namespace, component string, queueLengths map[string]int) {
+	StaggerRestartQueueDepth.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "component": component})
+	for mspid, length := range queueLengths {
+		StaggerRestartQueueDepth.WithLabelValues(namespace, component, mspid).Set(float64(length))
+	}
+}
+
+// DeleteInstance drops the series of a component that has been deleted
+func DeleteInstance(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	CertificateExpiryTimestamp.DeletePartialMatch(labels)
+	RestartRequestsTotal.DeletePartialMatch(labels)
+}
+
+// RecordReconcile counts the result of a reconcile, errors without an operator
+// error code are recorded with code "0"
+func RecordReconcile(controller string, err error) {
+	if err == nil {
+		ReconcileResultsTotal.WithLabelValues(controller, "success", "").Inc()
+		return
+	}
+
+	code := strconv.Itoa(operatorerrors.GetErrorCode(err))
+	ReconcileResultsTotal.WithLabelValues(controller, "error", code).Inc()
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics_test
+
+import (
+	"time"
+
+	"github.com/IBM-Blockchain/fabric-operator/pkg/metrics"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Metrics", func() {
+	Context("certificate expiry", func() {
+		It("records the time the certificate expires", func() {
+			expireDate := time.Now().Add(time.Hour)
+			metrics.SetCertificateExpiry("ns", "peer1", "tls", expireDate)
+			timestamp := testutil.ToFloat64(metrics.CertificateExpiryTimestamp.WithLabelValues("ns", "peer1", "tls"))
+			Expect(timestamp).To(Equal(float64(expireDate.Unix())))
+		})
+	})
+
+	Context("delete instance", func() {
+		It("drops the series of the deleted component", func() {
+			metrics.SetCertificateExpiry("ns", "orderer1", "tls", time.Now())
+			metrics.SetCertificateExpiry("ns", "orderer1", "ecert", time.Now())
+			metrics.SetCertificateExpiry("ns", "orderer2", "tls", time.Now())
+			metrics.IncRestartRequests("ns", "orderer1", "tlsUpdate")
+
+			metrics.DeleteInstance("ns", "orderer1")
+			Expect(metrics.CertificateExpiryTimestamp.DeleteLabelValues("ns", "orderer1", "tls")).To(BeFalse())
+			Expect(metrics.CertificateExpiryTimestamp.DeleteLabelValues("ns", "orderer1", "ecert")).To(BeFalse())
+			Expect(metrics.RestartRequestsTotal.DeleteLabelValues("ns", "orderer1", "tlsUpdate")).To(BeFalse())
+			Expect(metrics.CertificateExpiryTimestamp.DeleteLabelValues("ns", "orderer2", "tls")).To(BeTrue())
+		})
+	})
+
+	Context("restart requests", func() {
+		It("counts restart requests by reason", func() {
+			metrics.IncRestartRequests("ns", "peer1", "tlsUpdate")
+			metrics.IncRestartRequests("ns", "peer1", "tlsUpdate")
+			Expect(testutil.ToFloat64(metrics.RestartRequestsTotal.WithLabelValues("ns", "peer1", "tlsUpdate"))).To(Equal(float64(2)))
+		})
+	})
+
+	Context("stagger restart queue depth", func() {
+		It("drops queues that no longer exist", func() {
+			metrics.SetStaggerRestartQueueDepth("ns", "peer", map[string]int{"org1": 2, "org2": 1})
+			Expect(testutil.ToFloat64(metrics.StaggerRestartQueueDepth.WithLabelValues("ns", "peer", "org1"))).To(Equal(float64(2)))
+
+			metrics.SetStaggerRestartQueueDepth("ns", "peer", map[string]int{"org2": 0})
+			Expect(testutil.CollectAndCount(metrics.StaggerRestartQueueDepth)).To(Equal(1))
+		})
+	})
+
+	Context("reconcile results", func() {
+		It("labels errors with the operator error code", func() {
+			metrics.RecordReconcile("ibppeer", nil)
+			metrics.RecordReconcile("ibppeer", errors.New("failed"))
+			metrics.RecordReconcile("ibppeer", operatorerrors.New(operatorerrors.InvalidPeerInitSpec, "invalid"))
+
+			Expect(testutil.ToFloat64(metrics.ReconcileResultsTotal.WithLabelValues("ibppeer", "success", ""))).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(metrics.ReconcileResultsTotal.WithLabelValues("ibppeer", "error", "0"))).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(metrics.ReconcileResultsTotal.WithLabelValues("ibppeer", "error", "15"))).To(Equal(float64(1)))
+		})
+	})
+})
@@ -16,7 +16,7 @@
  * limitations under the License.
  */
 
-package ingressv1beta1_test
+package ingress_test
 
 import (
 	"testing"
@@ -25,7 +25,7 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-func TestDeployment(t *testing.T) {
+func TestIngress(t *testing.T) {
 	RegisterFailHandler(Fail)
-	RunSpecs(t, "Deployment Suite")
+	RunSpecs(t, "Ingress Migrator Suite")
 }
@@ -0,0 +1,224 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/pkg/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("ingress_migrator")
+
+// ownerKinds are the kinds of the custom resources whose ingresses are migrated
+var ownerKinds = map[string]bool{
+	"IBPCA":      true,
+	"IBPPeer":    true,
+	"IBPOrderer": true,
+	"IBPConsole": true,
+}
+
+// Migrator converts the networking.k8s.io/v1beta1 ingresses of the components to
+// networking.k8s.io/v1 ingresses, which are the only ingresses the operator manages
+type Migrator struct {
+	Client k8sclient.Client
+	Reader client.Reader
+}
+
+func New(client k8sclient.Client, reader client.Reader) *Migrator {
+	return &Migrator{
+		Client: client,
+		Reader: reader,
+	}
+}
+
+// Migrate converts the v1beta1 ingresses owned by custom resources in the namespace. Nothing
+// is migrated on clusters that no longer serve networking.k8s.io/v1beta1 ingresses.
+func (m *Migrator) Migrate(namespace string) error {
+	ingressList := &networkingv1beta1.IngressList{}
+	err := m.Reader.List(context.TODO(), ingressList, client.InNamespace(namespace))
+	if err != nil {
+		if meta.IsNoMatchError(err) || k8serrors.IsNotFound(err) {
+			log.Info("networking.k8s.io/v1beta1 ingresses are not served, no ingresses to migrate")
+			return nil
+		}
+		return errors.Wrap(err, "failed to list v1beta1 ingresses")
+	}
+
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		if !IsOwnedByComponent(ingress) {
+			continue
+		}
+
+		err = m.MigrateIngress(ingress)
+		if err != nil {
+			return errors.Wrapf(err, "failed to migrate ingress '%s'", ingress.GetName())
+		}
+	}
+
+	return nil
+}
+
+// MigrateIngress converts a v1beta1 ingress to a v1 ingress. Clusters that serve both versions
+// store a single object, it only needs the ingress class set in the spec as the v1 ingress
+// manager does.
+func (m *Migrator) MigrateIngress(ingress *networkingv1beta1.Ingress) error {
+	nn := types.NamespacedName{Name: ingress.GetName(), Namespace: ingress.GetNamespace()}
+
+	existing := &networkingv1.Ingress{}
+	err := m.Reader.Get(context.TODO(), nn, existing)
+	if err == nil {
+		if existing.Spec.IngressClassName != nil {
+			return nil
+		}
+
+		ingressClass := existing.Annotations["kubernetes.io/ingress.class"]
+		if ingressClass == "" {
+			return nil
+		}
+
+		log.Info(fmt.Sprintf("Setting ingress class of ingress '%s' to '%s'", ingress.GetName(), ingressClass))
+		existing.Spec.IngressClassName = &ingressClass
+		return m.Client.Update(context.TODO(), existing)
+	}
+	if meta.IsNoMatchError(err) {
+		return errors.New("networking.k8s.io/v1 ingresses are not served, Kubernetes 1.19 or later is required")
+	}
+	if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Converting ingress '%s' to networking.k8s.io/v1", ingress.GetName()))
+	err = m.Client.Delete(context.TODO(), ingress)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "failed to delete v1beta1 ingress")
+	}
+
+	err = m.Client.Create(context.TODO(), ConvertIngress(ingress))
+	if err != nil {
+		return errors.Wrap(err, "failed to create v1 ingress")
+	}
+
+	return nil
+}
+
+// IsOwnedByComponent returns true if the ingress is owned by a CA, peer, orderer or console
+func IsOwnedByComponent(obj metav1.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if strings.HasPrefix(ref.APIVersion, current.GroupVersion.Group+"/") && ownerKinds[ref.Kind] {
+			return true
+		}
+	}
+	return false
+}
+
+// ConvertIngress converts a v1beta1 ingress to a v1 ingress with identical hosts, paths and TLS
+func ConvertIngress(ingress *networkingv1beta1.Ingress) *networkingv1.Ingress {
+	converted := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            ingress.GetName(),
+			Namespace:       ingress.GetNamespace(),
+			Labels:          ingress.GetLabels(),
+			Annotations:     ingress.GetAnnotations(),
+			OwnerReferences: ingress.GetOwnerReferences(),
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: ingress.Spec.IngressClassName,
+		},
+	}
+
+	if converted.Spec.IngressClassName == nil {
+		if ingressClass := ingress.Annotations["kubernetes.io/ingress.class"]; ingressClass != "" {
+			converted.Spec.IngressClassName = &ingressClass
+		}
+	}
+
+	if ingress.Spec.Backend != nil {
+		converted.Spec.DefaultBackend = convertBackend(*ingress.Spec.Backend)
+	}
+
+	for _, tls := range ingress.Spec.TLS {
+		converted.Spec.TLS = append(converted.Spec.TLS, networkingv1.IngressTLS{
+			Hosts:      tls.Hosts,
+			SecretName: tls.SecretName,
+		})
+	}
+
+	for _, rule := range ingress.Spec.Rules {
+		convertedRule := networkingv1.IngressRule{
+			Host: rule.Host,
+		}
+
+		if rule.HTTP != nil {
+			convertedRule.HTTP = &networkingv1.HTTPIngressRuleValue{}
+			for _, path := range rule.HTTP.Paths {
+				pathType := networkingv1.PathTypeImplementationSpecific
+				if path.PathType != nil {
+					pathType = networkingv1.PathType(*path.PathType)
+				}
+
+				convertedRule.HTTP.Paths = append(convertedRule.HTTP.Paths, networkingv1.HTTPIngressPath{
+					Path:     path.Path,
+					PathType: &pathType,
+					Backend:  *convertBackend(path.Backend),
+				})
+			}
+		}
+
+		converted.Spec.Rules = append(converted.Spec.Rules, convertedRule)
+	}
+
+	return converted
+}
+
+func convertBackend(backend networkingv1beta1.IngressBackend) *networkingv1.IngressBackend {
+	if backend.Resource != nil {
+		return &networkingv1.IngressBackend{
+			Resource: backend.Resource,
+		}
+	}
+
+	port := networkingv1.ServiceBackendPort{}
+	if backend.ServicePort.Type == intstr.Int {
+		port.Number = backend.ServicePort.IntVal
+	} else {
+		port.Name = backend.ServicePort.StrVal
+	}
+
+	return &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: backend.ServiceName,
+			Port: port,
+		},
+	}
+}
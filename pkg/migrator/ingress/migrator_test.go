@@ -0,0 +1,162 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ingress_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/IBM-Blockchain/fabric-operator/pkg/controller/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/migrator/ingress"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Ingress migrator", func() {
+	var (
+		migrator       *ingress.Migrator
+		mockKubeClient *mocks.Client
+		betaIngress    networkingv1beta1.Ingress
+	)
+
+	BeforeEach(func() {
+		mockKubeClient = &mocks.Client{}
+
+		pathType := networkingv1beta1.PathTypeImplementationSpecific
+		betaIngress = networkingv1beta1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "peer1",
+				Namespace: "ns1",
+				Annotations: map[string]string{
+					"kubernetes.io/ingress.class": "nginx",
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "ibp.com/v1beta1",
+						Kind:       "IBPPeer",
+						Name:       "peer1",
+					},
+				},
+			},
+			Spec: networkingv1beta1.IngressSpec{
+				TLS: []networkingv1beta1.IngressTLS{
+					{Hosts: []string{"peer1.domain"}},
+				},
+				Rules: []networkingv1beta1.IngressRule{
+					{
+						Host: "peer1.domain",
+						IngressRuleValue: networkingv1beta1.IngressRuleValue{
+							HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+								Paths: []networkingv1beta1.HTTPIngressPath{
+									{
+										Path:     "/",
+										PathType: &pathType,
+										Backend: networkingv1beta1.IngressBackend{
+											ServiceName: "peer1",
+											ServicePort: intstr.FromString("peer-api"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		mockKubeClient.ListStub = func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+			switch obj := obj.(type) {
+			case *networkingv1beta1.IngressList:
+				obj.Items = []networkingv1beta1.Ingress{betaIngress}
+			}
+			return nil
+		}
+		mockKubeClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+			return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+		}
+
+		migrator = ingress.New(mockKubeClient, mockKubeClient)
+	})
+
+	Context("migrate", func() {
+		It("returns an error if unable to list ingresses", func() {
+			mockKubeClient.ListReturns(k8serrors.NewInternalError(context.DeadlineExceeded))
+			mockKubeClient.ListStub = nil
+			err := migrator.Migrate("ns1")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to list v1beta1 ingresses"))
+		})
+
+		It("skips ingresses not owned by a component", func() {
+			betaIngress.OwnerReferences = nil
+			err := migrator.Migrate("ns1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.CreateCallCount()).To(Equal(0))
+			Expect(mockKubeClient.DeleteCallCount()).To(Equal(0))
+		})
+
+		It("replaces a v1beta1 ingress with a v1 ingress", func() {
+			err := migrator.Migrate("ns1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.DeleteCallCount()).To(Equal(1))
+			Expect(mockKubeClient.CreateCallCount()).To(Equal(1))
+
+			_, obj, _ := mockKubeClient.CreateArgsForCall(0)
+			created := obj.(*networkingv1.Ingress)
+			Expect(created.Name).To(Equal("peer1"))
+			Expect(created.OwnerReferences).To(Equal(betaIngress.OwnerReferences))
+		})
+
+		It("sets the ingress class of an ingress already served as v1", func() {
+			mockKubeClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+				obj.SetAnnotations(betaIngress.Annotations)
+				return nil
+			}
+			err := migrator.Migrate("ns1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.DeleteCallCount()).To(Equal(0))
+			Expect(mockKubeClient.UpdateCallCount()).To(Equal(1))
+
+			_, obj, _ := mockKubeClient.UpdateArgsForCall(0)
+			Expect(*obj.(*networkingv1.Ingress).Spec.IngressClassName).To(Equal("nginx"))
+		})
+	})
+
+	Context("convert ingress", func() {
+		It("keeps hosts, TLS and backends", func() {
+			converted := ingress.ConvertIngress(&betaIngress)
+			Expect(*converted.Spec.IngressClassName).To(Equal("nginx"))
+			Expect(converted.Spec.TLS[0].Hosts).To(Equal([]string{"peer1.domain"}))
+			Expect(converted.Spec.Rules[0].Host).To(Equal("peer1.domain"))
+
+			path := converted.Spec.Rules[0].HTTP.Paths[0]
+			Expect(path.Path).To(Equal("/"))
+			Expect(path.Backend.Service.Name).To(Equal("peer1"))
+			Expect(path.Backend.Service.Port.Name).To(Equal("peer-api"))
+		})
+	})
+})
@@ -19,9 +19,13 @@
 package migrator
 
 import (
+	"os"
+
 	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/global"
 	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/migrator/ingress"
+	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -48,8 +52,12 @@ func New(mgr manager.Manager, cfg *config.Config, namespace string) *Migrator {
 }
 
 func (m *Migrator) Migrate() error {
-
-	// No-op
+	// Ingresses are migrated in the watched namespace, or in all namespaces if the operator
+	// is cluster scoped
+	err := ingress.New(m.Client, m.Reader).Migrate(os.Getenv("WATCH_NAMESPACE"))
+	if err != nil {
+		return errors.Wrap(err, "failed to migrate v1beta1 ingresses")
+	}
 
 	return nil
 }
@@ -84,8 +84,17 @@ func (m *Migrate) MigrationNeeded(instance metav1.Object) bool {
 	var deploymentUpdated bool
 	var configUpdated bool
 
-	dep := obj.(*appsv1.Deployment)
-	for _, cont := range dep.Spec.Template.Spec.Containers {
+	var template corev1.PodTemplateSpec
+	switch workload := obj.(type) {
+	case *appsv1.Deployment:
+		template = workload.Spec.Template
+	case *appsv1.StatefulSet:
+		template = workload.Spec.Template
+	default:
+		return false
+	}
+
+	for _, cont := range template.Spec.Containers {
 		if strings.ToLower(cont.Name) == "dind" {
 			// DinD container found, instance is not at v2
 			deploymentUpdated = false
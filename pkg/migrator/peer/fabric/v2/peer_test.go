@@ -144,6 +144,31 @@ var _ = Describe("V2 peer migrator", func() {
 			Expect(needed).To(Equal(true))
 		})
 
+		It("returns true if config map not updated for a peer running as a stateful set", func() {
+			sts := &appsv1.StatefulSet{
+				Spec: appsv1.StatefulSetSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name: "peer",
+								},
+							},
+						},
+					},
+				},
+			}
+			deploymentManager.GetReturns(sts, nil)
+
+			needed := migrator.MigrationNeeded(instance)
+			Expect(needed).To(Equal(true))
+		})
+
+		It("returns false if the workload type is unknown", func() {
+			deploymentManager.GetReturns(&corev1.Pod{}, nil)
+			needed := migrator.MigrationNeeded(instance)
+			Expect(needed).To(Equal(false))
+		})
 	})
 
 	Context("upgrade dbs peer", func() {
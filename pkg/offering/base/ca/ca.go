@@ -38,7 +38,9 @@ import (
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
 	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
 	cav1 "github.com/IBM-Blockchain/fabric-operator/pkg/apis/ca/v1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/backup"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/certificate"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/events"
 	initializer "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/ca"
 	commonconfig "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/config"
 	controllerclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
@@ -52,6 +54,7 @@ import (
 	"github.com/IBM-Blockchain/fabric-operator/pkg/util/pointer"
 	"github.com/IBM-Blockchain/fabric-operator/version"
 	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/record"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
@@ -80,6 +83,7 @@ type Override interface {
 	RoleBinding(v1.Object, *rbacv1.RoleBinding, resources.Action) error
 	ServiceAccount(v1.Object, *corev1.ServiceAccount, resources.Action) error
 	IsPostgres(instance *current.IBPCA) bool
+	IsRemoteDB(instance *current.IBPCA) bool
 }
 
 //go:generate counterfeiter -o mocks/update.go -fake-name Update . Update
@@ -155,18 +159,24 @@ type CA struct {
 	RenewCertTimers    map[string]*time.Timer
 
 	Restart RestartManager
+
+	Restorer Restorer
+
+	Recorder record.EventRecorder
 }
 
-func New(client controllerclient.Client, scheme *runtime.Scheme, config *config.Config, o Override) *CA {
+func New(client controllerclient.Client, scheme *runtime.Scheme, config *config.Config, recorder record.EventRecorder, o Override) *CA {
 	ca := &CA{
 		Client:   client,
 		Scheme:   scheme,
 		Config:   config,
 		Override: o,
+		Recorder: recorder,
+		Restorer: backup.New(client, scheme, config.Operator.Backup.Images),
 	}
 	ca.CreateManagers()
 	ca.Initializer = NewInitializer(config.CAInitConfig, scheme, client, ca.GetLabels, config.Operator.CA.Timeouts.HSMInitJob)
-	ca.Restart = restart.New(client, config.Operator.Restart.WaitTime.Get(), config.Operator.Restart.Timeout.Get())
+	ca.Restart = restart.New(client, recorder, config.Operator.Restart.WaitTime.Get(), config.Operator.Restart.Timeout.Get())
 	ca.CertificateManager = &certificate.CertificateManager{
 		Client: client,
 		Scheme: scheme,
@@ -181,7 +191,7 @@ func (ca *CA) CreateManagers() {
 	resourceManager := resourcemanager.New(ca.Client, ca.Scheme)
 	ca.DeploymentManager = resourceManager.CreateDeploymentManager("", override.Deployment, ca.GetLabels, ca.Config.CAInitConfig.DeploymentFile)
 	ca.ServiceManager = resourceManager.CreateServiceManager("", override.Service, ca.GetLabels, ca.Config.CAInitConfig.ServiceFile)
-	ca.PVCManager = resourceManager.CreatePVCManager("", override.PVC, ca.GetLabels, ca.Config.CAInitConfig.PVCFile)
+	ca.PVCManager = resourceManager.CreatePVCManager("", ca.RestorePVC(override.PVC), ca.GetLabels, ca.Config.CAInitConfig.PVCFile)
 	ca.RoleManager = resourceManager.CreateRoleManager("", override.Role, ca.GetLabels, ca.Config.CAInitConfig.RoleFile)
 	ca.RoleBindingManager = resourceManager.CreateRoleBindingManager("", override.RoleBinding, ca.GetLabels, ca.Config.CAInitConfig.RoleBindingFile)
 	ca.ServiceAccountManager = resourceManager.CreateServiceAccountManager("", override.ServiceAccount, ca.GetLabels, ca.Config.CAInitConfig.ServiceAccountFile)
@@ -239,6 +249,23 @@ func (ca *CA) Reconcile(instance *current.IBPCA, update Update) (common.Result,
 		return common.Result{}, operatorerrors.Wrap(err, operatorerrors.CAInitilizationFailed, "failed to initialize ca")
 	}
 
+	restored, err := ca.RestoreVolumes(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to restore volumes")
+	}
+	if !restored {
+		return common.Result{
+			Result: reconcile.Result{
+				RequeueAfter: RestoreRequeueInterval,
+			},
+			Status: &current.CRStatus{
+				Type:    current.Deploying,
+				Reason:  "restoringVolumes",
+				Message: fmt.Sprintf("Restoring volumes from backup '%s'", instance.Spec.RestoreFrom.Backup),
+			},
+		}, nil
+	}
+
 	err = ca.ReconcileManagers(instance, update)
 	if err != nil {
 		return common.Result{}, errors.Wrap(err, "failed to reconcile managers")
@@ -407,6 +434,7 @@ func (ca *CA) Initialize(instance *current.IBPCA, update Update) error {
 		if err != nil {
 			return err
 		}
+		events.Normal(ca.Recorder, instance, events.Initialized, "Initialized enrollment CA crypto material and config")
 	}
 
 	tresp, err := ca.Initializer.HandleTLSCAInit(instance, update)
@@ -419,6 +447,7 @@ func (ca *CA) Initialize(instance *current.IBPCA, update Update) error {
 		if err != nil {
 			return err
 		}
+		events.Normal(ca.Recorder, instance, events.Initialized, "Initialized TLS CA crypto material and config")
 	}
 
 	// If deployment exists, and configoverride update detected need to restart pod(s) to pick up
@@ -468,7 +497,7 @@ func (ca *CA) ReconcileManagers(instance *current.IBPCA, updated Update) error {
 
 	update := updated.SpecUpdated()
 
-	if !ca.Override.IsPostgres(instance) {
+	if !ca.Override.IsRemoteDB(instance) {
 		log.Info("Using sqlite database, creating pvc...")
 		ca.PVCManager.SetCustomName(instance.Spec.CustomNames.PVC.CA)
 		err = ca.PVCManager.Reconcile(instance, update)
@@ -961,9 +990,11 @@ func (ca *CA) HandleActions(instance *current.IBPCA, update Update) error {
 
 		if err := ca.RenewCert(instance, ca.GetEndpointsDNS(instance)); err != nil {
 			log.Error(err, "Resetting action flag on failure")
+			events.Warning(ca.Recorder, instance, events.CertificateRenewalFailed, "Failed to renew TLS certificate: %s", err)
 			instance.ResetTLSRenew()
 			return err
 		}
+		events.Normal(ca.Recorder, instance, events.CertificateRenewed, "Renewed TLS certificate")
 		instance.ResetTLSRenew()
 	}
 
@@ -1040,5 +1071,7 @@ func (ca *CA) ReconcileFabricCAMigration(instance *current.IBPCA) error {
 	if err != nil {
 		return err
 	}
+	events.Normal(ca.Recorder, instance, events.Migrated, "Migrated config map '%s'", cmname)
+
 	return nil
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/ca/config"
 	caconfig "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/ca/config"
 	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -136,6 +137,13 @@ func (i *Initialize) CreateEnrollmentCAConfig(instance *current.IBPCA) (*initial
 		}
 	}
 
+	if instance.Spec.RestoreFrom != nil {
+		caOverrides, err = i.RestoreCrypto(instance, caOverrides)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to restore crypto")
+		}
+	}
+
 	resp, err := i.Initializer.Create(instance, caOverrides, sca)
 	if err != nil {
 		return nil, err
@@ -174,6 +182,34 @@ func (i *Initialize) UpdateEnrollmentCAConfig(instance *current.IBPCA) (*initial
 	return resp, nil
 }
 
+// RestoreCrypto sets the CA and TLS certificates and keys of the enrollment CA from the
+// crypto backup that the CA is restored from, the TLS CA is not part of the crypto backup
+// and is initialized as usual
+func (i *Initialize) RestoreCrypto(instance *current.IBPCA, caOverrides *cav1.ServerConfig) (*cav1.ServerConfig, error) {
+	crypto, err := common.GetRestoreCrypto(i.Client, instance, instance.Spec.RestoreFrom)
+	if err != nil {
+		return nil, err
+	}
+	if crypto.CA == nil {
+		return nil, fmt.Errorf("crypto backup '%s' does not contain CA crypto", instance.Spec.RestoreFrom.GetCryptoBackupSecret(instance.GetName()))
+	}
+
+	log.Info(fmt.Sprintf("Restoring crypto of CA '%s' from crypto backup '%s'", instance.GetName(), instance.Spec.RestoreFrom.GetCryptoBackupSecret(instance.GetName())))
+	if caOverrides == nil {
+		caOverrides = &cav1.ServerConfig{}
+	}
+
+	caOverrides.CAConfig.CA.Certfile = crypto.CA.SignCerts
+	caOverrides.CAConfig.CA.Keyfile = crypto.CA.KeyStore
+
+	if crypto.TLS != nil {
+		caOverrides.TLS.CertFile = crypto.TLS.SignCerts
+		caOverrides.TLS.KeyFile = crypto.TLS.KeyStore
+	}
+
+	return caOverrides, nil
+}
+
 func (i *Initialize) GetEnrollmentInitCA(instance *current.IBPCA, data []byte) (*initializer.CA, error) {
 	serverConfig := &cav1.ServerConfig{}
 	err := yaml.Unmarshal(data, serverConfig)
@@ -19,6 +19,9 @@
 package baseca_test
 
 import (
+	"context"
+	"encoding/json"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -29,10 +32,14 @@ import (
 	initializer "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/ca"
 	baseca "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/ca"
 	basecamocks "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/ca/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
 
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("Initialize CA", func() {
@@ -127,6 +134,54 @@ var _ = Describe("Initialize CA", func() {
 			_, err := cainit.CreateEnrollmentCAConfig(instance)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		Context("restored from a backup", func() {
+			BeforeEach(func() {
+				instance.Name = "ca1"
+				instance.Spec.RestoreFrom = &current.RestoreFrom{
+					Backup: "nightly",
+				}
+
+				caBackup, err := json.Marshal(&common.Backup{
+					List: []*current.MSP{{SignCerts: "cacert", KeyStore: "cakey"}},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				tlsBackup, err := json.Marshal(&common.Backup{
+					List: []*current.MSP{{SignCerts: "tlscert", KeyStore: "tlskey"}},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				mockClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+					switch obj.(type) {
+					case *corev1.Secret:
+						Expect(nn.Name).To(Equal("ca1-crypto-backup"))
+						o := obj.(*corev1.Secret)
+						o.Data = map[string][]byte{
+							"ca-backup.json":  caBackup,
+							"tls-backup.json": tlsBackup,
+						}
+					}
+					return nil
+				}
+			})
+
+			It("creates config with the crypto of the backup", func() {
+				_, err := cainit.CreateEnrollmentCAConfig(instance)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, caOverrides, _ := mockinitializer.CreateArgsForCall(0)
+				Expect(caOverrides.CAConfig.CA.Certfile).To(Equal("cacert"))
+				Expect(caOverrides.CAConfig.CA.Keyfile).To(Equal("cakey"))
+				Expect(caOverrides.TLS.CertFile).To(Equal("tlscert"))
+				Expect(caOverrides.TLS.KeyFile).To(Equal("tlskey"))
+			})
+
+			It("returns an error if the crypto backup does not contain CA crypto", func() {
+				mockClient.GetStub = nil
+				_, err := cainit.CreateEnrollmentCAConfig(instance)
+				Expect(err).To(MatchError("failed to restore crypto: crypto backup 'ca1-crypto-backup' does not contain CA crypto"))
+			})
+		})
 	})
 
 	Context("update enrollment ca's config", func() {
@@ -176,6 +231,30 @@ var _ = Describe("Initialize CA", func() {
 		})
 	})
 
+	Context("sync db config", func() {
+		It("uses the mysql database of the enrollment CA for the TLS CA", func() {
+			jm, err := util.ConvertToJsonMessage(&cav1.ServerConfig{
+				CAConfig: cav1.CAConfig{
+					DB: &cav1.CAConfigDB{
+						Type:       "mysql",
+						Datasource: "db:db@tcp(0.0.0.0:3306)/fabric?parseTime=true",
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			instance.Spec.ConfigOverride.CA = &runtime.RawExtension{Raw: *jm}
+
+			synced, err := cainit.SyncDBConfig(instance)
+			Expect(err).NotTo(HaveOccurred())
+
+			tlsca := &cav1.ServerConfig{}
+			err = json.Unmarshal(synced.Spec.ConfigOverride.TLSCA.Raw, tlsca)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tlsca.CAConfig.DB.Type).To(Equal("mysql"))
+			Expect(tlsca.CAConfig.DB.Datasource).To(Equal("db:db@tcp(0.0.0.0:3306)/fabric?parseTime=true"))
+		})
+	})
+
 	Context("merge crypto", func() {
 		var (
 			oldCrypto = map[string][]byte{}
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -87,7 +88,7 @@ func (o *Override) CreateDeployment(instance *current.IBPCA, k8sDep *appsv1.Depl
 
 	deployment.SetImagePullSecrets(instance.Spec.ImagePullSecrets)
 
-	if !o.IsPostgres(instance) {
+	if !o.IsRemoteDB(instance) {
 		claimName := instance.Name + "-pvc"
 		if instance.Spec.CustomNames.PVC.CA != "" {
 			claimName = instance.Spec.CustomNames.PVC.CA
@@ -167,7 +168,7 @@ func (o *Override) CommonDeployment(instance *current.IBPCA, deployment *dep.Dep
 		initCont.SetImage(image.CAInitImage, image.CAInitTag)
 	}
 
-	if o.IsPostgres(instance) {
+	if o.IsRemoteDB(instance) {
 		deployment.SetStrategy(appsv1.RollingUpdateDeploymentStrategyType)
 	}
 
@@ -234,8 +235,9 @@ func (o *Override) ValidateServerConfig(byteArray *[]byte, configType string) er
 	}
 
 	if overrides.DB != nil {
-		if overrides.DB.Type != "postgres" {
-			return errors.New(fmt.Sprintf("DB Type in %s config override should be `postgres` to allow replicas > 1", configType))
+		dbType := strings.ToLower(overrides.DB.Type)
+		if dbType != "postgres" && dbType != "mysql" {
+			return errors.New(fmt.Sprintf("DB Type in %s config override should be `postgres` or `mysql` to allow replicas > 1", configType))
 		}
 
 		if overrides.DB.Datasource == "" {
@@ -415,6 +415,38 @@ var _ = Describe("Deployment Overrides", func() {
 					})
 				})
 			})
+
+			When("using mysql", func() {
+				BeforeEach(func() {
+					caConfig := &v1.ServerConfig{
+						CAConfig: v1.CAConfig{
+							DB: &v1.CAConfigDB{
+								Type: "mysql",
+							},
+						},
+					}
+
+					caConfigJson, err := util.ConvertToJsonMessage(caConfig)
+					Expect(err).NotTo(HaveOccurred())
+					instance.Spec.ConfigOverride = &current.ConfigOverride{
+						CA: &runtime.RawExtension{Raw: *caConfigJson},
+					}
+				})
+
+				It("does not create a PVC volume and sets strategy to rolling update", func() {
+					err := overrider.Deployment(instance, deployment, resources.Create)
+					Expect(err).NotTo(HaveOccurred())
+
+					for _, volume := range deployment.Spec.Template.Spec.Volumes {
+						Expect(volume.Name).NotTo(Equal("fabric-ca"))
+					}
+					Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+						Name:      "shared",
+						MountPath: "/data",
+					}))
+					Expect(deployment.Spec.Strategy.Type).To(Equal(appsv1.RollingUpdateDeploymentStrategyType))
+				})
+			})
 		})
 
 		Context("replicas is greater than 1", func() {
@@ -446,7 +478,7 @@ var _ = Describe("Deployment Overrides", func() {
 				}
 				err = overrider.Deployment(instance, deployment, resources.Create)
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("DB Type in CA config override should be `postgres` to allow replicas > 1"))
+				Expect(err.Error()).To(Equal("DB Type in CA config override should be `postgres` or `mysql` to allow replicas > 1"))
 			})
 
 			It("returns an error if datasource is empty in CA override", func() {
@@ -519,7 +551,7 @@ var _ = Describe("Deployment Overrides", func() {
 				}
 				err = overrider.Deployment(instance, deployment, resources.Create)
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("DB Type in TLSCA config override should be `postgres` to allow replicas > 1"))
+				Expect(err.Error()).To(Equal("DB Type in TLSCA config override should be `postgres` or `mysql` to allow replicas > 1"))
 			})
 
 			It("returns an error if datasource is empty in TLSCA override", func() {
@@ -667,7 +699,7 @@ var _ = Describe("Deployment Overrides", func() {
 			}
 			err = overrider.Deployment(instance, deployment, resources.Update)
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(Equal("DB Type in CA config override should be `postgres` to allow replicas > 1"))
+			Expect(err.Error()).To(Equal("DB Type in CA config override should be `postgres` or `mysql` to allow replicas > 1"))
 		})
 
 		It("returns an error if datasource is empty in CA override", func() {
@@ -751,7 +783,7 @@ var _ = Describe("Deployment Overrides", func() {
 
 			err = overrider.Deployment(instance, deployment, resources.Update)
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(Equal("DB Type in TLSCA config override should be `postgres` to allow replicas > 1"))
+			Expect(err.Error()).To(Equal("DB Type in TLSCA config override should be `postgres` or `mysql` to allow replicas > 1"))
 		})
 
 		It("returns an error if datasource is empty in TLSCA override", func() {
@@ -35,6 +35,20 @@ type Override struct {
 }
 
 func (o *Override) IsPostgres(instance *current.IBPCA) bool {
+	return o.usesDB(instance, "postgres")
+}
+
+func (o *Override) IsMySQL(instance *current.IBPCA) bool {
+	return o.usesDB(instance, "mysql")
+}
+
+// IsRemoteDB returns true if the CA stores its data in a database outside of the
+// CA pod, in which case no persistent volume is required and replicas can be scaled
+func (o *Override) IsRemoteDB(instance *current.IBPCA) bool {
+	return o.IsPostgres(instance) || o.IsMySQL(instance)
+}
+
+func (o *Override) usesDB(instance *current.IBPCA, dbType string) bool {
 	if instance.Spec.ConfigOverride != nil {
 		if instance.Spec.ConfigOverride.CA != nil {
 			caOverrides := &v1.ServerConfig{}
@@ -44,7 +58,7 @@ func (o *Override) IsPostgres(instance *current.IBPCA) bool {
 			}
 
 			if caOverrides.DB != nil {
-				if strings.ToLower(caOverrides.DB.Type) == "postgres" {
+				if strings.ToLower(caOverrides.DB.Type) == dbType {
 					return true
 				}
 			}
@@ -58,7 +72,7 @@ func (o *Override) IsPostgres(instance *current.IBPCA) bool {
 			}
 
 			if tlscaOverrides.DB != nil {
-				if strings.ToLower(tlscaOverrides.DB.Type) == "postgres" {
+				if strings.ToLower(tlscaOverrides.DB.Type) == dbType {
 					return true
 				}
 			}
@@ -92,7 +106,7 @@ func (o *Override) GetNodeAffinity(instance *current.IBPCA) *corev1.NodeAffinity
 	}
 	common.AddArchSelector(arch, &nodeSelectorTerms)
 
-	if !o.IsPostgres(instance) {
+	if !o.IsRemoteDB(instance) {
 		common.AddZoneSelector(zone, &nodeSelectorTerms)
 		common.AddRegionSelector(region, &nodeSelectorTerms)
 	}
@@ -144,7 +158,7 @@ func (o *Override) GetPodAntiAffinity(instance *current.IBPCA) *corev1.PodAntiAf
 		},
 	}
 
-	if o.IsPostgres(instance) {
+	if o.IsRemoteDB(instance) {
 		term := corev1.WeightedPodAffinityTerm{
 			Weight: 100,
 			PodAffinityTerm: corev1.PodAffinityTerm{
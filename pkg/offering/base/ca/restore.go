@@ -0,0 +1,86 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package baseca
+
+import (
+	"fmt"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RestoreRequeueInterval is the interval at which CAs whose volume is being restored
+// are reconciled again
+const RestoreRequeueInterval = 10 * time.Second
+
+//go:generate counterfeiter -o mocks/restorer.go -fake-name Restorer . Restorer
+
+type Restorer interface {
+	RestorePVC(v1.Object, *current.RestoreFrom, *corev1.PersistentVolumeClaim) error
+	RestoreVolume(v1.Object, *current.RestoreFrom, string) (bool, error)
+}
+
+// RestorePVC wraps the override of the persistent volume claim so that the claim of a
+// CA being restored is provisioned from the snapshot of its backup
+func (ca *CA) RestorePVC(override func(v1.Object, *corev1.PersistentVolumeClaim, resources.Action) error) func(v1.Object, *corev1.PersistentVolumeClaim, resources.Action) error {
+	return func(object v1.Object, pvc *corev1.PersistentVolumeClaim, action resources.Action) error {
+		err := override(object, pvc, action)
+		if err != nil {
+			return err
+		}
+
+		instance := object.(*current.IBPCA)
+		if action != resources.Create || instance.Spec.RestoreFrom == nil {
+			return nil
+		}
+
+		return ca.Restorer.RestorePVC(instance, instance.Spec.RestoreFrom, pvc)
+	}
+}
+
+// RestoreVolumes creates the volume of a CA being restored and restores the archive of
+// its backup into it, returns true once the volume is restored. The volume is only
+// restored before the deployment of the CA is created, and only if the CA uses the
+// sqlite database stored on the volume.
+func (ca *CA) RestoreVolumes(instance *current.IBPCA) (bool, error) {
+	if instance.Spec.RestoreFrom == nil || ca.DeploymentManager.Exists(instance) || ca.Override.IsRemoteDB(instance) {
+		return true, nil
+	}
+
+	ca.PVCManager.SetCustomName(instance.Spec.CustomNames.PVC.CA)
+	err := ca.PVCManager.Reconcile(instance, false)
+	if err != nil {
+		return false, errors.Wrap(err, "failed PVC reconciliation")
+	}
+
+	restored, err := ca.Restorer.RestoreVolume(instance, instance.Spec.RestoreFrom, ca.PVCManager.GetName(instance))
+	if err != nil {
+		return false, err
+	}
+
+	if !restored {
+		log.Info(fmt.Sprintf("Waiting for volume of CA '%s' to be restored from backup '%s'", instance.GetName(), instance.Spec.RestoreFrom.Backup))
+	}
+
+	return restored, nil
+}
@@ -39,6 +39,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	k8sruntime "sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -104,15 +105,18 @@ type Console struct {
 	Override Override
 
 	Restart RestartManager
+
+	Recorder record.EventRecorder
 }
 
-func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config, o Override) *Console {
+func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config, recorder record.EventRecorder, o Override) *Console {
 	console := &Console{
 		Client:   client,
 		Scheme:   scheme,
 		Config:   config,
 		Override: o,
-		Restart:  restart.New(client, config.Operator.Restart.WaitTime.Get(), config.Operator.Restart.Timeout.Get()),
+		Recorder: recorder,
+		Restart:  restart.New(client, recorder, config.Operator.Restart.WaitTime.Get(), config.Operator.Restart.Timeout.Get()),
 	}
 
 	console.CreateManagers()
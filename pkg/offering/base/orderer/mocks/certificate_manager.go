@@ -45,6 +45,21 @@ type CertificateManager struct {
 		result1 time.Duration
 		result2 error
 	}
+	GetMissingHostsStub        func(common.SecretType, v1.Object, []string) ([]string, error)
+	getMissingHostsMutex       sync.RWMutex
+	getMissingHostsArgsForCall []struct {
+		arg1 common.SecretType
+		arg2 v1.Object
+		arg3 []string
+	}
+	getMissingHostsReturns struct {
+		result1 []string
+		result2 error
+	}
+	getMissingHostsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	GetSignCertStub        func(string, string) ([]byte, error)
 	getSignCertMutex       sync.RWMutex
 	getSignCertArgsForCall []struct {
@@ -59,6 +74,24 @@ type CertificateManager struct {
 		result1 []byte
 		result2 error
 	}
+	ReenrollTLSCertStub        func(v1.Object, *v1beta1.EnrollmentSpec, string, bool) ([]byte, []byte, error)
+	reenrollTLSCertMutex       sync.RWMutex
+	reenrollTLSCertArgsForCall []struct {
+		arg1 v1.Object
+		arg2 *v1beta1.EnrollmentSpec
+		arg3 string
+		arg4 bool
+	}
+	reenrollTLSCertReturns struct {
+		result1 []byte
+		result2 []byte
+		result3 error
+	}
+	reenrollTLSCertReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 []byte
+		result3 error
+	}
 	RenewCertStub        func(common.SecretType, certificate.Instance, *v1beta1.EnrollmentSpec, *commona.BCCSP, string, bool, bool) error
 	renewCertMutex       sync.RWMutex
 	renewCertArgsForCall []struct {
@@ -76,6 +109,32 @@ type CertificateManager struct {
 	renewCertReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateKeyStub        func(string, []byte, v1.Object) error
+	updateKeyMutex       sync.RWMutex
+	updateKeyArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+		arg3 v1.Object
+	}
+	updateKeyReturns struct {
+		result1 error
+	}
+	updateKeyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateSignCertStub        func(string, []byte, v1.Object) error
+	updateSignCertMutex       sync.RWMutex
+	updateSignCertArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+		arg3 v1.Object
+	}
+	updateSignCertReturns struct {
+		result1 error
+	}
+	updateSignCertReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -87,15 +146,16 @@ func (fake *CertificateManager) CheckCertificatesForExpire(arg1 v1.Object, arg2
 		arg1 v1.Object
 		arg2 int64
 	}{arg1, arg2})
+	stub := fake.CheckCertificatesForExpireStub
+	fakeReturns := fake.checkCertificatesForExpireReturns
 	fake.recordInvocation("CheckCertificatesForExpire", []interface{}{arg1, arg2})
 	fake.checkCertificatesForExpireMutex.Unlock()
-	if fake.CheckCertificatesForExpireStub != nil {
-		return fake.CheckCertificatesForExpireStub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
 	}
-	fakeReturns := fake.checkCertificatesForExpireReturns
 	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
 }
 
@@ -155,15 +215,16 @@ func (fake *CertificateManager) GetDurationToNextRenewal(arg1 common.SecretType,
 		arg2 v1.Object
 		arg3 int64
 	}{arg1, arg2, arg3})
+	stub := fake.GetDurationToNextRenewalStub
+	fakeReturns := fake.getDurationToNextRenewalReturns
 	fake.recordInvocation("GetDurationToNextRenewal", []interface{}{arg1, arg2, arg3})
 	fake.getDurationToNextRenewalMutex.Unlock()
-	if fake.GetDurationToNextRenewalStub != nil {
-		return fake.GetDurationToNextRenewalStub(arg1, arg2, arg3)
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.getDurationToNextRenewalReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -212,6 +273,76 @@ func (fake *CertificateManager) GetDurationToNextRenewalReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
+func (fake *CertificateManager) GetMissingHosts(arg1 common.SecretType, arg2 v1.Object, arg3 []string) ([]string, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.getMissingHostsMutex.Lock()
+	ret, specificReturn := fake.getMissingHostsReturnsOnCall[len(fake.getMissingHostsArgsForCall)]
+	fake.getMissingHostsArgsForCall = append(fake.getMissingHostsArgsForCall, struct {
+		arg1 common.SecretType
+		arg2 v1.Object
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("GetMissingHosts", []interface{}{arg1, arg2, arg3Copy})
+	fake.getMissingHostsMutex.Unlock()
+	if fake.GetMissingHostsStub != nil {
+		return fake.GetMissingHostsStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getMissingHostsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CertificateManager) GetMissingHostsCallCount() int {
+	fake.getMissingHostsMutex.RLock()
+	defer fake.getMissingHostsMutex.RUnlock()
+	return len(fake.getMissingHostsArgsForCall)
+}
+
+func (fake *CertificateManager) GetMissingHostsCalls(stub func(common.SecretType, v1.Object, []string) ([]string, error)) {
+	fake.getMissingHostsMutex.Lock()
+	defer fake.getMissingHostsMutex.Unlock()
+	fake.GetMissingHostsStub = stub
+}
+
+func (fake *CertificateManager) GetMissingHostsArgsForCall(i int) (common.SecretType, v1.Object, []string) {
+	fake.getMissingHostsMutex.RLock()
+	defer fake.getMissingHostsMutex.RUnlock()
+	argsForCall := fake.getMissingHostsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CertificateManager) GetMissingHostsReturns(result1 []string, result2 error) {
+	fake.getMissingHostsMutex.Lock()
+	defer fake.getMissingHostsMutex.Unlock()
+	fake.GetMissingHostsStub = nil
+	fake.getMissingHostsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CertificateManager) GetMissingHostsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.getMissingHostsMutex.Lock()
+	defer fake.getMissingHostsMutex.Unlock()
+	fake.GetMissingHostsStub = nil
+	if fake.getMissingHostsReturnsOnCall == nil {
+		fake.getMissingHostsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.getMissingHostsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CertificateManager) GetSignCert(arg1 string, arg2 string) ([]byte, error) {
 	fake.getSignCertMutex.Lock()
 	ret, specificReturn := fake.getSignCertReturnsOnCall[len(fake.getSignCertArgsForCall)]
@@ -219,15 +350,16 @@ func (fake *CertificateManager) GetSignCert(arg1 string, arg2 string) ([]byte, e
 		arg1 string
 		arg2 string
 	}{arg1, arg2})
+	stub := fake.GetSignCertStub
+	fakeReturns := fake.getSignCertReturns
 	fake.recordInvocation("GetSignCert", []interface{}{arg1, arg2})
 	fake.getSignCertMutex.Unlock()
-	if fake.GetSignCertStub != nil {
-		return fake.GetSignCertStub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.getSignCertReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -276,6 +408,76 @@ func (fake *CertificateManager) GetSignCertReturnsOnCall(i int, result1 []byte,
 	}{result1, result2}
 }
 
+func (fake *CertificateManager) ReenrollTLSCert(arg1 v1.Object, arg2 *v1beta1.EnrollmentSpec, arg3 string, arg4 bool) ([]byte, []byte, error) {
+	fake.reenrollTLSCertMutex.Lock()
+	ret, specificReturn := fake.reenrollTLSCertReturnsOnCall[len(fake.reenrollTLSCertArgsForCall)]
+	fake.reenrollTLSCertArgsForCall = append(fake.reenrollTLSCertArgsForCall, struct {
+		arg1 v1.Object
+		arg2 *v1beta1.EnrollmentSpec
+		arg3 string
+		arg4 bool
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ReenrollTLSCertStub
+	fakeReturns := fake.reenrollTLSCertReturns
+	fake.recordInvocation("ReenrollTLSCert", []interface{}{arg1, arg2, arg3, arg4})
+	fake.reenrollTLSCertMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *CertificateManager) ReenrollTLSCertCallCount() int {
+	fake.reenrollTLSCertMutex.RLock()
+	defer fake.reenrollTLSCertMutex.RUnlock()
+	return len(fake.reenrollTLSCertArgsForCall)
+}
+
+func (fake *CertificateManager) ReenrollTLSCertCalls(stub func(v1.Object, *v1beta1.EnrollmentSpec, string, bool) ([]byte, []byte, error)) {
+	fake.reenrollTLSCertMutex.Lock()
+	defer fake.reenrollTLSCertMutex.Unlock()
+	fake.ReenrollTLSCertStub = stub
+}
+
+func (fake *CertificateManager) ReenrollTLSCertArgsForCall(i int) (v1.Object, *v1beta1.EnrollmentSpec, string, bool) {
+	fake.reenrollTLSCertMutex.RLock()
+	defer fake.reenrollTLSCertMutex.RUnlock()
+	argsForCall := fake.reenrollTLSCertArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *CertificateManager) ReenrollTLSCertReturns(result1 []byte, result2 []byte, result3 error) {
+	fake.reenrollTLSCertMutex.Lock()
+	defer fake.reenrollTLSCertMutex.Unlock()
+	fake.ReenrollTLSCertStub = nil
+	fake.reenrollTLSCertReturns = struct {
+		result1 []byte
+		result2 []byte
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *CertificateManager) ReenrollTLSCertReturnsOnCall(i int, result1 []byte, result2 []byte, result3 error) {
+	fake.reenrollTLSCertMutex.Lock()
+	defer fake.reenrollTLSCertMutex.Unlock()
+	fake.ReenrollTLSCertStub = nil
+	if fake.reenrollTLSCertReturnsOnCall == nil {
+		fake.reenrollTLSCertReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 []byte
+			result3 error
+		})
+	}
+	fake.reenrollTLSCertReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 []byte
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *CertificateManager) RenewCert(arg1 common.SecretType, arg2 certificate.Instance, arg3 *v1beta1.EnrollmentSpec, arg4 *commona.BCCSP, arg5 string, arg6 bool, arg7 bool) error {
 	fake.renewCertMutex.Lock()
 	ret, specificReturn := fake.renewCertReturnsOnCall[len(fake.renewCertArgsForCall)]
@@ -288,15 +490,16 @@ func (fake *CertificateManager) RenewCert(arg1 common.SecretType, arg2 certifica
 		arg6 bool
 		arg7 bool
 	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	stub := fake.RenewCertStub
+	fakeReturns := fake.renewCertReturns
 	fake.recordInvocation("RenewCert", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
 	fake.renewCertMutex.Unlock()
-	if fake.RenewCertStub != nil {
-		return fake.RenewCertStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.renewCertReturns
 	return fakeReturns.result1
 }
 
@@ -342,6 +545,142 @@ func (fake *CertificateManager) RenewCertReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *CertificateManager) UpdateKey(arg1 string, arg2 []byte, arg3 v1.Object) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.updateKeyMutex.Lock()
+	ret, specificReturn := fake.updateKeyReturnsOnCall[len(fake.updateKeyArgsForCall)]
+	fake.updateKeyArgsForCall = append(fake.updateKeyArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+		arg3 v1.Object
+	}{arg1, arg2Copy, arg3})
+	stub := fake.UpdateKeyStub
+	fakeReturns := fake.updateKeyReturns
+	fake.recordInvocation("UpdateKey", []interface{}{arg1, arg2Copy, arg3})
+	fake.updateKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CertificateManager) UpdateKeyCallCount() int {
+	fake.updateKeyMutex.RLock()
+	defer fake.updateKeyMutex.RUnlock()
+	return len(fake.updateKeyArgsForCall)
+}
+
+func (fake *CertificateManager) UpdateKeyCalls(stub func(string, []byte, v1.Object) error) {
+	fake.updateKeyMutex.Lock()
+	defer fake.updateKeyMutex.Unlock()
+	fake.UpdateKeyStub = stub
+}
+
+func (fake *CertificateManager) UpdateKeyArgsForCall(i int) (string, []byte, v1.Object) {
+	fake.updateKeyMutex.RLock()
+	defer fake.updateKeyMutex.RUnlock()
+	argsForCall := fake.updateKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CertificateManager) UpdateKeyReturns(result1 error) {
+	fake.updateKeyMutex.Lock()
+	defer fake.updateKeyMutex.Unlock()
+	fake.UpdateKeyStub = nil
+	fake.updateKeyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CertificateManager) UpdateKeyReturnsOnCall(i int, result1 error) {
+	fake.updateKeyMutex.Lock()
+	defer fake.updateKeyMutex.Unlock()
+	fake.UpdateKeyStub = nil
+	if fake.updateKeyReturnsOnCall == nil {
+		fake.updateKeyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateKeyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CertificateManager) UpdateSignCert(arg1 string, arg2 []byte, arg3 v1.Object) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.updateSignCertMutex.Lock()
+	ret, specificReturn := fake.updateSignCertReturnsOnCall[len(fake.updateSignCertArgsForCall)]
+	fake.updateSignCertArgsForCall = append(fake.updateSignCertArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+		arg3 v1.Object
+	}{arg1, arg2Copy, arg3})
+	stub := fake.UpdateSignCertStub
+	fakeReturns := fake.updateSignCertReturns
+	fake.recordInvocation("UpdateSignCert", []interface{}{arg1, arg2Copy, arg3})
+	fake.updateSignCertMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CertificateManager) UpdateSignCertCallCount() int {
+	fake.updateSignCertMutex.RLock()
+	defer fake.updateSignCertMutex.RUnlock()
+	return len(fake.updateSignCertArgsForCall)
+}
+
+func (fake *CertificateManager) UpdateSignCertCalls(stub func(string, []byte, v1.Object) error) {
+	fake.updateSignCertMutex.Lock()
+	defer fake.updateSignCertMutex.Unlock()
+	fake.UpdateSignCertStub = stub
+}
+
+func (fake *CertificateManager) UpdateSignCertArgsForCall(i int) (string, []byte, v1.Object) {
+	fake.updateSignCertMutex.RLock()
+	defer fake.updateSignCertMutex.RUnlock()
+	argsForCall := fake.updateSignCertArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CertificateManager) UpdateSignCertReturns(result1 error) {
+	fake.updateSignCertMutex.Lock()
+	defer fake.updateSignCertMutex.Unlock()
+	fake.UpdateSignCertStub = nil
+	fake.updateSignCertReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CertificateManager) UpdateSignCertReturnsOnCall(i int, result1 error) {
+	fake.updateSignCertMutex.Lock()
+	defer fake.updateSignCertMutex.Unlock()
+	fake.UpdateSignCertStub = nil
+	if fake.updateSignCertReturnsOnCall == nil {
+		fake.updateSignCertReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateSignCertReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *CertificateManager) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -349,10 +688,18 @@ func (fake *CertificateManager) Invocations() map[string][][]interface{} {
 	defer fake.checkCertificatesForExpireMutex.RUnlock()
 	fake.getDurationToNextRenewalMutex.RLock()
 	defer fake.getDurationToNextRenewalMutex.RUnlock()
+	fake.getMissingHostsMutex.RLock()
+	defer fake.getMissingHostsMutex.RUnlock()
 	fake.getSignCertMutex.RLock()
 	defer fake.getSignCertMutex.RUnlock()
+	fake.reenrollTLSCertMutex.RLock()
+	defer fake.reenrollTLSCertMutex.RUnlock()
 	fake.renewCertMutex.RLock()
 	defer fake.renewCertMutex.RUnlock()
+	fake.updateKeyMutex.RLock()
+	defer fake.updateKeyMutex.RUnlock()
+	fake.updateSignCertMutex.RLock()
+	defer fake.updateSignCertMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
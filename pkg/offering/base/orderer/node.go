@@ -22,8 +22,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,8 +33,11 @@ import (
 	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/action"
 	commonapi "github.com/IBM-Blockchain/fabric-operator/pkg/apis/common"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/backup"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/certificate"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/events"
 	commoninit "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/certmanager"
 	commonconfig "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/config"
 	initializer "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/orderer"
 	ordererconfig "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/orderer/config/v1"
@@ -43,6 +48,7 @@ import (
 	controllerclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
 	resourcemanager "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/manager"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/statefulset"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer/override"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common/reconcilechecks"
@@ -58,6 +64,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -148,7 +155,11 @@ type CertificateManager interface {
 	CheckCertificatesForExpire(instance v1.Object, numSecondsBeforeExpire int64) (current.IBPCRStatusType, string, error)
 	GetSignCert(string, string) ([]byte, error)
 	GetDurationToNextRenewal(commoninit.SecretType, v1.Object, int64) (time.Duration, error)
+	GetMissingHosts(commoninit.SecretType, v1.Object, []string) ([]string, error)
 	RenewCert(commoninit.SecretType, certificate.Instance, *current.EnrollmentSpec, *commonapi.BCCSP, string, bool, bool) error
+	ReenrollTLSCert(v1.Object, *current.EnrollmentSpec, string, bool) ([]byte, []byte, error)
+	UpdateSignCert(string, []byte, v1.Object) error
+	UpdateKey(string, []byte, v1.Object) error
 }
 
 //go:generate counterfeiter -o mocks/restart_manager.go -fake-name RestartManager . RestartManager
@@ -173,13 +184,14 @@ type OrdererConfig interface {
 }
 
 type Manager struct {
-	Client controllerclient.Client
-	Scheme *runtime.Scheme
-	Config *config.Config
+	Client   controllerclient.Client
+	Scheme   *runtime.Scheme
+	Config   *config.Config
+	Recorder record.EventRecorder
 }
 
 func (m *Manager) GetNode(nodeNumber int, renewCertTimers map[string]*time.Timer, restartManager RestartManager) *Node {
-	return NewNode(m.Client, m.Scheme, m.Config, fmt.Sprintf("%s%d", NODE, nodeNumber), renewCertTimers, restartManager)
+	return NewNode(m.Client, m.Scheme, m.Config, m.Recorder, fmt.Sprintf("%s%d", NODE, nodeNumber), renewCertTimers, restartManager)
 }
 
 var _ IBPOrderer = &Node{}
@@ -196,6 +208,7 @@ type Node struct {
 	RoleManager           resources.Manager
 	RoleBindingManager    resources.Manager
 	ServiceAccountManager resources.Manager
+	PDBManager            resources.Manager
 
 	Override    Override
 	Initializer InitializeIBPOrderer
@@ -205,9 +218,15 @@ type Node struct {
 	RenewCertTimers    map[string]*time.Timer
 
 	Restart RestartManager
+
+	Restorer Restorer
+
+	ChannelAdmin ChannelAdmin
+
+	Recorder record.EventRecorder
 }
 
-func NewNode(client controllerclient.Client, scheme *runtime.Scheme, config *config.Config, name string, renewCertTimers map[string]*time.Timer, restartManager RestartManager) *Node {
+func NewNode(client controllerclient.Client, scheme *runtime.Scheme, config *config.Config, recorder record.EventRecorder, name string, renewCertTimers map[string]*time.Timer, restartManager RestartManager) *Node {
 	n := &Node{
 		Client: client,
 		Scheme: scheme,
@@ -220,6 +239,9 @@ func NewNode(client controllerclient.Client, scheme *runtime.Scheme, config *con
 		Name:            name,
 		RenewCertTimers: renewCertTimers,
 		Restart:         restartManager,
+		Recorder:        recorder,
+		Restorer:        backup.New(client, scheme, config.Operator.Backup.Images),
+		ChannelAdmin:    newClusterAdmin(30 * time.Second),
 	}
 	n.CreateManagers()
 
@@ -233,7 +255,7 @@ func NewNode(client controllerclient.Client, scheme *runtime.Scheme, config *con
 	return n
 }
 
-func NewNodeWithOverrides(client controllerclient.Client, scheme *runtime.Scheme, config *config.Config, name string, o Override, renewCertTimers map[string]*time.Timer, restartManager RestartManager) *Node {
+func NewNodeWithOverrides(client controllerclient.Client, scheme *runtime.Scheme, config *config.Config, recorder record.EventRecorder, name string, o Override, renewCertTimers map[string]*time.Timer, restartManager RestartManager) *Node {
 	n := &Node{
 		Client:          client,
 		Scheme:          scheme,
@@ -242,6 +264,9 @@ func NewNodeWithOverrides(client controllerclient.Client, scheme *runtime.Scheme
 		Name:            name,
 		RenewCertTimers: renewCertTimers,
 		Restart:         restartManager,
+		Recorder:        recorder,
+		Restorer:        backup.New(client, scheme, config.Operator.Backup.Images),
+		ChannelAdmin:    newClusterAdmin(30 * time.Second),
 	}
 	n.CreateManagers()
 
@@ -258,13 +283,25 @@ func NewNodeWithOverrides(client controllerclient.Client, scheme *runtime.Scheme
 func (n *Node) CreateManagers() {
 	override := n.Override
 	resourceManager := resourcemanager.New(n.Client, n.Scheme)
-	n.DeploymentManager = resourceManager.CreateDeploymentManager("", override.Deployment, n.GetLabels, n.Config.OrdererInitConfig.DeploymentFile)
+	n.DeploymentManager = &statefulset.WorkloadManager{
+		Deployment: resourceManager.CreateDeploymentManager("", override.Deployment, n.GetLabels, n.Config.OrdererInitConfig.DeploymentFile),
+		StatefulSet: resourceManager.CreateStatefulSetManager("", override.Deployment, map[string]func(v1.Object, *corev1.PersistentVolumeClaim, resources.Action) error{
+			"orderer-data": override.PVC,
+		}, n.GetLabels, n.Config.OrdererInitConfig.StatefulSetFile),
+		UseStatefulSet: n.UsesStatefulSet,
+	}
 	n.ServiceManager = resourceManager.CreateServiceManager("", override.Service, n.GetLabels, n.Config.OrdererInitConfig.ServiceFile)
-	n.PVCManager = resourceManager.CreatePVCManager("", override.PVC, n.GetLabels, n.Config.OrdererInitConfig.PVCFile)
+	n.PVCManager = resourceManager.CreatePVCManager("", n.RestorePVC(override.PVC), n.GetLabels, n.Config.OrdererInitConfig.PVCFile)
 	n.EnvConfigMapManager = resourceManager.CreateConfigMapManager("env", override.EnvCM, n.GetLabels, n.Config.OrdererInitConfig.CMFile, nil)
 	n.RoleManager = resourceManager.CreateRoleManager("", nil, n.GetLabels, n.Config.OrdererInitConfig.RoleFile)
 	n.RoleBindingManager = resourceManager.CreateRoleBindingManager("", nil, n.GetLabels, n.Config.OrdererInitConfig.RoleBindingFile)
 	n.ServiceAccountManager = resourceManager.CreateServiceAccountManager("", nil, n.GetLabels, n.Config.OrdererInitConfig.ServiceAccountFile)
+	n.PDBManager = resourceManager.CreatePodDisruptionBudgetManager(n.GetPodDisruptionBudgetName, n.PodDisruptionBudget, n.GetPodDisruptionBudgetLabels)
+}
+
+// UsesStatefulSet returns true if the orderer node runs as a stateful set
+func (n *Node) UsesStatefulSet(instance v1.Object) bool {
+	return instance.(*current.IBPOrderer).Spec.UsesStatefulSet()
 }
 
 func (n *Node) Reconcile(instance *current.IBPOrderer, update Update) (common.Result, error) {
@@ -319,11 +356,37 @@ func (n *Node) Reconcile(instance *current.IBPOrderer, update Update) (common.Re
 		}, nil
 	}
 
+	issued, err := n.CertManagerCertificateIssued(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to check cert-manager certificate")
+	}
+	if !issued {
+		log.Info(fmt.Sprintf("Waiting for cert-manager to issue the TLS certificate of '%s'", instance.GetName()))
+		return WaitForCertManager(instance), nil
+	}
+
 	err = n.Initialize(instance, update)
 	if err != nil {
 		return common.Result{}, operatorerrors.Wrap(err, operatorerrors.OrdererInitilizationFailed, "failed to initialize orderer node")
 	}
 
+	restored, err := n.RestoreVolumes(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to restore volumes")
+	}
+	if !restored {
+		return common.Result{
+			Result: reconcile.Result{
+				RequeueAfter: RestoreRequeueInterval,
+			},
+			Status: &current.CRStatus{
+				Type:    current.Deploying,
+				Reason:  "restoringVolumes",
+				Message: fmt.Sprintf("Restoring volumes from backup '%s'", instance.Spec.RestoreFrom.Backup),
+			},
+		}, nil
+	}
+
 	err = n.ReconcileManagers(instance, update, nil)
 	if err != nil {
 		return common.Result{}, errors.Wrap(err, "failed to reconcile managers")
@@ -360,6 +423,16 @@ func (n *Node) Reconcile(instance *current.IBPOrderer, update Update) (common.Re
 		}
 	}
 
+	err = n.ReconcileCertManagerCertificate(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile cert-manager certificate")
+	}
+
+	err = n.ReconcileTLSCertHosts(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile TLS certificate hosts")
+	}
+
 	if update.EcertUpdated() {
 		log.Info("Ecert was updated")
 		// Request deployment restart for tls cert update
@@ -382,11 +455,17 @@ func (n *Node) Reconcile(instance *current.IBPOrderer, update Update) (common.Re
 		return common.Result{}, errors.Wrap(err, "failed to handle actions")
 	}
 
+	rotationResult, err := n.ReconcileTLSCertRotation(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to rotate TLS certificate")
+	}
+
 	if err := n.HandleRestart(instance, update); err != nil {
 		return common.Result{}, err
 	}
 
 	return common.Result{
+		Result: rotationResult.Result,
 		Status: status,
 	}, nil
 }
@@ -586,6 +665,14 @@ func (n *Node) InitializeCreate(instance *current.IBPOrderer, initOrderer *initi
 	if err != nil {
 		return err
 	}
+
+	if instance.Spec.RestoreFrom != nil {
+		err = n.RestoreCrypto(instance, initOrderer)
+		if err != nil {
+			return errors.Wrap(err, "failed to restore crypto")
+		}
+	}
+
 	resp, err := n.Initializer.Create(configOverride.(OrdererConfig), initOrderer, n.GetInitStoragePath(instance))
 	if err != nil {
 		return err
@@ -620,6 +707,8 @@ func (n *Node) InitializeCreate(instance *current.IBPOrderer, initOrderer *initi
 				return err
 			}
 		}
+
+		events.Normal(n.Recorder, instance, events.Initialized, "Generated crypto material and config for orderer node")
 	}
 
 	return nil
@@ -720,10 +809,13 @@ func (n *Node) ReconcileManagers(instance *current.IBPOrderer, updated Update, g
 
 	update := updated.SpecUpdated()
 
-	n.PVCManager.SetCustomName(instance.Spec.CustomNames.PVC.Orderer)
-	err = n.PVCManager.Reconcile(instance, update)
-	if err != nil {
-		return errors.Wrapf(err, "failed PVC reconciliation")
+	// A stateful set creates its volumes from its volume claim templates
+	if !instance.Spec.UsesStatefulSet() {
+		n.PVCManager.SetCustomName(instance.Spec.CustomNames.PVC.Orderer)
+		err = n.PVCManager.Reconcile(instance, update)
+		if err != nil {
+			return errors.Wrapf(err, "failed PVC reconciliation")
+		}
 	}
 
 	err = n.ServiceManager.Reconcile(instance, update)
@@ -756,6 +848,11 @@ func (n *Node) ReconcileManagers(instance *current.IBPOrderer, updated Update, g
 		return errors.Wrap(err, "failed Deployment reconciliation")
 	}
 
+	err = n.PDBManager.Reconcile(instance, update)
+	if err != nil {
+		return errors.Wrap(err, "failed PodDisruptionBudget reconciliation")
+	}
+
 	return nil
 }
 
@@ -1074,6 +1171,17 @@ func (n *Node) UpdateConnectionProfileConfigmap(instance *current.IBPOrderer, en
 }
 
 func (n *Node) GetEndpoints(instance *current.IBPOrderer) *current.OrdererEndpoints {
+	if instance.Spec.Service.UsesLoadBalancerAddress() {
+		if host, _, err := common.SplitHostPort(instance.Spec.ExternalAddress); err == nil {
+			return &current.OrdererEndpoints{
+				API:        "grpcs://" + net.JoinHostPort(host, strconv.Itoa(common.OrdererAPIPort)),
+				Operations: "https://" + net.JoinHostPort(host, strconv.Itoa(common.OrdererOperationsPort)),
+				Grpcweb:    "https://" + net.JoinHostPort(host, strconv.Itoa(common.GrpcwebPort)),
+				Admin:      "https://" + net.JoinHostPort(host, strconv.Itoa(common.OrdererAdminPort)),
+			}
+		}
+	}
+
 	endpoints := &current.OrdererEndpoints{
 		API:        "grpcs://" + instance.Namespace + "-" + instance.Name + "-orderer." + instance.Spec.Domain + ":443",
 		Operations: "https://" + instance.Namespace + "-" + instance.Name + "-operations." + instance.Spec.Domain + ":443",
@@ -1233,6 +1341,7 @@ func (n *Node) ReconcileFabricOrdererMigration(instance *current.IBPOrderer) err
 		if err := n.Initializer.CreateOrUpdateConfigMap(instance, ordererConfig); err != nil {
 			return errors.Wrapf(err, "failed to create/update '%s' orderer's config map", instance.GetName())
 		}
+		events.Normal(n.Recorder, instance, events.Migrated, "Migrated orderer node config to fabric version %s", instance.Spec.FabricVersion)
 	}
 
 	return nil
@@ -1322,6 +1431,10 @@ func (n *Node) RenewCert(certType commoninit.SecretType, obj runtime.Object, new
 		return errors.New(fmt.Sprintf("missing secret spec for instance '%s'", instance.GetName()))
 	}
 
+	if certType == commoninit.TLS && instance.Spec.Secret.UsesCertManagerForTLS() {
+		return errors.New("cannot renew TLS certificate issued by cert-manager, certificate is renewed by cert-manager")
+	}
+
 	if instance.Spec.Secret.Enrollment != nil {
 		log.Info(fmt.Sprintf("Renewing %s certificate for instance '%s'", string(certType), instance.Name))
 
@@ -1335,15 +1448,127 @@ func (n *Node) RenewCert(certType commoninit.SecretType, obj runtime.Object, new
 
 		err = n.CertificateManager.RenewCert(certType, instance, spec, bccsp, storagePath, hsmEnabled, newKey)
 		if err != nil {
+			events.Warning(n.Recorder, instance, events.CertificateRenewalFailed, "Failed to renew %s certificate: %s", certType, err)
 			return err
 		}
+		events.Normal(n.Recorder, instance, events.CertificateRenewed, "Renewed %s certificate", certType)
 	} else {
+		events.Warning(n.Recorder, instance, events.CertificateRenewalFailed, "Cannot auto-renew %s certificate created by MSP, force renewal required", certType)
 		return errors.New("cannot auto-renew certificate created by MSP, force renewal required")
 	}
 
 	return nil
 }
 
+// ReconcileTLSCertHosts compares the hosts in the TLS enrollment CSR against the subject
+// alternative names of the current TLS certificate. If a host is missing, e.g. because the
+// domain or external endpoint changed, the TLS certificate is reenrolled with the current
+// hosts and a restart of the deployment is requested to pick up the new certificate.
+func (n *Node) ReconcileTLSCertHosts(instance *current.IBPOrderer) error {
+	secret := instance.Spec.Secret
+	if secret == nil || secret.Enrollment == nil || secret.Enrollment.TLS == nil || secret.Enrollment.TLS.CSR == nil {
+		return nil
+	}
+	// Certificates passed in the MSP spec can't be reenrolled by the operator
+	if secret.MSP != nil && secret.MSP.TLS != nil {
+		return nil
+	}
+	// cert-manager reissues the certificate when the hosts of its Certificate change
+	if secret.UsesCertManagerForTLS() {
+		return nil
+	}
+
+	missing, err := n.CertificateManager.GetMissingHosts(commoninit.TLS, instance, secret.Enrollment.TLS.CSR.Hosts)
+	if err != nil {
+		return errors.Wrap(err, "failed to get hosts missing from TLS certificate")
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("TLS certificate for instance '%s' is missing hosts %v, reenrolling", instance.GetName(), missing))
+	err = n.RenewCert(commoninit.TLS, instance, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to reenroll TLS certificate with current hosts")
+	}
+
+	err = n.Restart.ForCertUpdate(commoninit.TLS, instance)
+	if err != nil {
+		return errors.Wrap(err, "failed to update restart config")
+	}
+
+	return nil
+}
+
+// CertManagerCertificateIssued returns true if cert-manager has issued the TLS certificate of the
+// orderer node, or if the TLS certificate is not issued by cert-manager. The orderer node is not initialized
+// before its TLS certificate has been issued.
+func (n *Node) CertManagerCertificateIssued(instance *current.IBPOrderer) (bool, error) {
+	if !instance.Spec.Secret.UsesCertManagerForTLS() {
+		return true, nil
+	}
+
+	cm := certmanager.New(n.Client, n.Scheme, instance, instance.Spec.Secret.Enrollment.TLS)
+	return cm.IsIssued()
+}
+
+// WaitForCertManager returns the result of a reconcile that waits for cert-manager to issue the
+// TLS certificate of the orderer node
+func WaitForCertManager(instance *current.IBPOrderer) common.Result {
+	return common.Result{
+		Result: reconcile.Result{
+			RequeueAfter: certmanager.RequeueInterval,
+		},
+		Status: &current.CRStatus{
+			Type:    current.Deploying,
+			Reason:  "waitingForCertManager",
+			Message: fmt.Sprintf("Waiting for cert-manager to issue the TLS certificate of '%s'", instance.GetName()),
+		},
+	}
+}
+
+// ReconcileCertManagerCertificate keeps the cert-manager Certificate of the TLS certificate in sync
+// with the hosts in the TLS enrollment CSR, and updates the TLS secrets when cert-manager renewed
+// the certificate. The update of the TLS secrets requests a restart of the deployment.
+func (n *Node) ReconcileCertManagerCertificate(instance *current.IBPOrderer) error {
+	if !instance.Spec.Secret.UsesCertManagerForTLS() {
+		return nil
+	}
+
+	cm := certmanager.New(n.Client, n.Scheme, instance, instance.Spec.Secret.Enrollment.TLS)
+	err := cm.ReconcileCertificate()
+	if err != nil {
+		return err
+	}
+
+	cert, err := n.CertificateManager.GetSignCert(fmt.Sprintf("tls-%s-signcert", instance.GetName()), instance.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	crypto, err := cm.GetRenewedCrypto(cert)
+	if err != nil {
+		return errors.Wrap(err, "failed to get TLS certificate renewed by cert-manager")
+	}
+	if crypto == nil {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("cert-manager renewed TLS certificate for instance '%s', updating TLS secrets", instance.GetName()))
+	err = common.BackupCrypto(n.Client, n.Scheme, instance, n.GetLabels(instance))
+	if err != nil {
+		return errors.Wrap(err, "failed to backup crypto before updating TLS secrets")
+	}
+
+	err = n.Initializer.UpdateSecrets(commoninit.TLS, instance, crypto)
+	if err != nil {
+		return errors.Wrap(err, "failed to update TLS secrets")
+	}
+	events.Normal(n.Recorder, instance, events.CertificateRenewed, "Renewed %s certificate", commoninit.TLS)
+
+	return nil
+}
+
 func (n *Node) EnrollForEcert(instance *current.IBPOrderer) error {
 	log.Info(fmt.Sprintf("Ecert enroll triggered via action parameter for '%s'", instance.GetName()))
 
@@ -1356,6 +1581,7 @@ func (n *Node) EnrollForEcert(instance *current.IBPOrderer) error {
 	storagePath := filepath.Join(n.GetInitStoragePath(instance), "ecert")
 	crypto, err := action.Enroll(instance, ecertSpec, storagePath, n.Client, n.Scheme, true, n.Config.Operator.Orderer.Timeouts.EnrollJob)
 	if err != nil {
+		events.Warning(n.Recorder, instance, events.EnrollmentFailed, "Failed to enroll for ecert: %s", err)
 		return errors.Wrap(err, "failed to enroll for ecert")
 	}
 
@@ -1363,6 +1589,7 @@ func (n *Node) EnrollForEcert(instance *current.IBPOrderer) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to generate ecert secrets")
 	}
+	events.Normal(n.Recorder, instance, events.Enrolled, "Enrolled for ecert")
 
 	return nil
 }
@@ -1374,11 +1601,15 @@ func (n *Node) EnrollForTLSCert(instance *current.IBPOrderer) error {
 	if secret == nil || secret.Enrollment == nil || secret.Enrollment.TLS == nil {
 		return errors.New("unable to enroll, no TLS enrollment information provided")
 	}
+	if secret.UsesCertManagerForTLS() {
+		return errors.New("unable to enroll, TLS certificate is issued by cert-manager")
+	}
 	tlscertSpec := secret.Enrollment.TLS
 
 	storagePath := filepath.Join(n.GetInitStoragePath(instance), "tls")
 	crypto, err := action.Enroll(instance, tlscertSpec, storagePath, n.Client, n.Scheme, false, n.Config.Operator.Orderer.Timeouts.EnrollJob)
 	if err != nil {
+		events.Warning(n.Recorder, instance, events.EnrollmentFailed, "Failed to enroll for TLS cert: %s", err)
 		return errors.Wrap(err, "failed to enroll for TLS cert")
 	}
 
@@ -1386,6 +1617,7 @@ func (n *Node) EnrollForTLSCert(instance *current.IBPOrderer) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to generate ecert secrets")
 	}
+	events.Normal(n.Recorder, instance, events.Enrolled, "Enrolled for TLS cert")
 
 	return nil
 }
@@ -1474,6 +1706,8 @@ func (n *Node) FabricOrdererMigrationV2_0(instance *current.IBPOrderer) error {
 		return err
 	}
 
+	events.Normal(n.Recorder, instance, events.Migrated, "Migrated orderer node config to fabric version %s", instance.Spec.FabricVersion)
+
 	return nil
 }
 
@@ -1563,6 +1797,9 @@ func (n *Node) FabricOrdererMigrationV2_4(instance *current.IBPOrderer) error {
 	if err != nil {
 		return err
 	}
+
+	events.Normal(n.Recorder, instance, events.Migrated, "Migrated orderer node config to fabric version %s", instance.Spec.FabricVersion)
+
 	return nil
 }
 
@@ -1657,6 +1894,9 @@ func (n *Node) FabricOrdererMigrationV2_5(instance *current.IBPOrderer) error {
 	if err != nil {
 		return err
 	}
+
+	events.Normal(n.Recorder, instance, events.Migrated, "Migrated orderer node config to fabric version %s", instance.Spec.FabricVersion)
+
 	return nil
 }
 
@@ -1791,6 +2031,13 @@ func (n *Node) ReenrollTLSCert(instance *current.IBPOrderer) error {
 
 func (n *Node) ReenrollTLSCertNewKey(instance *current.IBPOrderer) error {
 	log.Info("TLS with new key reenroll triggered via action parameter")
+	if instance.Spec.NodeNumber != nil {
+		// Nodes of a cluster switch to the new key once their consenter certificate is replaced
+		if err := n.StageTLSCertNewKey(instance); err != nil {
+			return errors.Wrap(err, "tls reenroll with new key action failed")
+		}
+		return nil
+	}
 	if err := n.reenrollCert(instance, commoninit.TLS, true); err != nil {
 		return errors.Wrap(err, "tls reenroll with new key action failed")
 	}
@@ -1903,6 +2150,11 @@ func (n *Node) CheckCertificates(instance *current.IBPOrderer) (*current.CRStatu
 
 func (n *Node) SetCertificateTimer(instance *current.IBPOrderer, certType commoninit.SecretType) error {
 	certName := fmt.Sprintf("%s-%s-signcert", certType, instance.Name)
+	if certType == commoninit.TLS && instance.Spec.Secret.UsesCertManagerForTLS() {
+		log.Info(fmt.Sprintf("Not setting timer to renew %s, certificate is renewed by cert-manager", certName))
+		return nil
+	}
+
 	numSecondsBeforeExpire := instance.Spec.GetNumSecondsWarningPeriod()
 	duration, err := n.CertificateManager.GetDurationToNextRenewal(certType, instance, numSecondsBeforeExpire)
 	if err != nil {
@@ -42,11 +42,13 @@ import (
 	v1 "github.com/IBM-Blockchain/fabric-operator/pkg/apis/orderer/v1"
 	v2 "github.com/IBM-Blockchain/fabric-operator/pkg/apis/orderer/v2"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/certificate"
+	commoninit "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common"
 	commonconfig "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/config"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/mspparser"
 	ordererinit "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/orderer"
 	oconfig "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/orderer/config/v1"
 	v2config "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/orderer/config/v2"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
 	managermocks "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/mocks"
 	baseorderer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer/mocks"
@@ -57,6 +59,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -127,6 +130,8 @@ var _ = Describe("Base Orderer Node", func() {
 			case *corev1.Secret:
 				o := obj.(*corev1.Secret)
 				switch types.Name {
+				case "tls-" + instance.Name + "-rotation":
+					return k8serrors.NewNotFound(schema.GroupResource{}, "not found")
 				case "ecert-" + instance.Name + "-signcert":
 					o.Name = "ecert-" + instance.Name + "-signcert"
 					o.Namespace = instance.Namespace
@@ -163,6 +168,7 @@ var _ = Describe("Base Orderer Node", func() {
 		roleMgr := &managermocks.ResourceManager{}
 		roleBindingMgr := &managermocks.ResourceManager{}
 		serviceAccountMgr := &managermocks.ResourceManager{}
+		pdbMgr := &managermocks.ResourceManager{}
 
 		initializer = &orderermocks.InitializeIBPOrderer{}
 		initializer.GetInitOrdererReturns(&ordererinit.Orderer{}, nil)
@@ -205,6 +211,7 @@ var _ = Describe("Base Orderer Node", func() {
 			RoleManager:           roleMgr,
 			RoleBindingManager:    roleBindingMgr,
 			ServiceAccountManager: serviceAccountMgr,
+			PDBManager:            pdbMgr,
 
 			CertificateManager: certificateMgr,
 			RenewCertTimers:    make(map[string]*time.Timer),
@@ -880,6 +887,179 @@ var _ = Describe("Base Orderer Node", func() {
 		})
 	})
 
+	Context("reconcile TLS cert hosts", func() {
+		var restartMgr *orderermocks.RestartManager
+
+		BeforeEach(func() {
+			restartMgr = node.Restart.(*orderermocks.RestartManager)
+			instance.Spec.Secret = &current.SecretSpec{
+				Enrollment: &current.EnrollmentSpec{
+					TLS: &current.Enrollment{
+						CSR: &current.CSR{
+							Hosts: []string{"orderer.new-domain.com", "127.0.0.1"},
+						},
+					},
+				},
+			}
+		})
+
+		It("does nothing if certificate was passed in MSP spec", func() {
+			instance.Spec.Secret.MSP = &current.MSPSpec{
+				TLS: &current.MSP{},
+			}
+			err := node.ReconcileTLSCertHosts(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certificateMgr.GetMissingHostsCallCount()).To(Equal(0))
+		})
+
+		It("returns error if fails to get missing hosts", func() {
+			certificateMgr.GetMissingHostsReturns(nil, errors.New("get missing hosts error"))
+			err := node.ReconcileTLSCertHosts(instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("get missing hosts error"))
+		})
+
+		It("does not reenroll if certificate contains all hosts", func() {
+			certificateMgr.GetMissingHostsReturns([]string{}, nil)
+			err := node.ReconcileTLSCertHosts(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certificateMgr.RenewCertCallCount()).To(Equal(0))
+			Expect(restartMgr.ForCertUpdateCallCount()).To(Equal(0))
+		})
+
+		It("returns error if fails to reenroll certificate", func() {
+			certificateMgr.GetMissingHostsReturns([]string{"orderer.new-domain.com"}, nil)
+			certificateMgr.RenewCertReturns(errors.New("renew error"))
+			err := node.ReconcileTLSCertHosts(instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("renew error"))
+			Expect(restartMgr.ForCertUpdateCallCount()).To(Equal(0))
+		})
+
+		It("reenrolls certificate and requests restart if hosts are missing", func() {
+			certificateMgr.GetMissingHostsReturns([]string{"orderer.new-domain.com"}, nil)
+			err := node.ReconcileTLSCertHosts(instance)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, hosts := certificateMgr.GetMissingHostsArgsForCall(0)
+			Expect(hosts).To(Equal([]string{"orderer.new-domain.com", "127.0.0.1"}))
+
+			Expect(certificateMgr.RenewCertCallCount()).To(Equal(1))
+			certType, _, spec, _, _, _, newKey := certificateMgr.RenewCertArgsForCall(0)
+			Expect(certType).To(Equal(commoninit.TLS))
+			Expect(spec.TLS.CSR.Hosts).To(ContainElement("orderer.new-domain.com"))
+			Expect(newKey).To(BeFalse())
+
+			Expect(restartMgr.ForCertUpdateCallCount()).To(Equal(1))
+			certType, _ = restartMgr.ForCertUpdateArgsForCall(0)
+			Expect(certType).To(Equal(commoninit.TLS))
+		})
+	})
+
+	Context("reconcile cert-manager certificate", func() {
+		var (
+			issuedCert  []byte
+			certCreated bool
+		)
+
+		BeforeEach(func() {
+			issuedCert = generateCertPemBytes(90)
+			certCreated = false
+			instance.Spec.Secret = &current.SecretSpec{
+				Enrollment: &current.EnrollmentSpec{
+					TLS: &current.Enrollment{
+						CertManager: &current.CertManagerIssuer{
+							Name: "issuer",
+						},
+						CSR: &current.CSR{
+							Hosts: []string{"orderer.domain.com"},
+						},
+					},
+				},
+			}
+
+			mockKubeClient.GetStub = func(ctx context.Context, types types.NamespacedName, obj client.Object) error {
+				switch obj.(type) {
+				case *unstructured.Unstructured:
+					if !certCreated {
+						return k8serrors.NewNotFound(schema.GroupResource{}, "not found")
+					}
+				case *corev1.Secret:
+					o := obj.(*corev1.Secret)
+					switch types.Name {
+					case "tls-" + instance.Name + "-certmanager":
+						o.Name = types.Name
+						o.Namespace = instance.Namespace
+						o.Data = map[string][]byte{
+							"tls.crt": issuedCert,
+							"tls.key": []byte("key"),
+							"ca.crt":  []byte("cacert"),
+						}
+					case instance.Name + "-crypto-backup":
+						return k8serrors.NewNotFound(schema.GroupResource{}, "not found")
+					}
+				}
+				return nil
+			}
+			mockKubeClient.CreateStub = func(ctx context.Context, obj client.Object, opts ...controllerclient.CreateOption) error {
+				if _, ok := obj.(*unstructured.Unstructured); ok {
+					certCreated = true
+				}
+				return nil
+			}
+		})
+
+		It("does nothing if TLS certificate is not issued by cert-manager", func() {
+			instance.Spec.Secret.Enrollment.TLS.CertManager = nil
+			err := node.ReconcileCertManagerCertificate(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.CreateCallCount()).To(Equal(0))
+			Expect(certificateMgr.GetSignCertCallCount()).To(Equal(0))
+		})
+
+		It("returns error if fails to create certificate", func() {
+			mockKubeClient.CreateReturns(errors.New("create error"))
+			mockKubeClient.CreateStub = nil
+			err := node.ReconcileCertManagerCertificate(instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("create error"))
+		})
+
+		It("does not update secrets if certificate has not changed", func() {
+			certificateMgr.GetSignCertReturns(issuedCert, nil)
+			err := node.ReconcileCertManagerCertificate(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certCreated).To(Equal(true))
+			Expect(initializer.UpdateSecretsCallCount()).To(Equal(0))
+		})
+
+		It("updates TLS secrets if cert-manager renewed the certificate", func() {
+			certificateMgr.GetSignCertReturns(generateCertPemBytes(29), nil)
+			err := node.ReconcileCertManagerCertificate(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(initializer.UpdateSecretsCallCount()).To(Equal(1))
+
+			prefix, _, resp := initializer.UpdateSecretsArgsForCall(0)
+			Expect(prefix).To(Equal(commoninit.TLS))
+			Expect(resp.SignCert).To(Equal(issuedCert))
+			Expect(resp.CACerts).To(Equal([][]byte{[]byte("cacert")}))
+		})
+
+		It("reports the certificate as issued once cert-manager wrote it to the secret", func() {
+			issued, err := node.CertManagerCertificateIssued(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issued).To(Equal(true))
+		})
+
+		It("creates the certificate and waits if cert-manager has not issued it yet", func() {
+			issuedCert = nil
+			issued, err := node.CertManagerCertificateIssued(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issued).To(Equal(false))
+			Expect(certCreated).To(Equal(true))
+		})
+	})
+
 	Context("update cr status", func() {
 		It("returns error if fails to get current instance", func() {
 			mockKubeClient.GetReturns(errors.New("get error"))
@@ -42,6 +42,7 @@ import (
 	"github.com/IBM-Blockchain/fabric-operator/version"
 	"github.com/gogo/protobuf/proto"
 	"github.com/hyperledger/fabric-lib-go/bccsp"
+	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
 	fmsp "github.com/hyperledger/fabric/msp"
@@ -55,6 +56,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -65,6 +67,9 @@ var log = logf.Log.WithName("base_orderer")
 
 const (
 	defaultOrdererNode = "./definitions/orderer/orderernode.yaml"
+
+	// MinBFTClusterSize is the smallest BFT cluster (3f+1) able to tolerate a single faulty node
+	MinBFTClusterSize = 4
 )
 
 //go:generate counterfeiter -o mocks/node_manager.go -fake-name NodeManager . NodeManager
@@ -86,21 +91,27 @@ type Orderer struct {
 	Override        Override
 	RenewCertTimers map[string]*time.Timer
 	RestartManager  *restart.RestartManager
+	Recorder        record.EventRecorder
+
+	ChannelAdmin ChannelAdmin
 }
 
-func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config, o Override) *Orderer {
+func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config, recorder record.EventRecorder, o Override) *Orderer {
 	orderer := &Orderer{
 		Client: client,
 		Scheme: scheme,
 		Config: config,
 		NodeManager: &Manager{
-			Client: client,
-			Scheme: scheme,
-			Config: config,
+			Client:   client,
+			Scheme:   scheme,
+			Config:   config,
+			Recorder: recorder,
 		},
 		Override:        o,
 		RenewCertTimers: make(map[string]*time.Timer),
-		RestartManager:  restart.New(client, config.Operator.Restart.WaitTime.Get(), config.Operator.Restart.Timeout.Get()),
+		Recorder:        recorder,
+		RestartManager:  restart.New(client, recorder, config.Operator.Restart.WaitTime.Get(), config.Operator.Restart.Timeout.Get()),
+		ChannelAdmin:    newClusterAdmin(30 * time.Second),
 	}
 	orderer.CreateManagers()
 	return orderer
@@ -112,7 +123,14 @@ func (o *Orderer) CreateManagers() {
 }
 
 func (o *Orderer) PreReconcileChecks(instance *current.IBPOrderer, update Update) (bool, error) {
-	if strings.ToLower(instance.Spec.OrdererType) != "etcdraft" {
+	switch {
+	case instance.Spec.IsRaft():
+	case instance.Spec.IsBFT():
+		err := o.BFTChecks(instance)
+		if err != nil {
+			return false, err
+		}
+	default:
 		return false, operatorerrors.New(operatorerrors.InvalidOrdererType, fmt.Sprintf("orderer type '%s' is not supported", instance.Spec.OrdererType))
 	}
 
@@ -167,6 +185,32 @@ func (o *Orderer) PreReconcileChecks(instance *current.IBPOrderer, update Update
 	return false, nil
 }
 
+// BFTChecks verifies that a BFT ordering service can be deployed from the spec. BFT orderers
+// do not support a system channel and require at least 3f+1 nodes to tolerate f faulty nodes.
+func (o *Orderer) BFTChecks(instance *current.IBPOrderer) error {
+	if instance.Spec.FabricVersion == "" || version.String(instance.Spec.FabricVersion).LessThan(version.V3_0_0) {
+		return operatorerrors.New(operatorerrors.InvalidOrdererType, fmt.Sprintf("orderer type '%s' requires fabric version %s or higher, fabric version is '%s'", instance.Spec.OrdererType, version.V3_0_0, instance.Spec.FabricVersion))
+	}
+
+	if !instance.Spec.IsUsingChannelLess() {
+		return operatorerrors.New(operatorerrors.InvalidOrdererType, fmt.Sprintf("orderer type '%s' does not support a system channel, useChannelLess must be set", instance.Spec.OrdererType))
+	}
+
+	// Only the parent of the cluster knows the size of the cluster, nodes have a cluster size of 1.
+	// An unset cluster size deploys a single node, see ClusterSizeUpdate.
+	if instance.Spec.NodeNumber == nil {
+		size := instance.Spec.ClusterSize
+		if size == 0 {
+			size = 1
+		}
+		if size < MinBFTClusterSize {
+			return operatorerrors.New(operatorerrors.InvalidOrdererType, fmt.Sprintf("orderer type '%s' requires a cluster size of at least %d (3f+1), cluster size is %d", instance.Spec.OrdererType, MinBFTClusterSize, size))
+		}
+	}
+
+	return nil
+}
+
 func (o *Orderer) ClusterSizeUpdate(instance *current.IBPOrderer) bool {
 	size := instance.Spec.ClusterSize
 	if size == 0 {
@@ -245,6 +289,16 @@ func (o *Orderer) ReconcileCluster(instance *current.IBPOrderer, update Update,
 		return common.Result{}, err
 	}
 
+	if len(nodes.Items) > size {
+		return o.ScaleDown(instance, nodes.Items)
+	}
+
+	if instance.Status.Type == current.Deployed || instance.Status.Type == current.Warning || instance.Status.Type == current.Error {
+		if len(nodes.Items) < size || JoinPending(nodes.Items) {
+			return o.ScaleUp(instance, nodes.Items)
+		}
+	}
+
 	if len(nodes.Items) == size {
 		if instance.Spec.IsPrecreateOrderer() {
 			return common.Result{}, err
@@ -412,6 +466,12 @@ func (o *Orderer) GetClusterNodes(instance *current.IBPOrderer) (current.IBPOrde
 }
 
 func (o *Orderer) CreateNodeCR(instance *current.IBPOrderer, number int) error {
+	return o.createNodeCR(instance, number, false)
+}
+
+// createNodeCR creates the custom resource of the node. A node that joins a deployed cluster
+// starts without channels and is joined to the channels of the cluster by the parent.
+func (o *Orderer) createNodeCR(instance *current.IBPOrderer, number int, join bool) error {
 	if instance.Spec.NodeNumber != nil {
 		return fmt.Errorf("only parent orderer can create nodes custom resources, instance '%s' is not a parent", instance.GetName())
 	}
@@ -459,11 +519,19 @@ func (o *Orderer) CreateNodeCR(instance *current.IBPOrderer, number int) error {
 		}
 	}
 
-	if instance.Spec.IsUsingChannelLess() {
+	if join {
+		node.Spec.UseChannelLess = pointer.Bool(true)
+		node.Spec.JoinCluster = pointer.Bool(true)
+	} else if instance.Spec.IsUsingChannelLess() {
 		node.Spec.UseChannelLess = instance.Spec.UseChannelLess
 	} else {
 		node.Spec.IsPrecreate = pointer.Bool(true)
 	}
+	if node.Spec.RestoreFrom != nil && node.Spec.RestoreFrom.Component != "" {
+		// Each node is restored from the node with the same number in the backed up cluster
+		node.Spec.RestoreFrom.Component = fmt.Sprintf("%snode%d", node.Spec.RestoreFrom.Component, number)
+	}
+
 	node.Spec.NodeNumber = &number
 	node.Spec.ClusterSize = 1
 	node.Spec.ClusterSecret = nil
@@ -538,6 +606,17 @@ func (o *Orderer) LoadInitialProfile(instance *current.IBPOrderer) (*configtx.Pr
 		return nil, err
 	}
 
+	if instance.Spec.IsBFT() {
+		initProfile.SetOrdererType(configtx.ConsensusTypeBFT)
+		// BFT consensus requires V3_0 channel capability
+		initProfile.Capabilities = map[string]bool{
+			"V3_0": true,
+		}
+		initProfile.SetCapabilitiesForOrderer(map[string]bool{
+			"V2_0": true,
+		})
+	}
+
 	org := &configtx.Organization{
 		Name:           instance.Spec.OrgName,
 		ID:             instance.Spec.MSPID,
@@ -577,15 +656,34 @@ func (o *Orderer) AddHostPortToProfile(initProfile *configtx.Profile, instance *
 			return errors.Wrapf(err, "failed to find secret '%s'", n.Name)
 		}
 
-		domain := instance.Spec.Domain
-		fqdn := instance.Namespace + "-" + instance.Name + node.Name + "-orderer" + "." + domain
+		// Nodes exposed with a load balancer are reached at the address of their load balancer,
+		// which is only known to the node
+		nodeInstance := &current.IBPOrderer{}
+		err = o.Client.Get(context.TODO(), types.NamespacedName{Name: instance.Name + node.Name, Namespace: instance.Namespace}, nodeInstance)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get node '%s'", instance.Name+node.Name)
+		}
+		host, port := common.GetOrdererAddress(nodeInstance)
+
+		log.Info(fmt.Sprintf("Adding consentor domain '%s' to genesis block", host))
+
+		initProfile.AddOrdererAddress(fmt.Sprintf("%s:%d", host, port))
 
-		log.Info(fmt.Sprintf("Adding consentor domain '%s' to genesis block", fqdn))
+		if instance.Spec.IsBFT() {
+			id, err := common.GetConsenterID(nodeInstance)
+			if err != nil {
+				return err
+			}
+			err = o.AddBFTConsenterToProfile(initProfile, instance, id, node, host, port, tlsSecret.Data["cert.pem"])
+			if err != nil {
+				return err
+			}
+			continue
+		}
 
-		initProfile.AddOrdererAddress(fmt.Sprintf("%s:%d", fqdn, 443))
 		consentors := &etcdraft.Consenter{
-			Host:          fqdn,
-			Port:          443,
+			Host:          host,
+			Port:          uint32(port), // #nosec G115
 			ClientTlsCert: tlsSecret.Data["cert.pem"],
 			ServerTlsCert: tlsSecret.Data["cert.pem"],
 		}
@@ -597,6 +695,45 @@ func (o *Orderer) AddHostPortToProfile(initProfile *configtx.Profile, instance *
 	return nil
 }
 
+// AddBFTConsenterToProfile adds the node to the BFT consenter mapping. Unlike raft, BFT consenters
+// are identified by their enrollment certificate in addition to their TLS certificate.
+func (o *Orderer) AddBFTConsenterToProfile(initProfile *configtx.Profile, instance *current.IBPOrderer, id uint32, node *Node, host string, port int, tlsCert []byte) error {
+	n := types.NamespacedName{
+		Name:      fmt.Sprintf("ecert-%s%s-signcert", instance.Name, node.Name),
+		Namespace: instance.Namespace,
+	}
+
+	ecertSecret := &corev1.Secret{}
+	err := wait.Poll(500*time.Millisecond, o.Config.Operator.Orderer.Timeouts.SecretPoll.Get(), func() (bool, error) {
+		err := o.Client.Get(context.TODO(), n, ecertSecret)
+		if err == nil {
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to find secret '%s'", n.Name)
+	}
+
+	log.Info(fmt.Sprintf("Adding BFT consenter '%s' with id %d to genesis block", host, id))
+
+	consenter := &cb.Consenter{
+		Id:            id,
+		Host:          host,
+		Port:          uint32(port), // #nosec G115
+		MspId:         instance.Spec.MSPID,
+		Identity:      ecertSecret.Data["cert.pem"],
+		ClientTlsCert: tlsCert,
+		ServerTlsCert: tlsCert,
+	}
+	err = configtx.ValidateBFTConsenter(consenter)
+	if err != nil {
+		return err
+	}
+
+	return initProfile.AddBFTConsentingNode(consenter)
+}
+
 func (o *Orderer) GetMSPConfig(instance *current.IBPOrderer, ID string) (*msp.MSPConfig, error) {
 	isIntermediate := false
 	admincert := [][]byte{}
@@ -35,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -113,6 +114,63 @@ var _ = Describe("Base Orderer", func() {
 		})
 	})
 
+	Context("BFT checks", func() {
+		BeforeEach(func() {
+			instance.Spec.OrdererType = "BFT"
+			instance.Spec.ClusterSize = 4
+			instance.Spec.FabricVersion = "3.0.0"
+			instance.Spec.UseChannelLess = pointer.Bool(true)
+		})
+
+		It("returns no error for valid BFT cluster", func() {
+			err := orderer.BFTChecks(instance)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error if cluster size is less than 3f+1", func() {
+			instance.Spec.ClusterSize = 3
+			err := orderer.BFTChecks(instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("requires a cluster size of at least 4"))
+		})
+
+		It("returns an error if cluster size is not set", func() {
+			instance.Spec.ClusterSize = 0
+			err := orderer.BFTChecks(instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("requires a cluster size of at least 4 (3f+1), cluster size is 1"))
+		})
+
+		It("does not check cluster size on a cluster node", func() {
+			instance.Spec.ClusterSize = 1
+			nodeNumber := 1
+			instance.Spec.NodeNumber = &nodeNumber
+			err := orderer.BFTChecks(instance)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error if system channel is used", func() {
+			instance.Spec.UseChannelLess = nil
+			err := orderer.BFTChecks(instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not support a system channel"))
+		})
+
+		It("returns an error if fabric version does not support BFT", func() {
+			instance.Spec.FabricVersion = "2.5.4"
+			err := orderer.BFTChecks(instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("requires fabric version 3.0.0 or higher"))
+		})
+
+		It("returns an error if fabric version is not set", func() {
+			instance.Spec.FabricVersion = ""
+			err := orderer.BFTChecks(instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("requires fabric version 3.0.0 or higher"))
+		})
+	})
+
 	Context("check csr hosts", func() {
 		It("adds csr hosts if not present", func() {
 			instance = &current.IBPOrderer{
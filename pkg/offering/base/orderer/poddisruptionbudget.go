@@ -0,0 +1,81 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package baseorderer
+
+import (
+	"fmt"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	policyv1 "k8s.io/api/policy/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// GetPodDisruptionBudgetName returns the name of the pod disruption budget shared by the
+// nodes of the cluster of the node
+func (n *Node) GetPodDisruptionBudgetName(instance v1.Object) string {
+	return fmt.Sprintf("%s-pdb", n.GetLabels(instance)["orderingservice"])
+}
+
+// GetPodDisruptionBudgetLabels returns the labels of the cluster of the node, without the
+// labels that identify the node itself
+func (n *Node) GetPodDisruptionBudgetLabels(instance v1.Object) map[string]string {
+	labels := n.GetLabels(instance)
+	delete(labels, "app")
+	delete(labels, "orderingnode")
+	return labels
+}
+
+// PodDisruptionBudget sets the budget of the nodes of the cluster so that voluntary
+// disruptions, such as node drains, never take down more nodes than the cluster can lose
+// while keeping quorum. Clusters that can't lose any node, e.g. raft clusters of one or two
+// nodes, allow no voluntary disruption at all and block node drains until they are scaled up.
+func (n *Node) PodDisruptionBudget(object v1.Object, pdb *policyv1.PodDisruptionBudget, action resources.Action) error {
+	instance := object.(*current.IBPOrderer)
+
+	nodes, err := n.GetClusterNodes(instance)
+	if err != nil {
+		return err
+	}
+
+	max := MaxUnavailable(len(nodes), instance.Spec.IsBFT())
+	if max == 0 {
+		log.Info(fmt.Sprintf("Orderer cluster '%s' of %d node(s) can't lose a node without losing quorum, the pod disruption budget allows no disruption", n.GetLabels(instance)["orderingservice"], len(nodes)))
+	}
+	maxUnavailable := intstr.FromInt(max)
+	pdb.Spec.MaxUnavailable = &maxUnavailable
+	pdb.Spec.Selector = &v1.LabelSelector{
+		MatchLabels: map[string]string{
+			"orderingservice": n.GetLabels(instance)["orderingservice"],
+		},
+	}
+
+	return nil
+}
+
+// MaxUnavailable returns the number of nodes of a cluster that can be unavailable without the
+// cluster losing quorum, 0 if the cluster can't lose any node
+func MaxUnavailable(nodes int, bft bool) int {
+	maxUnavailable := nodes - Quorum(nodes, bft)
+	if maxUnavailable < 0 {
+		return 0
+	}
+	return maxUnavailable
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package baseorderer_test
+
+import (
+	"context"
+	"fmt"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	cmocks "github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	baseorderer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	policyv1 "k8s.io/api/policy/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Base Orderer Pod Disruption Budget", func() {
+	var (
+		instance   *current.IBPOrderer
+		node       *baseorderer.Node
+		mockClient *cmocks.Client
+		clusterLen int
+	)
+
+	BeforeEach(func() {
+		nodeNumber := 1
+		instance = &current.IBPOrderer{
+			Spec: current.IBPOrdererSpec{
+				NodeNumber: &nodeNumber,
+			},
+		}
+		instance.Name = "orderer1node1"
+		instance.Namespace = "namespace"
+		instance.Labels = map[string]string{"parent": "orderer1"}
+
+		clusterLen = 5
+		mockClient = &cmocks.Client{}
+		mockClient.ListStub = func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+			list := obj.(*current.IBPOrdererList)
+			for i := 1; i <= clusterLen; i++ {
+				item := current.IBPOrderer{}
+				item.Name = fmt.Sprintf("orderer1node%d", i)
+				list.Items = append(list.Items, item)
+			}
+			return nil
+		}
+
+		node = &baseorderer.Node{
+			Client: mockClient,
+		}
+	})
+
+	Context("max unavailable", func() {
+		It("returns the number of raft nodes that can be lost while keeping quorum", func() {
+			Expect(baseorderer.MaxUnavailable(3, false)).To(Equal(1))
+			Expect(baseorderer.MaxUnavailable(5, false)).To(Equal(2))
+		})
+
+		It("returns the number of BFT nodes that can be lost while keeping quorum", func() {
+			Expect(baseorderer.MaxUnavailable(4, true)).To(Equal(1))
+			Expect(baseorderer.MaxUnavailable(7, true)).To(Equal(2))
+		})
+
+		It("allows no node to be disrupted if the cluster can't lose a node", func() {
+			Expect(baseorderer.MaxUnavailable(1, false)).To(Equal(0))
+			Expect(baseorderer.MaxUnavailable(2, false)).To(Equal(0))
+			Expect(baseorderer.MaxUnavailable(3, true)).To(Equal(0))
+		})
+	})
+
+	Context("pod disruption budget", func() {
+		It("is shared by the nodes of the cluster", func() {
+			Expect(node.GetPodDisruptionBudgetName(instance)).To(Equal("orderer1-pdb"))
+			Expect(node.GetPodDisruptionBudgetLabels(instance)).NotTo(HaveKey("app"))
+		})
+
+		It("selects the pods of the cluster and derives the budget from quorum", func() {
+			pdb := &policyv1.PodDisruptionBudget{}
+			err := node.PodDisruptionBudget(instance, pdb, resources.Create)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pdb.Spec.Selector.MatchLabels).To(Equal(map[string]string{"orderingservice": "orderer1"}))
+			Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(2))
+		})
+
+		It("updates the budget when the size of the cluster changes", func() {
+			clusterLen = 3
+			pdb := &policyv1.PodDisruptionBudget{}
+			err := node.PodDisruptionBudget(instance, pdb, resources.Update)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+		})
+	})
+})
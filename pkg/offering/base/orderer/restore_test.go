@@ -0,0 +1,128 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package baseorderer_test
+
+import (
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	cmocks "github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	managermocks "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/mocks"
+	baseorderer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer"
+	orderermocks "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Base Orderer Node Restore", func() {
+	var (
+		node          *baseorderer.Node
+		instance      *current.IBPOrderer
+		deploymentMgr *orderermocks.DeploymentManager
+		pvcMgr        *managermocks.ResourceManager
+		restorer      *orderermocks.Restorer
+	)
+
+	BeforeEach(func() {
+		deploymentMgr = &orderermocks.DeploymentManager{}
+		pvcMgr = &managermocks.ResourceManager{}
+		pvcMgr.GetNameReturns("orderer1node1-pvc")
+		restorer = &orderermocks.Restorer{}
+		restorer.RestoreVolumeReturns(true, nil)
+
+		instance = &current.IBPOrderer{
+			Spec: current.IBPOrdererSpec{
+				RestoreFrom: &current.RestoreFrom{
+					Backup: "nightly",
+				},
+			},
+		}
+		instance.Name = "orderer1node1"
+		instance.Namespace = "namespace"
+
+		node = &baseorderer.Node{
+			Client:            &cmocks.Client{},
+			DeploymentManager: deploymentMgr,
+			PVCManager:        pvcMgr,
+			Restorer:          restorer,
+		}
+	})
+
+	Context("restore volumes", func() {
+		It("does nothing if the node is not restored from a backup", func() {
+			instance.Spec.RestoreFrom = nil
+			restored, err := node.RestoreVolumes(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(Equal(true))
+			Expect(pvcMgr.ReconcileCallCount()).To(Equal(0))
+		})
+
+		It("does nothing if the deployment already exists", func() {
+			deploymentMgr.ExistsReturns(true)
+			restored, err := node.RestoreVolumes(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(Equal(true))
+			Expect(restorer.RestoreVolumeCallCount()).To(Equal(0))
+		})
+
+		It("restores the node volume", func() {
+			restored, err := node.RestoreVolumes(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(Equal(true))
+			Expect(pvcMgr.ReconcileCallCount()).To(Equal(1))
+			_, _, volume := restorer.RestoreVolumeArgsForCall(0)
+			Expect(volume).To(Equal("orderer1node1-pvc"))
+		})
+
+		It("returns false while the volume is being restored", func() {
+			restorer.RestoreVolumeReturns(false, nil)
+			restored, err := node.RestoreVolumes(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(Equal(false))
+		})
+
+		It("returns an error if restoring the volume fails", func() {
+			restorer.RestoreVolumeReturns(false, errors.New("restore job failed"))
+			_, err := node.RestoreVolumes(instance)
+			Expect(err).To(MatchError("restore job failed"))
+		})
+	})
+
+	Context("restore pvc override", func() {
+		var override func(v1.Object, *corev1.PersistentVolumeClaim, resources.Action) error
+
+		BeforeEach(func() {
+			override = node.RestorePVC(func(v1.Object, *corev1.PersistentVolumeClaim, resources.Action) error {
+				return nil
+			})
+		})
+
+		It("provisions the claim from the backup on create", func() {
+			Expect(override(instance, &corev1.PersistentVolumeClaim{}, resources.Create)).To(Succeed())
+			Expect(restorer.RestorePVCCallCount()).To(Equal(1))
+		})
+
+		It("does not restore the claim on update", func() {
+			Expect(override(instance, &corev1.PersistentVolumeClaim{}, resources.Update)).To(Succeed())
+			Expect(restorer.RestorePVCCallCount()).To(Equal(0))
+		})
+	})
+})
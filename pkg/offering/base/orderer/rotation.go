@@ -0,0 +1,249 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package baseorderer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel/peeradmin"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/events"
+	commoninit "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TLSCertRotationRequeueInterval is the interval at which a node that is replacing its consenter
+// certificate on the channels of the cluster is reconciled again
+const TLSCertRotationRequeueInterval = 30 * time.Second
+
+// GetTLSCertRotationSecretName returns the name of the secret that holds the new TLS certificate
+// and key of the node while its consenter certificate is being replaced
+func GetTLSCertRotationSecretName(instance *current.IBPOrderer) string {
+	return fmt.Sprintf("tls-%s-rotation", instance.GetName())
+}
+
+// StageTLSCertNewKey reenrolls the TLS certificate of the node with a new key and stores the
+// certificate and key in the rotation secret. Consenters are identified by their TLS certificate,
+// so the node only starts using the new key once its consenter certificate has been replaced on
+// every channel.
+func (n *Node) StageTLSCertNewKey(instance *current.IBPOrderer) error {
+	if instance.Spec.Secret == nil || instance.Spec.Secret.Enrollment == nil {
+		return errors.New("cannot reenroll TLS certificate created by MSP, force renewal required")
+	}
+
+	cert, key, err := n.CertificateManager.ReenrollTLSCert(instance, instance.Spec.Secret.Enrollment, n.GetInitStoragePath(instance), true)
+	if err != nil {
+		events.Warning(n.Recorder, instance, events.CertificateRenewalFailed, "Failed to renew %s certificate: %s", commoninit.TLS, err)
+		return err
+	}
+
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			"cert.pem": cert,
+			"key.pem":  key,
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	secret.Name = GetTLSCertRotationSecretName(instance)
+	secret.Namespace = instance.GetNamespace()
+	secret.Labels = instance.GetLabels()
+
+	err = n.Client.CreateOrUpdate(context.TODO(), secret, k8sclient.CreateOrUpdateOption{Owner: instance, Scheme: n.Scheme})
+	if err != nil {
+		return errors.Wrap(err, "failed to create TLS certificate rotation secret")
+	}
+
+	log.Info(fmt.Sprintf("Staged new TLS certificate of node '%s', replacing its consenter certificate on the channels of the cluster", instance.GetName()))
+	return nil
+}
+
+// ReconcileTLSCertRotation replaces the consenter certificate of the node on every channel of the
+// cluster with the staged TLS certificate. Once the config updates have been committed to every
+// channel, the TLS secrets of the node are updated with the staged certificate and key, which
+// restarts the node with the new certificate.
+func (n *Node) ReconcileTLSCertRotation(instance *current.IBPOrderer) (common.Result, error) {
+	secret := &corev1.Secret{}
+	err := n.Client.Get(context.TODO(), types.NamespacedName{Name: GetTLSCertRotationSecretName(instance), Namespace: instance.GetNamespace()}, secret)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return common.Result{}, nil
+		}
+		return common.Result{}, errors.Wrap(err, "failed to get TLS certificate rotation secret")
+	}
+
+	cert := secret.Data["cert.pem"]
+	key := secret.Data["key.pem"]
+	if len(cert) == 0 || len(key) == 0 {
+		return common.Result{}, errors.Errorf("TLS certificate rotation secret '%s' does not contain a certificate and key", secret.GetName())
+	}
+
+	nodes, err := n.GetClusterNodes(instance)
+	if err != nil {
+		return common.Result{}, err
+	}
+
+	// The config updates are submitted through another node of the cluster, as the node stops
+	// being part of a channel once the update is committed and until it is restarted
+	admin := instance
+	for i := range nodes {
+		if nodes[i].GetName() != instance.GetName() && nodes[i].Status.Type == current.Deployed {
+			admin = &nodes[i]
+			break
+		}
+	}
+
+	replaced, err := n.ReplaceConsenterCert(admin, instance, nodes, cert)
+	if err != nil {
+		return common.Result{}, err
+	}
+	if !replaced {
+		log.Info(fmt.Sprintf("Waiting for consenter certificate of node '%s' to be replaced on all channels", instance.GetName()))
+		return common.Result{
+			Result: reconcile.Result{
+				RequeueAfter: TLSCertRotationRequeueInterval,
+			},
+		}, nil
+	}
+
+	log.Info(fmt.Sprintf("Consenter certificate of node '%s' replaced on all channels, updating TLS certificate", instance.GetName()))
+	err = n.CertificateManager.UpdateSignCert(fmt.Sprintf("%s-%s-signcert", commoninit.TLS, instance.GetName()), cert, instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to update TLS signcert secret")
+	}
+
+	err = n.CertificateManager.UpdateKey(fmt.Sprintf("%s-%s-keystore", commoninit.TLS, instance.GetName()), key, instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to update TLS keystore secret")
+	}
+	events.Normal(n.Recorder, instance, events.CertificateRenewed, "Renewed %s certificate", commoninit.TLS)
+
+	err = n.Client.Delete(context.TODO(), secret)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return common.Result{}, errors.Wrap(err, "failed to delete TLS certificate rotation secret")
+	}
+
+	return common.Result{}, nil
+}
+
+// ReplaceConsenterCert submits the config updates that replace the consenter certificate of the
+// node with the TLS certificate on the channels of the cluster, using the identity of the admin
+// node. Returns true once the node uses the certificate on every channel it is a consenter of.
+func (n *Node) ReplaceConsenterCert(admin, node *current.IBPOrderer, nodes []current.IBPOrderer, tlsCert []byte) (bool, error) {
+	access, err := getNodeAdmin(n.Client, admin)
+	if err != nil {
+		return false, err
+	}
+
+	channels, err := n.ChannelAdmin.ListChannels(access.adminURL, access.creds)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list channels of node '%s'", admin.GetName())
+	}
+
+	host, port := common.GetOrdererAddress(node)
+	replaced := true
+	for _, channelID := range channels {
+		config, err := n.ChannelAdmin.FetchConfig(access.endpoint, access.signer, channelID)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to fetch config of channel '%s'", channelID)
+		}
+
+		update, err := peeradmin.UpdateConsenterCertUpdate(config, channelID, host, uint32(port), tlsCert) // #nosec G115
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to compute config update of channel '%s'", channelID)
+		}
+		if update == nil {
+			continue
+		}
+		replaced = false
+
+		err = checkRotationQuorum(config, channelID, node, nodes)
+		if err != nil {
+			return false, err
+		}
+
+		log.Info(fmt.Sprintf("Replacing certificate of consenter '%s' on channel '%s'", ConsenterAddress(node), channelID))
+		err = n.ChannelAdmin.UpdateConfig(access.endpoint, access.signer, update)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to replace certificate of consenter '%s' on channel '%s'", ConsenterAddress(node), channelID)
+		}
+	}
+
+	return replaced, nil
+}
+
+// checkRotationQuorum returns an error if the channel would lose quorum while the node is
+// unavailable, from the time its consenter certificate is replaced until it is restarted with
+// the new certificate
+func checkRotationQuorum(config *cb.Config, channelID string, node *current.IBPOrderer, nodes []current.IBPOrderer) error {
+	consenters, bft, err := peeradmin.Consenters(config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get consenters of channel '%s'", channelID)
+	}
+
+	available := len(consenters)
+	for i := range nodes {
+		if nodes[i].GetName() == node.GetName() || nodes[i].Status.Type != current.Deployed {
+			if util.ContainsValue(ConsenterAddress(&nodes[i]), consenters) {
+				available--
+			}
+		}
+	}
+
+	if available < Quorum(len(consenters), bft) {
+		return errors.Errorf("refusing to replace certificate of node '%s' on channel '%s', quorum would be lost: %d of %d consenters would be available", node.GetName(), channelID, available, len(consenters))
+	}
+
+	return nil
+}
+
+// GetClusterNodes returns the nodes of the cluster of the node
+func (n *Node) GetClusterNodes(instance *current.IBPOrderer) ([]current.IBPOrderer, error) {
+	parent := instance.GetLabels()["parent"]
+	if parent == "" {
+		return []current.IBPOrderer{*instance}, nil
+	}
+
+	labelSelector, err := labels.Parse(fmt.Sprintf("parent=%s", parent))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse selector for parent name")
+	}
+
+	ordererList := &current.IBPOrdererList{}
+	err = n.Client.List(context.TODO(), ordererList, &client.ListOptions{
+		LabelSelector: labelSelector,
+		Namespace:     instance.GetNamespace(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list nodes of cluster '%s'", parent)
+	}
+
+	return ordererList.Items, nil
+}
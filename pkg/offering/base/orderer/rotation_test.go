@@ -0,0 +1,157 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package baseorderer_test
+
+import (
+	"context"
+	"errors"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	cmocks "github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	baseorderer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer"
+	orderermocks "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Base Orderer TLS Certificate Rotation", func() {
+	var (
+		instance       *current.IBPOrderer
+		node           *baseorderer.Node
+		mockClient     *cmocks.Client
+		certificateMgr *orderermocks.CertificateManager
+		channelAdmin   *orderermocks.ChannelAdmin
+	)
+
+	BeforeEach(func() {
+		nodeNumber := 1
+		instance = &current.IBPOrderer{
+			Spec: current.IBPOrdererSpec{
+				NodeNumber: &nodeNumber,
+				Domain:     "example.com",
+				Secret: &current.SecretSpec{
+					Enrollment: &current.EnrollmentSpec{},
+				},
+			},
+		}
+		instance.Name = "orderer1node1"
+		instance.Namespace = "namespace"
+
+		mockClient = &cmocks.Client{}
+		certificateMgr = &orderermocks.CertificateManager{}
+		certificateMgr.ReenrollTLSCertReturns([]byte("cert"), []byte("key"), nil)
+		channelAdmin = &orderermocks.ChannelAdmin{}
+
+		node = &baseorderer.Node{
+			Client:             mockClient,
+			CertificateManager: certificateMgr,
+			ChannelAdmin:       channelAdmin,
+			Recorder:           record.NewFakeRecorder(10),
+		}
+	})
+
+	Context("stage TLS cert with new key", func() {
+		It("returns an error if the certificate was not created by enrollment", func() {
+			instance.Spec.Secret.Enrollment = nil
+			err := node.StageTLSCertNewKey(instance)
+			Expect(err).To(MatchError(ContainSubstring("cannot reenroll TLS certificate created by MSP")))
+			Expect(certificateMgr.ReenrollTLSCertCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if reenroll fails", func() {
+			certificateMgr.ReenrollTLSCertReturns(nil, nil, errors.New("reenroll error"))
+			err := node.StageTLSCertNewKey(instance)
+			Expect(err).To(MatchError(ContainSubstring("reenroll error")))
+			Expect(mockClient.CreateOrUpdateCallCount()).To(Equal(0))
+		})
+
+		It("stores the new certificate and key in the rotation secret", func() {
+			err := node.StageTLSCertNewKey(instance)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, _, newKey := certificateMgr.ReenrollTLSCertArgsForCall(0)
+			Expect(newKey).To(Equal(true))
+
+			Expect(mockClient.CreateOrUpdateCallCount()).To(Equal(1))
+			_, obj, _ := mockClient.CreateOrUpdateArgsForCall(0)
+			secret := obj.(*corev1.Secret)
+			Expect(secret.Name).To(Equal("tls-orderer1node1-rotation"))
+			Expect(secret.Data["cert.pem"]).To(Equal([]byte("cert")))
+			Expect(secret.Data["key.pem"]).To(Equal([]byte("key")))
+
+			Expect(certificateMgr.UpdateSignCertCallCount()).To(Equal(0))
+			Expect(certificateMgr.UpdateKeyCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("reconcile TLS cert rotation", func() {
+		It("does nothing if no rotation is in progress", func() {
+			mockClient.GetStub = func(ctx context.Context, key types.NamespacedName, obj client.Object) error {
+				return k8serrors.NewNotFound(schema.GroupResource{}, "not found")
+			}
+
+			result, err := node.ReconcileTLSCertRotation(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			Expect(mockClient.ListCallCount()).To(Equal(0))
+			Expect(channelAdmin.ListChannelsCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if the rotation secret is missing the certificate", func() {
+			mockClient.GetStub = func(ctx context.Context, key types.NamespacedName, obj client.Object) error {
+				o := obj.(*corev1.Secret)
+				o.Data = map[string][]byte{"key.pem": []byte("key")}
+				return nil
+			}
+
+			_, err := node.ReconcileTLSCertRotation(instance)
+			Expect(err).To(MatchError(ContainSubstring("does not contain a certificate and key")))
+			Expect(certificateMgr.UpdateSignCertCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if the admin endpoint of the cluster cannot be found", func() {
+			mockClient.GetStub = func(ctx context.Context, key types.NamespacedName, obj client.Object) error {
+				switch obj.(type) {
+				case *corev1.Secret:
+					o := obj.(*corev1.Secret)
+					o.Data = map[string][]byte{"cert.pem": []byte("cert"), "key.pem": []byte("key")}
+					return nil
+				}
+				return errors.New("not found")
+			}
+
+			_, err := node.ReconcileTLSCertRotation(instance)
+			Expect(err).To(MatchError(ContainSubstring("failed to get connection profile of orderer node 'orderer1node1'")))
+			Expect(certificateMgr.UpdateSignCertCallCount()).To(Equal(0))
+			Expect(certificateMgr.UpdateKeyCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("rotation secret name", func() {
+		It("does not use the name of a TLS secret that triggers a restart", func() {
+			Expect(baseorderer.GetTLSCertRotationSecretName(instance)).To(Equal("tls-orderer1node1-rotation"))
+		})
+	})
+})
@@ -0,0 +1,311 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package baseorderer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel/participation"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel/peeradmin"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ScaleDownRequeueInterval is the interval at which a cluster that is removing a
+	// consenter from its channels is reconciled again
+	ScaleDownRequeueInterval = 30 * time.Second
+)
+
+//go:generate counterfeiter -o mocks/channel_admin.go -fake-name ChannelAdmin . ChannelAdmin
+
+type ChannelAdmin interface {
+	ListChannels(adminURL string, creds *participation.Credentials) ([]string, error)
+	GetChannel(adminURL string, creds *participation.Credentials, channelID string) (*participation.ChannelInfo, error)
+	Join(adminURL string, creds *participation.Credentials, block []byte) (*participation.ChannelInfo, error)
+	FetchConfig(orderer *peeradmin.Endpoint, signer *peeradmin.Signer, channelID string) (*cb.Config, error)
+	FetchConfigBlock(orderer *peeradmin.Endpoint, signer *peeradmin.Signer, channelID string) (*cb.Block, error)
+	UpdateConfig(orderer *peeradmin.Endpoint, signer *peeradmin.Signer, update *cb.ConfigUpdate) error
+}
+
+// clusterAdmin lists the channels of a node through the channel participation API and
+// updates the channel configs through the orderer API
+type clusterAdmin struct {
+	participation *participation.Client
+	orderer       *peeradmin.Client
+}
+
+func (a *clusterAdmin) ListChannels(adminURL string, creds *participation.Credentials) ([]string, error) {
+	return a.participation.ListChannels(adminURL, creds)
+}
+
+func (a *clusterAdmin) GetChannel(adminURL string, creds *participation.Credentials, channelID string) (*participation.ChannelInfo, error) {
+	return a.participation.GetChannel(adminURL, creds, channelID)
+}
+
+func (a *clusterAdmin) Join(adminURL string, creds *participation.Credentials, block []byte) (*participation.ChannelInfo, error) {
+	return a.participation.Join(adminURL, creds, block)
+}
+
+func (a *clusterAdmin) FetchConfigBlock(orderer *peeradmin.Endpoint, signer *peeradmin.Signer, channelID string) (*cb.Block, error) {
+	return a.orderer.FetchConfigBlock(orderer, signer, channelID)
+}
+
+func (a *clusterAdmin) FetchConfig(orderer *peeradmin.Endpoint, signer *peeradmin.Signer, channelID string) (*cb.Config, error) {
+	return a.orderer.FetchConfig(orderer, signer, channelID)
+}
+
+func (a *clusterAdmin) UpdateConfig(orderer *peeradmin.Endpoint, signer *peeradmin.Signer, update *cb.ConfigUpdate) error {
+	return a.orderer.UpdateConfig(orderer, signer, update)
+}
+
+func newClusterAdmin(timeout time.Duration) ChannelAdmin {
+	return &clusterAdmin{
+		participation: participation.New(timeout),
+		orderer:       peeradmin.New(timeout),
+	}
+}
+
+// Quorum returns the number of consenters of a channel that must be available for the
+// channel to order transactions, 2f+1 of 3f+1 BFT consenters or a majority of raft consenters
+func Quorum(consenters int, bft bool) int {
+	if bft {
+		f := (consenters - 1) / 3
+		return (consenters + f + 2) / 2
+	}
+	return consenters/2 + 1
+}
+
+// ConsenterAddress returns the host:port the node is registered with as a consenter
+func ConsenterAddress(node *current.IBPOrderer) string {
+	host, port := common.GetOrdererAddress(node)
+	return fmt.Sprintf("%s:%d", host, port)
+}
+
+// ScaleDown removes the node with the highest number from the cluster once the cluster size
+// has been decreased. The consenter of the node is removed from every channel first, and the
+// node is only deleted once the removal has been committed to every channel.
+func (o *Orderer) ScaleDown(instance *current.IBPOrderer, nodes []current.IBPOrderer) (common.Result, error) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodeNumber(&nodes[i]) < nodeNumber(&nodes[j])
+	})
+	leaving := &nodes[len(nodes)-1]
+	if nodeNumber(leaving) <= instance.Spec.ClusterSize {
+		return common.Result{}, errors.Errorf("cluster has %d nodes but no node with a number greater than cluster size %d", len(nodes), instance.Spec.ClusterSize)
+	}
+
+	log.Info(fmt.Sprintf("Scaling down cluster '%s' to %d nodes, removing node '%s'", instance.GetName(), instance.Spec.ClusterSize, leaving.GetName()))
+
+	var admin *current.IBPOrderer
+	for i := range nodes {
+		if &nodes[i] != leaving && nodes[i].Status.Type == current.Deployed {
+			admin = &nodes[i]
+			break
+		}
+	}
+	if admin == nil {
+		return common.Result{}, errors.Errorf("no deployed node left in cluster '%s' to remove node '%s' from its channels", instance.GetName(), leaving.GetName())
+	}
+
+	removed, err := o.RemoveConsenter(admin, leaving, nodes)
+	if err != nil {
+		return common.Result{}, err
+	}
+	if !removed {
+		log.Info(fmt.Sprintf("Waiting for removal of node '%s' from channels to be committed", leaving.GetName()))
+		return common.Result{
+			Result: reconcile.Result{
+				RequeueAfter: ScaleDownRequeueInterval,
+			},
+		}, nil
+	}
+
+	log.Info(fmt.Sprintf("Node '%s' has been removed from all channels, deleting node", leaving.GetName()))
+	err = o.GetNode(nodeNumber(leaving)).Delete(leaving)
+	if err != nil {
+		return common.Result{}, errors.Wrapf(err, "failed to delete resources of node '%s'", leaving.GetName())
+	}
+
+	err = o.Client.Delete(context.TODO(), leaving)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return common.Result{}, errors.Wrapf(err, "failed to delete node '%s'", leaving.GetName())
+	}
+
+	return common.Result{
+		Result: reconcile.Result{
+			Requeue: true,
+		},
+	}, nil
+}
+
+// RemoveConsenter submits the config updates that remove the leaving node from the consenters
+// of the channels of the cluster, using the identity of the admin node. Returns true once the
+// leaving node is no longer a consenter of any channel.
+func (o *Orderer) RemoveConsenter(admin, leaving *current.IBPOrderer, nodes []current.IBPOrderer) (bool, error) {
+	access, err := getNodeAdmin(o.Client, admin)
+	if err != nil {
+		return false, err
+	}
+
+	channels, err := o.ChannelAdmin.ListChannels(access.adminURL, access.creds)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list channels of node '%s'", admin.GetName())
+	}
+
+	host, port := common.GetOrdererAddress(leaving)
+	removed := true
+	for _, channelID := range channels {
+		config, err := o.ChannelAdmin.FetchConfig(access.endpoint, access.signer, channelID)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to fetch config of channel '%s'", channelID)
+		}
+
+		update, err := peeradmin.RemoveConsenterUpdate(config, channelID, host, uint32(port)) // #nosec G115
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to compute config update of channel '%s'", channelID)
+		}
+		if update == nil {
+			continue
+		}
+		removed = false
+
+		err = CheckQuorum(config, channelID, leaving, nodes)
+		if err != nil {
+			return false, err
+		}
+
+		log.Info(fmt.Sprintf("Removing consenter '%s' from channel '%s'", ConsenterAddress(leaving), channelID))
+		err = o.ChannelAdmin.UpdateConfig(access.endpoint, access.signer, update)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to remove consenter '%s' from channel '%s'", ConsenterAddress(leaving), channelID)
+		}
+	}
+
+	return removed, nil
+}
+
+// CheckQuorum returns an error if removing the leaving node from the consenters of the channel
+// would cause the channel to lose quorum. The update needs a quorum of the current consenters
+// to be ordered, and the remaining consenters need a quorum of their own afterwards. Consenters
+// that are not nodes of the cluster cannot be observed and are assumed to be available.
+func CheckQuorum(config *cb.Config, channelID string, leaving *current.IBPOrderer, nodes []current.IBPOrderer) error {
+	consenters, bft, err := peeradmin.Consenters(config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get consenters of channel '%s'", channelID)
+	}
+
+	if !util.ContainsValue(ConsenterAddress(leaving), consenters) {
+		// Only the orderer endpoint of the node is removed
+		return nil
+	}
+
+	if len(consenters) <= 1 {
+		return errors.Errorf("refusing to remove node '%s', it is the last consenter of channel '%s'", leaving.GetName(), channelID)
+	}
+
+	unavailable := 0
+	for i := range nodes {
+		if util.ContainsValue(ConsenterAddress(&nodes[i]), consenters) && nodes[i].Status.Type != current.Deployed {
+			unavailable++
+		}
+	}
+
+	available := len(consenters) - unavailable
+	remaining := available
+	if leaving.Status.Type == current.Deployed {
+		remaining--
+	}
+
+	if available < Quorum(len(consenters), bft) || remaining < Quorum(len(consenters)-1, bft) {
+		return errors.Errorf("refusing to remove node '%s' from channel '%s', quorum would be lost: %d of %d consenters are available", leaving.GetName(), channelID, available, len(consenters))
+	}
+
+	return nil
+}
+
+// nodeAdmin is the access to a node of the cluster that is used to administer the channels
+// of the cluster
+type nodeAdmin struct {
+	adminURL string
+	creds    *participation.Credentials
+	endpoint *peeradmin.Endpoint
+	signer   *peeradmin.Signer
+}
+
+func getNodeAdmin(client k8sclient.Client, node *current.IBPOrderer) (*nodeAdmin, error) {
+	adminURL, creds, err := (&channel.Channel{Client: client}).GetAdminAccess(node)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := GetNodeSigner(client, node)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nodeAdmin{
+		adminURL: adminURL,
+		creds:    creds,
+		endpoint: &peeradmin.Endpoint{
+			Address:    ConsenterAddress(node),
+			TLSCACerts: creds.RootCAs,
+			ClientCert: creds.Cert,
+			ClientKey:  creds.Key,
+		},
+		signer: signer,
+	}, nil
+}
+
+// GetNodeSigner returns the signer for the enrollment identity of the node. Channels created
+// by the operator grant every member of the orderer organization the admin role.
+func GetNodeSigner(client k8sclient.Client, node *current.IBPOrderer) (*peeradmin.Signer, error) {
+	cert, err := common.GetEcertSignCertBytes(client, node)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get ecert signcert of node '%s'", node.GetName())
+	}
+
+	key, err := common.GetEcertKeystoreBytes(client, node)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get ecert keystore of node '%s'", node.GetName())
+	}
+
+	signer, err := peeradmin.NewSigner(node.Spec.MSPID, cert, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load enrollment identity of node '%s'", node.GetName())
+	}
+
+	return signer, nil
+}
+
+func nodeNumber(node *current.IBPOrderer) int {
+	if node.Spec.NodeNumber == nil {
+		return 0
+	}
+	return *node.Spec.NodeNumber
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package baseorderer_test
+
+import (
+	"fmt"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	cmocks "github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	baseorderer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer"
+	orderermocks "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer/mocks"
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Base Orderer Scale Down", func() {
+	var (
+		instance     *current.IBPOrderer
+		nodes        []current.IBPOrderer
+		orderer      *baseorderer.Orderer
+		channelAdmin *orderermocks.ChannelAdmin
+		mockClient   *cmocks.Client
+	)
+
+	newNode := func(number int) current.IBPOrderer {
+		node := current.IBPOrderer{
+			Spec: current.IBPOrdererSpec{
+				NodeNumber: &number,
+				Domain:     "example.com",
+			},
+			Status: current.IBPOrdererStatus{
+				CRStatus: current.CRStatus{
+					Type: current.Deployed,
+				},
+			},
+		}
+		node.Name = fmt.Sprintf("orderer1node%d", number)
+		node.Namespace = "namespace"
+		return node
+	}
+
+	newConfig := func(nodes ...current.IBPOrderer) *cb.Config {
+		consenters := []*etcdraft.Consenter{}
+		for i := range nodes {
+			host, _ := baseorderer.GetDomainPort(baseorderer.ConsenterAddress(&nodes[i]))
+			consenters = append(consenters, &etcdraft.Consenter{Host: host, Port: 443})
+		}
+		metadata, err := proto.Marshal(&etcdraft.ConfigMetadata{Consenters: consenters})
+		Expect(err).NotTo(HaveOccurred())
+		consensusType, err := proto.Marshal(&ab.ConsensusType{Type: "etcdraft", Metadata: metadata})
+		Expect(err).NotTo(HaveOccurred())
+
+		return &cb.Config{
+			ChannelGroup: &cb.ConfigGroup{
+				Groups: map[string]*cb.ConfigGroup{
+					"Orderer": {
+						Values: map[string]*cb.ConfigValue{
+							"ConsensusType": {
+								Value: consensusType,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		instance = &current.IBPOrderer{
+			Spec: current.IBPOrdererSpec{
+				ClusterSize: 2,
+			},
+		}
+		instance.Name = "orderer1"
+		instance.Namespace = "namespace"
+
+		nodes = []current.IBPOrderer{newNode(3), newNode(1), newNode(2)}
+
+		channelAdmin = &orderermocks.ChannelAdmin{}
+		mockClient = &cmocks.Client{}
+		orderer = &baseorderer.Orderer{
+			Client:       mockClient,
+			ChannelAdmin: channelAdmin,
+		}
+	})
+
+	Context("quorum", func() {
+		It("returns a majority of raft consenters", func() {
+			Expect(baseorderer.Quorum(1, false)).To(Equal(1))
+			Expect(baseorderer.Quorum(2, false)).To(Equal(2))
+			Expect(baseorderer.Quorum(3, false)).To(Equal(2))
+			Expect(baseorderer.Quorum(4, false)).To(Equal(3))
+			Expect(baseorderer.Quorum(5, false)).To(Equal(3))
+		})
+
+		It("returns 2f+1 of BFT consenters", func() {
+			Expect(baseorderer.Quorum(4, true)).To(Equal(3))
+			Expect(baseorderer.Quorum(5, true)).To(Equal(4))
+			Expect(baseorderer.Quorum(7, true)).To(Equal(5))
+		})
+	})
+
+	Context("check quorum", func() {
+		It("allows removing a consenter if quorum is kept", func() {
+			config := newConfig(nodes...)
+			Expect(baseorderer.CheckQuorum(config, "channel1", &nodes[0], nodes)).To(Succeed())
+		})
+
+		It("allows removing a node that is not a consenter", func() {
+			config := newConfig(nodes[1], nodes[2])
+			Expect(baseorderer.CheckQuorum(config, "channel1", &nodes[0], nodes)).To(Succeed())
+		})
+
+		It("refuses to remove the last consenter", func() {
+			config := newConfig(nodes[0])
+			err := baseorderer.CheckQuorum(config, "channel1", &nodes[0], nodes)
+			Expect(err).To(MatchError(ContainSubstring("it is the last consenter of channel 'channel1'")))
+		})
+
+		It("refuses to remove a consenter if another consenter is unavailable", func() {
+			nodes[1].Status.Type = current.Error
+			config := newConfig(nodes...)
+			err := baseorderer.CheckQuorum(config, "channel1", &nodes[0], nodes)
+			Expect(err).To(MatchError(ContainSubstring("quorum would be lost: 2 of 3 consenters are available")))
+		})
+
+		It("allows removing an unavailable consenter if the remaining consenters have quorum", func() {
+			nodes[0].Status.Type = current.Error
+			config := newConfig(nodes...)
+			Expect(baseorderer.CheckQuorum(config, "channel1", &nodes[0], nodes)).To(Succeed())
+		})
+	})
+
+	Context("scale down", func() {
+		It("returns an error if no node is numbered above the cluster size", func() {
+			instance.Spec.ClusterSize = 3
+			nodes = append(nodes[1:], newNode(2))
+			_, err := orderer.ScaleDown(instance, nodes)
+			Expect(err).To(MatchError(ContainSubstring("no node with a number greater than cluster size 3")))
+		})
+
+		It("returns an error if no other node is deployed", func() {
+			nodes[1].Status.Type = current.Error
+			nodes[2].Status.Type = current.Error
+			_, err := orderer.ScaleDown(instance, nodes)
+			Expect(err).To(MatchError(ContainSubstring("no deployed node left in cluster 'orderer1' to remove node 'orderer1node3'")))
+			Expect(channelAdmin.ListChannelsCallCount()).To(Equal(0))
+			Expect(mockClient.DeleteCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("consenter address", func() {
+		It("returns the address the node is registered with", func() {
+			Expect(baseorderer.ConsenterAddress(&nodes[0])).To(Equal("namespace-orderer1node3-orderer.example.com:443"))
+		})
+	})
+})
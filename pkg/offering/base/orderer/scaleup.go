@@ -0,0 +1,294 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package baseorderer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel/participation"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel/peeradmin"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ScaleUpRequeueInterval is the interval at which a cluster that is joining a node to its
+	// channels is reconciled again
+	ScaleUpRequeueInterval = 10 * time.Second
+
+	// participationStatusActive is the status reported by the channel participation API once
+	// a node has caught up with the channel
+	participationStatusActive = "active"
+)
+
+// JoinPending returns true if a node that was added to the deployed cluster has not been
+// joined to the channels of the cluster yet
+func JoinPending(nodes []current.IBPOrderer) bool {
+	for _, node := range nodes {
+		if node.Spec.IsJoiningCluster() {
+			return true
+		}
+	}
+	return false
+}
+
+// ScaleUp adds nodes to a deployed cluster once the cluster size has been increased. Nodes are
+// added one at a time, the next node is only created once the previous node has joined every
+// channel of the cluster. Clusters bootstrapped with a system channel are not supported, as the
+// nodes of those clusters cannot be joined to channels through the channel participation API.
+func (o *Orderer) ScaleUp(instance *current.IBPOrderer, nodes []current.IBPOrderer) (common.Result, error) {
+	if !instance.Spec.IsUsingChannelLess() {
+		return common.Result{}, errors.Errorf("cannot add nodes to cluster '%s', adding nodes requires a cluster that uses channel participation instead of a system channel", instance.GetName())
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodeNumber(&nodes[i]) < nodeNumber(&nodes[j])
+	})
+
+	for i := range nodes {
+		if nodes[i].Spec.IsJoiningCluster() {
+			return o.JoinCluster(&nodes[i], nodes)
+		}
+	}
+
+	if len(nodes) >= instance.Spec.ClusterSize {
+		return common.Result{}, nil
+	}
+
+	number := 1
+	if len(nodes) > 0 {
+		number = nodeNumber(&nodes[len(nodes)-1]) + 1
+	}
+
+	log.Info(fmt.Sprintf("Scaling up cluster '%s' to %d nodes, adding node %d", instance.GetName(), instance.Spec.ClusterSize, number))
+	err := o.createNodeCR(instance, number, true)
+	if err != nil {
+		return common.Result{}, err
+	}
+
+	return common.Result{
+		Result: reconcile.Result{
+			RequeueAfter: ScaleUpRequeueInterval,
+		},
+	}, nil
+}
+
+// JoinCluster joins the node to the channels of the cluster one channel at a time. The node is
+// joined to a channel as a follower first, and is added as a consenter of the channel once it
+// has caught up. The join status of every channel is recorded in the status of the node.
+func (o *Orderer) JoinCluster(joining *current.IBPOrderer, nodes []current.IBPOrderer) (common.Result, error) {
+	requeue := common.Result{
+		Result: reconcile.Result{
+			RequeueAfter: ScaleUpRequeueInterval,
+		},
+	}
+
+	if joining.Status.Type != current.Deployed {
+		log.Info(fmt.Sprintf("Waiting for node '%s' to be deployed before joining it to the channels of the cluster", joining.GetName()))
+		return requeue, nil
+	}
+
+	var admin *current.IBPOrderer
+	for i := range nodes {
+		if !nodes[i].Spec.IsJoiningCluster() && nodes[i].Status.Type == current.Deployed {
+			admin = &nodes[i]
+			break
+		}
+	}
+	if admin == nil {
+		return common.Result{}, errors.Errorf("no deployed node in cluster to join node '%s' to the channels of the cluster", joining.GetName())
+	}
+
+	access, err := getNodeAdmin(o.Client, admin)
+	if err != nil {
+		return common.Result{}, err
+	}
+
+	joiningURL, joiningCreds, err := (&channel.Channel{Client: o.Client}).GetAdminAccess(joining)
+	if err != nil {
+		return common.Result{}, err
+	}
+
+	consenter, err := GetConsenter(o.Client, joining)
+	if err != nil {
+		return common.Result{}, err
+	}
+
+	channels, err := o.ChannelAdmin.ListChannels(access.adminURL, access.creds)
+	if err != nil {
+		return common.Result{}, errors.Wrapf(err, "failed to list channels of node '%s'", admin.GetName())
+	}
+
+	statuses := make([]current.OrdererChannelStatus, len(channels))
+	pending := false
+	var joinErr error
+	for i, channelID := range channels {
+		statuses[i] = current.OrdererChannelStatus{
+			Name:   channelID,
+			Status: current.NodePending,
+		}
+		if previous := joining.Status.GetChannel(channelID); previous != nil {
+			statuses[i] = *previous
+		}
+		if pending || joinErr != nil || (statuses[i].Status == current.NodeJoined && statuses[i].Consenter) {
+			continue
+		}
+
+		pending, joinErr = o.joinChannel(access, joiningURL, joiningCreds, consenter, channelID, &statuses[i])
+		if joinErr != nil {
+			statuses[i].Status = current.NodeFailed
+			statuses[i].Message = joinErr.Error()
+		}
+	}
+
+	joining.Status.Channels = statuses
+	err = o.PatchStatus(joining)
+	if err != nil {
+		return common.Result{}, errors.Wrapf(err, "failed to update channel status of node '%s'", joining.GetName())
+	}
+
+	if joinErr != nil {
+		return common.Result{}, joinErr
+	}
+	if pending {
+		return requeue, nil
+	}
+
+	log.Info(fmt.Sprintf("Node '%s' has joined all %d channels of the cluster", joining.GetName(), len(channels)))
+	joining.Spec.JoinCluster = nil
+	err = o.Client.Patch(context.TODO(), joining, nil, k8sclient.PatchOption{
+		Resilient: &k8sclient.ResilientPatch{
+			Retry:    3,
+			Into:     &current.IBPOrderer{},
+			Strategy: client.MergeFrom,
+		},
+	})
+	if err != nil {
+		return common.Result{}, errors.Wrapf(err, "failed to update node '%s'", joining.GetName())
+	}
+
+	// Continue with the next node
+	return common.Result{
+		Result: reconcile.Result{
+			Requeue: true,
+		},
+	}, nil
+}
+
+// joinChannel performs the next step of joining the node to the channel and updates the status
+// of the channel. Returns true if the step has to be observed before the node can continue with
+// the next channel.
+func (o *Orderer) joinChannel(access *nodeAdmin, joiningURL string, joiningCreds *participation.Credentials, consenter *cb.Consenter, channelID string, status *current.OrdererChannelStatus) (bool, error) {
+	info, err := o.ChannelAdmin.GetChannel(joiningURL, joiningCreds, channelID)
+	if err == participation.ErrChannelNotFound {
+		block, err := o.ChannelAdmin.FetchConfigBlock(access.endpoint, access.signer, channelID)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to fetch config block of channel '%s'", channelID)
+		}
+		blockBytes, err := proto.Marshal(block)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to marshal config block")
+		}
+
+		log.Info(fmt.Sprintf("Joining node '%s:%d' to channel '%s' as a follower", consenter.Host, consenter.Port, channelID))
+		_, err = o.ChannelAdmin.Join(joiningURL, joiningCreds, blockBytes)
+		if err != nil && err != participation.ErrChannelExists {
+			return false, errors.Wrapf(err, "failed to join channel '%s'", channelID)
+		}
+
+		status.Status = current.NodePending
+		status.Message = "Joined channel as follower, waiting for node to catch up"
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get channel '%s'", channelID)
+	}
+
+	if info.Status != participationStatusActive {
+		status.Status = current.NodePending
+		status.Message = fmt.Sprintf("Waiting for node to catch up, node is %s at height %d", info.Status, info.Height)
+		return true, nil
+	}
+
+	config, err := o.ChannelAdmin.FetchConfig(access.endpoint, access.signer, channelID)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to fetch config of channel '%s'", channelID)
+	}
+
+	update, err := peeradmin.AddConsenterUpdate(config, channelID, consenter)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to compute config update of channel '%s'", channelID)
+	}
+	if update != nil {
+		log.Info(fmt.Sprintf("Adding consenter '%s:%d' to channel '%s'", consenter.Host, consenter.Port, channelID))
+		err = o.ChannelAdmin.UpdateConfig(access.endpoint, access.signer, update)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to add consenter to channel '%s'", channelID)
+		}
+
+		status.Status = current.NodePending
+		status.Message = "Adding node as consenter"
+		return true, nil
+	}
+
+	status.Status = current.NodeJoined
+	status.Consenter = true
+	status.Message = ""
+	return false, nil
+}
+
+// GetConsenter returns the consenter of the node. The node number is used as the consenter id
+// of BFT channels, as it is for the nodes the channels were created with.
+func GetConsenter(client k8sclient.Client, node *current.IBPOrderer) (*cb.Consenter, error) {
+	tlsCert, err := common.GetTLSSignCertBytes(client, node)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get tls signcert of node '%s'", node.GetName())
+	}
+
+	ecert, err := common.GetEcertSignCertBytes(client, node)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get ecert signcert of node '%s'", node.GetName())
+	}
+
+	id, err := common.GetConsenterID(node)
+	if err != nil {
+		return nil, err
+	}
+
+	host, port := common.GetOrdererAddress(node)
+	return &cb.Consenter{
+		Id:            id,
+		Host:          host,
+		Port:          uint32(port), // #nosec G115
+		MspId:         node.Spec.MSPID,
+		Identity:      ecert,
+		ClientTlsCert: tlsCert,
+		ServerTlsCert: tlsCert,
+	}, nil
+}
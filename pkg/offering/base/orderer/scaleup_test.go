@@ -0,0 +1,164 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package baseorderer_test
+
+import (
+	"context"
+	"fmt"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	cmocks "github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	baseorderer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer"
+	orderermocks "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Base Orderer Scale Up", func() {
+	var (
+		instance     *current.IBPOrderer
+		nodes        []current.IBPOrderer
+		orderer      *baseorderer.Orderer
+		channelAdmin *orderermocks.ChannelAdmin
+		mockClient   *cmocks.Client
+	)
+
+	newNode := func(number int) current.IBPOrderer {
+		node := current.IBPOrderer{
+			Spec: current.IBPOrdererSpec{
+				NodeNumber:     &number,
+				UseChannelLess: pointer.Bool(true),
+			},
+			Status: current.IBPOrdererStatus{
+				CRStatus: current.CRStatus{
+					Type: current.Deployed,
+				},
+			},
+		}
+		node.Name = fmt.Sprintf("orderer1node%d", number)
+		node.Namespace = "namespace"
+		return node
+	}
+
+	BeforeEach(func() {
+		instance = &current.IBPOrderer{
+			Spec: current.IBPOrdererSpec{
+				License: current.License{
+					Accept: true,
+				},
+				ClusterSize:    4,
+				UseChannelLess: pointer.Bool(true),
+			},
+			Status: current.IBPOrdererStatus{
+				CRStatus: current.CRStatus{
+					Type:    current.Deployed,
+					Version: "1.0.0",
+				},
+			},
+		}
+		instance.Name = "orderer1"
+		instance.Namespace = "namespace"
+
+		nodes = []current.IBPOrderer{newNode(3), newNode(1), newNode(2)}
+
+		channelAdmin = &orderermocks.ChannelAdmin{}
+		mockClient = &cmocks.Client{}
+		orderer = &baseorderer.Orderer{
+			Client:       mockClient,
+			ChannelAdmin: channelAdmin,
+		}
+	})
+
+	Context("join pending", func() {
+		It("returns false if no node is joining the cluster", func() {
+			Expect(baseorderer.JoinPending(nodes)).To(Equal(false))
+		})
+
+		It("returns true if a node is joining the cluster", func() {
+			nodes[0].Spec.JoinCluster = pointer.Bool(true)
+			Expect(baseorderer.JoinPending(nodes)).To(Equal(true))
+		})
+	})
+
+	Context("scale up", func() {
+		It("returns an error if the cluster uses a system channel", func() {
+			instance.Spec.UseChannelLess = nil
+			_, err := orderer.ScaleUp(instance, nodes)
+			Expect(err).To(MatchError(ContainSubstring("adding nodes requires a cluster that uses channel participation")))
+			Expect(mockClient.CreateCallCount()).To(Equal(0))
+		})
+
+		It("creates the next node as a node joining the cluster", func() {
+			result, err := orderer.ScaleUp(instance, nodes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(baseorderer.ScaleUpRequeueInterval))
+
+			Expect(mockClient.CreateCallCount()).To(Equal(1))
+			_, obj, _ := mockClient.CreateArgsForCall(0)
+			node := obj.(*current.IBPOrderer)
+			Expect(node.Name).To(Equal("orderer1node4"))
+			Expect(*node.Spec.NodeNumber).To(Equal(4))
+			Expect(node.Spec.IsJoiningCluster()).To(Equal(true))
+			Expect(node.Spec.IsUsingChannelLess()).To(Equal(true))
+			Expect(node.Spec.IsPrecreateOrderer()).To(Equal(false))
+		})
+
+		It("does nothing if the cluster has all of its nodes", func() {
+			instance.Spec.ClusterSize = 3
+			result, err := orderer.ScaleUp(instance, nodes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(Equal(false))
+			Expect(result.RequeueAfter).To(BeZero())
+			Expect(mockClient.CreateCallCount()).To(Equal(0))
+		})
+
+		It("waits for the joining node to be deployed before creating the next node", func() {
+			nodes[0].Spec.JoinCluster = pointer.Bool(true)
+			nodes[0].Status.Type = current.Deploying
+			instance.Spec.ClusterSize = 5
+
+			result, err := orderer.ScaleUp(instance, nodes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(baseorderer.ScaleUpRequeueInterval))
+			Expect(mockClient.CreateCallCount()).To(Equal(0))
+			Expect(channelAdmin.ListChannelsCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if no other node is deployed", func() {
+			nodes[0].Spec.JoinCluster = pointer.Bool(true)
+			nodes[1].Status.Type = current.Error
+			nodes[2].Status.Type = current.Error
+
+			_, err := orderer.ScaleUp(instance, nodes)
+			Expect(err).To(MatchError(ContainSubstring("no deployed node in cluster to join node 'orderer1node3'")))
+		})
+
+		It("returns an error if the admin endpoint of the node cannot be found", func() {
+			nodes[0].Spec.JoinCluster = pointer.Bool(true)
+			mockClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+				return fmt.Errorf("not found")
+			}
+
+			_, err := orderer.ScaleUp(instance, nodes)
+			Expect(err).To(MatchError(ContainSubstring("failed to get connection profile of orderer node 'orderer1node1'")))
+		})
+	})
+})
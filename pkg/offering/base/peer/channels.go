@@ -0,0 +1,303 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package basepeer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel/peeradmin"
+	controllerclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ChannelRequeueInterval is the interval at which peers with channels that are not
+// joined yet are reconciled again
+const ChannelRequeueInterval = 1 * time.Minute
+
+//go:generate counterfeiter -o mocks/channel_admin.go -fake-name ChannelAdmin . ChannelAdmin
+
+type ChannelAdmin interface {
+	GetChannels(peer *peeradmin.Endpoint, signer *peeradmin.Signer) ([]string, error)
+	FetchGenesisBlock(orderer *peeradmin.Endpoint, signer *peeradmin.Signer, channelID string) (*cb.Block, error)
+	JoinChannel(peer *peeradmin.Endpoint, signer *peeradmin.Signer, block *cb.Block) error
+	JoinChannelBySnapshot(peer *peeradmin.Endpoint, signer *peeradmin.Signer, snapshotDir string) error
+	JoinBySnapshotInProgress(peer *peeradmin.Endpoint, signer *peeradmin.Signer) (bool, error)
+	GenerateSnapshot(peer *peeradmin.Endpoint, signer *peeradmin.Signer, channelID string, blockNumber uint64) error
+	PendingSnapshots(peer *peeradmin.Endpoint, signer *peeradmin.Signer, channelID string) ([]uint64, error)
+	FetchConfig(orderer *peeradmin.Endpoint, signer *peeradmin.Signer, channelID string) (*cb.Config, error)
+	UpdateConfig(orderer *peeradmin.Endpoint, signer *peeradmin.Signer, update *cb.ConfigUpdate) error
+}
+
+// ReconcileChannels joins the peer to the channels listed in the spec and sets it as
+// an anchor peer where requested. Failures of individual channels are surfaced in the
+// status of the peer and retried on the next reconcile.
+func (p *Peer) ReconcileChannels(instance *current.IBPPeer) (reconcile.Result, error) {
+	if len(instance.Spec.Channels) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	if instance.Spec.AdminSecret == "" {
+		return reconcile.Result{}, errors.New("adminSecret must be set to join the peer to channels")
+	}
+
+	status, err := p.DeploymentManager.DeploymentStatus(instance)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get deployment status")
+	}
+	if status.ReadyReplicas == 0 {
+		log.Info(fmt.Sprintf("Peer '%s' is not ready, waiting to join channels", instance.GetName()))
+		return reconcile.Result{RequeueAfter: ChannelRequeueInterval}, nil
+	}
+
+	signer, err := GetAdminSigner(p.Client, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	peerEndpoint, err := GetPeerAdminEndpoint(p.Client, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	joined, err := p.ChannelAdmin.GetChannels(peerEndpoint, signer)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get channels joined by peer")
+	}
+
+	statuses := []current.PeerChannelStatus{}
+	requeue := false
+	for _, channel := range instance.Spec.Channels {
+		channelStatus := p.reconcileChannel(instance, channel, signer, peerEndpoint, util.ContainsValue(channel.Name, joined))
+		if channelStatus.Status != current.NodeJoined || channel.AnchorPeer != channelStatus.AnchorPeer {
+			requeue = true
+		}
+		statuses = append(statuses, channelStatus)
+	}
+
+	err = p.UpdateChannelStatus(instance, statuses)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if requeue {
+		return reconcile.Result{RequeueAfter: ChannelRequeueInterval}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (p *Peer) reconcileChannel(instance *current.IBPPeer, channel current.PeerChannel, signer *peeradmin.Signer, peerEndpoint *peeradmin.Endpoint, joined bool) current.PeerChannelStatus {
+	channelStatus := current.PeerChannelStatus{
+		Name:   channel.Name,
+		Status: current.NodeJoined,
+	}
+	if previous := instance.Status.GetChannel(channel.Name); previous != nil {
+		channelStatus.AnchorPeer = previous.AnchorPeer
+	}
+
+	failed := func(err error) current.PeerChannelStatus {
+		log.Error(err, fmt.Sprintf("Peer '%s' failed to reconcile channel '%s'", instance.GetName(), channel.Name))
+		channelStatus.Status = current.NodeFailed
+		channelStatus.Message = err.Error()
+		return channelStatus
+	}
+
+	ordererEndpoint, err := GetOrdererEndpoint(channel)
+	if err != nil {
+		return failed(err)
+	}
+
+	if !joined && channel.Snapshot != "" {
+		joining, err := p.joinBySnapshot(instance, channel, signer, peerEndpoint)
+		if err != nil {
+			return failed(err)
+		}
+		if joining {
+			channelStatus.Status = current.NodePending
+			channelStatus.Message = "Joining channel from snapshot"
+			return channelStatus
+		}
+	}
+
+	if !joined {
+		block, err := p.ChannelAdmin.FetchGenesisBlock(ordererEndpoint, signer, channel.Name)
+		if err != nil {
+			return failed(err)
+		}
+
+		log.Info(fmt.Sprintf("Joining peer '%s' to channel '%s'", instance.GetName(), channel.Name))
+		err = p.ChannelAdmin.JoinChannel(peerEndpoint, signer, block)
+		if err != nil {
+			return failed(err)
+		}
+	}
+
+	if channel.AnchorPeer && !channelStatus.AnchorPeer {
+		err = p.SetAnchorPeer(instance, channel.Name, signer, ordererEndpoint)
+		if err != nil {
+			return failed(err)
+		}
+		channelStatus.AnchorPeer = true
+	}
+
+	if !channel.AnchorPeer {
+		// Removing an anchor peer is not supported, the status only tracks anchor
+		// peers that are requested in the spec
+		channelStatus.AnchorPeer = false
+	}
+
+	return channelStatus
+}
+
+// SetAnchorPeer adds the external endpoint of the peer to the anchor peers of its
+// organization on the channel, if not already present
+func (p *Peer) SetAnchorPeer(instance *current.IBPPeer, channelID string, signer *peeradmin.Signer, orderer *peeradmin.Endpoint) error {
+	endpoint := instance.Spec.PeerExternalEndpoint
+	if endpoint == "" || endpoint == "do-not-set" {
+		return errors.New("peerExternalEndpoint must be set to use the peer as an anchor peer")
+	}
+
+	host, portStr, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return errors.Wrapf(err, "invalid peer external endpoint '%s'", endpoint)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return errors.Wrapf(err, "invalid port in peer external endpoint '%s'", endpoint)
+	}
+
+	config, err := p.ChannelAdmin.FetchConfig(orderer, signer, channelID)
+	if err != nil {
+		return err
+	}
+
+	update, err := peeradmin.AnchorPeersUpdate(config, channelID, instance.Spec.MSPID, &pb.AnchorPeer{
+		Host: host,
+		Port: int32(port),
+	})
+	if err != nil {
+		return err
+	}
+	if update == nil {
+		// Anchor peer already set
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("Setting peer '%s' as anchor peer on channel '%s'", instance.GetName(), channelID))
+	return p.ChannelAdmin.UpdateConfig(orderer, signer, update)
+}
+
+// GetAdminSigner returns the signer for the admin identity stored in the admin secret
+func GetAdminSigner(client controllerclient.Client, instance *current.IBPPeer) (*peeradmin.Signer, error) {
+	secret := &corev1.Secret{}
+	err := client.Get(context.TODO(), types.NamespacedName{Name: instance.Spec.AdminSecret, Namespace: instance.GetNamespace()}, secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get admin secret '%s'", instance.Spec.AdminSecret)
+	}
+
+	signer, err := peeradmin.NewSigner(instance.Spec.MSPID, secret.Data["cert.pem"], secret.Data["key.pem"])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load admin identity from secret '%s'", instance.Spec.AdminSecret)
+	}
+
+	return signer, nil
+}
+
+// GetPeerAdminEndpoint returns the API endpoint of the peer, using the TLS certificate
+// of the peer for mutual TLS
+func GetPeerAdminEndpoint(client controllerclient.Client, instance *current.IBPPeer) (*peeradmin.Endpoint, error) {
+	cert, err := common.GetTLSSignCertBytes(client, instance)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tls signcert")
+	}
+
+	key, err := common.GetTLSKeystoreBytes(client, instance)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tls keystore")
+	}
+
+	rootCAs, err := common.GetTLSCACertBytes(client, instance)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tls cacerts")
+	}
+
+	intermediateCAs, err := common.GetTLSIntercertBytes(client, instance)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tls intercerts")
+	}
+
+	return &peeradmin.Endpoint{
+		Address:    GetAPIEndpoint(instance),
+		TLSCACerts: append(rootCAs, intermediateCAs...),
+		ClientCert: cert,
+		ClientKey:  key,
+	}, nil
+}
+
+// GetOrdererEndpoint returns the orderer endpoint of the channel
+func GetOrdererEndpoint(channel current.PeerChannel) (*peeradmin.Endpoint, error) {
+	if channel.OrdererEndpoint == "" {
+		return nil, errors.Errorf("ordererEndpoint of channel '%s' is not set", channel.Name)
+	}
+
+	tlsCACert, err := util.Base64ToBytes(channel.OrdererTLSCACert)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode orderer tls ca cert of channel '%s'", channel.Name)
+	}
+
+	return &peeradmin.Endpoint{
+		Address:    channel.OrdererEndpoint,
+		TLSCACerts: [][]byte{tlsCACert},
+	}, nil
+}
+
+// UpdateChannelStatus patches the channels in the status of the peer if they changed
+func (p *Peer) UpdateChannelStatus(instance *current.IBPPeer, statuses []current.PeerChannelStatus) error {
+	if reflect.DeepEqual(instance.Status.Channels, statuses) {
+		return nil
+	}
+
+	instance.Status.Channels = statuses
+	err := p.Client.PatchStatus(context.TODO(), instance, nil, controllerclient.PatchOption{
+		Resilient: &controllerclient.ResilientPatch{
+			Retry:    2,
+			Into:     &current.IBPPeer{},
+			Strategy: k8sclient.MergeFrom,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to update channel status")
+	}
+
+	return nil
+}
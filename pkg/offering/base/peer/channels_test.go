@@ -0,0 +1,288 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package basepeer_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	cmocks "github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	basepeer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer"
+	peermocks "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer/mocks"
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Base Peer Channels", func() {
+	var (
+		peer             *basepeer.Peer
+		instance         *current.IBPPeer
+		mockKubeClient   *cmocks.Client
+		deploymentMgr    *peermocks.DeploymentManager
+		channelAdmin     *peermocks.ChannelAdmin
+		adminCert        []byte
+		adminKey         []byte
+		ordererTLSCACert string
+	)
+
+	BeforeEach(func() {
+		adminCert, adminKey = generateIdentity()
+		ordererTLSCACert = base64.StdEncoding.EncodeToString(adminCert)
+
+		instance = &current.IBPPeer{
+			Spec: current.IBPPeerSpec{
+				MSPID:                "Org1MSP",
+				Domain:               "domain",
+				PeerExternalEndpoint: "peer1.domain:443",
+				AdminSecret:          "org1-admin",
+				Channels: []current.PeerChannel{
+					{
+						Name:             "channel1",
+						OrdererEndpoint:  "orderer.domain:443",
+						OrdererTLSCACert: ordererTLSCACert,
+					},
+				},
+			},
+		}
+		instance.Name = "peer1"
+		instance.Namespace = "random"
+
+		mockKubeClient = &cmocks.Client{}
+		mockKubeClient.GetStub = func(ctx context.Context, types types.NamespacedName, obj client.Object) error {
+			switch obj.(type) {
+			case *corev1.Secret:
+				o := obj.(*corev1.Secret)
+				switch types.Name {
+				case "org1-admin":
+					o.Data = map[string][]byte{"cert.pem": adminCert, "key.pem": adminKey}
+				case "tls-peer1-signcert":
+					o.Data = map[string][]byte{"cert.pem": adminCert}
+				case "tls-peer1-keystore":
+					o.Data = map[string][]byte{"key.pem": adminKey}
+				case "tls-peer1-cacerts":
+					o.Data = map[string][]byte{"cacert-0.pem": adminCert}
+				}
+			}
+			return nil
+		}
+
+		deploymentMgr = &peermocks.DeploymentManager{}
+		deploymentMgr.DeploymentStatusReturns(appsv1.DeploymentStatus{ReadyReplicas: 1}, nil)
+
+		channelAdmin = &peermocks.ChannelAdmin{}
+		channelAdmin.GetChannelsReturns([]string{}, nil)
+		channelAdmin.FetchGenesisBlockReturns(&cb.Block{}, nil)
+		channelAdmin.FetchConfigReturns(channelConfig("Org1", "Org1MSP"), nil)
+
+		peer = &basepeer.Peer{
+			Client:            mockKubeClient,
+			DeploymentManager: deploymentMgr,
+			ChannelAdmin:      channelAdmin,
+		}
+	})
+
+	Context("reconcile channels", func() {
+		It("does nothing if no channels are listed", func() {
+			instance.Spec.Channels = nil
+			result, err := peer.ReconcileChannels(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			Expect(channelAdmin.GetChannelsCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if admin secret is not set", func() {
+			instance.Spec.AdminSecret = ""
+			_, err := peer.ReconcileChannels(instance)
+			Expect(err).To(MatchError(ContainSubstring("adminSecret must be set")))
+		})
+
+		It("requeues if the peer is not ready", func() {
+			deploymentMgr.DeploymentStatusReturns(appsv1.DeploymentStatus{}, nil)
+			result, err := peer.ReconcileChannels(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(basepeer.ChannelRequeueInterval))
+			Expect(channelAdmin.GetChannelsCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if it fails to get the channels of the peer", func() {
+			channelAdmin.GetChannelsReturns(nil, errors.New("connection refused"))
+			_, err := peer.ReconcileChannels(instance)
+			Expect(err).To(MatchError(ContainSubstring("failed to get channels joined by peer")))
+		})
+
+		It("joins the peer to the channel", func() {
+			result, err := peer.ReconcileChannels(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			Expect(channelAdmin.FetchGenesisBlockCallCount()).To(Equal(1))
+			orderer, signer, channelID := channelAdmin.FetchGenesisBlockArgsForCall(0)
+			Expect(orderer.Address).To(Equal("orderer.domain:443"))
+			Expect(signer.MSPID).To(Equal("Org1MSP"))
+			Expect(channelID).To(Equal("channel1"))
+
+			Expect(channelAdmin.JoinChannelCallCount()).To(Equal(1))
+			peerEndpoint, _, _ := channelAdmin.JoinChannelArgsForCall(0)
+			Expect(peerEndpoint.Address).To(Equal("grpcs://random-peer1-peer.domain:443"))
+
+			Expect(mockKubeClient.PatchStatusCallCount()).To(Equal(1))
+			Expect(instance.Status.Channels).To(Equal([]current.PeerChannelStatus{
+				{Name: "channel1", Status: current.NodeJoined},
+			}))
+		})
+
+		It("does not join a channel the peer has already joined", func() {
+			channelAdmin.GetChannelsReturns([]string{"channel1"}, nil)
+			_, err := peer.ReconcileChannels(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(channelAdmin.JoinChannelCallCount()).To(Equal(0))
+		})
+
+		It("does not patch the status if nothing changed", func() {
+			channelAdmin.GetChannelsReturns([]string{"channel1"}, nil)
+			instance.Status.Channels = []current.PeerChannelStatus{
+				{Name: "channel1", Status: current.NodeJoined},
+			}
+			_, err := peer.ReconcileChannels(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.PatchStatusCallCount()).To(Equal(0))
+		})
+
+		It("marks the channel as failed and requeues if join fails", func() {
+			channelAdmin.JoinChannelReturns(errors.New("access denied"))
+			result, err := peer.ReconcileChannels(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(basepeer.ChannelRequeueInterval))
+			Expect(instance.Status.Channels[0].Status).To(Equal(current.NodeFailed))
+			Expect(instance.Status.Channels[0].Message).To(Equal("access denied"))
+		})
+
+		It("marks the channel as failed if the orderer tls ca cert is invalid", func() {
+			instance.Spec.Channels[0].OrdererTLSCACert = "invalid!"
+			_, err := peer.ReconcileChannels(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.Channels[0].Status).To(Equal(current.NodeFailed))
+			Expect(channelAdmin.FetchGenesisBlockCallCount()).To(Equal(0))
+		})
+
+		Context("anchor peer", func() {
+			BeforeEach(func() {
+				instance.Spec.Channels[0].AnchorPeer = true
+			})
+
+			It("sets the peer as an anchor peer", func() {
+				result, err := peer.ReconcileChannels(instance)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+
+				Expect(channelAdmin.UpdateConfigCallCount()).To(Equal(1))
+				_, _, update := channelAdmin.UpdateConfigArgsForCall(0)
+				Expect(update.ChannelId).To(Equal("channel1"))
+				Expect(update.WriteSet.Groups["Application"].Groups["Org1"].Values).To(HaveKey("AnchorPeers"))
+				Expect(instance.Status.Channels[0].AnchorPeer).To(Equal(true))
+			})
+
+			It("does not update the config if the anchor peer is already set", func() {
+				instance.Status.Channels = []current.PeerChannelStatus{
+					{Name: "channel1", Status: current.NodeJoined, AnchorPeer: true},
+				}
+				_, err := peer.ReconcileChannels(instance)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(channelAdmin.FetchConfigCallCount()).To(Equal(0))
+				Expect(channelAdmin.UpdateConfigCallCount()).To(Equal(0))
+			})
+
+			It("marks the channel as failed if the external endpoint is not set", func() {
+				instance.Spec.PeerExternalEndpoint = "do-not-set"
+				result, err := peer.ReconcileChannels(instance)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(basepeer.ChannelRequeueInterval))
+				Expect(instance.Status.Channels[0].Status).To(Equal(current.NodeFailed))
+				Expect(instance.Status.Channels[0].Message).To(ContainSubstring("peerExternalEndpoint must be set"))
+			})
+
+			It("marks the channel as failed if the config update is rejected", func() {
+				channelAdmin.UpdateConfigReturns(errors.New("orderer rejected envelope"))
+				_, err := peer.ReconcileChannels(instance)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(instance.Status.Channels[0].Status).To(Equal(current.NodeFailed))
+				Expect(instance.Status.Channels[0].AnchorPeer).To(Equal(false))
+			})
+		})
+	})
+})
+
+func generateIdentity() ([]byte, []byte) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	Expect(err).NotTo(HaveOccurred())
+
+	key, err := x509.MarshalPKCS8PrivateKey(priv)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key})
+}
+
+func channelConfig(orgName, mspID string) *cb.Config {
+	fabricMSPConfig, err := proto.Marshal(&msp.FabricMSPConfig{Name: mspID})
+	Expect(err).NotTo(HaveOccurred())
+	mspConfig, err := proto.Marshal(&msp.MSPConfig{Config: fabricMSPConfig})
+	Expect(err).NotTo(HaveOccurred())
+
+	return &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				"Application": {
+					Groups: map[string]*cb.ConfigGroup{
+						orgName: {
+							ModPolicy: "Admins",
+							Values: map[string]*cb.ConfigValue{
+								"MSP": {Value: mspConfig},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package basepeer
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// LoadBalancerRequeueInterval is the interval at which peers waiting for an address to be
+// assigned to their load balancer are reconciled again
+const LoadBalancerRequeueInterval = 10 * time.Second
+
+// UpdateLoadBalancerEndpoint creates the LoadBalancer service of the peer and sets the external
+// endpoint of the peer to the address assigned to the load balancer. The external endpoint needs
+// to be known before the peer enrolls for its TLS certificate, so the service is reconciled ahead
+// of the other resources. Returns false if no address has been assigned yet.
+func (p *Peer) UpdateLoadBalancerEndpoint(instance *current.IBPPeer) (updated bool, assigned bool, err error) {
+	err = p.ServiceManager.Reconcile(instance, false)
+	if err != nil {
+		return false, false, errors.Wrap(err, "failed Service reconciliation")
+	}
+
+	address, err := common.GetLoadBalancerAddress(p.Client, p.ServiceManager.GetName(instance), instance.GetNamespace())
+	if err != nil {
+		return false, false, errors.Wrap(err, "failed to get load balancer address")
+	}
+	if address == "" {
+		return false, false, nil
+	}
+
+	// Service discovery is disabled
+	if instance.Spec.PeerExternalEndpoint == "do-not-set" {
+		return false, true, nil
+	}
+
+	externalEndpoint := net.JoinHostPort(address, strconv.Itoa(common.PeerAPIPort))
+	if instance.Spec.PeerExternalEndpoint == externalEndpoint {
+		return false, true, nil
+	}
+
+	log.Info(fmt.Sprintf("Setting external endpoint of peer '%s' to load balancer address '%s'", instance.GetName(), externalEndpoint))
+	instance.Spec.PeerExternalEndpoint = externalEndpoint
+	return true, true, nil
+}
+
+// WaitForLoadBalancer returns the result of a reconcile that waits for an address to be
+// assigned to the load balancer of the peer
+func WaitForLoadBalancer(instance *current.IBPPeer) common.Result {
+	return common.Result{
+		Result: reconcile.Result{
+			RequeueAfter: LoadBalancerRequeueInterval,
+		},
+		Status: &current.CRStatus{
+			Type:    current.Deploying,
+			Reason:  "waitingForLoadBalancer",
+			Message: fmt.Sprintf("Waiting for an address to be assigned to the load balancer of '%s'", instance.GetName()),
+		},
+	}
+}
+
+// GetLoadBalancerHost returns the host of the load balancer the peer is reached at, or an empty
+// string if the peer is not exposed with a load balancer
+func GetLoadBalancerHost(instance *current.IBPPeer) string {
+	if !instance.Spec.Service.UsesLoadBalancerAddress() {
+		return ""
+	}
+
+	host, _, err := common.SplitHostPort(instance.Spec.PeerExternalEndpoint)
+	if err != nil {
+		return ""
+	}
+	return host
+}
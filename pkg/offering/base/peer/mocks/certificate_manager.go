@@ -45,6 +45,21 @@ type CertificateManager struct {
 		result1 time.Duration
 		result2 error
 	}
+	GetMissingHostsStub        func(common.SecretType, v1.Object, []string) ([]string, error)
+	getMissingHostsMutex       sync.RWMutex
+	getMissingHostsArgsForCall []struct {
+		arg1 common.SecretType
+		arg2 v1.Object
+		arg3 []string
+	}
+	getMissingHostsReturns struct {
+		result1 []string
+		result2 error
+	}
+	getMissingHostsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	GetSignCertStub        func(string, string) ([]byte, error)
 	getSignCertMutex       sync.RWMutex
 	getSignCertArgsForCall []struct {
@@ -212,6 +227,76 @@ func (fake *CertificateManager) GetDurationToNextRenewalReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
+func (fake *CertificateManager) GetMissingHosts(arg1 common.SecretType, arg2 v1.Object, arg3 []string) ([]string, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.getMissingHostsMutex.Lock()
+	ret, specificReturn := fake.getMissingHostsReturnsOnCall[len(fake.getMissingHostsArgsForCall)]
+	fake.getMissingHostsArgsForCall = append(fake.getMissingHostsArgsForCall, struct {
+		arg1 common.SecretType
+		arg2 v1.Object
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("GetMissingHosts", []interface{}{arg1, arg2, arg3Copy})
+	fake.getMissingHostsMutex.Unlock()
+	if fake.GetMissingHostsStub != nil {
+		return fake.GetMissingHostsStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getMissingHostsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CertificateManager) GetMissingHostsCallCount() int {
+	fake.getMissingHostsMutex.RLock()
+	defer fake.getMissingHostsMutex.RUnlock()
+	return len(fake.getMissingHostsArgsForCall)
+}
+
+func (fake *CertificateManager) GetMissingHostsCalls(stub func(common.SecretType, v1.Object, []string) ([]string, error)) {
+	fake.getMissingHostsMutex.Lock()
+	defer fake.getMissingHostsMutex.Unlock()
+	fake.GetMissingHostsStub = stub
+}
+
+func (fake *CertificateManager) GetMissingHostsArgsForCall(i int) (common.SecretType, v1.Object, []string) {
+	fake.getMissingHostsMutex.RLock()
+	defer fake.getMissingHostsMutex.RUnlock()
+	argsForCall := fake.getMissingHostsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CertificateManager) GetMissingHostsReturns(result1 []string, result2 error) {
+	fake.getMissingHostsMutex.Lock()
+	defer fake.getMissingHostsMutex.Unlock()
+	fake.GetMissingHostsStub = nil
+	fake.getMissingHostsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CertificateManager) GetMissingHostsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.getMissingHostsMutex.Lock()
+	defer fake.getMissingHostsMutex.Unlock()
+	fake.GetMissingHostsStub = nil
+	if fake.getMissingHostsReturnsOnCall == nil {
+		fake.getMissingHostsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.getMissingHostsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CertificateManager) GetSignCert(arg1 string, arg2 string) ([]byte, error) {
 	fake.getSignCertMutex.Lock()
 	ret, specificReturn := fake.getSignCertReturnsOnCall[len(fake.getSignCertArgsForCall)]
@@ -349,6 +434,8 @@ func (fake *CertificateManager) Invocations() map[string][][]interface{} {
 	defer fake.checkCertificatesForExpireMutex.RUnlock()
 	fake.getDurationToNextRenewalMutex.RLock()
 	defer fake.getDurationToNextRenewalMutex.RUnlock()
+	fake.getMissingHostsMutex.RLock()
+	defer fake.getMissingHostsMutex.RUnlock()
 	fake.getSignCertMutex.RLock()
 	defer fake.getSignCertMutex.RUnlock()
 	fake.renewCertMutex.RLock()
@@ -189,6 +189,16 @@ type Update struct {
 	restartNeededReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	SnapshotRequestedStub        func() bool
+	snapshotRequestedMutex       sync.RWMutex
+	snapshotRequestedArgsForCall []struct {
+	}
+	snapshotRequestedReturns struct {
+		result1 bool
+	}
+	snapshotRequestedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	SpecUpdatedStub        func() bool
 	specUpdatedMutex       sync.RWMutex
 	specUpdatedArgsForCall []struct {
@@ -258,15 +268,16 @@ func (fake *Update) CertificateCreated() bool {
 	ret, specificReturn := fake.certificateCreatedReturnsOnCall[len(fake.certificateCreatedArgsForCall)]
 	fake.certificateCreatedArgsForCall = append(fake.certificateCreatedArgsForCall, struct {
 	}{})
+	stub := fake.CertificateCreatedStub
+	fakeReturns := fake.certificateCreatedReturns
 	fake.recordInvocation("CertificateCreated", []interface{}{})
 	fake.certificateCreatedMutex.Unlock()
-	if fake.CertificateCreatedStub != nil {
-		return fake.CertificateCreatedStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.certificateCreatedReturns
 	return fakeReturns.result1
 }
 
@@ -310,15 +321,16 @@ func (fake *Update) CertificateUpdated() bool {
 	ret, specificReturn := fake.certificateUpdatedReturnsOnCall[len(fake.certificateUpdatedArgsForCall)]
 	fake.certificateUpdatedArgsForCall = append(fake.certificateUpdatedArgsForCall, struct {
 	}{})
+	stub := fake.CertificateUpdatedStub
+	fakeReturns := fake.certificateUpdatedReturns
 	fake.recordInvocation("CertificateUpdated", []interface{}{})
 	fake.certificateUpdatedMutex.Unlock()
-	if fake.CertificateUpdatedStub != nil {
-		return fake.CertificateUpdatedStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.certificateUpdatedReturns
 	return fakeReturns.result1
 }
 
@@ -362,15 +374,16 @@ func (fake *Update) ConfigOverridesUpdated() bool {
 	ret, specificReturn := fake.configOverridesUpdatedReturnsOnCall[len(fake.configOverridesUpdatedArgsForCall)]
 	fake.configOverridesUpdatedArgsForCall = append(fake.configOverridesUpdatedArgsForCall, struct {
 	}{})
+	stub := fake.ConfigOverridesUpdatedStub
+	fakeReturns := fake.configOverridesUpdatedReturns
 	fake.recordInvocation("ConfigOverridesUpdated", []interface{}{})
 	fake.configOverridesUpdatedMutex.Unlock()
-	if fake.ConfigOverridesUpdatedStub != nil {
-		return fake.ConfigOverridesUpdatedStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.configOverridesUpdatedReturns
 	return fakeReturns.result1
 }
 
@@ -414,15 +427,16 @@ func (fake *Update) CryptoBackupNeeded() bool {
 	ret, specificReturn := fake.cryptoBackupNeededReturnsOnCall[len(fake.cryptoBackupNeededArgsForCall)]
 	fake.cryptoBackupNeededArgsForCall = append(fake.cryptoBackupNeededArgsForCall, struct {
 	}{})
+	stub := fake.CryptoBackupNeededStub
+	fakeReturns := fake.cryptoBackupNeededReturns
 	fake.recordInvocation("CryptoBackupNeeded", []interface{}{})
 	fake.cryptoBackupNeededMutex.Unlock()
-	if fake.CryptoBackupNeededStub != nil {
-		return fake.CryptoBackupNeededStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.cryptoBackupNeededReturns
 	return fakeReturns.result1
 }
 
@@ -466,15 +480,16 @@ func (fake *Update) EcertEnroll() bool {
 	ret, specificReturn := fake.ecertEnrollReturnsOnCall[len(fake.ecertEnrollArgsForCall)]
 	fake.ecertEnrollArgsForCall = append(fake.ecertEnrollArgsForCall, struct {
 	}{})
+	stub := fake.EcertEnrollStub
+	fakeReturns := fake.ecertEnrollReturns
 	fake.recordInvocation("EcertEnroll", []interface{}{})
 	fake.ecertEnrollMutex.Unlock()
-	if fake.EcertEnrollStub != nil {
-		return fake.EcertEnrollStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.ecertEnrollReturns
 	return fakeReturns.result1
 }
 
@@ -518,15 +533,16 @@ func (fake *Update) EcertNewKeyReenroll() bool {
 	ret, specificReturn := fake.ecertNewKeyReenrollReturnsOnCall[len(fake.ecertNewKeyReenrollArgsForCall)]
 	fake.ecertNewKeyReenrollArgsForCall = append(fake.ecertNewKeyReenrollArgsForCall, struct {
 	}{})
+	stub := fake.EcertNewKeyReenrollStub
+	fakeReturns := fake.ecertNewKeyReenrollReturns
 	fake.recordInvocation("EcertNewKeyReenroll", []interface{}{})
 	fake.ecertNewKeyReenrollMutex.Unlock()
-	if fake.EcertNewKeyReenrollStub != nil {
-		return fake.EcertNewKeyReenrollStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.ecertNewKeyReenrollReturns
 	return fakeReturns.result1
 }
 
@@ -570,15 +586,16 @@ func (fake *Update) EcertReenrollNeeded() bool {
 	ret, specificReturn := fake.ecertReenrollNeededReturnsOnCall[len(fake.ecertReenrollNeededArgsForCall)]
 	fake.ecertReenrollNeededArgsForCall = append(fake.ecertReenrollNeededArgsForCall, struct {
 	}{})
+	stub := fake.EcertReenrollNeededStub
+	fakeReturns := fake.ecertReenrollNeededReturns
 	fake.recordInvocation("EcertReenrollNeeded", []interface{}{})
 	fake.ecertReenrollNeededMutex.Unlock()
-	if fake.EcertReenrollNeededStub != nil {
-		return fake.EcertReenrollNeededStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.ecertReenrollNeededReturns
 	return fakeReturns.result1
 }
 
@@ -622,15 +639,16 @@ func (fake *Update) EcertUpdated() bool {
 	ret, specificReturn := fake.ecertUpdatedReturnsOnCall[len(fake.ecertUpdatedArgsForCall)]
 	fake.ecertUpdatedArgsForCall = append(fake.ecertUpdatedArgsForCall, struct {
 	}{})
+	stub := fake.EcertUpdatedStub
+	fakeReturns := fake.ecertUpdatedReturns
 	fake.recordInvocation("EcertUpdated", []interface{}{})
 	fake.ecertUpdatedMutex.Unlock()
-	if fake.EcertUpdatedStub != nil {
-		return fake.EcertUpdatedStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.ecertUpdatedReturns
 	return fakeReturns.result1
 }
 
@@ -674,15 +692,16 @@ func (fake *Update) FabricVersionUpdated() bool {
 	ret, specificReturn := fake.fabricVersionUpdatedReturnsOnCall[len(fake.fabricVersionUpdatedArgsForCall)]
 	fake.fabricVersionUpdatedArgsForCall = append(fake.fabricVersionUpdatedArgsForCall, struct {
 	}{})
+	stub := fake.FabricVersionUpdatedStub
+	fakeReturns := fake.fabricVersionUpdatedReturns
 	fake.recordInvocation("FabricVersionUpdated", []interface{}{})
 	fake.fabricVersionUpdatedMutex.Unlock()
-	if fake.FabricVersionUpdatedStub != nil {
-		return fake.FabricVersionUpdatedStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.fabricVersionUpdatedReturns
 	return fakeReturns.result1
 }
 
@@ -726,15 +745,16 @@ func (fake *Update) GetCreatedCertType() common.SecretType {
 	ret, specificReturn := fake.getCreatedCertTypeReturnsOnCall[len(fake.getCreatedCertTypeArgsForCall)]
 	fake.getCreatedCertTypeArgsForCall = append(fake.getCreatedCertTypeArgsForCall, struct {
 	}{})
+	stub := fake.GetCreatedCertTypeStub
+	fakeReturns := fake.getCreatedCertTypeReturns
 	fake.recordInvocation("GetCreatedCertType", []interface{}{})
 	fake.getCreatedCertTypeMutex.Unlock()
-	if fake.GetCreatedCertTypeStub != nil {
-		return fake.GetCreatedCertTypeStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.getCreatedCertTypeReturns
 	return fakeReturns.result1
 }
 
@@ -778,15 +798,16 @@ func (fake *Update) ImagesUpdated() bool {
 	ret, specificReturn := fake.imagesUpdatedReturnsOnCall[len(fake.imagesUpdatedArgsForCall)]
 	fake.imagesUpdatedArgsForCall = append(fake.imagesUpdatedArgsForCall, struct {
 	}{})
+	stub := fake.ImagesUpdatedStub
+	fakeReturns := fake.imagesUpdatedReturns
 	fake.recordInvocation("ImagesUpdated", []interface{}{})
 	fake.imagesUpdatedMutex.Unlock()
-	if fake.ImagesUpdatedStub != nil {
-		return fake.ImagesUpdatedStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.imagesUpdatedReturns
 	return fakeReturns.result1
 }
 
@@ -830,15 +851,16 @@ func (fake *Update) MSPUpdated() bool {
 	ret, specificReturn := fake.mSPUpdatedReturnsOnCall[len(fake.mSPUpdatedArgsForCall)]
 	fake.mSPUpdatedArgsForCall = append(fake.mSPUpdatedArgsForCall, struct {
 	}{})
+	stub := fake.MSPUpdatedStub
+	fakeReturns := fake.mSPUpdatedReturns
 	fake.recordInvocation("MSPUpdated", []interface{}{})
 	fake.mSPUpdatedMutex.Unlock()
-	if fake.MSPUpdatedStub != nil {
-		return fake.MSPUpdatedStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.mSPUpdatedReturns
 	return fakeReturns.result1
 }
 
@@ -882,15 +904,16 @@ func (fake *Update) MigrateToV2() bool {
 	ret, specificReturn := fake.migrateToV2ReturnsOnCall[len(fake.migrateToV2ArgsForCall)]
 	fake.migrateToV2ArgsForCall = append(fake.migrateToV2ArgsForCall, struct {
 	}{})
+	stub := fake.MigrateToV2Stub
+	fakeReturns := fake.migrateToV2Returns
 	fake.recordInvocation("MigrateToV2", []interface{}{})
 	fake.migrateToV2Mutex.Unlock()
-	if fake.MigrateToV2Stub != nil {
-		return fake.MigrateToV2Stub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.migrateToV2Returns
 	return fakeReturns.result1
 }
 
@@ -934,15 +957,16 @@ func (fake *Update) MigrateToV24() bool {
 	ret, specificReturn := fake.migrateToV24ReturnsOnCall[len(fake.migrateToV24ArgsForCall)]
 	fake.migrateToV24ArgsForCall = append(fake.migrateToV24ArgsForCall, struct {
 	}{})
+	stub := fake.MigrateToV24Stub
+	fakeReturns := fake.migrateToV24Returns
 	fake.recordInvocation("MigrateToV24", []interface{}{})
 	fake.migrateToV24Mutex.Unlock()
-	if fake.MigrateToV24Stub != nil {
-		return fake.MigrateToV24Stub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.migrateToV24Returns
 	return fakeReturns.result1
 }
 
@@ -986,15 +1010,16 @@ func (fake *Update) MigrateToV25() bool {
 	ret, specificReturn := fake.migrateToV25ReturnsOnCall[len(fake.migrateToV25ArgsForCall)]
 	fake.migrateToV25ArgsForCall = append(fake.migrateToV25ArgsForCall, struct {
 	}{})
+	stub := fake.MigrateToV25Stub
+	fakeReturns := fake.migrateToV25Returns
 	fake.recordInvocation("MigrateToV25", []interface{}{})
 	fake.migrateToV25Mutex.Unlock()
-	if fake.MigrateToV25Stub != nil {
-		return fake.MigrateToV25Stub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.migrateToV25Returns
 	return fakeReturns.result1
 }
 
@@ -1038,15 +1063,16 @@ func (fake *Update) NodeOUUpdated() bool {
 	ret, specificReturn := fake.nodeOUUpdatedReturnsOnCall[len(fake.nodeOUUpdatedArgsForCall)]
 	fake.nodeOUUpdatedArgsForCall = append(fake.nodeOUUpdatedArgsForCall, struct {
 	}{})
+	stub := fake.NodeOUUpdatedStub
+	fakeReturns := fake.nodeOUUpdatedReturns
 	fake.recordInvocation("NodeOUUpdated", []interface{}{})
 	fake.nodeOUUpdatedMutex.Unlock()
-	if fake.NodeOUUpdatedStub != nil {
-		return fake.NodeOUUpdatedStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.nodeOUUpdatedReturns
 	return fakeReturns.result1
 }
 
@@ -1090,15 +1116,16 @@ func (fake *Update) PeerTagUpdated() bool {
 	ret, specificReturn := fake.peerTagUpdatedReturnsOnCall[len(fake.peerTagUpdatedArgsForCall)]
 	fake.peerTagUpdatedArgsForCall = append(fake.peerTagUpdatedArgsForCall, struct {
 	}{})
+	stub := fake.PeerTagUpdatedStub
+	fakeReturns := fake.peerTagUpdatedReturns
 	fake.recordInvocation("PeerTagUpdated", []interface{}{})
 	fake.peerTagUpdatedMutex.Unlock()
-	if fake.PeerTagUpdatedStub != nil {
-		return fake.PeerTagUpdatedStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.peerTagUpdatedReturns
 	return fakeReturns.result1
 }
 
@@ -1142,15 +1169,16 @@ func (fake *Update) RestartNeeded() bool {
 	ret, specificReturn := fake.restartNeededReturnsOnCall[len(fake.restartNeededArgsForCall)]
 	fake.restartNeededArgsForCall = append(fake.restartNeededArgsForCall, struct {
 	}{})
+	stub := fake.RestartNeededStub
+	fakeReturns := fake.restartNeededReturns
 	fake.recordInvocation("RestartNeeded", []interface{}{})
 	fake.restartNeededMutex.Unlock()
-	if fake.RestartNeededStub != nil {
-		return fake.RestartNeededStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.restartNeededReturns
 	return fakeReturns.result1
 }
 
@@ -1189,20 +1217,74 @@ func (fake *Update) RestartNeededReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *Update) SnapshotRequested() bool {
+	fake.snapshotRequestedMutex.Lock()
+	ret, specificReturn := fake.snapshotRequestedReturnsOnCall[len(fake.snapshotRequestedArgsForCall)]
+	fake.snapshotRequestedArgsForCall = append(fake.snapshotRequestedArgsForCall, struct {
+	}{})
+	stub := fake.SnapshotRequestedStub
+	fakeReturns := fake.snapshotRequestedReturns
+	fake.recordInvocation("SnapshotRequested", []interface{}{})
+	fake.snapshotRequestedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Update) SnapshotRequestedCallCount() int {
+	fake.snapshotRequestedMutex.RLock()
+	defer fake.snapshotRequestedMutex.RUnlock()
+	return len(fake.snapshotRequestedArgsForCall)
+}
+
+func (fake *Update) SnapshotRequestedCalls(stub func() bool) {
+	fake.snapshotRequestedMutex.Lock()
+	defer fake.snapshotRequestedMutex.Unlock()
+	fake.SnapshotRequestedStub = stub
+}
+
+func (fake *Update) SnapshotRequestedReturns(result1 bool) {
+	fake.snapshotRequestedMutex.Lock()
+	defer fake.snapshotRequestedMutex.Unlock()
+	fake.SnapshotRequestedStub = nil
+	fake.snapshotRequestedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *Update) SnapshotRequestedReturnsOnCall(i int, result1 bool) {
+	fake.snapshotRequestedMutex.Lock()
+	defer fake.snapshotRequestedMutex.Unlock()
+	fake.SnapshotRequestedStub = nil
+	if fake.snapshotRequestedReturnsOnCall == nil {
+		fake.snapshotRequestedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.snapshotRequestedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *Update) SpecUpdated() bool {
 	fake.specUpdatedMutex.Lock()
 	ret, specificReturn := fake.specUpdatedReturnsOnCall[len(fake.specUpdatedArgsForCall)]
 	fake.specUpdatedArgsForCall = append(fake.specUpdatedArgsForCall, struct {
 	}{})
+	stub := fake.SpecUpdatedStub
+	fakeReturns := fake.specUpdatedReturns
 	fake.recordInvocation("SpecUpdated", []interface{}{})
 	fake.specUpdatedMutex.Unlock()
-	if fake.SpecUpdatedStub != nil {
-		return fake.SpecUpdatedStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.specUpdatedReturns
 	return fakeReturns.result1
 }
 
@@ -1246,15 +1328,16 @@ func (fake *Update) TLSCertEnroll() bool {
 	ret, specificReturn := fake.tLSCertEnrollReturnsOnCall[len(fake.tLSCertEnrollArgsForCall)]
 	fake.tLSCertEnrollArgsForCall = append(fake.tLSCertEnrollArgsForCall, struct {
 	}{})
+	stub := fake.TLSCertEnrollStub
+	fakeReturns := fake.tLSCertEnrollReturns
 	fake.recordInvocation("TLSCertEnroll", []interface{}{})
 	fake.tLSCertEnrollMutex.Unlock()
-	if fake.TLSCertEnrollStub != nil {
-		return fake.TLSCertEnrollStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.tLSCertEnrollReturns
 	return fakeReturns.result1
 }
 
@@ -1298,15 +1381,16 @@ func (fake *Update) TLSCertUpdated() bool {
 	ret, specificReturn := fake.tLSCertUpdatedReturnsOnCall[len(fake.tLSCertUpdatedArgsForCall)]
 	fake.tLSCertUpdatedArgsForCall = append(fake.tLSCertUpdatedArgsForCall, struct {
 	}{})
+	stub := fake.TLSCertUpdatedStub
+	fakeReturns := fake.tLSCertUpdatedReturns
 	fake.recordInvocation("TLSCertUpdated", []interface{}{})
 	fake.tLSCertUpdatedMutex.Unlock()
-	if fake.TLSCertUpdatedStub != nil {
-		return fake.TLSCertUpdatedStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.tLSCertUpdatedReturns
 	return fakeReturns.result1
 }
 
@@ -1350,15 +1434,16 @@ func (fake *Update) TLSReenrollNeeded() bool {
 	ret, specificReturn := fake.tLSReenrollNeededReturnsOnCall[len(fake.tLSReenrollNeededArgsForCall)]
 	fake.tLSReenrollNeededArgsForCall = append(fake.tLSReenrollNeededArgsForCall, struct {
 	}{})
+	stub := fake.TLSReenrollNeededStub
+	fakeReturns := fake.tLSReenrollNeededReturns
 	fake.recordInvocation("TLSReenrollNeeded", []interface{}{})
 	fake.tLSReenrollNeededMutex.Unlock()
-	if fake.TLSReenrollNeededStub != nil {
-		return fake.TLSReenrollNeededStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.tLSReenrollNeededReturns
 	return fakeReturns.result1
 }
 
@@ -1402,15 +1487,16 @@ func (fake *Update) TLScertNewKeyReenroll() bool {
 	ret, specificReturn := fake.tLScertNewKeyReenrollReturnsOnCall[len(fake.tLScertNewKeyReenrollArgsForCall)]
 	fake.tLScertNewKeyReenrollArgsForCall = append(fake.tLScertNewKeyReenrollArgsForCall, struct {
 	}{})
+	stub := fake.TLScertNewKeyReenrollStub
+	fakeReturns := fake.tLScertNewKeyReenrollReturns
 	fake.recordInvocation("TLScertNewKeyReenroll", []interface{}{})
 	fake.tLScertNewKeyReenrollMutex.Unlock()
-	if fake.TLScertNewKeyReenrollStub != nil {
-		return fake.TLScertNewKeyReenrollStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.tLScertNewKeyReenrollReturns
 	return fakeReturns.result1
 }
 
@@ -1454,15 +1540,16 @@ func (fake *Update) UpgradeDBs() bool {
 	ret, specificReturn := fake.upgradeDBsReturnsOnCall[len(fake.upgradeDBsArgsForCall)]
 	fake.upgradeDBsArgsForCall = append(fake.upgradeDBsArgsForCall, struct {
 	}{})
+	stub := fake.UpgradeDBsStub
+	fakeReturns := fake.upgradeDBsReturns
 	fake.recordInvocation("UpgradeDBs", []interface{}{})
 	fake.upgradeDBsMutex.Unlock()
-	if fake.UpgradeDBsStub != nil {
-		return fake.UpgradeDBsStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.upgradeDBsReturns
 	return fakeReturns.result1
 }
 
@@ -1540,6 +1627,8 @@ func (fake *Update) Invocations() map[string][][]interface{} {
 	defer fake.peerTagUpdatedMutex.RUnlock()
 	fake.restartNeededMutex.RLock()
 	defer fake.restartNeededMutex.RUnlock()
+	fake.snapshotRequestedMutex.RLock()
+	defer fake.snapshotRequestedMutex.RUnlock()
 	fake.specUpdatedMutex.RLock()
 	defer fake.specUpdatedMutex.RUnlock()
 	fake.tLSCertEnrollMutex.RLock()
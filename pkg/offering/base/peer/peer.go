@@ -23,8 +23,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,8 +34,12 @@ import (
 	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/action"
 	commonapi "github.com/IBM-Blockchain/fabric-operator/pkg/apis/common"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/backup"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/certificate"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel/peeradmin"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/events"
 	commoninit "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/certmanager"
 	commonconfig "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/config"
 	initializer "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/peer"
 	peerconfig "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/peer/config/v1"
@@ -42,6 +48,7 @@ import (
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
 	jobv1 "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/job"
 	resourcemanager "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/manager"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/statefulset"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/migrator/peer/fabric"
 	v2 "github.com/IBM-Blockchain/fabric-operator/pkg/migrator/peer/fabric/v2"
 	v25 "github.com/IBM-Blockchain/fabric-operator/pkg/migrator/peer/fabric/v25"
@@ -59,6 +66,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -122,6 +130,7 @@ type CertificateManager interface {
 	CheckCertificatesForExpire(instance v1.Object, numSecondsBeforeExpire int64) (current.IBPCRStatusType, string, error)
 	GetSignCert(string, string) ([]byte, error)
 	GetDurationToNextRenewal(commoninit.SecretType, v1.Object, int64) (time.Duration, error)
+	GetMissingHosts(commoninit.SecretType, v1.Object, []string) ([]string, error)
 	RenewCert(commoninit.SecretType, certificate.Instance, *current.EnrollmentSpec, *commonapi.BCCSP, string, bool, bool) error
 }
 
@@ -153,6 +162,7 @@ type Update interface {
 	MigrateToV24() bool
 	MigrateToV25() bool
 	UpgradeDBs() bool
+	SnapshotRequested() bool
 	MSPUpdated() bool
 	EcertEnroll() bool
 	TLSCertEnroll() bool
@@ -197,6 +207,7 @@ type Peer struct {
 	RoleManager           resources.Manager
 	RoleBindingManager    resources.Manager
 	ServiceAccountManager resources.Manager
+	PDBManager            resources.Manager
 
 	Override    Override
 	Initializer InitializeIBPPeer
@@ -205,14 +216,25 @@ type Peer struct {
 	RenewCertTimers    map[string]*time.Timer
 
 	Restart RestartManager
+
+	ChannelAdmin ChannelAdmin
+
+	Restorer         Restorer
+	SnapshotTransfer SnapshotTransfer
+
+	Recorder record.EventRecorder
 }
 
-func New(client controllerclient.Client, scheme *runtime.Scheme, config *config.Config, o Override) *Peer {
+func New(client controllerclient.Client, scheme *runtime.Scheme, config *config.Config, recorder record.EventRecorder, o Override) *Peer {
+	backups := backup.New(client, scheme, config.Operator.Backup.Images)
 	p := &Peer{
-		Client:   client,
-		Scheme:   scheme,
-		Config:   config,
-		Override: o,
+		Client:           client,
+		Scheme:           scheme,
+		Config:           config,
+		Override:         o,
+		Recorder:         recorder,
+		Restorer:         backups,
+		SnapshotTransfer: backups,
 	}
 
 	p.CreateManagers()
@@ -227,7 +249,9 @@ func New(client controllerclient.Client, scheme *runtime.Scheme, config *config.
 	p.CertificateManager = certificate.New(client, scheme)
 	p.RenewCertTimers = make(map[string]*time.Timer)
 
-	p.Restart = restart.New(client, config.Operator.Restart.WaitTime.Get(), config.Operator.Restart.Timeout.Get())
+	p.Restart = restart.New(client, recorder, config.Operator.Restart.WaitTime.Get(), config.Operator.Restart.Timeout.Get())
+
+	p.ChannelAdmin = peeradmin.New(30 * time.Second)
 
 	return p
 }
@@ -237,13 +261,26 @@ func (p *Peer) CreateManagers() {
 	resourceManager := resourcemanager.New(p.Client, p.Scheme)
 	peerConfig := p.Config.PeerInitConfig
 
-	p.DeploymentManager = resourceManager.CreateDeploymentManager("", override.Deployment, p.GetLabels, peerConfig.DeploymentFile)
-	p.PVCManager = resourceManager.CreatePVCManager("", override.PVC, p.GetLabels, peerConfig.PVCFile)
-	p.StateDBPVCManager = resourceManager.CreatePVCManager("statedb", override.StateDBPVC, p.GetLabels, peerConfig.CouchDBPVCFile)
+	p.DeploymentManager = &statefulset.WorkloadManager{
+		Deployment: resourceManager.CreateDeploymentManager("", override.Deployment, p.GetLabels, peerConfig.DeploymentFile),
+		StatefulSet: resourceManager.CreateStatefulSetManager("", override.Deployment, map[string]func(v1.Object, *corev1.PersistentVolumeClaim, resources.Action) error{
+			"fabric-peer-0": override.PVC,
+			"db-data":       override.StateDBPVC,
+		}, p.GetLabels, peerConfig.StatefulSetFile),
+		UseStatefulSet: p.UsesStatefulSet,
+	}
+	p.PVCManager = resourceManager.CreatePVCManager("", p.RestorePVC(override.PVC), p.GetLabels, peerConfig.PVCFile)
+	p.StateDBPVCManager = resourceManager.CreatePVCManager("statedb", p.RestorePVC(override.StateDBPVC), p.GetLabels, peerConfig.CouchDBPVCFile)
 	p.RoleManager = resourceManager.CreateRoleManager("", nil, p.GetLabels, peerConfig.RoleFile)
 	p.RoleBindingManager = resourceManager.CreateRoleBindingManager("", nil, p.GetLabels, peerConfig.RoleBindingFile)
 	p.ServiceAccountManager = resourceManager.CreateServiceAccountManager("", nil, p.GetLabels, peerConfig.ServiceAccountFile)
 	p.ServiceManager = resourceManager.CreateServiceManager("", override.Service, p.GetLabels, peerConfig.ServiceFile)
+	p.PDBManager = resourceManager.CreatePodDisruptionBudgetManager(p.GetPodDisruptionBudgetName, p.PodDisruptionBudget, p.GetPodDisruptionBudgetLabels)
+}
+
+// UsesStatefulSet returns true if the peer runs as a stateful set
+func (p *Peer) UsesStatefulSet(instance v1.Object) bool {
+	return instance.(*current.IBPPeer).Spec.UsesStatefulSet()
 }
 
 func (p *Peer) PreReconcileChecks(instance *current.IBPPeer, update Update) (bool, error) {
@@ -306,6 +343,14 @@ func (p *Peer) PreReconcileChecks(instance *current.IBPPeer, update Update) (boo
 		return false, err
 	}
 
+	var replicasDeleted bool
+	if instance.Spec.ReplicaNumber == nil {
+		replicasDeleted, err = p.DeleteReplicas(instance)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	var replicasUpdated bool
 	if instance.Spec.Replicas == nil {
 		replicas := int32(1)
@@ -314,17 +359,18 @@ func (p *Peer) PreReconcileChecks(instance *current.IBPPeer, update Update) (boo
 	}
 
 	dbTypeUpdated := p.CheckDBType(instance)
-	updated := dbTypeUpdated || zoneUpdated || regionUpdated || hsmImageUpdated || replicasUpdated || imagesUpdated
+	updated := dbTypeUpdated || zoneUpdated || regionUpdated || hsmImageUpdated || replicasUpdated || imagesUpdated || replicasDeleted
 
 	if updated {
 		log.Info(fmt.Sprintf(
-			"dbTypeUpdate %t, zoneUpdated %t, regionUpdated %t, hsmImageUpdated %t, replicasUpdated %t, imagesUpdated %t",
+			"dbTypeUpdate %t, zoneUpdated %t, regionUpdated %t, hsmImageUpdated %t, replicasUpdated %t, imagesUpdated %t, replicasDeleted %t",
 			dbTypeUpdated,
 			zoneUpdated,
 			regionUpdated,
 			hsmImageUpdated,
 			replicasUpdated,
-			imagesUpdated))
+			imagesUpdated,
+			replicasDeleted))
 	}
 
 	return updated, nil
@@ -565,6 +611,13 @@ func (p *Peer) InitializeCreate(instance *current.IBPPeer, initPeer *initializer
 	}
 	configOverrides := co.(CoreConfig)
 
+	if instance.Spec.RestoreFrom != nil {
+		err = p.RestoreCrypto(instance, initPeer)
+		if err != nil {
+			return errors.Wrap(err, "failed to restore crypto")
+		}
+	}
+
 	resp, err := p.Initializer.Create(configOverrides, initPeer, storagePath)
 	if err != nil {
 		return err
@@ -606,6 +659,8 @@ func (p *Peer) InitializeCreate(instance *current.IBPPeer, initPeer *initializer
 				return err
 			}
 		}
+
+		events.Normal(p.Recorder, instance, events.Initialized, "Generated crypto material and config for peer")
 	}
 
 	return nil
@@ -658,11 +713,37 @@ func (p *Peer) Reconcile(instance *current.IBPPeer, update Update) (common.Resul
 		}, nil
 	}
 
+	issued, err := p.CertManagerCertificateIssued(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to check cert-manager certificate")
+	}
+	if !issued {
+		log.Info(fmt.Sprintf("Waiting for cert-manager to issue the TLS certificate of '%s'", instance.GetName()))
+		return WaitForCertManager(instance), nil
+	}
+
 	err = p.Initialize(instance, update)
 	if err != nil {
 		return common.Result{}, operatorerrors.Wrap(err, operatorerrors.PeerInitilizationFailed, "failed to initialize peer")
 	}
 
+	restored, err := p.RestoreVolumes(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to restore volumes")
+	}
+	if !restored {
+		return common.Result{
+			Result: reconcile.Result{
+				RequeueAfter: RestoreRequeueInterval,
+			},
+			Status: &current.CRStatus{
+				Type:    current.Deploying,
+				Reason:  "restoringVolumes",
+				Message: fmt.Sprintf("Restoring volumes from backup '%s'", instance.Spec.RestoreFrom.Backup),
+			},
+		}, nil
+	}
+
 	err = p.ReconcileManagers(instance, update)
 	if err != nil {
 		return common.Result{}, errors.Wrap(err, "failed to reconcile managers")
@@ -697,6 +778,16 @@ func (p *Peer) Reconcile(instance *current.IBPPeer, update Update) (common.Resul
 		// A successful update will trigger a tlsCertUpdated or ecertUpdated event, which will handle restarting deployment
 	}
 
+	err = p.ReconcileCertManagerCertificate(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile cert-manager certificate")
+	}
+
+	err = p.ReconcileTLSCertHosts(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile TLS certificate hosts")
+	}
+
 	if update.EcertUpdated() {
 		log.Info("Ecert was updated")
 		// Request deployment restart for tls cert update
@@ -723,8 +814,19 @@ func (p *Peer) Reconcile(instance *current.IBPPeer, update Update) (common.Resul
 		return common.Result{}, err
 	}
 
+	channelsResult, err := p.ReconcileChannels(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile channels")
+	}
+
+	snapshotsResult, err := p.ReconcileSnapshots(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile snapshots")
+	}
+
 	return common.Result{
 		Status: status,
+		Result: MergeResults(channelsResult, snapshotsResult),
 	}, nil
 }
 
@@ -733,16 +835,19 @@ func (p *Peer) ReconcileManagers(instance *current.IBPPeer, updated Update) erro
 
 	update := updated.SpecUpdated()
 
-	p.PVCManager.SetCustomName(instance.Spec.CustomNames.PVC.Peer)
-	err = p.PVCManager.Reconcile(instance, update)
-	if err != nil {
-		return errors.Wrap(err, "failed PVC reconciliation")
-	}
+	// A stateful set creates its volumes from its volume claim templates
+	if !instance.Spec.UsesStatefulSet() {
+		p.PVCManager.SetCustomName(instance.Spec.CustomNames.PVC.Peer)
+		err = p.PVCManager.Reconcile(instance, update)
+		if err != nil {
+			return errors.Wrap(err, "failed PVC reconciliation")
+		}
 
-	p.StateDBPVCManager.SetCustomName(instance.Spec.CustomNames.PVC.StateDB)
-	err = p.StateDBPVCManager.Reconcile(instance, update)
-	if err != nil {
-		return errors.Wrap(err, "failed CouchDB PVC reconciliation")
+		p.StateDBPVCManager.SetCustomName(instance.Spec.CustomNames.PVC.StateDB)
+		err = p.StateDBPVCManager.Reconcile(instance, update)
+		if err != nil {
+			return errors.Wrap(err, "failed CouchDB PVC reconciliation")
+		}
 	}
 
 	err = p.ReconcileSecret(instance)
@@ -760,6 +865,11 @@ func (p *Peer) ReconcileManagers(instance *current.IBPPeer, updated Update) erro
 		return errors.Wrap(err, "failed Deployment reconciliation")
 	}
 
+	err = p.PDBManager.Reconcile(instance, update)
+	if err != nil {
+		return errors.Wrap(err, "failed PodDisruptionBudget reconciliation")
+	}
+
 	err = p.ReconcilePeerRBAC(instance)
 	if err != nil {
 		return errors.Wrap(err, "failed RBAC reconciliation")
@@ -1055,14 +1165,30 @@ func (p *Peer) UpdateConnectionProfileConfigmap(instance *current.IBPPeer, endpo
 }
 
 func (p *Peer) GetEndpoints(instance *current.IBPPeer) *current.PeerEndpoints {
+	if host := GetLoadBalancerHost(instance); host != "" {
+		return &current.PeerEndpoints{
+			API:        "grpcs://" + net.JoinHostPort(host, strconv.Itoa(common.PeerAPIPort)),
+			Operations: "https://" + net.JoinHostPort(host, strconv.Itoa(common.PeerOperationsPort)),
+			Grpcweb:    "https://" + net.JoinHostPort(host, strconv.Itoa(common.GrpcwebPort)),
+		}
+	}
+
 	endpoints := &current.PeerEndpoints{
-		API:        "grpcs://" + instance.Namespace + "-" + instance.Name + "-peer." + instance.Spec.Domain + ":443",
+		API:        GetAPIEndpoint(instance),
 		Operations: "https://" + instance.Namespace + "-" + instance.Name + "-operations." + instance.Spec.Domain + ":443",
 		Grpcweb:    "https://" + instance.Namespace + "-" + instance.Name + "-grpcweb." + instance.Spec.Domain + ":443",
 	}
 	return endpoints
 }
 
+// GetAPIEndpoint returns the external API endpoint of the peer
+func GetAPIEndpoint(instance *current.IBPPeer) string {
+	if host := GetLoadBalancerHost(instance); host != "" {
+		return "grpcs://" + net.JoinHostPort(host, strconv.Itoa(common.PeerAPIPort))
+	}
+	return "grpcs://" + instance.Namespace + "-" + instance.Name + "-peer." + instance.Spec.Domain + ":443"
+}
+
 func (p *Peer) ConfigExists(instance *current.IBPPeer) bool {
 	name := fmt.Sprintf("%s-config", instance.GetName())
 	namespacedName := types.NamespacedName{
@@ -1103,6 +1229,115 @@ func (p *Peer) CheckCSRHosts(instance *current.IBPPeer, hosts []string) bool {
 	return false
 }
 
+// ReconcileTLSCertHosts compares the hosts in the TLS enrollment CSR against the subject
+// alternative names of the current TLS certificate. If a host is missing, e.g. because the
+// domain or external endpoint changed, the TLS certificate is reenrolled with the current
+// hosts and a restart of the deployment is requested to pick up the new certificate.
+func (p *Peer) ReconcileTLSCertHosts(instance *current.IBPPeer) error {
+	secret := instance.Spec.Secret
+	if secret == nil || secret.Enrollment == nil || secret.Enrollment.TLS == nil || secret.Enrollment.TLS.CSR == nil {
+		return nil
+	}
+	// Certificates passed in the MSP spec can't be reenrolled by the operator
+	if secret.MSP != nil && secret.MSP.TLS != nil {
+		return nil
+	}
+	// cert-manager reissues the certificate when the hosts of its Certificate change
+	if secret.UsesCertManagerForTLS() {
+		return nil
+	}
+
+	missing, err := p.CertificateManager.GetMissingHosts(commoninit.TLS, instance, secret.Enrollment.TLS.CSR.Hosts)
+	if err != nil {
+		return errors.Wrap(err, "failed to get hosts missing from TLS certificate")
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("TLS certificate for instance '%s' is missing hosts %v, reenrolling", instance.GetName(), missing))
+	err = p.RenewCert(commoninit.TLS, instance, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to reenroll TLS certificate with current hosts")
+	}
+
+	err = p.Restart.ForCertUpdate(commoninit.TLS, instance)
+	if err != nil {
+		return errors.Wrap(err, "failed to update restart config")
+	}
+
+	return nil
+}
+
+// CertManagerCertificateIssued returns true if cert-manager has issued the TLS certificate of the
+// peer, or if the TLS certificate is not issued by cert-manager. The peer is not initialized
+// before its TLS certificate has been issued.
+func (p *Peer) CertManagerCertificateIssued(instance *current.IBPPeer) (bool, error) {
+	if !instance.Spec.Secret.UsesCertManagerForTLS() {
+		return true, nil
+	}
+
+	cm := certmanager.New(p.Client, p.Scheme, instance, instance.Spec.Secret.Enrollment.TLS)
+	return cm.IsIssued()
+}
+
+// WaitForCertManager returns the result of a reconcile that waits for cert-manager to issue the
+// TLS certificate of the peer
+func WaitForCertManager(instance *current.IBPPeer) common.Result {
+	return common.Result{
+		Result: reconcile.Result{
+			RequeueAfter: certmanager.RequeueInterval,
+		},
+		Status: &current.CRStatus{
+			Type:    current.Deploying,
+			Reason:  "waitingForCertManager",
+			Message: fmt.Sprintf("Waiting for cert-manager to issue the TLS certificate of '%s'", instance.GetName()),
+		},
+	}
+}
+
+// ReconcileCertManagerCertificate keeps the cert-manager Certificate of the TLS certificate in sync
+// with the hosts in the TLS enrollment CSR, and updates the TLS secrets when cert-manager renewed
+// the certificate. The update of the TLS secrets requests a restart of the deployment.
+func (p *Peer) ReconcileCertManagerCertificate(instance *current.IBPPeer) error {
+	if !instance.Spec.Secret.UsesCertManagerForTLS() {
+		return nil
+	}
+
+	cm := certmanager.New(p.Client, p.Scheme, instance, instance.Spec.Secret.Enrollment.TLS)
+	err := cm.ReconcileCertificate()
+	if err != nil {
+		return err
+	}
+
+	cert, err := p.CertificateManager.GetSignCert(fmt.Sprintf("tls-%s-signcert", instance.GetName()), instance.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	crypto, err := cm.GetRenewedCrypto(cert)
+	if err != nil {
+		return errors.Wrap(err, "failed to get TLS certificate renewed by cert-manager")
+	}
+	if crypto == nil {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("cert-manager renewed TLS certificate for instance '%s', updating TLS secrets", instance.GetName()))
+	err = common.BackupCrypto(p.Client, p.Scheme, instance, p.GetLabels(instance))
+	if err != nil {
+		return errors.Wrap(err, "failed to backup crypto before updating TLS secrets")
+	}
+
+	err = p.Initializer.GenerateSecrets(commoninit.TLS, instance, crypto)
+	if err != nil {
+		return errors.Wrap(err, "failed to update TLS secrets")
+	}
+	events.Normal(p.Recorder, instance, events.CertificateRenewed, "Renewed %s certificate", commoninit.TLS)
+
+	return nil
+}
+
 func (p *Peer) GetBCCSPSectionForInstance(instance *current.IBPPeer) (*commonapi.BCCSP, error) {
 	var bccsp *commonapi.BCCSP
 	if instance.IsHSMEnabled() {
@@ -1138,6 +1373,7 @@ func (p *Peer) ReconcileFabricPeerMigrationV1_4(instance *current.IBPPeer) error
 		if err := p.Initializer.CoreConfigMap().CreateOrUpdate(instance, peerConfig); err != nil {
 			return errors.Wrapf(err, "failed to create/update '%s' peer's config map", instance.GetName())
 		}
+		events.Normal(p.Recorder, instance, events.Migrated, "Migrated peer config to fabric version %s", instance.Spec.FabricVersion)
 	}
 
 	return nil
@@ -1201,6 +1437,7 @@ func (p *Peer) ReconcileFabricPeerMigrationV2_0(instance *current.IBPPeer) error
 	if err := fabric.V2Migrate(instance, migrator, instance.Spec.FabricVersion, p.Config.Operator.Peer.Timeouts.DBMigration); err != nil {
 		return err
 	}
+	events.Normal(p.Recorder, instance, events.Migrated, "Migrated peer to fabric version %s", instance.Spec.FabricVersion)
 
 	return nil
 }
@@ -1217,6 +1454,7 @@ func (p *Peer) ReconcileFabricPeerMigrationV2_4(instance *current.IBPPeer) error
 	if err := fabric.V24Migrate(instance, migrator, instance.Spec.FabricVersion, p.Config.Operator.Peer.Timeouts.DBMigration); err != nil {
 		return err
 	}
+	events.Normal(p.Recorder, instance, events.Migrated, "Migrated peer to fabric version %s", instance.Spec.FabricVersion)
 
 	return nil
 }
@@ -1233,6 +1471,7 @@ func (p *Peer) ReconcileFabricPeerMigrationV2_5(instance *current.IBPPeer) error
 	if err := fabric.V25Migrate(instance, migrator, instance.Spec.FabricVersion, p.Config.Operator.Peer.Timeouts.DBMigration); err != nil {
 		return err
 	}
+	events.Normal(p.Recorder, instance, events.Migrated, "Migrated peer to fabric version %s", instance.Spec.FabricVersion)
 
 	return nil
 }
@@ -1363,6 +1602,7 @@ func (p *Peer) EnrollForEcert(instance *current.IBPPeer) error {
 	storagePath := filepath.Join(p.GetInitStoragePath(instance), "ecert")
 	crypto, err := action.Enroll(instance, ecertSpec, storagePath, p.Client, p.Scheme, true, p.Config.Operator.Peer.Timeouts.EnrollJob)
 	if err != nil {
+		events.Warning(p.Recorder, instance, events.EnrollmentFailed, "Failed to enroll for ecert: %s", err)
 		return errors.Wrap(err, "failed to enroll for ecert")
 	}
 
@@ -1370,6 +1610,7 @@ func (p *Peer) EnrollForEcert(instance *current.IBPPeer) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to generate ecert secrets")
 	}
+	events.Normal(p.Recorder, instance, events.Enrolled, "Enrolled for ecert")
 
 	return nil
 }
@@ -1381,11 +1622,15 @@ func (p *Peer) EnrollForTLSCert(instance *current.IBPPeer) error {
 	if secret == nil || secret.Enrollment == nil || secret.Enrollment.TLS == nil {
 		return errors.New("unable to enroll, no TLS enrollment information provided")
 	}
+	if secret.UsesCertManagerForTLS() {
+		return errors.New("unable to enroll, TLS certificate is issued by cert-manager")
+	}
 	tlscertSpec := secret.Enrollment.TLS
 
 	storagePath := filepath.Join(p.GetInitStoragePath(instance), "tls")
 	crypto, err := action.Enroll(instance, tlscertSpec, storagePath, p.Client, p.Scheme, false, p.Config.Operator.Peer.Timeouts.EnrollJob)
 	if err != nil {
+		events.Warning(p.Recorder, instance, events.EnrollmentFailed, "Failed to enroll for TLS cert: %s", err)
 		return errors.Wrap(err, "failed to enroll for TLS cert")
 	}
 
@@ -1393,6 +1638,7 @@ func (p *Peer) EnrollForTLSCert(instance *current.IBPPeer) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to generate ecert secrets")
 	}
+	events.Normal(p.Recorder, instance, events.Enrolled, "Enrolled for TLS cert")
 
 	return nil
 }
@@ -1485,6 +1731,15 @@ func (p *Peer) HandleActions(instance *current.IBPPeer, update Update) error {
 		instance.ResetTLSEnroll()
 	}
 
+	if update.SnapshotRequested() {
+		if err := p.Snapshot(instance); err != nil {
+			log.Error(err, "Resetting action flag on failure")
+			instance.ResetSnapshot()
+			return err
+		}
+		instance.ResetSnapshot()
+	}
+
 	// Upgrade DBs needs to be one of the last thing that should be performed to allow for other
 	// update flags to be processed
 	if update.UpgradeDBs() {
@@ -1600,6 +1855,10 @@ func (p *Peer) RenewCert(certType commoninit.SecretType, obj runtime.Object, new
 		return errors.New(fmt.Sprintf("missing secret spec for instance '%s'", instance.GetName()))
 	}
 
+	if certType == commoninit.TLS && instance.Spec.Secret.UsesCertManagerForTLS() {
+		return errors.New("cannot renew TLS certificate issued by cert-manager, certificate is renewed by cert-manager")
+	}
+
 	if instance.Spec.Secret.Enrollment != nil {
 		log.Info(fmt.Sprintf("Renewing %s certificate for instance '%s'", string(certType), instance.Name))
 
@@ -1613,9 +1872,12 @@ func (p *Peer) RenewCert(certType commoninit.SecretType, obj runtime.Object, new
 
 		err = p.CertificateManager.RenewCert(certType, instance, spec, bccsp, storagePath, hsmEnabled, newKey)
 		if err != nil {
+			events.Warning(p.Recorder, instance, events.CertificateRenewalFailed, "Failed to renew %s certificate: %s", certType, err)
 			return err
 		}
+		events.Normal(p.Recorder, instance, events.CertificateRenewed, "Renewed %s certificate", certType)
 	} else {
+		events.Warning(p.Recorder, instance, events.CertificateRenewalFailed, "Cannot auto-renew %s certificate created by MSP, force renewal required", certType)
 		return errors.New("cannot auto-renew certificate created by MSP, force renewal required")
 	}
 
@@ -1701,6 +1963,11 @@ func (p *Peer) CheckCertificates(instance *current.IBPPeer) (*current.CRStatus,
 
 func (p *Peer) SetCertificateTimer(instance *current.IBPPeer, certType commoninit.SecretType) error {
 	certName := fmt.Sprintf("%s-%s-signcert", certType, instance.Name)
+	if certType == commoninit.TLS && instance.Spec.Secret.UsesCertManagerForTLS() {
+		log.Info(fmt.Sprintf("Not setting timer to renew %s, certificate is renewed by cert-manager", certName))
+		return nil
+	}
+
 	numSecondsBeforeExpire := instance.Spec.GetNumSecondsWarningPeriod()
 	duration, err := p.CertificateManager.GetDurationToNextRenewal(certType, instance, numSecondsBeforeExpire)
 	if err != nil {
@@ -38,11 +38,13 @@ import (
 	"github.com/IBM-Blockchain/fabric-operator/pkg/apis/deployer"
 	v1 "github.com/IBM-Blockchain/fabric-operator/pkg/apis/peer/v1"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/certificate"
+	commoninit "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common"
 	commonconfig "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/config"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/enroller"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/mspparser"
 	peerinit "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/peer"
 	pconfig "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/peer/config/v1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
 	managermocks "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/mocks"
 	basepeer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer/mocks"
@@ -56,6 +58,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -78,6 +81,7 @@ var _ = Describe("Base Peer", func() {
 		roleMgr           *managermocks.ResourceManager
 		roleBindingMgr    *managermocks.ResourceManager
 		serviceAccountMgr *managermocks.ResourceManager
+		pdbMgr            *managermocks.ResourceManager
 
 		certificateMgr *peermocks.CertificateManager
 		initializer    *peermocks.InitializeIBPPeer
@@ -160,6 +164,7 @@ var _ = Describe("Base Peer", func() {
 		roleMgr = &managermocks.ResourceManager{}
 		roleBindingMgr = &managermocks.ResourceManager{}
 		serviceAccountMgr = &managermocks.ResourceManager{}
+		pdbMgr = &managermocks.ResourceManager{}
 
 		scheme := &runtime.Scheme{}
 		cfg = &config.Config{
@@ -200,6 +205,7 @@ var _ = Describe("Base Peer", func() {
 			RoleManager:           roleMgr,
 			RoleBindingManager:    roleBindingMgr,
 			ServiceAccountManager: serviceAccountMgr,
+			PDBManager:            pdbMgr,
 			Initializer:           initializer,
 
 			CertificateManager: certificateMgr,
@@ -539,6 +545,13 @@ var _ = Describe("Base Peer", func() {
 			Expect(err.Error()).To(ContainSubstring("failed to reconcile service account"))
 		})
 
+		It("returns an error if pod disruption budget manager fails to reconcile", func() {
+			pdbMgr.ReconcileReturns(errors.New("failed to reconcile pod disruption budget"))
+			_, err := peer.Reconcile(instance, update)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed PodDisruptionBudget reconciliation: failed to reconcile pod disruption budget"))
+		})
+
 		It("does not return an error on a successful reconcile", func() {
 			_, err := peer.Reconcile(instance, update)
 			Expect(err).NotTo(HaveOccurred())
@@ -619,6 +632,179 @@ var _ = Describe("Base Peer", func() {
 			Expect(instance.Spec.Secret.Enrollment.TLS.CSR.Hosts).To(ContainElement(hosts[1]))
 		})
 	})
+
+	Context("reconcile TLS cert hosts", func() {
+		var restartMgr *peermocks.RestartManager
+
+		BeforeEach(func() {
+			restartMgr = peer.Restart.(*peermocks.RestartManager)
+			instance.Spec.Secret = &current.SecretSpec{
+				Enrollment: &current.EnrollmentSpec{
+					TLS: &current.Enrollment{
+						CSR: &current.CSR{
+							Hosts: []string{"peer.new-domain.com", "127.0.0.1"},
+						},
+					},
+				},
+			}
+		})
+
+		It("does nothing if certificate was passed in MSP spec", func() {
+			instance.Spec.Secret.MSP = &current.MSPSpec{
+				TLS: &current.MSP{},
+			}
+			err := peer.ReconcileTLSCertHosts(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certificateMgr.GetMissingHostsCallCount()).To(Equal(0))
+		})
+
+		It("returns error if fails to get missing hosts", func() {
+			certificateMgr.GetMissingHostsReturns(nil, errors.New("get missing hosts error"))
+			err := peer.ReconcileTLSCertHosts(instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("get missing hosts error"))
+		})
+
+		It("does not reenroll if certificate contains all hosts", func() {
+			certificateMgr.GetMissingHostsReturns([]string{}, nil)
+			err := peer.ReconcileTLSCertHosts(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certificateMgr.RenewCertCallCount()).To(Equal(0))
+			Expect(restartMgr.ForCertUpdateCallCount()).To(Equal(0))
+		})
+
+		It("returns error if fails to reenroll certificate", func() {
+			certificateMgr.GetMissingHostsReturns([]string{"peer.new-domain.com"}, nil)
+			certificateMgr.RenewCertReturns(errors.New("renew error"))
+			err := peer.ReconcileTLSCertHosts(instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("renew error"))
+			Expect(restartMgr.ForCertUpdateCallCount()).To(Equal(0))
+		})
+
+		It("reenrolls certificate and requests restart if hosts are missing", func() {
+			certificateMgr.GetMissingHostsReturns([]string{"peer.new-domain.com"}, nil)
+			err := peer.ReconcileTLSCertHosts(instance)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, hosts := certificateMgr.GetMissingHostsArgsForCall(0)
+			Expect(hosts).To(Equal([]string{"peer.new-domain.com", "127.0.0.1"}))
+
+			Expect(certificateMgr.RenewCertCallCount()).To(Equal(1))
+			certType, _, spec, _, _, _, newKey := certificateMgr.RenewCertArgsForCall(0)
+			Expect(certType).To(Equal(commoninit.TLS))
+			Expect(spec.TLS.CSR.Hosts).To(ContainElement("peer.new-domain.com"))
+			Expect(newKey).To(BeFalse())
+
+			Expect(restartMgr.ForCertUpdateCallCount()).To(Equal(1))
+			certType, _ = restartMgr.ForCertUpdateArgsForCall(0)
+			Expect(certType).To(Equal(commoninit.TLS))
+		})
+	})
+
+	Context("reconcile cert-manager certificate", func() {
+		var (
+			issuedCert  []byte
+			certCreated bool
+		)
+
+		BeforeEach(func() {
+			issuedCert = generateCertPemBytes(90)
+			certCreated = false
+			instance.Spec.Secret = &current.SecretSpec{
+				Enrollment: &current.EnrollmentSpec{
+					TLS: &current.Enrollment{
+						CertManager: &current.CertManagerIssuer{
+							Name: "issuer",
+						},
+						CSR: &current.CSR{
+							Hosts: []string{"peer.domain.com"},
+						},
+					},
+				},
+			}
+
+			mockKubeClient.GetStub = func(ctx context.Context, types types.NamespacedName, obj client.Object) error {
+				switch obj.(type) {
+				case *unstructured.Unstructured:
+					if !certCreated {
+						return k8serrors.NewNotFound(schema.GroupResource{}, "not found")
+					}
+				case *corev1.Secret:
+					o := obj.(*corev1.Secret)
+					switch types.Name {
+					case "tls-" + instance.Name + "-certmanager":
+						o.Name = types.Name
+						o.Namespace = instance.Namespace
+						o.Data = map[string][]byte{
+							"tls.crt": issuedCert,
+							"tls.key": []byte("key"),
+							"ca.crt":  []byte("cacert"),
+						}
+					case instance.Name + "-crypto-backup":
+						return k8serrors.NewNotFound(schema.GroupResource{}, "not found")
+					}
+				}
+				return nil
+			}
+			mockKubeClient.CreateStub = func(ctx context.Context, obj client.Object, opts ...controllerclient.CreateOption) error {
+				if _, ok := obj.(*unstructured.Unstructured); ok {
+					certCreated = true
+				}
+				return nil
+			}
+		})
+
+		It("does nothing if TLS certificate is not issued by cert-manager", func() {
+			instance.Spec.Secret.Enrollment.TLS.CertManager = nil
+			err := peer.ReconcileCertManagerCertificate(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockKubeClient.CreateCallCount()).To(Equal(0))
+			Expect(certificateMgr.GetSignCertCallCount()).To(Equal(0))
+		})
+
+		It("returns error if fails to create certificate", func() {
+			mockKubeClient.CreateReturns(errors.New("create error"))
+			mockKubeClient.CreateStub = nil
+			err := peer.ReconcileCertManagerCertificate(instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("create error"))
+		})
+
+		It("does not update secrets if certificate has not changed", func() {
+			certificateMgr.GetSignCertReturns(issuedCert, nil)
+			err := peer.ReconcileCertManagerCertificate(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certCreated).To(Equal(true))
+			Expect(initializer.GenerateSecretsCallCount()).To(Equal(0))
+		})
+
+		It("updates TLS secrets if cert-manager renewed the certificate", func() {
+			certificateMgr.GetSignCertReturns(generateCertPemBytes(29), nil)
+			err := peer.ReconcileCertManagerCertificate(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(initializer.GenerateSecretsCallCount()).To(Equal(1))
+
+			prefix, _, resp := initializer.GenerateSecretsArgsForCall(0)
+			Expect(prefix).To(Equal(commoninit.TLS))
+			Expect(resp.SignCert).To(Equal(issuedCert))
+			Expect(resp.CACerts).To(Equal([][]byte{[]byte("cacert")}))
+		})
+
+		It("reports the certificate as issued once cert-manager wrote it to the secret", func() {
+			issued, err := peer.CertManagerCertificateIssued(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issued).To(Equal(true))
+		})
+
+		It("creates the certificate and waits if cert-manager has not issued it yet", func() {
+			issuedCert = nil
+			issued, err := peer.CertManagerCertificateIssued(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issued).To(Equal(false))
+			Expect(certCreated).To(Equal(true))
+		})
+	})
 	Context("check certificates", func() {
 		It("returns error if fails to get certificate expiry info", func() {
 			certificateMgr.CheckCertificatesForExpireReturns("", "", errors.New("cert expiry error"))
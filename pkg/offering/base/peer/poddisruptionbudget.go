@@ -0,0 +1,65 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package basepeer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	policyv1 "k8s.io/api/policy/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var invalidNameChars = regexp.MustCompile("[^a-z0-9-]+")
+
+// GetPodDisruptionBudgetName returns the name of the pod disruption budget shared by the
+// peers of the organization of the peer
+func (p *Peer) GetPodDisruptionBudgetName(instance v1.Object) string {
+	mspID := instance.(*current.IBPPeer).Spec.MSPID
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(mspID), "-"), "-")
+	return fmt.Sprintf("%s-peer-pdb", name)
+}
+
+// GetPodDisruptionBudgetLabels returns the labels of the organization of the peer, without
+// the labels that identify the peer itself
+func (p *Peer) GetPodDisruptionBudgetLabels(instance v1.Object) map[string]string {
+	labels := p.GetLabels(instance)
+	delete(labels, "app")
+	return labels
+}
+
+// PodDisruptionBudget sets the budget of the peers of the organization so that voluntary
+// disruptions, such as node drains, take down a single peer of the organization at a time
+func (p *Peer) PodDisruptionBudget(object v1.Object, pdb *policyv1.PodDisruptionBudget, action resources.Action) error {
+	instance := object.(*current.IBPPeer)
+
+	maxUnavailable := intstr.FromInt(1)
+	pdb.Spec.MaxUnavailable = &maxUnavailable
+	pdb.Spec.Selector = &v1.LabelSelector{
+		MatchLabels: map[string]string{
+			"orgname": instance.Spec.MSPID,
+		},
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package basepeer_test
+
+import (
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	basepeer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	policyv1 "k8s.io/api/policy/v1"
+)
+
+var _ = Describe("Base Peer Pod Disruption Budget", func() {
+	var (
+		instance *current.IBPPeer
+		peer     *basepeer.Peer
+	)
+
+	BeforeEach(func() {
+		instance = &current.IBPPeer{
+			Spec: current.IBPPeerSpec{
+				MSPID: "Org1_MSP",
+			},
+		}
+		instance.Name = "peer1"
+		instance.Namespace = "namespace"
+
+		peer = &basepeer.Peer{}
+	})
+
+	It("is shared by the peers of the organization", func() {
+		Expect(peer.GetPodDisruptionBudgetName(instance)).To(Equal("org1-msp-peer-pdb"))
+		Expect(peer.GetPodDisruptionBudgetLabels(instance)).NotTo(HaveKey("app"))
+		Expect(peer.GetPodDisruptionBudgetLabels(instance)).To(HaveKeyWithValue("orgname", "Org1_MSP"))
+	})
+
+	It("allows a single peer of the organization to be disrupted", func() {
+		pdb := &policyv1.PodDisruptionBudget{}
+		err := peer.PodDisruptionBudget(instance, pdb, resources.Create)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pdb.Spec.Selector.MatchLabels).To(Equal(map[string]string{"orgname": "Org1_MSP"}))
+		Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+	})
+})
@@ -0,0 +1,396 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package basepeer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	commoninit "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common"
+	controllerclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/statefulset"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReplicasAnnotation is set on a peer with replicas to the number of its replicas, a peer
+// without the annotation has no replicas to look up when it runs as a single peer
+const ReplicasAnnotation = "ibp.com/replicas"
+
+// GetReplicaName returns the name of the IBPPeer of a replica
+func GetReplicaName(name string, number int) string {
+	return fmt.Sprintf("%s-%d", name, number)
+}
+
+// GetReplicaEnrollID returns the enrollment ID a replica enrolls with. The operator has no
+// registrar credentials for the CA of the peer, so the enrollment IDs of all replicas, e.g.
+// 'peer1-1' and 'peer1-2' for the enrollment ID 'peer1', must be registered with the CA
+// before the number of replicas is increased
+func GetReplicaEnrollID(enrollID string, number int) string {
+	return fmt.Sprintf("%s-%d", enrollID, number)
+}
+
+// TearDownPeer deletes the workload, service, volumes and crypto material of a peer that ran
+// as a single peer before its number of replicas was increased. The replicas are separate peers
+// with their own enrollment IDs and volumes, nothing of the single peer is carried over to them.
+// Returns true if the resources of a single peer were found and deleted.
+func (p *Peer) TearDownPeer(instance *current.IBPPeer) (bool, error) {
+	if !p.DeploymentManager.Exists(instance) {
+		return false, nil
+	}
+
+	log.Info(fmt.Sprintf("Tearing down single peer '%s' that is scaled to %d replicas", instance.GetName(), *instance.Spec.Replicas))
+
+	err := p.DeploymentManager.Delete(instance)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete workload")
+	}
+
+	err = p.ServiceManager.Delete(instance)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete service")
+	}
+
+	p.PVCManager.SetCustomName(instance.Spec.CustomNames.PVC.Peer)
+	err = p.PVCManager.Delete(instance)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete pvc")
+	}
+
+	p.StateDBPVCManager.SetCustomName(instance.Spec.CustomNames.PVC.StateDB)
+	err = p.StateDBPVCManager.Delete(instance)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete statedb pvc")
+	}
+
+	// Claims created from the volume claim templates are not deleted with the stateful set
+	names := []string{
+		statefulset.ClaimName("fabric-peer-0", instance.GetName(), 0),
+		statefulset.ClaimName("db-data", instance.GetName(), 0),
+	}
+	for _, name := range names {
+		pvc := &corev1.PersistentVolumeClaim{}
+		pvc.Name = name
+		pvc.Namespace = instance.GetNamespace()
+		err = p.Client.Delete(context.TODO(), pvc)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return false, errors.Wrapf(err, "failed to delete pvc '%s'", name)
+		}
+	}
+
+	for _, prefix := range []commoninit.SecretType{commoninit.ECERT, commoninit.TLS} {
+		for _, suffix := range []string{"admincerts", "cacerts", "intercerts", "signcert", "keystore"} {
+			secret := &corev1.Secret{}
+			secret.Name = fmt.Sprintf("%s-%s-%s", prefix, instance.GetName(), suffix)
+			secret.Namespace = instance.GetNamespace()
+			err = p.Client.Delete(context.TODO(), secret)
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return false, errors.Wrapf(err, "failed to delete secret '%s'", secret.Name)
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// ReconcileReplicas reconciles a peer with more than one replica. Every replica is a separate
+// IBPPeer that is owned by the instance, created from its spec and enrolled with enrollment IDs
+// derived from the enrollment IDs of the instance. Replicas are created and deleted when the
+// number of replicas changes, other changes to the spec of the instance are not applied to the
+// replicas that exist already.
+func (p *Peer) ReconcileReplicas(instance *current.IBPPeer) (common.Result, error) {
+	log.Info(fmt.Sprintf("Reconciling replicas of peer '%s'", instance.GetName()))
+
+	replicas, err := p.GetReplicas(instance)
+	if err != nil {
+		return common.Result{}, err
+	}
+
+	size := int(*instance.Spec.Replicas)
+	if instance.GetAnnotations()[ReplicasAnnotation] != strconv.Itoa(size) {
+		annotations := instance.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[ReplicasAnnotation] = strconv.Itoa(size)
+		instance.SetAnnotations(annotations)
+
+		err = p.Client.Patch(context.TODO(), instance, nil, controllerclient.PatchOption{
+			Resilient: &controllerclient.ResilientPatch{
+				Retry:    3,
+				Into:     &current.IBPPeer{},
+				Strategy: k8sclient.MergeFrom,
+			},
+		})
+		if err != nil {
+			return common.Result{}, errors.Wrap(err, "failed to annotate peer with number of replicas")
+		}
+	}
+
+	active := []current.IBPPeer{}
+	for _, replica := range replicas {
+		if *replica.Spec.ReplicaNumber > size {
+			log.Info(fmt.Sprintf("Deleting replica '%s' of peer '%s'", replica.GetName(), instance.GetName()))
+			replicaRef := replica
+			err := p.Client.Delete(context.TODO(), &replicaRef)
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return common.Result{}, errors.Wrapf(err, "failed to delete replica '%s'", replica.GetName())
+			}
+			continue
+		}
+		active = append(active, replica)
+	}
+
+	for number := 1; number <= size; number++ {
+		if replicaExists(active, number) {
+			continue
+		}
+
+		err := p.CreateReplicaCR(instance, number)
+		if err != nil {
+			return common.Result{}, errors.Wrapf(err, "failed to create replica %d", number)
+		}
+	}
+
+	err = p.UpdateReplicasConnectionProfile(instance, active)
+	if err != nil {
+		return common.Result{}, err
+	}
+
+	return common.Result{
+		Status: replicasStatus(active, size),
+	}, nil
+}
+
+// DeleteReplicas deletes the replicas of a peer that was scaled down to a single replica,
+// the peer then runs as a single peer that enrolls with the enrollment IDs of its spec.
+// Replicas are only looked up for peers that are annotated with the number of replicas,
+// returns true if the annotation was removed and the instance needs to be updated.
+func (p *Peer) DeleteReplicas(instance *current.IBPPeer) (bool, error) {
+	if _, found := instance.GetAnnotations()[ReplicasAnnotation]; !found {
+		return false, nil
+	}
+
+	replicas, err := p.GetReplicas(instance)
+	if err != nil {
+		return false, err
+	}
+
+	for _, replica := range replicas {
+		log.Info(fmt.Sprintf("Deleting replica '%s' of peer '%s' scaled down to a single replica", replica.GetName(), instance.GetName()))
+		replicaRef := replica
+		err := p.Client.Delete(context.TODO(), &replicaRef)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return false, errors.Wrapf(err, "failed to delete replica '%s'", replica.GetName())
+		}
+	}
+
+	annotations := instance.GetAnnotations()
+	delete(annotations, ReplicasAnnotation)
+	instance.SetAnnotations(annotations)
+
+	return true, nil
+}
+
+// GetReplicas returns the replicas of the peer ordered by their number
+func (p *Peer) GetReplicas(instance *current.IBPPeer) ([]current.IBPPeer, error) {
+	labelSelector, err := labels.Parse(fmt.Sprintf("parent=%s", instance.GetName()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse selector for parent name")
+	}
+
+	peerList := &current.IBPPeerList{}
+	err = p.Client.List(context.TODO(), peerList, &k8sclient.ListOptions{
+		LabelSelector: labelSelector,
+		Namespace:     instance.GetNamespace(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list replicas")
+	}
+
+	replicas := []current.IBPPeer{}
+	for _, peer := range peerList.Items {
+		if peer.Spec.ReplicaNumber != nil {
+			replicas = append(replicas, peer)
+		}
+	}
+	sort.Slice(replicas, func(i, j int) bool {
+		return *replicas[i].Spec.ReplicaNumber < *replicas[j].Spec.ReplicaNumber
+	})
+
+	return replicas, nil
+}
+
+// CreateReplicaCR creates the custom resource of a replica of the peer
+func (p *Peer) CreateReplicaCR(instance *current.IBPPeer, number int) error {
+	if instance.Spec.Secret != nil && instance.Spec.Secret.MSP != nil {
+		return errors.New("replicas must be enrolled with the CA, the crypto material of 'spec.secret.msp' can't be shared between replicas")
+	}
+
+	name := GetReplicaName(instance.GetName(), number)
+	replica := &current.IBPPeer{
+		TypeMeta: instance.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: instance.GetNamespace(),
+		},
+		Spec: *instance.Spec.DeepCopy(),
+	}
+	replica.Labels = p.GetLabels(replica)
+	replica.Labels["parent"] = instance.GetName()
+
+	one := int32(1)
+	replica.Spec.Replicas = &one
+	replica.Spec.ReplicaNumber = &number
+	replica.Spec.Action = current.PeerAction{}
+
+	if replica.Spec.Secret != nil && replica.Spec.Secret.Enrollment != nil {
+		enrollment := replica.Spec.Secret.Enrollment
+		for _, e := range []*current.Enrollment{enrollment.Component, enrollment.TLS, enrollment.ClientAuth} {
+			if e != nil && e.EnrollID != "" {
+				e.EnrollID = GetReplicaEnrollID(e.EnrollID, number)
+			}
+		}
+	}
+
+	if replica.Spec.CustomNames.PVC.Peer != "" {
+		replica.Spec.CustomNames.PVC.Peer = GetReplicaName(replica.Spec.CustomNames.PVC.Peer, number)
+	}
+	if replica.Spec.CustomNames.PVC.StateDB != "" {
+		replica.Spec.CustomNames.PVC.StateDB = GetReplicaName(replica.Spec.CustomNames.PVC.StateDB, number)
+	}
+
+	if replica.Spec.RestoreFrom != nil && replica.Spec.RestoreFrom.Component != "" {
+		// Each replica is restored from the replica with the same number of the backed up peer
+		replica.Spec.RestoreFrom.Component = GetReplicaName(replica.Spec.RestoreFrom.Component, number)
+	}
+
+	log.Info(fmt.Sprintf("Creating replica '%s' of peer '%s'", name, instance.GetName()))
+	err := p.Client.Create(context.TODO(), replica, controllerclient.CreateOption{
+		Owner:  instance,
+		Scheme: p.Scheme,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateReplicasConnectionProfile updates the connection profile of a peer with replicas, it
+// holds the connection profiles of the replicas. The endpoints and crypto material of the first
+// replica are set at the top level of the profile for clients that connect to a single peer.
+func (p *Peer) UpdateReplicasConnectionProfile(instance *current.IBPPeer, replicas []current.IBPPeer) error {
+	connectionProfile := &current.PeerConnectionProfile{
+		Replicas: map[string]*current.PeerConnectionProfile{},
+	}
+
+	for _, replica := range replicas {
+		cm := &corev1.ConfigMap{}
+		err := p.Client.Get(context.TODO(), types.NamespacedName{Name: replica.Name + "-connection-profile", Namespace: replica.Namespace}, cm)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return errors.Wrapf(err, "failed to get connection profile of replica '%s'", replica.Name)
+		}
+
+		replicaProfile := &current.PeerConnectionProfile{}
+		err = json.Unmarshal(cm.BinaryData["profile.json"], replicaProfile)
+		if err != nil {
+			return errors.Wrapf(err, "failed to unmarshal connection profile of replica '%s'", replica.Name)
+		}
+
+		if len(connectionProfile.Replicas) == 0 {
+			connectionProfile.Endpoints = replicaProfile.Endpoints
+			connectionProfile.TLS = replicaProfile.TLS
+			connectionProfile.Component = replicaProfile.Component
+		}
+		connectionProfile.Replicas[replica.Name] = replicaProfile
+	}
+
+	bytes, err := json.Marshal(connectionProfile)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal connectionprofile")
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name + "-connection-profile",
+			Namespace: instance.Namespace,
+			Labels:    p.GetLabels(instance),
+		},
+		BinaryData: map[string][]byte{"profile.json": bytes},
+	}
+
+	err = p.Client.CreateOrUpdate(context.TODO(), cm, controllerclient.CreateOrUpdateOption{
+		Owner:  instance,
+		Scheme: p.Scheme,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to update connection profile configmap")
+	}
+
+	return nil
+}
+
+func replicaExists(replicas []current.IBPPeer, number int) bool {
+	for _, replica := range replicas {
+		if *replica.Spec.ReplicaNumber == number {
+			return true
+		}
+	}
+	return false
+}
+
+// replicasStatus returns the status of a peer with replicas, the peer is deployed once all
+// of its replicas are deployed
+func replicasStatus(replicas []current.IBPPeer, size int) *current.CRStatus {
+	deployed := 0
+	for _, replica := range replicas {
+		if replica.Status.Type == current.Deployed || replica.Status.Type == current.Warning {
+			deployed++
+		}
+	}
+
+	if deployed == size {
+		return &current.CRStatus{
+			Type:   current.Deployed,
+			Status: current.True,
+			Reason: "allReplicasDeployed",
+		}
+	}
+
+	return &current.CRStatus{
+		Type:    current.Deploying,
+		Status:  current.True,
+		Reason:  "waitingForReplicas",
+		Message: fmt.Sprintf("%d of %d replicas deployed", deployed, size),
+	}
+}
@@ -0,0 +1,266 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package basepeer_test
+
+import (
+	"context"
+	"encoding/json"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	cmocks "github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	managermocks "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/mocks"
+	basepeer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Base Peer Replicas", func() {
+	var (
+		instance       *current.IBPPeer
+		peer           *basepeer.Peer
+		mockKubeClient *cmocks.Client
+		replicas       []current.IBPPeer
+	)
+
+	newReplica := func(number int, status current.IBPCRStatusType) current.IBPPeer {
+		replica := current.IBPPeer{
+			Spec: current.IBPPeerSpec{
+				ReplicaNumber: &number,
+			},
+		}
+		replica.Name = basepeer.GetReplicaName("peer1", number)
+		replica.Namespace = "namespace"
+		replica.Status.Type = status
+		return replica
+	}
+
+	BeforeEach(func() {
+		size := int32(2)
+		instance = &current.IBPPeer{
+			Spec: current.IBPPeerSpec{
+				MSPID:    "Org1_MSP",
+				Replicas: &size,
+				Secret: &current.SecretSpec{
+					Enrollment: &current.EnrollmentSpec{
+						Component: &current.Enrollment{
+							EnrollID: "peer1",
+						},
+						TLS: &current.Enrollment{
+							EnrollID: "peer1tls",
+						},
+					},
+				},
+			},
+		}
+		instance.Name = "peer1"
+		instance.Namespace = "namespace"
+
+		replicas = []current.IBPPeer{}
+		mockKubeClient = &cmocks.Client{}
+		mockKubeClient.ListStub = func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+			switch obj := obj.(type) {
+			case *current.IBPPeerList:
+				obj.Items = replicas
+			}
+			return nil
+		}
+		mockKubeClient.GetStub = func(ctx context.Context, types types.NamespacedName, obj client.Object) error {
+			switch obj := obj.(type) {
+			case *corev1.ConfigMap:
+				profile := &current.PeerConnectionProfile{
+					Endpoints: current.PeerEndpoints{
+						API: "grpcs://" + types.Name,
+					},
+				}
+				bytes, _ := json.Marshal(profile)
+				obj.BinaryData = map[string][]byte{"profile.json": bytes}
+			}
+			return nil
+		}
+
+		peer = &basepeer.Peer{
+			Client: mockKubeClient,
+		}
+	})
+
+	It("creates a replica for every missing replica number", func() {
+		result, err := peer.ReconcileReplicas(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mockKubeClient.CreateCallCount()).To(Equal(2))
+		Expect(result.Status.Type).To(Equal(current.Deploying))
+		Expect(result.Status.Message).To(Equal("0 of 2 replicas deployed"))
+
+		_, obj, _ := mockKubeClient.CreateArgsForCall(1)
+		replica := obj.(*current.IBPPeer)
+		Expect(replica.Name).To(Equal("peer1-2"))
+		Expect(replica.Labels).To(HaveKeyWithValue("parent", "peer1"))
+		Expect(*replica.Spec.ReplicaNumber).To(Equal(2))
+		Expect(*replica.Spec.Replicas).To(Equal(int32(1)))
+		Expect(replica.Spec.Secret.Enrollment.Component.EnrollID).To(Equal("peer1-2"))
+		Expect(replica.Spec.Secret.Enrollment.TLS.EnrollID).To(Equal("peer1tls-2"))
+		Expect(instance.Spec.Secret.Enrollment.Component.EnrollID).To(Equal("peer1"))
+	})
+
+	It("deletes replicas above the number of replicas", func() {
+		replicas = []current.IBPPeer{
+			newReplica(1, current.Deployed),
+			newReplica(2, current.Deployed),
+			newReplica(3, current.Deployed),
+		}
+
+		result, err := peer.ReconcileReplicas(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mockKubeClient.CreateCallCount()).To(Equal(0))
+		Expect(mockKubeClient.DeleteCallCount()).To(Equal(1))
+		_, obj, _ := mockKubeClient.DeleteArgsForCall(0)
+		Expect(obj.GetName()).To(Equal("peer1-3"))
+		Expect(result.Status.Type).To(Equal(current.Deployed))
+	})
+
+	It("adds the connection profiles of the replicas to the connection profile", func() {
+		replicas = []current.IBPPeer{
+			newReplica(1, current.Deployed),
+			newReplica(2, current.Deploying),
+		}
+
+		_, err := peer.ReconcileReplicas(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mockKubeClient.CreateOrUpdateCallCount()).To(Equal(1))
+
+		_, obj, _ := mockKubeClient.CreateOrUpdateArgsForCall(0)
+		cm := obj.(*corev1.ConfigMap)
+		Expect(cm.Name).To(Equal("peer1-connection-profile"))
+
+		profile := &current.PeerConnectionProfile{}
+		err = json.Unmarshal(cm.BinaryData["profile.json"], profile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(profile.Endpoints.API).To(Equal("grpcs://peer1-1-connection-profile"))
+		Expect(profile.Replicas).To(HaveLen(2))
+		Expect(profile.Replicas).To(HaveKey("peer1-2"))
+	})
+
+	It("annotates the peer with the number of replicas", func() {
+		_, err := peer.ReconcileReplicas(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mockKubeClient.PatchCallCount()).To(Equal(1))
+		Expect(instance.Annotations).To(HaveKeyWithValue(basepeer.ReplicasAnnotation, "2"))
+
+		_, err = peer.ReconcileReplicas(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mockKubeClient.PatchCallCount()).To(Equal(1))
+	})
+
+	Context("scaled down to a single replica", func() {
+		BeforeEach(func() {
+			size := int32(1)
+			instance.Spec.Replicas = &size
+			replicas = []current.IBPPeer{
+				newReplica(1, current.Deployed),
+				newReplica(2, current.Deployed),
+			}
+		})
+
+		It("doesn't look up replicas of a peer that is not annotated", func() {
+			deleted, err := peer.DeleteReplicas(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(false))
+			Expect(mockKubeClient.ListCallCount()).To(Equal(0))
+			Expect(mockKubeClient.DeleteCallCount()).To(Equal(0))
+		})
+
+		It("deletes all replicas and removes the annotation", func() {
+			instance.Annotations = map[string]string{basepeer.ReplicasAnnotation: "2"}
+
+			deleted, err := peer.DeleteReplicas(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(true))
+			Expect(mockKubeClient.DeleteCallCount()).To(Equal(2))
+			_, obj, _ := mockKubeClient.DeleteArgsForCall(1)
+			Expect(obj.GetName()).To(Equal("peer1-2"))
+			Expect(instance.Annotations).NotTo(HaveKey(basepeer.ReplicasAnnotation))
+		})
+	})
+
+	Context("tear down of a single peer", func() {
+		var (
+			deploymentMgr *mocks.DeploymentManager
+			serviceMgr    *managermocks.ResourceManager
+			pvcMgr        *managermocks.ResourceManager
+			couchPvcMgr   *managermocks.ResourceManager
+		)
+
+		BeforeEach(func() {
+			deploymentMgr = &mocks.DeploymentManager{}
+			serviceMgr = &managermocks.ResourceManager{}
+			pvcMgr = &managermocks.ResourceManager{}
+			couchPvcMgr = &managermocks.ResourceManager{}
+			peer.DeploymentManager = deploymentMgr
+			peer.ServiceManager = serviceMgr
+			peer.PVCManager = pvcMgr
+			peer.StateDBPVCManager = couchPvcMgr
+		})
+
+		It("does nothing if the peer has no workload", func() {
+			tornDown, err := peer.TearDownPeer(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tornDown).To(Equal(false))
+			Expect(deploymentMgr.DeleteCallCount()).To(Equal(0))
+			Expect(mockKubeClient.DeleteCallCount()).To(Equal(0))
+		})
+
+		It("deletes the resources of a reconciled single peer", func() {
+			deploymentMgr.ExistsReturns(true)
+
+			tornDown, err := peer.TearDownPeer(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tornDown).To(Equal(true))
+			Expect(deploymentMgr.DeleteCallCount()).To(Equal(1))
+			Expect(serviceMgr.DeleteCallCount()).To(Equal(1))
+			Expect(pvcMgr.DeleteCallCount()).To(Equal(1))
+			Expect(couchPvcMgr.DeleteCallCount()).To(Equal(1))
+
+			deleted := []string{}
+			for i := 0; i < mockKubeClient.DeleteCallCount(); i++ {
+				_, obj, _ := mockKubeClient.DeleteArgsForCall(i)
+				deleted = append(deleted, obj.GetName())
+			}
+			Expect(deleted).To(ContainElements("fabric-peer-0-peer1-0", "db-data-peer1-0", "ecert-peer1-signcert", "tls-peer1-keystore"))
+		})
+
+		It("returns an error if the workload can't be deleted", func() {
+			deploymentMgr.ExistsReturns(true)
+			deploymentMgr.DeleteReturns(errors.New("delete error"))
+
+			_, err := peer.TearDownPeer(instance)
+			Expect(err).To(MatchError(ContainSubstring("failed to delete workload")))
+		})
+	})
+
+	It("returns an error if the peer has msp crypto", func() {
+		instance.Spec.Secret.MSP = &current.MSPSpec{}
+		_, err := peer.ReconcileReplicas(instance)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("can't be shared between replicas"))
+	})
+})
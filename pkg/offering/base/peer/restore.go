@@ -0,0 +1,134 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package basepeer
+
+import (
+	"fmt"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	commoninit "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common"
+	commonconfig "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/config"
+	initializer "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/peer"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RestoreRequeueInterval is the interval at which peers whose volumes are being
+// restored are reconciled again
+const RestoreRequeueInterval = 10 * time.Second
+
+//go:generate counterfeiter -o mocks/restorer.go -fake-name Restorer . Restorer
+
+type Restorer interface {
+	RestorePVC(v1.Object, *current.RestoreFrom, *corev1.PersistentVolumeClaim) error
+	RestoreVolume(v1.Object, *current.RestoreFrom, string) (bool, error)
+}
+
+// RestorePVC wraps the override of a persistent volume claim so that the claims of a
+// peer being restored are provisioned from the snapshots of its backup
+func (p *Peer) RestorePVC(override func(v1.Object, *corev1.PersistentVolumeClaim, resources.Action) error) func(v1.Object, *corev1.PersistentVolumeClaim, resources.Action) error {
+	return func(object v1.Object, pvc *corev1.PersistentVolumeClaim, action resources.Action) error {
+		err := override(object, pvc, action)
+		if err != nil {
+			return err
+		}
+
+		instance := object.(*current.IBPPeer)
+		if action != resources.Create || instance.Spec.RestoreFrom == nil {
+			return nil
+		}
+		if !util.ContainsValue(pvc.GetName(), p.RestoredVolumes(instance)) {
+			return nil
+		}
+
+		return p.Restorer.RestorePVC(instance, instance.Spec.RestoreFrom, pvc)
+	}
+}
+
+// RestoredVolumes returns the persistent volume claims that are restored from the backup,
+// the state database is only backed up when using CouchDB
+func (p *Peer) RestoredVolumes(instance *current.IBPPeer) []string {
+	p.PVCManager.SetCustomName(instance.Spec.CustomNames.PVC.Peer)
+	volumes := []string{p.PVCManager.GetName(instance)}
+
+	if instance.UsingCouchDB() {
+		p.StateDBPVCManager.SetCustomName(instance.Spec.CustomNames.PVC.StateDB)
+		volumes = append(volumes, p.StateDBPVCManager.GetName(instance))
+	}
+
+	return volumes
+}
+
+// RestoreVolumes creates the volumes of a peer being restored and restores the archives
+// of its backup into them, returns true once every volume is restored. Volumes are only
+// restored before the deployment of the peer is created.
+func (p *Peer) RestoreVolumes(instance *current.IBPPeer) (bool, error) {
+	if instance.Spec.RestoreFrom == nil || p.DeploymentManager.Exists(instance) {
+		return true, nil
+	}
+
+	p.PVCManager.SetCustomName(instance.Spec.CustomNames.PVC.Peer)
+	err := p.PVCManager.Reconcile(instance, false)
+	if err != nil {
+		return false, errors.Wrap(err, "failed PVC reconciliation")
+	}
+
+	p.StateDBPVCManager.SetCustomName(instance.Spec.CustomNames.PVC.StateDB)
+	err = p.StateDBPVCManager.Reconcile(instance, false)
+	if err != nil {
+		return false, errors.Wrap(err, "failed CouchDB PVC reconciliation")
+	}
+
+	restored := true
+	for _, volume := range p.RestoredVolumes(instance) {
+		done, err := p.Restorer.RestoreVolume(instance, instance.Spec.RestoreFrom, volume)
+		if err != nil {
+			return false, err
+		}
+		restored = restored && done
+	}
+
+	if !restored {
+		log.Info(fmt.Sprintf("Waiting for volumes of peer '%s' to be restored from backup '%s'", instance.GetName(), instance.Spec.RestoreFrom.Backup))
+	}
+
+	return restored, nil
+}
+
+// RestoreCrypto replaces the crypto of the init peer with the ecert and TLS crypto of the
+// crypto backup, so that the secrets of the peer are generated from the backup instead of
+// enrolling again
+func (p *Peer) RestoreCrypto(instance *current.IBPPeer, initPeer *initializer.Peer) error {
+	crypto, err := common.GetRestoreCrypto(p.Client, instance, instance.Spec.RestoreFrom)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Restoring crypto of peer '%s' from crypto backup '%s'", instance.GetName(), instance.Spec.RestoreFrom.GetCryptoBackupSecret(instance.GetName())))
+	initPeer.Cryptos = &commonconfig.Cryptos{}
+	return commoninit.GetMSPCrypto(initPeer.Cryptos, &current.MSPSpec{
+		Component: crypto.Ecert,
+		TLS:       crypto.TLS,
+	})
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package basepeer_test
+
+import (
+	"context"
+	"encoding/json"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	cmocks "github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common/mspparser"
+	peerinit "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/peer"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	managermocks "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/mocks"
+	basepeer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer"
+	peermocks "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Base Peer Restore", func() {
+	var (
+		peer           *basepeer.Peer
+		instance       *current.IBPPeer
+		mockKubeClient *cmocks.Client
+		deploymentMgr  *peermocks.DeploymentManager
+		pvcMgr         *managermocks.ResourceManager
+		couchPvcMgr    *managermocks.ResourceManager
+		restorer       *peermocks.Restorer
+	)
+
+	BeforeEach(func() {
+		mockKubeClient = &cmocks.Client{}
+		deploymentMgr = &peermocks.DeploymentManager{}
+		pvcMgr = &managermocks.ResourceManager{}
+		pvcMgr.GetNameReturns("peer1-pvc")
+		couchPvcMgr = &managermocks.ResourceManager{}
+		couchPvcMgr.GetNameReturns("peer1-statedb-pvc")
+		restorer = &peermocks.Restorer{}
+		restorer.RestoreVolumeReturns(true, nil)
+
+		instance = &current.IBPPeer{
+			Spec: current.IBPPeerSpec{
+				StateDb: "couchdb",
+				RestoreFrom: &current.RestoreFrom{
+					Backup: "nightly",
+				},
+			},
+		}
+		instance.Name = "peer1"
+		instance.Namespace = "namespace"
+
+		peer = &basepeer.Peer{
+			Client:            mockKubeClient,
+			DeploymentManager: deploymentMgr,
+			PVCManager:        pvcMgr,
+			StateDBPVCManager: couchPvcMgr,
+			Restorer:          restorer,
+		}
+	})
+
+	Context("restore volumes", func() {
+		It("does nothing if the peer is not restored from a backup", func() {
+			instance.Spec.RestoreFrom = nil
+			restored, err := peer.RestoreVolumes(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(Equal(true))
+			Expect(pvcMgr.ReconcileCallCount()).To(Equal(0))
+		})
+
+		It("does nothing if the deployment already exists", func() {
+			deploymentMgr.ExistsReturns(true)
+			restored, err := peer.RestoreVolumes(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(Equal(true))
+			Expect(restorer.RestoreVolumeCallCount()).To(Equal(0))
+		})
+
+		It("restores the peer and state database volumes", func() {
+			restored, err := peer.RestoreVolumes(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(Equal(true))
+			Expect(pvcMgr.ReconcileCallCount()).To(Equal(1))
+			Expect(couchPvcMgr.ReconcileCallCount()).To(Equal(1))
+			Expect(restorer.RestoreVolumeCallCount()).To(Equal(2))
+			_, _, volume := restorer.RestoreVolumeArgsForCall(1)
+			Expect(volume).To(Equal("peer1-statedb-pvc"))
+		})
+
+		It("does not restore the state database volume when using leveldb", func() {
+			instance.Spec.StateDb = "leveldb"
+			_, err := peer.RestoreVolumes(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restorer.RestoreVolumeCallCount()).To(Equal(1))
+		})
+
+		It("returns false while a volume is being restored", func() {
+			restorer.RestoreVolumeReturnsOnCall(0, false, nil)
+			restored, err := peer.RestoreVolumes(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(Equal(false))
+		})
+
+		It("returns an error if restoring a volume fails", func() {
+			restorer.RestoreVolumeReturns(false, errors.New("restore job failed"))
+			_, err := peer.RestoreVolumes(instance)
+			Expect(err).To(MatchError("restore job failed"))
+		})
+	})
+
+	Context("restore pvc override", func() {
+		var (
+			pvc      *corev1.PersistentVolumeClaim
+			override func(v1.Object, *corev1.PersistentVolumeClaim, resources.Action) error
+		)
+
+		BeforeEach(func() {
+			pvc = &corev1.PersistentVolumeClaim{}
+			pvc.Name = "peer1-pvc"
+			override = peer.RestorePVC(func(v1.Object, *corev1.PersistentVolumeClaim, resources.Action) error {
+				return nil
+			})
+		})
+
+		It("provisions the claim from the backup on create", func() {
+			Expect(override(instance, pvc, resources.Create)).To(Succeed())
+			Expect(restorer.RestorePVCCallCount()).To(Equal(1))
+		})
+
+		It("does not restore the claim on update", func() {
+			Expect(override(instance, pvc, resources.Update)).To(Succeed())
+			Expect(restorer.RestorePVCCallCount()).To(Equal(0))
+		})
+
+		It("does not restore the state database claim when using leveldb", func() {
+			instance.Spec.StateDb = "leveldb"
+			pvc.Name = "peer1-statedb-pvc"
+			Expect(override(instance, pvc, resources.Create)).To(Succeed())
+			Expect(restorer.RestorePVCCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("restore crypto", func() {
+		BeforeEach(func() {
+			ecert, err := json.Marshal(&common.Backup{
+				List: []*current.MSP{
+					{SignCerts: "old-ecert"},
+					{SignCerts: "ecert"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			tls, err := json.Marshal(&common.Backup{
+				List: []*current.MSP{{SignCerts: "tls"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			mockKubeClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj client.Object) error {
+				switch obj.(type) {
+				case *corev1.Secret:
+					Expect(nn.Name).To(Equal("peer1-crypto-backup"))
+					o := obj.(*corev1.Secret)
+					o.Data = map[string][]byte{
+						"ecert-backup.json": ecert,
+						"tls-backup.json":   tls,
+					}
+				}
+				return nil
+			}
+		})
+
+		It("uses the latest crypto of the crypto backup", func() {
+			initPeer := &peerinit.Peer{}
+			err := peer.RestoreCrypto(instance, initPeer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(initPeer.Cryptos.Enrollment.(*mspparser.MSPParser).Config.SignCerts).To(Equal("ecert"))
+			Expect(initPeer.Cryptos.TLS.(*mspparser.MSPParser).Config.SignCerts).To(Equal("tls"))
+			Expect(initPeer.Cryptos.ClientAuth).To(BeNil())
+		})
+
+		It("returns an error if the crypto backup secret is not found", func() {
+			mockKubeClient.GetReturns(errors.New("not found"))
+			mockKubeClient.GetStub = nil
+			err := peer.RestoreCrypto(instance, &peerinit.Peer{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to get crypto backup secret 'peer1-crypto-backup'"))
+		})
+	})
+})
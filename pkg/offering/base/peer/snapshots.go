@@ -0,0 +1,227 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package basepeer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/backup"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/channel/peeradmin"
+	controllerclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// SnapshotRequeueInterval is the interval at which peers with ledger snapshots in
+// progress are reconciled again
+const SnapshotRequeueInterval = 30 * time.Second
+
+//go:generate counterfeiter -o mocks/snapshot_transfer.go -fake-name SnapshotTransfer . SnapshotTransfer
+
+type SnapshotTransfer interface {
+	UploadSnapshot(*current.IBPPeer, string, *current.PeerSnapshotStatus) (current.BackupPhase, error)
+	DownloadSnapshot(*current.IBPPeer, string, string, string) (current.BackupPhase, error)
+}
+
+// Snapshot requests a ledger snapshot of the channel of the snapshot action, the snapshot
+// is copied to the snapshot storage by ReconcileSnapshots once the peer generated it
+func (p *Peer) Snapshot(instance *current.IBPPeer) error {
+	action := instance.Spec.Action.Snapshot
+	log.Info(fmt.Sprintf("Snapshot of channel '%s' at block %d triggered via action parameter for '%s'", action.Channel, action.BlockNumber, instance.GetName()))
+
+	if instance.Spec.SnapshotStorage == nil {
+		return errors.New("snapshotStorage must be set to generate snapshots")
+	}
+	if instance.Spec.AdminSecret == "" {
+		return errors.New("adminSecret must be set to generate snapshots")
+	}
+
+	signer, err := GetAdminSigner(p.Client, instance)
+	if err != nil {
+		return err
+	}
+
+	peerEndpoint, err := GetPeerAdminEndpoint(p.Client, instance)
+	if err != nil {
+		return err
+	}
+
+	err = p.ChannelAdmin.GenerateSnapshot(peerEndpoint, signer, action.Channel, action.BlockNumber)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	snapshots := append([]current.PeerSnapshotStatus{}, instance.Status.Snapshots...)
+	snapshots = append(snapshots, current.PeerSnapshotStatus{
+		Name:        backup.SnapshotJobName(instance.GetName(), now),
+		Channel:     action.Channel,
+		BlockNumber: action.BlockNumber,
+		Location:    backup.SnapshotLocation(instance.Spec.SnapshotStorage, instance, action.Channel, now),
+		Phase:       current.BackupPending,
+		RequestTime: metav1.NewTime(now),
+	})
+
+	return p.UpdateSnapshotStatus(instance, snapshots)
+}
+
+// ReconcileSnapshots copies the ledger snapshots that the peer generated to the snapshot
+// storage, and updates the phase of the snapshots in the status of the peer
+func (p *Peer) ReconcileSnapshots(instance *current.IBPPeer) (reconcile.Result, error) {
+	if !instance.Status.SnapshotsPending() {
+		return reconcile.Result{}, nil
+	}
+
+	signer, err := GetAdminSigner(p.Client, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	peerEndpoint, err := GetPeerAdminEndpoint(p.Client, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	p.PVCManager.SetCustomName(instance.Spec.CustomNames.PVC.Peer)
+	pvcName := p.PVCManager.GetName(instance)
+
+	snapshots := append([]current.PeerSnapshotStatus{}, instance.Status.Snapshots...)
+	for i := range snapshots {
+		snapshot := &snapshots[i]
+		if snapshot.Phase != current.BackupPending {
+			continue
+		}
+
+		generated, err := p.snapshotGenerated(peerEndpoint, signer, snapshot)
+		if err != nil {
+			snapshot.Message = err.Error()
+			continue
+		}
+		if !generated {
+			snapshot.Message = "Waiting for the peer to generate the snapshot"
+			continue
+		}
+
+		snapshot.Phase, err = p.SnapshotTransfer.UploadSnapshot(instance, pvcName, snapshot)
+		if err != nil {
+			log.Error(err, fmt.Sprintf("Failed to upload snapshot '%s' of peer '%s'", snapshot.Name, instance.GetName()))
+			snapshot.Phase = current.BackupFailed
+			snapshot.Message = err.Error()
+			continue
+		}
+		snapshot.Message = ""
+	}
+
+	err = p.UpdateSnapshotStatus(instance, snapshots)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if instance.Status.SnapshotsPending() {
+		return reconcile.Result{RequeueAfter: SnapshotRequeueInterval}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// snapshotGenerated returns true once the peer has no pending request for the snapshot,
+// a snapshot at block number 0 is generated once the channel has no pending requests
+func (p *Peer) snapshotGenerated(peerEndpoint *peeradmin.Endpoint, signer *peeradmin.Signer, snapshot *current.PeerSnapshotStatus) (bool, error) {
+	pending, err := p.ChannelAdmin.PendingSnapshots(peerEndpoint, signer, snapshot.Channel)
+	if err != nil {
+		return false, err
+	}
+
+	for _, blockNumber := range pending {
+		if snapshot.BlockNumber == 0 || blockNumber == snapshot.BlockNumber {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// UpdateSnapshotStatus patches the snapshots in the status of the peer if they changed
+func (p *Peer) UpdateSnapshotStatus(instance *current.IBPPeer, snapshots []current.PeerSnapshotStatus) error {
+	if reflect.DeepEqual(instance.Status.Snapshots, snapshots) {
+		return nil
+	}
+
+	instance.Status.Snapshots = snapshots
+	err := p.Client.PatchStatus(context.TODO(), instance, nil, controllerclient.PatchOption{
+		Resilient: &controllerclient.ResilientPatch{
+			Retry:    2,
+			Into:     &current.IBPPeer{},
+			Strategy: k8sclient.MergeFrom,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to update snapshot status")
+	}
+
+	return nil
+}
+
+// MergeResults returns a result that requeues at the soonest of the requested requeues
+func MergeResults(results ...reconcile.Result) reconcile.Result {
+	merged := reconcile.Result{}
+	for _, result := range results {
+		merged.Requeue = merged.Requeue || result.Requeue
+		if result.RequeueAfter > 0 && (merged.RequeueAfter == 0 || result.RequeueAfter < merged.RequeueAfter) {
+			merged.RequeueAfter = result.RequeueAfter
+		}
+	}
+	return merged
+}
+
+// joinBySnapshot downloads the ledger snapshot of the channel to the volume of the peer
+// and joins the peer to the channel from the snapshot, returns true while the peer is
+// joining the channel. A peer joins one channel from a snapshot at a time.
+func (p *Peer) joinBySnapshot(instance *current.IBPPeer, channel current.PeerChannel, signer *peeradmin.Signer, peerEndpoint *peeradmin.Endpoint) (bool, error) {
+	inProgress, err := p.ChannelAdmin.JoinBySnapshotInProgress(peerEndpoint, signer)
+	if err != nil {
+		return false, err
+	}
+	if inProgress {
+		return true, nil
+	}
+
+	p.PVCManager.SetCustomName(instance.Spec.CustomNames.PVC.Peer)
+	phase, err := p.SnapshotTransfer.DownloadSnapshot(instance, p.PVCManager.GetName(instance), channel.Name, channel.Snapshot)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to download snapshot '%s'", channel.Snapshot)
+	}
+	if phase != current.BackupCompleted {
+		return true, nil
+	}
+
+	log.Info(fmt.Sprintf("Joining peer '%s' to channel '%s' from snapshot '%s'", instance.GetName(), channel.Name, channel.Snapshot))
+	err = p.ChannelAdmin.JoinChannelBySnapshot(peerEndpoint, signer, backup.JoinBySnapshotPath(channel.Name))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,271 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package basepeer_test
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	cmocks "github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	managermocks "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/mocks"
+	basepeer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer"
+	peermocks "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer/mocks"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Base Peer Snapshots", func() {
+	var (
+		peer             *basepeer.Peer
+		instance         *current.IBPPeer
+		mockKubeClient   *cmocks.Client
+		deploymentMgr    *peermocks.DeploymentManager
+		pvcMgr           *managermocks.ResourceManager
+		channelAdmin     *peermocks.ChannelAdmin
+		snapshotTransfer *peermocks.SnapshotTransfer
+	)
+
+	BeforeEach(func() {
+		adminCert, adminKey := generateIdentity()
+
+		instance = &current.IBPPeer{
+			Spec: current.IBPPeerSpec{
+				MSPID:       "Org1MSP",
+				Domain:      "domain",
+				AdminSecret: "org1-admin",
+				SnapshotStorage: &current.BackupObjectStorage{
+					Endpoint:          "https://minio.example.com:9000",
+					Bucket:            "fabric",
+					CredentialsSecret: "minio-creds",
+				},
+				Action: current.PeerAction{
+					Snapshot: current.PeerSnapshotAction{
+						Channel:     "channel1",
+						BlockNumber: 100,
+					},
+				},
+			},
+		}
+		instance.Name = "peer1"
+		instance.Namespace = "random"
+
+		mockKubeClient = &cmocks.Client{}
+		mockKubeClient.GetStub = func(ctx context.Context, types types.NamespacedName, obj client.Object) error {
+			switch obj.(type) {
+			case *corev1.Secret:
+				o := obj.(*corev1.Secret)
+				switch types.Name {
+				case "org1-admin":
+					o.Data = map[string][]byte{"cert.pem": adminCert, "key.pem": adminKey}
+				case "tls-peer1-signcert":
+					o.Data = map[string][]byte{"cert.pem": adminCert}
+				case "tls-peer1-keystore":
+					o.Data = map[string][]byte{"key.pem": adminKey}
+				case "tls-peer1-cacerts":
+					o.Data = map[string][]byte{"cacert-0.pem": adminCert}
+				}
+			}
+			return nil
+		}
+
+		deploymentMgr = &peermocks.DeploymentManager{}
+		deploymentMgr.DeploymentStatusReturns(appsv1.DeploymentStatus{ReadyReplicas: 1}, nil)
+		pvcMgr = &managermocks.ResourceManager{}
+		pvcMgr.GetNameReturns("peer1-pvc")
+
+		channelAdmin = &peermocks.ChannelAdmin{}
+		channelAdmin.GetChannelsReturns([]string{}, nil)
+		channelAdmin.FetchGenesisBlockReturns(&cb.Block{}, nil)
+
+		snapshotTransfer = &peermocks.SnapshotTransfer{}
+		snapshotTransfer.UploadSnapshotReturns(current.BackupPending, nil)
+		snapshotTransfer.DownloadSnapshotReturns(current.BackupPending, nil)
+
+		peer = &basepeer.Peer{
+			Client:            mockKubeClient,
+			DeploymentManager: deploymentMgr,
+			PVCManager:        pvcMgr,
+			ChannelAdmin:      channelAdmin,
+			SnapshotTransfer:  snapshotTransfer,
+		}
+	})
+
+	Context("snapshot action", func() {
+		It("returns an error if snapshot storage is not set", func() {
+			instance.Spec.SnapshotStorage = nil
+			err := peer.Snapshot(instance)
+			Expect(err).To(MatchError(ContainSubstring("snapshotStorage must be set")))
+			Expect(channelAdmin.GenerateSnapshotCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if the peer rejects the snapshot request", func() {
+			channelAdmin.GenerateSnapshotReturns(errors.New("block number 100 is lower than the last committed block"))
+			err := peer.Snapshot(instance)
+			Expect(err).To(HaveOccurred())
+			Expect(instance.Status.Snapshots).To(BeEmpty())
+		})
+
+		It("requests a snapshot and records it in the status", func() {
+			err := peer.Snapshot(instance)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(channelAdmin.GenerateSnapshotCallCount()).To(Equal(1))
+			_, _, channelID, blockNumber := channelAdmin.GenerateSnapshotArgsForCall(0)
+			Expect(channelID).To(Equal("channel1"))
+			Expect(blockNumber).To(Equal(uint64(100)))
+
+			Expect(mockKubeClient.PatchStatusCallCount()).To(Equal(1))
+			Expect(instance.Status.Snapshots).To(HaveLen(1))
+			snapshot := instance.Status.Snapshots[0]
+			Expect(snapshot.Channel).To(Equal("channel1"))
+			Expect(snapshot.BlockNumber).To(Equal(uint64(100)))
+			Expect(snapshot.Phase).To(Equal(current.BackupPending))
+			Expect(snapshot.Name).To(HavePrefix("peer1-snapshot-"))
+			Expect(snapshot.Location).To(HavePrefix("fabric/random/peer1/snapshots/channel1/"))
+		})
+	})
+
+	Context("reconcile snapshots", func() {
+		BeforeEach(func() {
+			instance.Status.Snapshots = []current.PeerSnapshotStatus{
+				{
+					Name:        "peer1-snapshot-1",
+					Channel:     "channel1",
+					BlockNumber: 100,
+					Location:    "fabric/random/peer1/snapshots/channel1/1.tar.gz",
+					Phase:       current.BackupPending,
+				},
+			}
+		})
+
+		It("does nothing if no snapshots are pending", func() {
+			instance.Status.Snapshots[0].Phase = current.BackupCompleted
+			result, err := peer.ReconcileSnapshots(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+			Expect(channelAdmin.PendingSnapshotsCallCount()).To(Equal(0))
+		})
+
+		It("waits for the peer to generate the snapshot", func() {
+			channelAdmin.PendingSnapshotsReturns([]uint64{100}, nil)
+			result, err := peer.ReconcileSnapshots(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(basepeer.SnapshotRequeueInterval))
+			Expect(snapshotTransfer.UploadSnapshotCallCount()).To(Equal(0))
+			Expect(instance.Status.Snapshots[0].Message).To(ContainSubstring("Waiting for the peer"))
+		})
+
+		It("uploads the snapshot once generated", func() {
+			snapshotTransfer.UploadSnapshotReturns(current.BackupCompleted, nil)
+			result, err := peer.ReconcileSnapshots(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			Expect(snapshotTransfer.UploadSnapshotCallCount()).To(Equal(1))
+			_, pvcName, snapshot := snapshotTransfer.UploadSnapshotArgsForCall(0)
+			Expect(pvcName).To(Equal("peer1-pvc"))
+			Expect(snapshot.Name).To(Equal("peer1-snapshot-1"))
+			Expect(instance.Status.Snapshots[0].Phase).To(Equal(current.BackupCompleted))
+		})
+
+		It("marks the snapshot as failed if the upload fails", func() {
+			snapshotTransfer.UploadSnapshotReturns(current.BackupFailed, errors.New("job 'peer1-snapshot-1' failed"))
+			result, err := peer.ReconcileSnapshots(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			Expect(instance.Status.Snapshots[0].Phase).To(Equal(current.BackupFailed))
+			Expect(instance.Status.Snapshots[0].Message).To(Equal("job 'peer1-snapshot-1' failed"))
+		})
+	})
+
+	Context("join by snapshot", func() {
+		BeforeEach(func() {
+			adminCert, _ := generateIdentity()
+			instance.Spec.Channels = []current.PeerChannel{
+				{
+					Name:             "channel1",
+					OrdererEndpoint:  "orderer.domain:443",
+					OrdererTLSCACert: base64.StdEncoding.EncodeToString(adminCert),
+					Snapshot:         "fabric/random/peer0/snapshots/channel1/1.tar.gz",
+				},
+			}
+		})
+
+		It("downloads the snapshot before joining", func() {
+			result, err := peer.ReconcileChannels(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(basepeer.ChannelRequeueInterval))
+
+			Expect(snapshotTransfer.DownloadSnapshotCallCount()).To(Equal(1))
+			_, pvcName, channel, location := snapshotTransfer.DownloadSnapshotArgsForCall(0)
+			Expect(pvcName).To(Equal("peer1-pvc"))
+			Expect(channel).To(Equal("channel1"))
+			Expect(location).To(Equal("fabric/random/peer0/snapshots/channel1/1.tar.gz"))
+
+			Expect(channelAdmin.JoinChannelBySnapshotCallCount()).To(Equal(0))
+			Expect(channelAdmin.JoinChannelCallCount()).To(Equal(0))
+			Expect(instance.Status.Channels[0].Status).To(Equal(current.NodePending))
+		})
+
+		It("joins the channel from the downloaded snapshot", func() {
+			snapshotTransfer.DownloadSnapshotReturns(current.BackupCompleted, nil)
+			_, err := peer.ReconcileChannels(instance)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(channelAdmin.JoinChannelBySnapshotCallCount()).To(Equal(1))
+			_, _, snapshotDir := channelAdmin.JoinChannelBySnapshotArgsForCall(0)
+			Expect(snapshotDir).To(Equal("/data/peer/joinbysnapshot/channel1"))
+			Expect(channelAdmin.FetchGenesisBlockCallCount()).To(Equal(0))
+			Expect(instance.Status.Channels[0].Status).To(Equal(current.NodePending))
+		})
+
+		It("waits while the peer is joining from a snapshot", func() {
+			channelAdmin.JoinBySnapshotInProgressReturns(true, nil)
+			_, err := peer.ReconcileChannels(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshotTransfer.DownloadSnapshotCallCount()).To(Equal(0))
+			Expect(instance.Status.Channels[0].Message).To(Equal("Joining channel from snapshot"))
+		})
+
+		It("does not download the snapshot once the peer joined the channel", func() {
+			channelAdmin.GetChannelsReturns([]string{"channel1"}, nil)
+			_, err := peer.ReconcileChannels(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshotTransfer.DownloadSnapshotCallCount()).To(Equal(0))
+			Expect(instance.Status.Channels[0].Status).To(Equal(current.NodeJoined))
+		})
+	})
+
+	It("merges requeue results", func() {
+		Expect(basepeer.MergeResults(
+			reconcile.Result{},
+			reconcile.Result{RequeueAfter: time.Minute},
+			reconcile.Result{RequeueAfter: 30 * time.Second},
+		)).To(Equal(reconcile.Result{RequeueAfter: 30 * time.Second}))
+	})
+})
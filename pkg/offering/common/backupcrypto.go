@@ -344,6 +344,42 @@ func GetBackupSecret(client k8sclient.Client, instance v1.Object) (*corev1.Secre
 	return secret, nil
 }
 
+// GetRestoreCrypto returns the latest crypto of the crypto backup secret of the component
+// that the instance is restored from
+func GetRestoreCrypto(client k8sclient.Client, instance v1.Object, restore *current.RestoreFrom) (*Crypto, error) {
+	secretName := restore.GetCryptoBackupSecret(instance.GetName())
+	secret := &corev1.Secret{}
+	err := client.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: instance.GetNamespace()}, secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get crypto backup secret '%s'", secretName)
+	}
+
+	crypto := &Crypto{}
+	for key, msp := range map[string]**current.MSP{
+		"tls-backup.json":        &crypto.TLS,
+		"ecert-backup.json":      &crypto.Ecert,
+		"operations-backup.json": &crypto.Operations,
+		"ca-backup.json":         &crypto.CA,
+	} {
+		data := secret.Data[key]
+		if data == nil {
+			continue
+		}
+
+		backup := &Backup{}
+		err := json.Unmarshal(data, backup)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal '%s' of crypto backup secret '%s'", key, secretName)
+		}
+		if len(backup.List) > 0 {
+			// The latest backup is at the back of the queue
+			*msp = backup.List[len(backup.List)-1]
+		}
+	}
+
+	return crypto, nil
+}
+
 func CreateBackupSecret(client k8sclient.Client, scheme *runtime.Scheme, instance v1.Object, secret *corev1.Secret) error {
 	err := client.Create(context.TODO(), secret, k8sclient.CreateOption{
 		Owner:  instance,
@@ -0,0 +1,35 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/pkg/errors"
+)
+
+// GetConsenterID returns the id of the orderer node in the BFT consenter mapping. The node
+// number is used as the id, so the id of a node is the same in the genesis block of the
+// cluster, in channels created later and in config updates that add the node to a channel.
+func GetConsenterID(node *current.IBPOrderer) (uint32, error) {
+	if node.Spec.NodeNumber == nil || *node.Spec.NodeNumber < 1 {
+		return 0, errors.Errorf("orderer node '%s' has no node number to derive its consenter id from", node.GetName())
+	}
+
+	return uint32(*node.Spec.NodeNumber), nil // #nosec G115
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
+)
+
+var _ = Describe("Consenter", func() {
+	var node *current.IBPOrderer
+
+	BeforeEach(func() {
+		node = &current.IBPOrderer{}
+		node.Name = "orderer1node3"
+	})
+
+	Context("get consenter id", func() {
+		It("returns the node number as consenter id", func() {
+			number := 3
+			node.Spec.NodeNumber = &number
+			id, err := common.GetConsenterID(node)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(uint32(3)))
+		})
+
+		It("returns an error if the node has no node number", func() {
+			_, err := common.GetConsenterID(node)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("orderer1node3"))
+		})
+	})
+})
@@ -0,0 +1,95 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Ports of the peer and orderer services, at which the components are reached when they are
+// exposed with a load balancer
+const (
+	PeerAPIPort           = 7051
+	PeerOperationsPort    = 9443
+	OrdererAPIPort        = 7050
+	OrdererOperationsPort = 8443
+	OrdererAdminPort      = 9443
+	GrpcwebPort           = 7443
+
+	// IngressPort is the port components are reached at through their ingress
+	IngressPort = 443
+)
+
+// GetLoadBalancerAddress returns the IP or hostname assigned to the LoadBalancer service,
+// or an empty string if the load balancer has not been provisioned yet
+func GetLoadBalancerAddress(client k8sclient.Client, name, namespace string) (string, error) {
+	service := &corev1.Service{}
+	err := client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, service)
+	if err != nil {
+		return "", err
+	}
+
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.Hostname != "" {
+			return ingress.Hostname, nil
+		}
+		if ingress.IP != "" {
+			return ingress.IP, nil
+		}
+	}
+
+	return "", nil
+}
+
+// GetOrdererAddress returns the host and port the orderer node is reached at by clients and
+// other consenters. Nodes that use the address of their load balancer are reached at their
+// external address, other nodes at the hostname of their ingress.
+func GetOrdererAddress(node *current.IBPOrderer) (string, int) {
+	if node.Spec.Service.UsesLoadBalancerAddress() && node.Spec.ExternalAddress != "" {
+		host, port, err := SplitHostPort(node.Spec.ExternalAddress)
+		if err == nil {
+			return host, port
+		}
+	}
+
+	return fmt.Sprintf("%s-%s-orderer.%s", node.Namespace, node.Name, node.Spec.Domain), IngressPort
+}
+
+// SplitHostPort splits an address of the form host:port
+func SplitHostPort(address string) (string, int, error) {
+	host, p, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", 0, err
+	}
+
+	port, err := strconv.Atoi(p)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return host, port, nil
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
+)
+
+var _ = Describe("Load balancer", func() {
+	var (
+		mockKubeClient *mocks.Client
+		ingress        []corev1.LoadBalancerIngress
+	)
+
+	BeforeEach(func() {
+		ingress = nil
+		mockKubeClient = &mocks.Client{}
+		mockKubeClient.GetStub = func(ctx context.Context, types types.NamespacedName, obj client.Object) error {
+			switch obj := obj.(type) {
+			case *corev1.Service:
+				obj.Status.LoadBalancer.Ingress = ingress
+			}
+			return nil
+		}
+	})
+
+	Context("get load balancer address", func() {
+		It("returns an empty address until the load balancer is provisioned", func() {
+			address, err := common.GetLoadBalancerAddress(mockKubeClient, "orderer1node1", "ns1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(address).To(Equal(""))
+		})
+
+		It("returns the IP of the load balancer", func() {
+			ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+			address, err := common.GetLoadBalancerAddress(mockKubeClient, "orderer1node1", "ns1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(address).To(Equal("10.0.0.1"))
+		})
+
+		It("prefers the hostname of the load balancer", func() {
+			ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1", Hostname: "lb.example.com"}}
+			address, err := common.GetLoadBalancerAddress(mockKubeClient, "orderer1node1", "ns1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(address).To(Equal("lb.example.com"))
+		})
+	})
+
+	Context("get orderer address", func() {
+		var node *current.IBPOrderer
+
+		BeforeEach(func() {
+			node = &current.IBPOrderer{}
+			node.Name = "orderer1node1"
+			node.Namespace = "ns1"
+			node.Spec.Domain = "example.com"
+			node.Spec.ExternalAddress = "10.0.0.1:7050"
+		})
+
+		It("returns the ingress hostname", func() {
+			host, port := common.GetOrdererAddress(node)
+			Expect(host).To(Equal("ns1-orderer1node1-orderer.example.com"))
+			Expect(port).To(Equal(443))
+		})
+
+		It("returns the external address when the load balancer address is used", func() {
+			node.Spec.Service = &current.Service{Type: corev1.ServiceTypeLoadBalancer, UseLoadBalancerAddress: true}
+			host, port := common.GetOrdererAddress(node)
+			Expect(host).To(Equal("10.0.0.1"))
+			Expect(port).To(Equal(7050))
+		})
+	})
+})
@@ -68,6 +68,42 @@ func GetTLSIntercertEncoded(client k8sclient.Client, instance v1.Object) ([]stri
 	return getIntermediateCertEncoded("tls", client, instance)
 }
 
+func GetTLSSignCertBytes(client k8sclient.Client, instance v1.Object) ([]byte, error) {
+	return getSignCertBytes("tls", client, instance)
+}
+
+func GetTLSKeystoreBytes(client k8sclient.Client, instance v1.Object) ([]byte, error) {
+	return getKeystoreBytes("tls", client, instance)
+}
+
+func GetTLSCACertBytes(client k8sclient.Client, instance v1.Object) ([][]byte, error) {
+	return getCACertBytes("tls", client, instance)
+}
+
+func GetTLSIntercertBytes(client k8sclient.Client, instance v1.Object) ([][]byte, error) {
+	return getIntermediateCertBytes("tls", client, instance)
+}
+
+func GetEcertSignCertBytes(client k8sclient.Client, instance v1.Object) ([]byte, error) {
+	return getSignCertBytes("ecert", client, instance)
+}
+
+func GetEcertKeystoreBytes(client k8sclient.Client, instance v1.Object) ([]byte, error) {
+	return getKeystoreBytes("ecert", client, instance)
+}
+
+func GetEcertCACertBytes(client k8sclient.Client, instance v1.Object) ([][]byte, error) {
+	return getCACertBytes("ecert", client, instance)
+}
+
+func GetEcertAdmincertBytes(client k8sclient.Client, instance v1.Object) ([][]byte, error) {
+	return getAdmincertBytes("ecert", client, instance)
+}
+
+func GetEcertIntercertBytes(client k8sclient.Client, instance v1.Object) ([][]byte, error) {
+	return getIntermediateCertBytes("ecert", client, instance)
+}
+
 func getSignCertBytes(prefix common.SecretType, client k8sclient.Client, instance v1.Object) ([]byte, error) {
 	secretName := fmt.Sprintf("%s-%s-signcert", prefix, instance.GetName())
 	namespacedName := types.NamespacedName{
@@ -21,25 +21,24 @@ package k8sca
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
 	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
 	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
 	resourcemanager "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/manager"
 	baseca "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/ca"
 	basecaoverride "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/ca/override"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
 	override "github.com/IBM-Blockchain/fabric-operator/pkg/offering/k8s/ca/override"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
-	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
 	"github.com/IBM-Blockchain/fabric-operator/version"
 	"github.com/pkg/errors"
 	networkingv1 "k8s.io/api/networking/v1"
-	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -50,7 +49,7 @@ var log = logf.Log.WithName("k8s_ca")
 type Override interface {
 	baseca.Override
 	Ingress(v1.Object, *networkingv1.Ingress, resources.Action) error
-	Ingressv1beta1(v1.Object, *networkingv1beta1.Ingress, resources.Action) error
+	GatewayRoutes(v1.Object) ([]gatewayroute.Route, error)
 }
 
 var _ baseca.IBPCA = &CA{}
@@ -58,20 +57,20 @@ var _ baseca.IBPCA = &CA{}
 type CA struct {
 	*baseca.CA
 
-	IngressManager        resources.Manager
-	Ingressv1beta1Manager resources.Manager
+	IngressManager      resources.Manager
+	GatewayRouteManager resources.Manager
 
 	Override Override
 }
 
-func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config) *CA {
+func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config, recorder record.EventRecorder) *CA {
 	o := &override.Override{
 		Override: basecaoverride.Override{
 			Client: client,
 		},
 	}
 	ca := &CA{
-		CA:       baseca.New(client, scheme, config, o),
+		CA:       baseca.New(client, scheme, config, recorder, o),
 		Override: o,
 	}
 	ca.CreateManagers()
@@ -81,7 +80,7 @@ func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config)
 func (ca *CA) CreateManagers() {
 	resourceManager := resourcemanager.New(ca.Client, ca.Scheme)
 	ca.IngressManager = resourceManager.CreateIngressManager("", ca.Override.Ingress, ca.GetLabels, ca.Config.CAInitConfig.IngressFile)
-	ca.Ingressv1beta1Manager = resourceManager.CreateIngressv1beta1Manager("", ca.Override.Ingressv1beta1, ca.GetLabels, ca.Config.CAInitConfig.Ingressv1beta1File)
+	ca.GatewayRouteManager = resourceManager.CreateGatewayRouteManager(ca.Override.GatewayRoutes, ca.GetLabels, ca.Config.CAInitConfig.TLSRouteFile, ca.Config.CAInitConfig.HTTPRouteFile, ca.Config.CAInitConfig.BackendTLSPolicyFile)
 }
 
 func (ca *CA) Reconcile(instance *current.IBPCA, update baseca.Update) (common.Result, error) {
@@ -200,28 +199,29 @@ func (ca *CA) ReconcileManagers(instance *current.IBPCA, update baseca.Update) e
 }
 
 func (ca *CA) ReconcileIngressManager(instance *current.IBPCA, update bool) error {
-	if ca.Config.Operator.Globals.AllowKubernetesEighteen == "true" {
-		// check k8s version
-		version, err := util.GetServerVersion()
+	if instance.Spec.Ingress.UsesGatewayAPI() {
+		// Gateway API routes replace the ingress of the component
+		err := ca.IngressManager.Delete(instance)
 		if err != nil {
-			return err
+			return errors.Wrap(err, "failed to delete ingress")
 		}
-		if strings.Compare(version.Minor, "19") < 0 { // v1beta
-			err = ca.Ingressv1beta1Manager.Reconcile(instance, update)
-			if err != nil {
-				return errors.Wrap(err, "failed Ingressv1beta1 reconciliation")
-			}
-		} else {
-			err = ca.IngressManager.Reconcile(instance, update)
-			if err != nil {
-				return errors.Wrap(err, "failed Ingress reconciliation")
-			}
-		}
-	} else {
-		err := ca.IngressManager.Reconcile(instance, update)
+
+		err = ca.GatewayRouteManager.Reconcile(instance, update)
 		if err != nil {
-			return errors.Wrap(err, "failed Ingress reconciliation")
+			return errors.Wrap(err, "failed Gateway API route reconciliation")
 		}
+		return nil
+	}
+
+	// The ingress replaces any Gateway API routes of the component
+	err := ca.GatewayRouteManager.Delete(instance)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete Gateway API routes")
+	}
+
+	err = ca.IngressManager.Reconcile(instance, update)
+	if err != nil {
+		return errors.Wrap(err, "failed Ingress reconciliation")
 	}
 	return nil
 }
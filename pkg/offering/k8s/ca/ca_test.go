@@ -75,6 +75,7 @@ var _ = Describe("K8s CA", func() {
 		roleBindingMgr    *managermocks.ResourceManager
 		serviceAccountMgr *managermocks.ResourceManager
 		ingressMgr        *managermocks.ResourceManager
+		gatewayRouteMgr   *managermocks.ResourceManager
 
 		initMock *basecamocks.InitializeIBPCA
 		update   *basecamocks.Update
@@ -133,6 +134,7 @@ var _ = Describe("K8s CA", func() {
 			roleBindingMgr = &managermocks.ResourceManager{}
 			serviceAccountMgr = &managermocks.ResourceManager{}
 			ingressMgr = &managermocks.ResourceManager{}
+			gatewayRouteMgr = &managermocks.ResourceManager{}
 			initMock = &basecamocks.InitializeIBPCA{}
 			restartMgr := &basecamocks.RestartManager{}
 			certMgr = &basecamocks.CertificateManager{}
@@ -172,8 +174,9 @@ var _ = Describe("K8s CA", func() {
 					Restart:               restartMgr,
 					CertificateManager:    certMgr,
 				},
-				IngressManager: ingressMgr,
-				Override:       &override.Override{},
+				IngressManager:      ingressMgr,
+				GatewayRouteManager: gatewayRouteMgr,
+				Override:            &override.Override{},
 			}
 		})
 
@@ -234,6 +237,13 @@ var _ = Describe("K8s CA", func() {
 			Expect(err.Error()).To(Equal("failed to reconcile managers: failed Ingress reconciliation: failed to reconcile ingress"))
 		})
 
+		It("deletes the Gateway API routes when using an ingress", func() {
+			_, err := ca.Reconcile(instance, update)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gatewayRouteMgr.DeleteCallCount()).To(Equal(1))
+			Expect(gatewayRouteMgr.ReconcileCallCount()).To(Equal(0))
+		})
+
 		It("returns an error if restart fails", func() {
 			update.RestartNeededReturns(true)
 			mockKubeClient.PatchReturns(errors.New("patch failed"))
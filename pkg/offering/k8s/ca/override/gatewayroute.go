@@ -0,0 +1,60 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package override
+
+import (
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GatewayRoutes returns the Gateway API routes of the CA, the API is passed through to the
+// CA by SNI and the operations endpoint is routed over HTTPS, the Gateway verifies the
+// self-signed TLS certificate of the CA
+func (o *Override) GatewayRoutes(object v1.Object) ([]gatewayroute.Route, error) {
+	instance := object.(*current.IBPCA)
+
+	gateway := gatewayroute.GetGateway(instance.Spec.Ingress)
+
+	apihost := instance.Namespace + "-" + instance.Name + "-ca" + "." + instance.Spec.Domain
+	operationshost := instance.Namespace + "-" + instance.Name + "-operations" + "." + instance.Spec.Domain
+
+	return []gatewayroute.Route{
+		{
+			Kind:      gatewayroute.TLSRouteKind,
+			Hostnames: []string{apihost},
+			Gateway:   gateway,
+			Service:   instance.GetName(),
+			Port:      7054,
+		},
+		{
+			Suffix:    "operations",
+			Kind:      gatewayroute.HTTPRouteKind,
+			Hostnames: []string{operationshost},
+			Gateway:   gateway,
+			Service:   instance.GetName(),
+			Port:      9443,
+			BackendTLS: &gatewayroute.BackendTLS{
+				SectionName:    "operations",
+				Hostname:       operationshost,
+				CACertsSecrets: []string{instance.GetName() + "-ca-crypto"},
+			},
+		},
+	}, nil
+}
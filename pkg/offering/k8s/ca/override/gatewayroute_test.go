@@ -0,0 +1,69 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package override_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/k8s/ca/override"
+)
+
+var _ = Describe("K8s CA Gateway Route Overrides", func() {
+	var (
+		overrider *override.Override
+		instance  *current.IBPCA
+	)
+
+	BeforeEach(func() {
+		overrider = &override.Override{}
+		instance = &current.IBPCA{
+			Spec: current.IBPCASpec{
+				Domain: "test.domain",
+				Ingress: current.Ingress{
+					Mode: current.GatewayAPIMode,
+					Gateway: &current.GatewayReference{
+						Name: "gateway",
+					},
+				},
+			},
+		}
+		instance.Name = "ca1"
+		instance.Namespace = "namespace"
+	})
+
+	It("passes the API through to the CA and routes the operations endpoint over HTTPS", func() {
+		routes, err := overrider.GatewayRoutes(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(HaveLen(2))
+		Expect(routes[0].Kind).To(Equal(gatewayroute.TLSRouteKind))
+		Expect(routes[0].Hostnames).To(Equal([]string{"namespace-ca1-ca.test.domain"}))
+		Expect(routes[0].Port).To(Equal(int32(7054)))
+		Expect(routes[1].Suffix).To(Equal("operations"))
+		Expect(routes[1].Kind).To(Equal(gatewayroute.HTTPRouteKind))
+		Expect(routes[1].Port).To(Equal(int32(9443)))
+		Expect(routes[1].BackendTLS).To(Equal(&gatewayroute.BackendTLS{
+			SectionName:    "operations",
+			Hostname:       "namespace-ca1-operations.test.domain",
+			CACertsSecrets: []string{"ca1-ca-crypto"},
+		}))
+	})
+})
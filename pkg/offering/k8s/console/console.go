@@ -21,24 +21,23 @@ package k8sconsole
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
 	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
 	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
 	resourcemanager "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/manager"
 	baseconsole "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/console"
 	baseconsoleoverride "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/console/override"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/k8s/console/override"
-	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
 	"github.com/IBM-Blockchain/fabric-operator/version"
 	"github.com/pkg/errors"
 	networkingv1 "k8s.io/api/networking/v1"
-	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -49,25 +48,25 @@ var log = logf.Log.WithName("k8s_console")
 type Override interface {
 	baseconsole.Override
 	Ingress(v1.Object, *networkingv1.Ingress, resources.Action) error
-	Ingressv1beta1(v1.Object, *networkingv1beta1.Ingress, resources.Action) error
+	GatewayRoutes(v1.Object) ([]gatewayroute.Route, error)
 }
 
 type Console struct {
 	*baseconsole.Console
 
-	IngressManager        resources.Manager
-	Ingressv1beta1Manager resources.Manager
+	IngressManager      resources.Manager
+	GatewayRouteManager resources.Manager
 
 	Override Override
 }
 
-func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config) *Console {
+func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config, recorder record.EventRecorder) *Console {
 	o := &override.Override{
 		Override: baseconsoleoverride.Override{},
 	}
 
 	console := &Console{
-		Console:  baseconsole.New(client, scheme, config, o),
+		Console:  baseconsole.New(client, scheme, config, recorder, o),
 		Override: o,
 	}
 
@@ -79,7 +78,7 @@ func (c *Console) CreateManagers() {
 	override := c.Override
 	resourceManager := resourcemanager.New(c.Client, c.Scheme)
 	c.IngressManager = resourceManager.CreateIngressManager("", override.Ingress, c.GetLabels, c.Config.ConsoleInitConfig.IngressFile)
-	c.Ingressv1beta1Manager = resourceManager.CreateIngressv1beta1Manager("", override.Ingressv1beta1, c.GetLabels, c.Config.ConsoleInitConfig.Ingressv1beta1File)
+	c.GatewayRouteManager = resourceManager.CreateGatewayRouteManager(override.GatewayRoutes, c.GetLabels, c.Config.ConsoleInitConfig.TLSRouteFile, "", "")
 }
 
 func (c *Console) Reconcile(instance *current.IBPConsole, update baseconsole.Update) (common.Result, error) {
@@ -173,28 +172,29 @@ func (c *Console) ReconcileManagers(instance *current.IBPConsole, update bool) e
 }
 
 func (c *Console) ReconcileIngressManager(instance *current.IBPConsole, update bool) error {
-	if c.Config.Operator.Globals.AllowKubernetesEighteen == "true" {
-		// check k8s version
-		version, err := util.GetServerVersion()
+	if instance.Spec.Ingress.UsesGatewayAPI() {
+		// Gateway API routes replace the ingress of the component
+		err := c.IngressManager.Delete(instance)
 		if err != nil {
-			return err
+			return errors.Wrap(err, "failed to delete ingress")
 		}
-		if strings.Compare(version.Minor, "19") < 0 { // v1beta
-			err = c.Ingressv1beta1Manager.Reconcile(instance, update)
-			if err != nil {
-				return errors.Wrap(err, "failed Ingressv1beta1 reconciliation")
-			}
-		} else {
-			err = c.IngressManager.Reconcile(instance, update)
-			if err != nil {
-				return errors.Wrap(err, "failed Ingress reconciliation")
-			}
-		}
-	} else {
-		err := c.IngressManager.Reconcile(instance, update)
+
+		err = c.GatewayRouteManager.Reconcile(instance, update)
 		if err != nil {
-			return errors.Wrap(err, "failed Ingress reconciliation")
+			return errors.Wrap(err, "failed Gateway API route reconciliation")
 		}
+		return nil
+	}
+
+	// The ingress replaces any Gateway API routes of the component
+	err := c.GatewayRouteManager.Delete(instance)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete Gateway API routes")
+	}
+
+	err = c.IngressManager.Reconcile(instance, update)
+	if err != nil {
+		return errors.Wrap(err, "failed Ingress reconciliation")
 	}
 	return nil
 }
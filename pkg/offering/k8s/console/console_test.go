@@ -49,7 +49,7 @@ var _ = Describe("K8s Console", func() {
 		roleBindingMgr       *managermocks.ResourceManager
 		serviceAccountMgr    *managermocks.ResourceManager
 		ingressMgr           *managermocks.ResourceManager
-		ingressv1beta1Mgr    *managermocks.ResourceManager
+		gatewayRouteMgr      *managermocks.ResourceManager
 		update               *baseconsolemocks.Update
 	)
 
@@ -67,7 +67,7 @@ var _ = Describe("K8s Console", func() {
 		roleBindingMgr = &managermocks.ResourceManager{}
 		serviceAccountMgr = &managermocks.ResourceManager{}
 		ingressMgr = &managermocks.ResourceManager{}
-		ingressv1beta1Mgr = &managermocks.ResourceManager{}
+		gatewayRouteMgr = &managermocks.ResourceManager{}
 
 		instance = &current.IBPConsole{
 			Spec: current.IBPConsoleSpec{
@@ -137,8 +137,8 @@ var _ = Describe("K8s Console", func() {
 				ServiceAccountManager:    serviceAccountMgr,
 				Restart:                  &baseconsolemocks.RestartManager{},
 			},
-			IngressManager:        ingressMgr,
-			Ingressv1beta1Manager: ingressv1beta1Mgr,
+			IngressManager:      ingressMgr,
+			GatewayRouteManager: gatewayRouteMgr,
 		}
 	})
 
@@ -213,6 +213,13 @@ var _ = Describe("K8s Console", func() {
 			Expect(err.Error()).To(Equal("failed to reconcile managers: failed Ingress reconciliation: failed to reconcile ingress"))
 		})
 
+		It("deletes the Gateway API routes when using an ingress", func() {
+			_, err := console.Reconcile(instance, update)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gatewayRouteMgr.DeleteCallCount()).To(Equal(1))
+			Expect(gatewayRouteMgr.ReconcileCallCount()).To(Equal(0))
+		})
+
 		It("restarts pods by deleting deployment", func() {
 			update.RestartNeededReturns(true)
 			_, err := console.Reconcile(instance, update)
@@ -0,0 +1,45 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package override
+
+import (
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GatewayRoutes returns the Gateway API route of the console, TLS is passed through to the
+// console by SNI
+func (o *Override) GatewayRoutes(object v1.Object) ([]gatewayroute.Route, error) {
+	instance := object.(*current.IBPConsole)
+
+	gateway := gatewayroute.GetGateway(instance.Spec.Ingress)
+
+	consolehost := instance.Namespace + "-" + instance.Name + "-console" + "." + instance.Spec.NetworkInfo.Domain
+
+	return []gatewayroute.Route{
+		{
+			Kind:      gatewayroute.TLSRouteKind,
+			Hostnames: []string{consolehost},
+			Gateway:   gateway,
+			Service:   instance.GetName(),
+			Port:      3000,
+		},
+	}, nil
+}
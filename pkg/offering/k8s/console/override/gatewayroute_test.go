@@ -0,0 +1,68 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package override_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/k8s/console/override"
+)
+
+var _ = Describe("K8s Console Gateway Route Overrides", func() {
+	var (
+		overrider *override.Override
+		instance  *current.IBPConsole
+	)
+
+	BeforeEach(func() {
+		overrider = &override.Override{}
+		instance = &current.IBPConsole{
+			Spec: current.IBPConsoleSpec{
+				NetworkInfo: &current.NetworkInfo{
+					Domain: "test.domain",
+				},
+				Ingress: current.Ingress{
+					Mode: current.GatewayAPIMode,
+					Gateway: &current.GatewayReference{
+						Name: "gateway",
+					},
+				},
+			},
+		}
+		instance.Name = "console"
+		instance.Namespace = "namespace"
+	})
+
+	It("passes TLS through to the console", func() {
+		routes, err := overrider.GatewayRoutes(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(Equal([]gatewayroute.Route{
+			{
+				Kind:      gatewayroute.TLSRouteKind,
+				Hostnames: []string{"namespace-console-console.test.domain"},
+				Gateway:   current.GatewayReference{Name: "gateway"},
+				Service:   "console",
+				Port:      3000,
+			},
+		}))
+	})
+})
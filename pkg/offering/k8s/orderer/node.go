@@ -21,22 +21,20 @@ package k8sorderer
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
 	commoninit "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common"
 	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
 	resourcemanager "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/manager"
 	baseorderer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/k8s/orderer/override"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
-	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
 	"github.com/IBM-Blockchain/fabric-operator/version"
 	"github.com/pkg/errors"
 	networkingv1 "k8s.io/api/networking/v1"
-	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -45,7 +43,7 @@ import (
 type Override interface {
 	baseorderer.Override
 	Ingress(v1.Object, *networkingv1.Ingress, resources.Action) error
-	Ingressv1beta1(v1.Object, *networkingv1beta1.Ingress, resources.Action) error
+	GatewayRoutes(v1.Object) ([]gatewayroute.Route, error)
 }
 
 var _ baseorderer.IBPOrderer = &Node{}
@@ -53,8 +51,8 @@ var _ baseorderer.IBPOrderer = &Node{}
 type Node struct {
 	*baseorderer.Node
 
-	IngressManager        resources.Manager
-	Ingressv1beta1Manager resources.Manager
+	IngressManager      resources.Manager
+	GatewayRouteManager resources.Manager
 
 	Override Override
 }
@@ -72,7 +70,7 @@ func (n *Node) CreateManagers() {
 	override := n.Override
 	resourceManager := resourcemanager.New(n.Client, n.Scheme)
 	n.IngressManager = resourceManager.CreateIngressManager("", override.Ingress, n.GetLabels, n.Config.OrdererInitConfig.IngressFile)
-	n.Ingressv1beta1Manager = resourceManager.CreateIngressv1beta1Manager("", override.Ingressv1beta1, n.GetLabels, n.Config.OrdererInitConfig.Ingressv1beta1File)
+	n.GatewayRouteManager = resourceManager.CreateGatewayRouteManager(override.GatewayRoutes, n.GetLabels, n.Config.OrdererInitConfig.TLSRouteFile, n.Config.OrdererInitConfig.HTTPRouteFile, n.Config.OrdererInitConfig.BackendTLSPolicyFile)
 }
 
 func (n *Node) Reconcile(instance *current.IBPOrderer, update baseorderer.Update) (common.Result, error) {
@@ -99,6 +97,20 @@ func (n *Node) Reconcile(instance *current.IBPOrderer, update baseorderer.Update
 	if err != nil {
 		return common.Result{}, errors.Wrap(err, "failed pre reconcile checks")
 	}
+
+	// The address of the load balancer is the external address of the node, and must be known
+	// before the node enrolls for its TLS certificate
+	if instance.Spec.Service.UsesLoadBalancerAddress() {
+		loadBalancerUpdated, assigned, err := n.UpdateLoadBalancerEndpoint(instance)
+		if err != nil {
+			return common.Result{}, err
+		}
+		if !assigned {
+			log.Info(fmt.Sprintf("Waiting for an address to be assigned to the load balancer of '%s'", instance.GetName()))
+			return baseorderer.WaitForLoadBalancer(instance), nil
+		}
+		instanceUpdated = instanceUpdated || loadBalancerUpdated
+	}
 	externalEndpointUpdated := n.UpdateExternalEndpoint(instance)
 
 	if instanceUpdated || externalEndpointUpdated {
@@ -130,6 +142,15 @@ func (n *Node) Reconcile(instance *current.IBPOrderer, update baseorderer.Update
 		}, nil
 	}
 
+	issued, err := n.CertManagerCertificateIssued(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to check cert-manager certificate")
+	}
+	if !issued {
+		log.Info(fmt.Sprintf("Waiting for cert-manager to issue the TLS certificate of '%s'", instance.GetName()))
+		return baseorderer.WaitForCertManager(instance), nil
+	}
+
 	err = n.Initialize(instance, update)
 	if err != nil {
 		return common.Result{}, operatorerrors.Wrap(err, operatorerrors.OrdererInitilizationFailed, "failed to initialize orderer node")
@@ -188,6 +209,16 @@ func (n *Node) Reconcile(instance *current.IBPOrderer, update baseorderer.Update
 		return *result, nil
 	}
 
+	err = n.ReconcileCertManagerCertificate(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile cert-manager certificate")
+	}
+
+	err = n.ReconcileTLSCertHosts(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile TLS certificate hosts")
+	}
+
 	if update.EcertUpdated() {
 		log.Info("Ecert was updated")
 		// Request deployment restart for tls cert update
@@ -216,11 +247,17 @@ func (n *Node) Reconcile(instance *current.IBPOrderer, update baseorderer.Update
 		return common.Result{}, err
 	}
 
+	rotationResult, err := n.ReconcileTLSCertRotation(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to rotate TLS certificate")
+	}
+
 	if err := n.HandleRestart(instance, update); err != nil {
 		return common.Result{}, err
 	}
 
 	return common.Result{
+		Result: rotationResult.Result,
 		Status: status,
 	}, nil
 }
@@ -242,28 +279,29 @@ func (n *Node) ReconcileManagers(instance *current.IBPOrderer, updated baseorder
 }
 
 func (n *Node) ReconcileIngressManager(instance *current.IBPOrderer, update bool) error {
-	if n.Config.Operator.Globals.AllowKubernetesEighteen == "true" {
-		// check k8s version
-		version, err := util.GetServerVersion()
+	if instance.Spec.Ingress.UsesGatewayAPI() {
+		// Gateway API routes replace the ingress of the component
+		err := n.IngressManager.Delete(instance)
 		if err != nil {
-			return err
+			return errors.Wrap(err, "failed to delete ingress")
 		}
-		if strings.Compare(version.Minor, "19") < 0 { // v1beta
-			err = n.Ingressv1beta1Manager.Reconcile(instance, update)
-			if err != nil {
-				return errors.Wrap(err, "failed Ingressv1beta1 reconciliation")
-			}
-		} else {
-			err = n.IngressManager.Reconcile(instance, update)
-			if err != nil {
-				return errors.Wrap(err, "failed Ingress reconciliation")
-			}
-		}
-	} else {
-		err := n.IngressManager.Reconcile(instance, update)
+
+		err = n.GatewayRouteManager.Reconcile(instance, update)
 		if err != nil {
-			return errors.Wrap(err, "failed Ingress reconciliation")
+			return errors.Wrap(err, "failed Gateway API route reconciliation")
 		}
+		return nil
+	}
+
+	// The ingress replaces any Gateway API routes of the component
+	err := n.GatewayRouteManager.Delete(instance)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete Gateway API routes")
+	}
+
+	err = n.IngressManager.Reconcile(instance, update)
+	if err != nil {
+		return errors.Wrap(err, "failed Ingress reconciliation")
 	}
 	return nil
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/IBM-Blockchain/fabric-operator/version"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -45,7 +46,7 @@ type Orderer struct {
 	*baseorderer.Orderer
 }
 
-func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config) *Orderer {
+func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config, recorder record.EventRecorder) *Orderer {
 	o := &override.Override{
 		Override: baseordereroverride.Override{
 			Client: client,
@@ -54,7 +55,7 @@ func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config)
 	}
 
 	orderer := &Orderer{
-		Orderer: baseorderer.New(client, scheme, config, o),
+		Orderer: baseorderer.New(client, scheme, config, recorder, o),
 	}
 
 	return orderer
@@ -137,14 +138,19 @@ func (o *Orderer) ReconcileNode(instance *current.IBPOrderer, update baseorderer
 	if currentVer.EqualWithoutTag(version.V2_4_1) || currentVer.EqualWithoutTag(version.V2_5_1) || currentVer.GreaterThan(version.V2_4_1) {
 		hostAdmin := fmt.Sprintf("%s-%s-admin.%s", instance.Namespace, instance.Name, instance.Spec.Domain)
 		hosts = append(hosts, hostAPI, hostOperations, hostGrpc, hostAdmin, legacyHostAPI, "127.0.0.1")
-		//TODO: need to Re-enroll when orderer migrated from 1.4.x/2.2.x to 2.4.1
 	} else {
 		hosts = append(hosts, hostAPI, hostOperations, hostGrpc, legacyHostAPI, "127.0.0.1")
 	}
 
+	if instance.Spec.Service.UsesLoadBalancerAddress() {
+		if host, _, err := common.SplitHostPort(instance.Spec.ExternalAddress); err == nil {
+			hosts = append(hosts, host)
+		}
+	}
+
 	o.CheckCSRHosts(instance, hosts)
 
-	k8snode := NewNode(baseorderer.NewNode(o.Client, o.Scheme, o.Config, instance.GetName(), o.RenewCertTimers, o.RestartManager))
+	k8snode := NewNode(baseorderer.NewNode(o.Client, o.Scheme, o.Config, o.Recorder, instance.GetName(), o.RenewCertTimers, o.RestartManager))
 
 	log.Info(fmt.Sprintf("Reconciling Orderer node %s", instance.GetName()))
 	if !instance.Spec.IsUsingChannelLess() && instance.Spec.GenesisBlock == "" && !(instance.Spec.IsPrecreateOrderer()) {
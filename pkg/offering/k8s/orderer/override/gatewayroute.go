@@ -0,0 +1,91 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package override
+
+import (
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
+	"github.com/IBM-Blockchain/fabric-operator/version"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GatewayRoutes returns the Gateway API routes of the orderer, gRPC and the channel participation
+// API are passed through to the orderer by SNI and the operations and grpcweb endpoints are routed
+// over HTTPS, the Gateway verifies the TLS certificate of the orderer with the certificates of its TLS CA
+func (o *Override) GatewayRoutes(object v1.Object) ([]gatewayroute.Route, error) {
+	instance := object.(*current.IBPOrderer)
+
+	gateway := gatewayroute.GetGateway(instance.Spec.Ingress)
+
+	apihost := instance.Namespace + "-" + instance.Name + "-orderer" + "." + instance.Spec.Domain
+	operationshost := instance.Namespace + "-" + instance.Name + "-operations" + "." + instance.Spec.Domain
+	grpcwebhost := instance.Namespace + "-" + instance.Name + "-grpcweb" + "." + instance.Spec.Domain
+	tlsCACerts := []string{"tls-" + instance.GetName() + "-cacerts", "tls-" + instance.GetName() + "-intercerts"}
+
+	routes := []gatewayroute.Route{
+		{
+			Kind:      gatewayroute.TLSRouteKind,
+			Hostnames: []string{apihost},
+			Gateway:   gateway,
+			Service:   instance.GetName(),
+			Port:      7050,
+		},
+		{
+			Suffix:    "operations",
+			Kind:      gatewayroute.HTTPRouteKind,
+			Hostnames: []string{operationshost},
+			Gateway:   gateway,
+			Service:   instance.GetName(),
+			Port:      8443,
+			BackendTLS: &gatewayroute.BackendTLS{
+				SectionName:    "operations",
+				Hostname:       operationshost,
+				CACertsSecrets: tlsCACerts,
+			},
+		},
+		{
+			Suffix:    "grpcweb",
+			Kind:      gatewayroute.HTTPRouteKind,
+			Hostnames: []string{grpcwebhost},
+			Gateway:   gateway,
+			Service:   instance.GetName(),
+			Port:      7443,
+			BackendTLS: &gatewayroute.BackendTLS{
+				SectionName:    "grpcweb",
+				Hostname:       grpcwebhost,
+				CACertsSecrets: tlsCACerts,
+			},
+		},
+	}
+
+	currentVer := version.String(instance.Spec.FabricVersion)
+	if currentVer.EqualWithoutTag(version.V2_4_1) || currentVer.EqualWithoutTag(version.V2_5_1) || currentVer.GreaterThan(version.V2_4_1) {
+		adminhost := instance.Namespace + "-" + instance.Name + "-admin" + "." + instance.Spec.Domain
+		routes = append(routes, gatewayroute.Route{
+			Suffix:    "admin",
+			Kind:      gatewayroute.TLSRouteKind,
+			Hostnames: []string{adminhost},
+			Gateway:   gateway,
+			Service:   instance.GetName(),
+			Port:      9443,
+		})
+	}
+
+	return routes, nil
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package override_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/k8s/orderer/override"
+)
+
+var _ = Describe("K8s Orderer Gateway Route Overrides", func() {
+	var (
+		overrider *override.Override
+		instance  *current.IBPOrderer
+	)
+
+	BeforeEach(func() {
+		overrider = &override.Override{}
+		instance = &current.IBPOrderer{
+			Spec: current.IBPOrdererSpec{
+				Domain:        "test.domain",
+				FabricVersion: "2.4.1",
+				Ingress: current.Ingress{
+					Mode: current.GatewayAPIMode,
+					Gateway: &current.GatewayReference{
+						Name: "gateway",
+					},
+				},
+			},
+		}
+		instance.Name = "orderer1"
+		instance.Namespace = "namespace"
+	})
+
+	It("passes gRPC and the admin endpoint through to the orderer and routes the operations and grpcweb endpoints over HTTPS", func() {
+		routes, err := overrider.GatewayRoutes(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(HaveLen(4))
+		Expect(routes[0]).To(Equal(gatewayroute.Route{
+			Kind:      gatewayroute.TLSRouteKind,
+			Hostnames: []string{"namespace-orderer1-orderer.test.domain"},
+			Gateway:   current.GatewayReference{Name: "gateway"},
+			Service:   "orderer1",
+			Port:      7050,
+		}))
+		Expect(routes[1].Kind).To(Equal(gatewayroute.HTTPRouteKind))
+		Expect(routes[1].Port).To(Equal(int32(8443)))
+		Expect(routes[1].BackendTLS).To(Equal(&gatewayroute.BackendTLS{
+			SectionName:    "operations",
+			Hostname:       "namespace-orderer1-operations.test.domain",
+			CACertsSecrets: []string{"tls-orderer1-cacerts", "tls-orderer1-intercerts"},
+		}))
+		Expect(routes[2].Kind).To(Equal(gatewayroute.HTTPRouteKind))
+		Expect(routes[2].BackendTLS.SectionName).To(Equal("grpcweb"))
+		Expect(routes[3].Suffix).To(Equal("admin"))
+		Expect(routes[3].Kind).To(Equal(gatewayroute.TLSRouteKind))
+		Expect(routes[3].Hostnames).To(Equal([]string{"namespace-orderer1-admin.test.domain"}))
+	})
+
+	It("does not route the admin endpoint of orderers before 2.4.1", func() {
+		instance.Spec.FabricVersion = "1.4.12"
+		routes, err := overrider.GatewayRoutes(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(HaveLen(3))
+	})
+
+	It("returns the routes without a gateway if the gateway is not set", func() {
+		instance.Spec.Ingress.Gateway = nil
+		routes, err := overrider.GatewayRoutes(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes[0].Gateway).To(Equal(current.GatewayReference{}))
+	})
+})
@@ -0,0 +1,75 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package override
+
+import (
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GatewayRoutes returns the Gateway API routes of the peer, gRPC is passed through to the
+// peer by SNI and the operations and grpcweb endpoints are routed over HTTPS, the Gateway
+// verifies the TLS certificate of the peer with the certificates of its TLS CA
+func (o *Override) GatewayRoutes(object v1.Object) ([]gatewayroute.Route, error) {
+	instance := object.(*current.IBPPeer)
+
+	gateway := gatewayroute.GetGateway(instance.Spec.Ingress)
+
+	apihost := instance.Namespace + "-" + instance.Name + "-peer" + "." + instance.Spec.Domain
+	operationshost := instance.Namespace + "-" + instance.Name + "-operations" + "." + instance.Spec.Domain
+	grpcwebhost := instance.Namespace + "-" + instance.Name + "-grpcweb" + "." + instance.Spec.Domain
+	tlsCACerts := []string{"tls-" + instance.GetName() + "-cacerts", "tls-" + instance.GetName() + "-intercerts"}
+
+	return []gatewayroute.Route{
+		{
+			Kind:      gatewayroute.TLSRouteKind,
+			Hostnames: []string{apihost},
+			Gateway:   gateway,
+			Service:   instance.GetName(),
+			Port:      7051,
+		},
+		{
+			Suffix:    "operations",
+			Kind:      gatewayroute.HTTPRouteKind,
+			Hostnames: []string{operationshost},
+			Gateway:   gateway,
+			Service:   instance.GetName(),
+			Port:      9443,
+			BackendTLS: &gatewayroute.BackendTLS{
+				SectionName:    "operations",
+				Hostname:       operationshost,
+				CACertsSecrets: tlsCACerts,
+			},
+		},
+		{
+			Suffix:    "grpcweb",
+			Kind:      gatewayroute.HTTPRouteKind,
+			Hostnames: []string{grpcwebhost},
+			Gateway:   gateway,
+			Service:   instance.GetName(),
+			Port:      7443,
+			BackendTLS: &gatewayroute.BackendTLS{
+				SectionName:    "grpcweb",
+				Hostname:       grpcwebhost,
+				CACertsSecrets: tlsCACerts,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package override_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/k8s/peer/override"
+)
+
+var _ = Describe("K8s Peer Gateway Route Overrides", func() {
+	var (
+		overrider *override.Override
+		instance  *current.IBPPeer
+	)
+
+	BeforeEach(func() {
+		overrider = &override.Override{}
+		instance = &current.IBPPeer{
+			Spec: current.IBPPeerSpec{
+				Domain: "test.domain",
+				Ingress: current.Ingress{
+					Mode: current.GatewayAPIMode,
+					Gateway: &current.GatewayReference{
+						Name:      "gateway",
+						Namespace: "gateway-system",
+					},
+				},
+			},
+		}
+		instance.Name = "peer1"
+		instance.Namespace = "namespace"
+	})
+
+	It("passes gRPC through to the peer and routes the operations and grpcweb endpoints over HTTPS", func() {
+		routes, err := overrider.GatewayRoutes(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(Equal([]gatewayroute.Route{
+			{
+				Kind:      gatewayroute.TLSRouteKind,
+				Hostnames: []string{"namespace-peer1-peer.test.domain"},
+				Gateway:   *instance.Spec.Ingress.Gateway,
+				Service:   "peer1",
+				Port:      7051,
+			},
+			{
+				Suffix:    "operations",
+				Kind:      gatewayroute.HTTPRouteKind,
+				Hostnames: []string{"namespace-peer1-operations.test.domain"},
+				Gateway:   *instance.Spec.Ingress.Gateway,
+				Service:   "peer1",
+				Port:      9443,
+				BackendTLS: &gatewayroute.BackendTLS{
+					SectionName:    "operations",
+					Hostname:       "namespace-peer1-operations.test.domain",
+					CACertsSecrets: []string{"tls-peer1-cacerts", "tls-peer1-intercerts"},
+				},
+			},
+			{
+				Suffix:    "grpcweb",
+				Kind:      gatewayroute.HTTPRouteKind,
+				Hostnames: []string{"namespace-peer1-grpcweb.test.domain"},
+				Gateway:   *instance.Spec.Ingress.Gateway,
+				Service:   "peer1",
+				Port:      7443,
+				BackendTLS: &gatewayroute.BackendTLS{
+					SectionName:    "grpcweb",
+					Hostname:       "namespace-peer1-grpcweb.test.domain",
+					CACertsSecrets: []string{"tls-peer1-cacerts", "tls-peer1-intercerts"},
+				},
+			},
+		}))
+	})
+
+	It("returns the routes without a gateway if the gateway is not set", func() {
+		instance.Spec.Ingress.Gateway = nil
+		routes, err := overrider.GatewayRoutes(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(HaveLen(3))
+		Expect(routes[0].Gateway).To(Equal(current.GatewayReference{}))
+	})
+})
@@ -21,26 +21,25 @@ package k8speer
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
 	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
 	commoninit "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common"
 	controllerclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
 	resourcemanager "github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/manager"
 	basepeer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer"
 	basepeeroverride "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/peer/override"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/k8s/peer/override"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/operatorerrors"
-	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
 	"github.com/IBM-Blockchain/fabric-operator/version"
 	"github.com/pkg/errors"
 	networkingv1 "k8s.io/api/networking/v1"
-	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -51,7 +50,7 @@ var log = logf.Log.WithName("k8s_peer")
 type Override interface {
 	basepeer.Override
 	Ingress(v1.Object, *networkingv1.Ingress, resources.Action) error
-	Ingressv1beta1(v1.Object, *networkingv1beta1.Ingress, resources.Action) error
+	GatewayRoutes(v1.Object) ([]gatewayroute.Route, error)
 }
 
 var _ basepeer.IBPPeer = &Peer{}
@@ -59,13 +58,13 @@ var _ basepeer.IBPPeer = &Peer{}
 type Peer struct {
 	*basepeer.Peer
 
-	IngressManager        resources.Manager
-	Ingressv1beta1Manager resources.Manager
+	IngressManager      resources.Manager
+	GatewayRouteManager resources.Manager
 
 	Override Override
 }
 
-func New(client controllerclient.Client, scheme *runtime.Scheme, config *config.Config) *Peer {
+func New(client controllerclient.Client, scheme *runtime.Scheme, config *config.Config, recorder record.EventRecorder) *Peer {
 	o := &override.Override{
 		Override: basepeeroverride.Override{
 			Client:                        client,
@@ -76,7 +75,7 @@ func New(client controllerclient.Client, scheme *runtime.Scheme, config *config.
 	}
 
 	p := &Peer{
-		Peer:     basepeer.New(client, scheme, config, o),
+		Peer:     basepeer.New(client, scheme, config, recorder, o),
 		Override: o,
 	}
 
@@ -87,7 +86,7 @@ func New(client controllerclient.Client, scheme *runtime.Scheme, config *config.
 func (p *Peer) CreateManagers() {
 	resourceManager := resourcemanager.New(p.Client, p.Scheme)
 	p.IngressManager = resourceManager.CreateIngressManager("", p.Override.Ingress, p.GetLabels, p.Config.PeerInitConfig.IngressFile)
-	p.Ingressv1beta1Manager = resourceManager.CreateIngressv1beta1Manager("", p.Override.Ingressv1beta1, p.GetLabels, p.Config.PeerInitConfig.Ingressv1beta1File)
+	p.GatewayRouteManager = resourceManager.CreateGatewayRouteManager(p.Override.GatewayRoutes, p.GetLabels, p.Config.PeerInitConfig.TLSRouteFile, p.Config.PeerInitConfig.HTTPRouteFile, p.Config.PeerInitConfig.BackendTLSPolicyFile)
 }
 
 func (p *Peer) ReconcileManagers(instance *current.IBPPeer, update basepeer.Update) error {
@@ -121,11 +120,49 @@ func (p *Peer) Reconcile(instance *current.IBPPeer, update basepeer.Update) (com
 		}, nil
 	}
 
+	if instance.Spec.HasReplicas() {
+		// A peer that ran as a single peer before it was scaled up is replaced by its replicas
+		tornDown, err := p.TearDownPeer(instance)
+		if err != nil {
+			return common.Result{}, errors.Wrap(err, "failed to tear down single peer")
+		}
+		if tornDown {
+			err = p.IngressManager.Delete(instance)
+			if err != nil {
+				return common.Result{}, errors.Wrap(err, "failed to delete ingress of single peer")
+			}
+			err = p.GatewayRouteManager.Delete(instance)
+			if err != nil {
+				return common.Result{}, errors.Wrap(err, "failed to delete Gateway API routes of single peer")
+			}
+		}
+
+		result, err := p.ReconcileReplicas(instance)
+		if err != nil {
+			return common.Result{}, errors.Wrap(err, "failed to reconcile replicas")
+		}
+		return result, nil
+	}
+
 	instanceUpdated, err := p.PreReconcileChecks(instance, update)
 	if err != nil {
 		return common.Result{}, errors.Wrap(err, "failed pre reconcile checks")
 	}
 
+	// The address of the load balancer is the external endpoint of the peer, and must be known
+	// before the peer enrolls for its TLS certificate
+	if instance.Spec.Service.UsesLoadBalancerAddress() {
+		loadBalancerUpdated, assigned, err := p.UpdateLoadBalancerEndpoint(instance)
+		if err != nil {
+			return common.Result{}, err
+		}
+		if !assigned {
+			log.Info(fmt.Sprintf("Waiting for an address to be assigned to the load balancer of '%s'", instance.GetName()))
+			return basepeer.WaitForLoadBalancer(instance), nil
+		}
+		instanceUpdated = instanceUpdated || loadBalancerUpdated
+	}
+
 	// We do not have to wait for service to get the external endpoint
 	// thus we call UpdateExternalEndpoint in reconcile before reconcile managers
 	externalEndpointUpdated := p.UpdateExternalEndpoint(instance)
@@ -135,6 +172,9 @@ func (p *Peer) Reconcile(instance *current.IBPPeer, update basepeer.Update) (com
 	hostGrpcWeb := fmt.Sprintf("%s-%s-grpcweb.%s", instance.Namespace, instance.Name, instance.Spec.Domain)
 	legacyHostAPI := fmt.Sprintf("%s-%s.%s", instance.Namespace, instance.Name, instance.Spec.Domain)
 	hosts := []string{hostAPI, hostOperations, hostGrpcWeb, legacyHostAPI, "127.0.0.1"}
+	if host := basepeer.GetLoadBalancerHost(instance); host != "" {
+		hosts = append(hosts, host)
+	}
 	csrHostUpdated := p.CheckCSRHosts(instance, hosts)
 
 	if instanceUpdated || externalEndpointUpdated || csrHostUpdated {
@@ -174,6 +214,15 @@ func (p *Peer) Reconcile(instance *current.IBPPeer, update basepeer.Update) (com
 		return common.Result{}, err
 	}
 
+	issued, err := p.CertManagerCertificateIssued(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to check cert-manager certificate")
+	}
+	if !issued {
+		log.Info(fmt.Sprintf("Waiting for cert-manager to issue the TLS certificate of '%s'", instance.GetName()))
+		return basepeer.WaitForCertManager(instance), nil
+	}
+
 	err = p.Initialize(instance, update)
 	if err != nil {
 		return common.Result{}, operatorerrors.Wrap(err, operatorerrors.PeerInitilizationFailed, "failed to initialize peer")
@@ -229,6 +278,16 @@ func (p *Peer) Reconcile(instance *current.IBPPeer, update basepeer.Update) (com
 		return *result, nil
 	}
 
+	err = p.ReconcileCertManagerCertificate(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile cert-manager certificate")
+	}
+
+	err = p.ReconcileTLSCertHosts(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile TLS certificate hosts")
+	}
+
 	if update.EcertUpdated() {
 		log.Info("Ecert was updated")
 		// Request deployment restart for tls cert update
@@ -266,34 +325,46 @@ func (p *Peer) Reconcile(instance *current.IBPPeer, update basepeer.Update) (com
 		return common.Result{}, err
 	}
 
+	channelsResult, err := p.ReconcileChannels(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile channels")
+	}
+
+	snapshotsResult, err := p.ReconcileSnapshots(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile snapshots")
+	}
+
 	return common.Result{
 		Status: status,
+		Result: basepeer.MergeResults(channelsResult, snapshotsResult),
 	}, nil
 }
 
 func (p *Peer) ReconcileIngressManager(instance *current.IBPPeer, update bool) error {
-	if p.Config.Operator.Globals.AllowKubernetesEighteen == "true" {
-		// check k8s version
-		version, err := util.GetServerVersion()
+	if instance.Spec.Ingress.UsesGatewayAPI() {
+		// Gateway API routes replace the ingress of the component
+		err := p.IngressManager.Delete(instance)
 		if err != nil {
-			return err
+			return errors.Wrap(err, "failed to delete ingress")
 		}
-		if strings.Compare(version.Minor, "19") < 0 { // v1beta
-			err = p.Ingressv1beta1Manager.Reconcile(instance, update)
-			if err != nil {
-				return errors.Wrap(err, "failed Ingressv1beta1 reconciliation")
-			}
-		} else {
-			err = p.IngressManager.Reconcile(instance, update)
-			if err != nil {
-				return errors.Wrap(err, "failed Ingress reconciliation")
-			}
-		}
-	} else {
-		err := p.IngressManager.Reconcile(instance, update)
+
+		err = p.GatewayRouteManager.Reconcile(instance, update)
 		if err != nil {
-			return errors.Wrap(err, "failed Ingress reconciliation")
+			return errors.Wrap(err, "failed Gateway API route reconciliation")
 		}
+		return nil
+	}
+
+	// The ingress replaces any Gateway API routes of the component
+	err := p.GatewayRouteManager.Delete(instance)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete Gateway API routes")
+	}
+
+	err = p.IngressManager.Reconcile(instance, update)
+	if err != nil {
+		return errors.Wrap(err, "failed Ingress reconciliation")
 	}
 	return nil
 }
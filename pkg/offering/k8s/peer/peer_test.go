@@ -57,7 +57,9 @@ var _ = Describe("K8s Peer", func() {
 		roleMgr           *managermocks.ResourceManager
 		roleBindingMgr    *managermocks.ResourceManager
 		serviceAccountMgr *managermocks.ResourceManager
+		pdbMgr            *managermocks.ResourceManager
 		ingressMgr        *managermocks.ResourceManager
+		gatewayRouteMgr   *managermocks.ResourceManager
 		update            *mocks.Update
 		certificateMgr    *mocks.CertificateManager
 	)
@@ -130,7 +132,9 @@ var _ = Describe("K8s Peer", func() {
 		roleMgr = &managermocks.ResourceManager{}
 		roleBindingMgr = &managermocks.ResourceManager{}
 		serviceAccountMgr = &managermocks.ResourceManager{}
+		pdbMgr = &managermocks.ResourceManager{}
 		ingressMgr = &managermocks.ResourceManager{}
+		gatewayRouteMgr = &managermocks.ResourceManager{}
 		certificateMgr = &mocks.CertificateManager{}
 		restartMgr := &mocks.RestartManager{}
 
@@ -156,11 +160,13 @@ var _ = Describe("K8s Peer", func() {
 				RoleManager:           roleMgr,
 				RoleBindingManager:    roleBindingMgr,
 				ServiceAccountManager: serviceAccountMgr,
+				PDBManager:            pdbMgr,
 				Initializer:           initializer,
 				CertificateManager:    certificateMgr,
 				Restart:               restartMgr,
 			},
-			IngressManager: ingressMgr,
+			IngressManager:      ingressMgr,
+			GatewayRouteManager: gatewayRouteMgr,
 		}
 	})
 
@@ -227,6 +233,41 @@ var _ = Describe("K8s Peer", func() {
 			_, err := peer.Reconcile(instance, update)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("deletes the Gateway API routes when using an ingress", func() {
+			_, err := peer.Reconcile(instance, update)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gatewayRouteMgr.DeleteCallCount()).To(Equal(1))
+			Expect(gatewayRouteMgr.ReconcileCallCount()).To(Equal(0))
+		})
+
+		It("replaces a reconciled single peer with replicas when it is scaled up", func() {
+			_, err := peer.Reconcile(instance, update)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deploymentMgr.ReconcileCallCount()).To(Equal(1))
+			deploymentMgr.ExistsReturns(true)
+			creates := mockKubeClient.CreateCallCount()
+
+			replicas := int32(2)
+			instance.Spec.Replicas = &replicas
+			_, err = peer.Reconcile(instance, update)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("tearing down the single peer", func() {
+				Expect(deploymentMgr.DeleteCallCount()).To(Equal(1))
+				Expect(serviceMgr.DeleteCallCount()).To(Equal(1))
+				Expect(pvcMgr.DeleteCallCount()).To(Equal(1))
+				Expect(couchPvcMgr.DeleteCallCount()).To(Equal(1))
+				Expect(ingressMgr.DeleteCallCount()).To(Equal(1))
+				Expect(deploymentMgr.ReconcileCallCount()).To(Equal(1))
+			})
+
+			By("creating the replicas", func() {
+				Expect(mockKubeClient.CreateCallCount()).To(Equal(creates + 2))
+				_, obj, _ := mockKubeClient.CreateArgsForCall(creates + 1)
+				Expect(obj.GetName()).To(Equal("peer1-2"))
+			})
+		})
 	})
 
 	Context("ExternalEndpoint", func() {
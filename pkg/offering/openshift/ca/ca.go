@@ -37,6 +37,7 @@ import (
 	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -61,14 +62,14 @@ type CA struct {
 	Override Override
 }
 
-func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config) *CA {
+func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config, recorder record.EventRecorder) *CA {
 	o := &override.Override{
 		Override: basecaoverride.Override{
 			Client: client,
 		},
 	}
 	ca := &CA{
-		CA:       baseca.New(client, scheme, config, o),
+		CA:       baseca.New(client, scheme, config, recorder, o),
 		Override: o,
 	}
 	ca.CreateManagers()
@@ -36,6 +36,7 @@ import (
 	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -64,13 +65,13 @@ type Console struct {
 	Override Override
 }
 
-func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config) *Console {
+func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config, recorder record.EventRecorder) *Console {
 	o := &override.Override{
 		Override: baseconsoleoverride.Override{},
 	}
 
 	console := &Console{
-		Console:  baseconsole.New(client, scheme, config, o),
+		Console:  baseconsole.New(client, scheme, config, recorder, o),
 		Override: o,
 	}
 	console.CreateManagers()
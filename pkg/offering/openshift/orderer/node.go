@@ -130,6 +130,15 @@ func (n *Node) Reconcile(instance *current.IBPOrderer, update baseorderer.Update
 		}, nil
 	}
 
+	issued, err := n.CertManagerCertificateIssued(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to check cert-manager certificate")
+	}
+	if !issued {
+		log.Info(fmt.Sprintf("Waiting for cert-manager to issue the TLS certificate of '%s'", instance.GetName()))
+		return baseorderer.WaitForCertManager(instance), nil
+	}
+
 	err = n.Initialize(instance, update)
 	if err != nil {
 		return common.Result{}, operatorerrors.Wrap(err, operatorerrors.OrdererInitilizationFailed, "failed to initialize orderer node")
@@ -188,6 +197,16 @@ func (n *Node) Reconcile(instance *current.IBPOrderer, update baseorderer.Update
 		return *result, nil
 	}
 
+	err = n.ReconcileCertManagerCertificate(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile cert-manager certificate")
+	}
+
+	err = n.ReconcileTLSCertHosts(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile TLS certificate hosts")
+	}
+
 	if update.EcertUpdated() {
 		log.Info("Ecert was updated")
 		// Request deployment restart for tls cert update
@@ -216,11 +235,17 @@ func (n *Node) Reconcile(instance *current.IBPOrderer, update baseorderer.Update
 		return common.Result{}, err
 	}
 
+	rotationResult, err := n.ReconcileTLSCertRotation(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to rotate TLS certificate")
+	}
+
 	if err := n.HandleRestart(instance, update); err != nil {
 		return common.Result{}, err
 	}
 
 	return common.Result{
+		Result: rotationResult.Result,
 		Status: status,
 	}, nil
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/IBM-Blockchain/fabric-operator/version"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -47,7 +48,7 @@ type Orderer struct {
 	*baseorderer.Orderer
 }
 
-func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config) *Orderer {
+func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config, recorder record.EventRecorder) *Orderer {
 	o := &override.Override{
 		Override: baseordereroverride.Override{
 			Client: client,
@@ -56,7 +57,7 @@ func New(client k8sclient.Client, scheme *runtime.Scheme, config *config.Config)
 	}
 
 	orderer := &Orderer{
-		Orderer: baseorderer.New(client, scheme, config, o),
+		Orderer: baseorderer.New(client, scheme, config, recorder, o),
 	}
 
 	return orderer
@@ -142,7 +143,7 @@ func (o *Orderer) ReconcileNode(instance *current.IBPOrderer, update baseorderer
 
 	log.Info(fmt.Sprintf("Reconciling Orderer node %s", instance.GetName()))
 
-	openshiftnode := NewNode(baseorderer.NewNode(o.Client, o.Scheme, o.Config, instance.GetName(), o.RenewCertTimers, o.RestartManager))
+	openshiftnode := NewNode(baseorderer.NewNode(o.Client, o.Scheme, o.Config, o.Recorder, instance.GetName(), o.RenewCertTimers, o.RestartManager))
 
 	if !instance.Spec.IsUsingChannelLess() && instance.Spec.GenesisBlock == "" && !(instance.Spec.IsPrecreateOrderer()) {
 		return common.Result{}, fmt.Errorf("Genesis block not provided for orderer node: %s", instance.GetName())
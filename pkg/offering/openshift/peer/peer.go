@@ -39,6 +39,7 @@ import (
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -66,7 +67,7 @@ type Peer struct {
 	Override Override
 }
 
-func New(client controllerclient.Client, scheme *runtime.Scheme, config *config.Config, restclient *clientset.Clientset) *Peer {
+func New(client controllerclient.Client, scheme *runtime.Scheme, config *config.Config, recorder record.EventRecorder, restclient *clientset.Clientset) *Peer {
 	o := &override.Override{
 		Override: basepeeroverride.Override{
 			Client:                        client,
@@ -77,7 +78,7 @@ func New(client controllerclient.Client, scheme *runtime.Scheme, config *config.
 	}
 
 	peer := &Peer{
-		Peer:       basepeer.New(client, scheme, config, o),
+		Peer:       basepeer.New(client, scheme, config, recorder, o),
 		Override:   o,
 		RestClient: restclient,
 	}
@@ -134,6 +135,28 @@ func (p *Peer) Reconcile(instance *current.IBPPeer, update basepeer.Update) (com
 		}, nil
 	}
 
+	if instance.Spec.HasReplicas() {
+		// A peer that ran as a single peer before it was scaled up is replaced by its replicas
+		tornDown, err := p.TearDownPeer(instance)
+		if err != nil {
+			return common.Result{}, errors.Wrap(err, "failed to tear down single peer")
+		}
+		if tornDown {
+			for _, routeManager := range []resources.Manager{p.RouteManager, p.OperationsRouteManager, p.GRPCRouteManager} {
+				err = routeManager.Delete(instance)
+				if err != nil {
+					return common.Result{}, errors.Wrap(err, "failed to delete route of single peer")
+				}
+			}
+		}
+
+		result, err := p.ReconcileReplicas(instance)
+		if err != nil {
+			return common.Result{}, errors.Wrap(err, "failed to reconcile replicas")
+		}
+		return result, nil
+	}
+
 	instanceUpdated, err := p.PreReconcileChecks(instance, update)
 	if err != nil {
 		return common.Result{}, errors.Wrap(err, "failed pre reconcile checks")
@@ -187,6 +210,15 @@ func (p *Peer) Reconcile(instance *current.IBPPeer, update basepeer.Update) (com
 		return common.Result{}, err
 	}
 
+	issued, err := p.CertManagerCertificateIssued(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to check cert-manager certificate")
+	}
+	if !issued {
+		log.Info(fmt.Sprintf("Waiting for cert-manager to issue the TLS certificate of '%s'", instance.GetName()))
+		return basepeer.WaitForCertManager(instance), nil
+	}
+
 	err = p.Initialize(instance, update)
 	if err != nil {
 		return common.Result{}, operatorerrors.Wrap(err, operatorerrors.PeerInitilizationFailed, "failed to initialize peer")
@@ -242,6 +274,16 @@ func (p *Peer) Reconcile(instance *current.IBPPeer, update basepeer.Update) (com
 		return *result, nil
 	}
 
+	err = p.ReconcileCertManagerCertificate(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile cert-manager certificate")
+	}
+
+	err = p.ReconcileTLSCertHosts(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile TLS certificate hosts")
+	}
+
 	if update.EcertUpdated() {
 		log.Info("Ecert was updated")
 		// Request deployment restart for tls cert update
@@ -279,7 +321,18 @@ func (p *Peer) Reconcile(instance *current.IBPPeer, update basepeer.Update) (com
 		return common.Result{}, err
 	}
 
+	channelsResult, err := p.ReconcileChannels(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile channels")
+	}
+
+	snapshotsResult, err := p.ReconcileSnapshots(instance)
+	if err != nil {
+		return common.Result{}, errors.Wrap(err, "failed to reconcile snapshots")
+	}
+
 	return common.Result{
 		Status: status,
+		Result: basepeer.MergeResults(channelsResult, snapshotsResult),
 	}, nil
 }
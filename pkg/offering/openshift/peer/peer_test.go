@@ -119,6 +119,7 @@ var _ = Describe("Openshift Peer", func() {
 			roleMgr := &managermocks.ResourceManager{}
 			roleBindingMgr := &managermocks.ResourceManager{}
 			serviceAccountMgr := &managermocks.ResourceManager{}
+			pdbMgr := &managermocks.ResourceManager{}
 			certificateMgr := &peermocks.CertificateManager{}
 			restartMgr := &peermocks.RestartManager{}
 
@@ -147,6 +148,7 @@ var _ = Describe("Openshift Peer", func() {
 					RoleManager:           roleMgr,
 					RoleBindingManager:    roleBindingMgr,
 					ServiceAccountManager: serviceAccountMgr,
+					PDBManager:            pdbMgr,
 					Initializer:           initializer,
 					CertificateManager:    certificateMgr,
 					Restart:               restartMgr,
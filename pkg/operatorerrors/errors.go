@@ -52,6 +52,8 @@ const (
 	FabricOrdererMigrationFailed       = 25
 	InvalidCustomResourceCreateRequest = 26
 	FabricCAMigrationFailed            = 27
+	InvalidPDBCreateRequest            = 28
+	InvalidPDBUpdateRequest            = 29
 )
 
 var (
@@ -79,6 +81,8 @@ var (
 		FabricPeerMigrationFailed:          nil,
 		FabricOrdererMigrationFailed:       nil,
 		InvalidCustomResourceCreateRequest: nil,
+		InvalidPDBCreateRequest:            nil,
+		InvalidPDBUpdateRequest:            nil,
 	}
 )
 
@@ -20,15 +20,19 @@ package restart
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/IBM-Blockchain/fabric-operator/pkg/events"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/initializer/common"
 	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/metrics"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/restart/configmap"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/restart/staggerrestarts"
 	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -41,11 +45,13 @@ type RestartManager struct {
 	WaitTime               time.Duration
 	ConfigMapManager       *configmap.Manager
 	StaggerRestartsService *staggerrestarts.StaggerRestartsService
+	Recorder               record.EventRecorder
 }
 
-func New(client k8sclient.Client, waitTime, timeout time.Duration) *RestartManager {
+func New(client k8sclient.Client, recorder record.EventRecorder, waitTime, timeout time.Duration) *RestartManager {
 	r := &RestartManager{
 		Client:                 client,
+		Recorder:               recorder,
 		Timers:                 map[string]*time.Timer{},
 		WaitTime:               waitTime,
 		ConfigMapManager:       configmap.NewManager(client),
@@ -129,6 +135,8 @@ func (r *RestartManager) updateConfigFor(instance v1.Object, reason Reason) erro
 	if err != nil {
 		return err
 	}
+	events.Normal(r.Recorder, instance, events.RestartRequested, "Restart requested due to %s", reason)
+	metrics.IncRestartRequests(instance.GetNamespace(), instance.GetName(), string(reason))
 
 	return nil
 }
@@ -235,6 +243,30 @@ func (r *RestartManager) pendingRequests(restart *Restart) bool {
 	return false
 }
 
+// PendingReasons returns the reasons of the restart requests of the instance that
+// have not been completed yet
+func (r *RestartManager) PendingReasons(instance v1.Object) ([]string, error) {
+	cfg, err := r.GetConfig(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	restart := cfg.Instances[instance.GetName()]
+	if restart == nil {
+		return nil, nil
+	}
+
+	reasons := []string{}
+	for reason, req := range restart.Requests {
+		if req != nil && req.Status == Pending {
+			reasons = append(reasons, string(reason))
+		}
+	}
+	sort.Strings(reasons)
+
+	return reasons, nil
+}
+
 func (r *RestartManager) SetTimer(instance Instance, reason string) error {
 	cfg, err := r.GetConfig(instance)
 	if err != nil {
@@ -301,6 +333,7 @@ func (r *RestartManager) RestartDeployment(instance Instance, reason string) err
 	if err != nil {
 		return errors.Wrap(err, "failed to add restart request to queue")
 	}
+	events.Normal(r.Recorder, instance, events.RestartQueued, "Restart queued due to %s", reason)
 
 	return nil
 }
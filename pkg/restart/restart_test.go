@@ -39,6 +39,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 )
 
 var _ = Describe("Restart", func() {
@@ -47,6 +48,7 @@ var _ = Describe("Restart", func() {
 
 	var (
 		mockClient *controllermocks.Client
+		recorder   *record.FakeRecorder
 		instance   *current.IBPPeer
 
 		restartManager *restart.RestartManager
@@ -58,7 +60,8 @@ var _ = Describe("Restart", func() {
 
 	BeforeEach(func() {
 		mockClient = &controllermocks.Client{}
-		restartManager = restart.New(mockClient, 10*time.Minute, 5*time.Minute)
+		recorder = record.NewFakeRecorder(10)
+		restartManager = restart.New(mockClient, recorder, 10*time.Minute, 5*time.Minute)
 
 		instance = &current.IBPPeer{}
 		instance.Name = "peer1"
@@ -192,6 +195,28 @@ var _ = Describe("Restart", func() {
 		})
 	})
 
+	Context("pending reasons", func() {
+		It("returns error if fails to get config from config map", func() {
+			mockClient.GetReturns(errors.New("get error"))
+			_, err := restartManager.PendingReasons(instance)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns the reasons of the pending restart requests", func() {
+			instance.Name = "peer4"
+			reasons, err := restartManager.PendingReasons(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reasons).To(Equal([]string{"adminCert", "ecertUpdate"}))
+		})
+
+		It("returns no reasons if restart requests are complete", func() {
+			instance.Name = "peer2"
+			reasons, err := restartManager.PendingReasons(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reasons).To(BeEmpty())
+		})
+	})
+
 	Context("for admin cert update", func() {
 		It("returns error if fails to get config from config map", func() {
 			mockClient.GetReturns(errors.New("get error"))
@@ -228,6 +253,12 @@ var _ = Describe("Restart", func() {
 			Expect(updatedCfg.Instances["newpeer"].Requests[restart.ADMINCERT].RequestTimestamp).NotTo(Equal(""))
 		})
 
+		It("records an event for the restart request", func() {
+			err := restartManager.ForAdminCertUpdate(instance)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(Equal("Normal RestartRequested Restart requested due to adminCert")))
+		})
+
 	})
 
 	Context("for ecert reenroll", func() {
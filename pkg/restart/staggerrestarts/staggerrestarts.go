@@ -31,12 +31,14 @@ import (
 	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/action"
 	k8sclient "github.com/IBM-Blockchain/fabric-operator/pkg/k8s/controllerclient"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/metrics"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/restart/configmap"
 	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
 	"github.com/pkg/errors"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -336,6 +338,18 @@ func (s *StaggerRestartsService) Reconcile(componentType, namespace string) (boo
 			}
 
 			if len(pods) > 0 {
+				// Restarting the running pod disrupts it like a node drain would, hold the
+				// restart back until the disruption budget of the pod allows it
+				allowed, err := s.DisruptionAllowed(&pods[0])
+				if err != nil {
+					return requeue, errors.Wrapf(err, "failed to check disruption budget for %s", name)
+				}
+				if !allowed {
+					log.Info(fmt.Sprintf("%s restart held back, pod disruption budget allows no disruption", component.CRName))
+					requeue = true
+					continue
+				}
+
 				component.PodName = pods[0].Name
 			}
 
@@ -465,7 +479,18 @@ func (s *StaggerRestartsService) GetConfig(componentType, namespace string) (*Re
 
 func (s *StaggerRestartsService) UpdateConfig(componentType, namespace string, cfg *RestartConfig) error {
 	cmName := fmt.Sprintf("%s-restart-config", componentType)
-	return s.ConfigMapManager.UpdateConfig(cmName, namespace, cfg)
+	err := s.ConfigMapManager.UpdateConfig(cmName, namespace, cfg)
+	if err != nil {
+		return err
+	}
+
+	queueLengths := map[string]int{}
+	for mspid, queue := range cfg.Queues {
+		queueLengths[mspid] = len(queue)
+	}
+	metrics.SetStaggerRestartQueueDepth(namespace, componentType, queueLengths)
+
+	return nil
 }
 
 func (s *StaggerRestartsService) RestartDeployment(name, namespace string) error {
@@ -502,6 +527,19 @@ func (s *StaggerRestartsService) CheckDeployments(name, namespace string) (bool,
 		deploymentsExists = true
 	}
 
+	// Components that run as a stateful set don't have a deployment
+	if !deploymentsExists {
+		stsList := &appsv1.StatefulSetList{}
+		err = s.Client.List(context.TODO(), stsList, listOptions)
+		if err != nil {
+			log.Error(err, "failed to get stateful set list for %s", name)
+			return deploymentsExists, nil
+		}
+		if len(stsList.Items) > 0 {
+			deploymentsExists = true
+		}
+	}
+
 	return deploymentsExists, nil
 }
 
@@ -553,6 +591,39 @@ func (s *StaggerRestartsService) GetRunningPods(name, namespace string) ([]corev
 	return pods, nil
 }
 
+// DisruptionAllowed returns false if a pod disruption budget that selects the pod allows no
+// disruption, as other pods covered by the budget are unavailable. A budget that allows no
+// disruption while all of its pods are healthy, such as the budget of an orderer cluster that
+// can't lose a node, never allows one. Restarts are required to apply changes to the spec, so
+// they are not held back by such a budget, the unavoidable disruption is logged instead.
+func (s *StaggerRestartsService) DisruptionAllowed(pod *corev1.Pod) (bool, error) {
+	pdbList := &policyv1.PodDisruptionBudgetList{}
+	err := s.Client.List(context.TODO(), pdbList, &client.ListOptions{Namespace: pod.GetNamespace()})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list pod disruption budgets")
+	}
+
+	for _, pdb := range pdbList.Items {
+		selector, err := v1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to parse selector of pod disruption budget %s", pdb.GetName())
+		}
+		if selector.Empty() || !selector.Matches(labels.Set(pod.GetLabels())) {
+			continue
+		}
+
+		if pdb.Status.DisruptionsAllowed < 1 {
+			if pdb.Status.ExpectedPods > 0 && pdb.Status.CurrentHealthy >= pdb.Status.ExpectedPods {
+				log.Info(fmt.Sprintf("Pod disruption budget %s allows no disruption of its healthy pods, restarting pod %s disrupts it", pdb.GetName(), pod.GetName()))
+				continue
+			}
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 func queuesToString(queues map[string][]*Component) string {
 	lst := []string{}
 	for org, queue := range queues {
@@ -33,6 +33,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -245,6 +246,26 @@ var _ = Describe("Staggerrestarts", func() {
 				})
 			})
 
+			It("does not set deleted status for components that run as a stateful set", func() {
+				mockClient.ListStub = func(ctx context.Context, obj client.ObjectList, opts ...k8sclient.ListOption) error {
+					switch obj.(type) {
+					case *appsv1.StatefulSetList:
+						sts := obj.(*appsv1.StatefulSetList)
+						sts.Items = []appsv1.StatefulSet{{ObjectMeta: v1.ObjectMeta{Name: "org1peer1"}}}
+					}
+					return nil
+				}
+				requeue, err := service.Reconcile("peer", "namespace")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requeue).To(Equal(false))
+
+				_, cm, _ := mockClient.CreateOrUpdateArgsForCall(0)
+				cfg := getRestartConfig(cm.(*corev1.ConfigMap))
+				Expect(cfg.Queues["org1"][0].CRName).To(Equal("org1peer1"))
+				Expect(cfg.Queues["org1"][0].Status).To(Equal(staggerrestarts.Waiting))
+				Expect(len(cfg.Log)).To(Equal(0))
+			})
+
 			It("returns error if fails to restart deployment", func() {
 				mockClient.PatchReturns(errors.New("patch error"))
 				requeue, err := service.Reconcile("peer", "namespace")
@@ -278,6 +299,88 @@ var _ = Describe("Staggerrestarts", func() {
 				})
 
 			})
+
+			Context("pod disruption budget", func() {
+				var pdb *policyv1.PodDisruptionBudget
+
+				BeforeEach(func() {
+					pod.Labels = map[string]string{"orgname": "org1"}
+					pdb = &policyv1.PodDisruptionBudget{
+						Spec: policyv1.PodDisruptionBudgetSpec{
+							Selector: &v1.LabelSelector{
+								MatchLabels: map[string]string{"orgname": "org1"},
+							},
+						},
+					}
+
+					mockClient.ListStub = func(ctx context.Context, obj client.ObjectList, opts ...k8sclient.ListOption) error {
+						switch obj.(type) {
+						case *corev1.PodList:
+							pods := obj.(*corev1.PodList)
+							pods.Items = []corev1.Pod{*pod}
+						case *appsv1.DeploymentList:
+							deployments := obj.(*appsv1.DeploymentList)
+							deployments.Items = []appsv1.Deployment{*dep}
+						case *policyv1.PodDisruptionBudgetList:
+							pdbs := obj.(*policyv1.PodDisruptionBudgetList)
+							pdbs.Items = []policyv1.PodDisruptionBudget{*pdb}
+						}
+						return nil
+					}
+				})
+
+				It("holds back restart if the budget of the pod allows no disruption", func() {
+					requeue, err := service.Reconcile("peer", "namespace")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(requeue).To(Equal(true))
+					Expect(mockClient.PatchCallCount()).To(Equal(0))
+					Expect(mockClient.CreateOrUpdateCallCount()).To(Equal(0))
+				})
+
+				It("restarts deployment if the budget of the pod allows a disruption", func() {
+					pdb.Status.DisruptionsAllowed = 1
+
+					requeue, err := service.Reconcile("peer", "namespace")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(requeue).To(Equal(false))
+
+					_, cm, _ := mockClient.CreateOrUpdateArgsForCall(0)
+					cfg := getRestartConfig(cm.(*corev1.ConfigMap))
+					Expect(cfg.Queues["org1"][0].Status).To(Equal(staggerrestarts.Waiting))
+				})
+
+				It("restarts deployment if the budget allows no disruption of its healthy pods", func() {
+					pdb.Status.ExpectedPods = 2
+					pdb.Status.CurrentHealthy = 2
+
+					requeue, err := service.Reconcile("peer", "namespace")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(requeue).To(Equal(false))
+
+					_, cm, _ := mockClient.CreateOrUpdateArgsForCall(0)
+					cfg := getRestartConfig(cm.(*corev1.ConfigMap))
+					Expect(cfg.Queues["org1"][0].Status).To(Equal(staggerrestarts.Waiting))
+				})
+
+				It("holds back restart if other pods of the budget are unhealthy", func() {
+					pdb.Status.ExpectedPods = 2
+					pdb.Status.CurrentHealthy = 1
+
+					requeue, err := service.Reconcile("peer", "namespace")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(requeue).To(Equal(true))
+					Expect(mockClient.PatchCallCount()).To(Equal(0))
+				})
+
+				It("restarts deployment if the budget does not select the pod", func() {
+					pdb.Spec.Selector.MatchLabels = map[string]string{"orgname": "org2"}
+
+					requeue, err := service.Reconcile("peer", "namespace")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(requeue).To(Equal(false))
+					Expect(mockClient.PatchCallCount()).To(Equal(2))
+				})
+			})
 		})
 
 		Context("waiting", func() {
@@ -46,10 +46,10 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
-	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/apimachinery/pkg/util/yaml"
@@ -196,6 +196,21 @@ func GetDeploymentFromFile(file string) (*appsv1.Deployment, error) {
 	return dep, nil
 }
 
+func GetStatefulSetFromFile(file string) (*appsv1.StatefulSet, error) {
+	jsonBytes, err := ConvertYamlFileToJson(file)
+	if err != nil {
+		return nil, err
+	}
+
+	sts := &appsv1.StatefulSet{}
+	err = json.Unmarshal(jsonBytes, &sts)
+	if err != nil {
+		return nil, err
+	}
+
+	return sts, nil
+}
+
 func GetServiceFromFile(file string) (*corev1.Service, error) {
 	jsonBytes, err := ConvertYamlFileToJson(file)
 	if err != nil {
@@ -259,19 +274,21 @@ func GetIngressFromFile(file string) (*networkingv1.Ingress, error) {
 	return ingress, nil
 }
 
-func GetIngressv1beta1FromFile(file string) (*networkingv1beta1.Ingress, error) {
+// GetUnstructuredFromFile reads a resource whose type is not registered with the scheme,
+// such as a Gateway API route
+func GetUnstructuredFromFile(file string) (*unstructured.Unstructured, error) {
 	jsonBytes, err := ConvertYamlFileToJson(file)
 	if err != nil {
 		return nil, err
 	}
 
-	ingress := &networkingv1beta1.Ingress{}
-	err = json.Unmarshal(jsonBytes, &ingress)
+	obj := &unstructured.Unstructured{}
+	err = obj.UnmarshalJSON(jsonBytes)
 	if err != nil {
 		return nil, err
 	}
 
-	return ingress, nil
+	return obj, nil
 }
 
 func GetSecretFromFile(file string) (*corev1.Secret, error) {
@@ -878,6 +895,16 @@ func IsSecretEcert(secretName string) bool {
 	return false
 }
 
+// IsSecretCertManagerTLSCert returns true if cert-manager issues the TLS certificate of a
+// component to the secret
+func IsSecretCertManagerTLSCert(secretName string) bool {
+	if strings.HasSuffix(secretName, "-certmanager") {
+		return strings.HasPrefix(secretName, "tls")
+	}
+
+	return false
+}
+
 func ConvertSpec(in interface{}, out interface{}) error {
 	jsonBytes, err := yaml1.Marshal(in)
 	if err != nil {
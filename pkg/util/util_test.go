@@ -114,14 +114,6 @@ var _ = Describe("Util", func() {
 		})
 	})
 
-	Context("GetIngressv1beta1FromFile", func() {
-		It("returns an error if config is incorrectly defined", func() {
-			_, err := util.GetIngressv1beta1FromFile("testdata/invalid_kind.yaml")
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("cannot unmarshal"))
-		})
-	})
-
 	Context("GetRoleFromFile", func() {
 		It("returns an error if config is incorrectly defined", func() {
 			_, err := util.GetRoleFromFile("testdata/invalid_kind.yaml")
@@ -0,0 +1,142 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common/reconcilechecks"
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Defaulter sets the default images of the fabric version of CAs, peers and orderers at
+// admission time, the same defaults are otherwise set during reconcile
+type Defaulter struct {
+	Config *config.Config
+
+	decoder *admission.Decoder
+}
+
+// InjectDecoder injects the decoder of the webhook server
+func (d *Defaulter) InjectDecoder(decoder *admission.Decoder) error {
+	d.decoder = decoder
+	return nil
+}
+
+// Handle returns a patch that sets the defaults on the admitted object
+func (d *Defaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj, err := decode(d.decoder, req.Kind.Kind, req.Object)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var old client.Object
+	if req.Operation == admissionv1.Update {
+		old, err = decode(d.decoder, req.Kind.Kind, req.OldObject)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
+	err = d.Default(obj, old)
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// Default sets the images of the fabric version on the instance if the images are not set,
+// or the fabric version of the instance changed. Old is nil for new instances.
+func (d *Defaulter) Default(obj, old client.Object) error {
+	instance, ok := obj.(reconcilechecks.Instance)
+	if !ok {
+		// Consoles have no fabric version
+		return nil
+	}
+
+	// Instances without a fabric version are rejected by the validator
+	if instance.GetFabricVersion() == "" {
+		return nil
+	}
+
+	if d.Config == nil || d.Config.Operator.Versions == nil {
+		return nil
+	}
+
+	_, err := reconcilechecks.FabricVersionHelper(instance, d.Config.Operator.Versions, newUpdate(obj, old))
+	if err != nil {
+		return errors.Wrap(err, "failed to set default images")
+	}
+
+	return nil
+}
+
+// Update captures the changes to the fabric version and images of an instance
+type Update struct {
+	imagesUpdated        bool
+	fabricVersionUpdated bool
+}
+
+func newUpdate(obj, old client.Object) *Update {
+	update := &Update{}
+	if old == nil {
+		return update
+	}
+
+	switch instance := obj.(type) {
+	case *current.IBPCA:
+		oldInstance := old.(*current.IBPCA)
+		update.imagesUpdated = !reflect.DeepEqual(oldInstance.Spec.Images, instance.Spec.Images)
+		update.fabricVersionUpdated = oldInstance.Spec.FabricVersion != instance.Spec.FabricVersion
+	case *current.IBPPeer:
+		oldInstance := old.(*current.IBPPeer)
+		update.imagesUpdated = !reflect.DeepEqual(oldInstance.Spec.Images, instance.Spec.Images)
+		update.fabricVersionUpdated = oldInstance.Spec.FabricVersion != instance.Spec.FabricVersion
+	case *current.IBPOrderer:
+		oldInstance := old.(*current.IBPOrderer)
+		update.imagesUpdated = !reflect.DeepEqual(oldInstance.Spec.Images, instance.Spec.Images)
+		update.fabricVersionUpdated = oldInstance.Spec.FabricVersion != instance.Spec.FabricVersion
+	}
+
+	return update
+}
+
+// ImagesUpdated returns true if the images of the instance changed
+func (u *Update) ImagesUpdated() bool {
+	return u.imagesUpdated
+}
+
+// FabricVersionUpdated returns true if the fabric version of the instance changed
+func (u *Update) FabricVersionUpdated() bool {
+	return u.fabricVersionUpdated
+}
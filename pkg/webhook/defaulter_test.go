@@ -0,0 +1,99 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook_test
+
+import (
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/apis/deployer"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/webhook"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Defaulter", func() {
+	var (
+		defaulter *webhook.Defaulter
+		peer      *current.IBPPeer
+	)
+
+	BeforeEach(func() {
+		defaulter = &webhook.Defaulter{
+			Config: &config.Config{
+				Operator: config.Operator{
+					Versions: &deployer.Versions{
+						Peer: map[string]deployer.VersionPeer{
+							"2.5.4-1": {
+								Default: true,
+								Version: "2.5.4-1",
+								Image: deployer.PeerImages{
+									PeerImage: "hyperledger/fabric-peer",
+									PeerTag:   "2.5.4",
+								},
+							},
+							"2.5.5-1": {
+								Version: "2.5.5-1",
+								Image: deployer.PeerImages{
+									PeerImage: "hyperledger/fabric-peer",
+									PeerTag:   "2.5.5",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		peer = &current.IBPPeer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "peer1",
+			},
+			Spec: current.IBPPeerSpec{
+				FabricVersion: "2.5.4",
+			},
+		}
+	})
+
+	It("normalizes the fabric version and sets the default images of new instances", func() {
+		Expect(defaulter.Default(peer, nil)).To(Succeed())
+		Expect(peer.Spec.FabricVersion).To(Equal("2.5.4-1"))
+		Expect(peer.Spec.Images.PeerImage).To(Equal("hyperledger/fabric-peer"))
+		Expect(peer.Spec.Images.PeerTag).To(Equal("2.5.4"))
+	})
+
+	It("keeps the images of new instances if set", func() {
+		peer.Spec.Images = &current.PeerImages{PeerImage: "custom/peer", PeerTag: "latest"}
+		Expect(defaulter.Default(peer, nil)).To(Succeed())
+		Expect(peer.Spec.Images.PeerImage).To(Equal("custom/peer"))
+	})
+
+	It("sets the images of the new fabric version on update", func() {
+		Expect(defaulter.Default(peer, nil)).To(Succeed())
+
+		old := peer.DeepCopy()
+		peer.Spec.FabricVersion = "2.5.5-1"
+		Expect(defaulter.Default(peer, old)).To(Succeed())
+		Expect(peer.Spec.Images.PeerTag).To(Equal("2.5.5"))
+	})
+
+	It("does not default consoles", func() {
+		Expect(defaulter.Default(&current.IBPConsole{}, nil)).To(Succeed())
+	})
+})
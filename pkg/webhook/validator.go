@@ -0,0 +1,480 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	ordererconfig "github.com/IBM-Blockchain/fabric-operator/pkg/initializer/orderer/config/v1"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/manager/resources/gatewayroute"
+	baseconsole "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/console"
+	baseorderer "github.com/IBM-Blockchain/fabric-operator/pkg/offering/base/orderer"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/offering/common/reconcilechecks/images"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	"github.com/IBM-Blockchain/fabric-operator/version"
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Validator rejects custom resources that would otherwise fail the pre reconcile checks
+// of the controllers
+type Validator struct {
+	Client util.Client
+	Config *config.Config
+
+	decoder *admission.Decoder
+}
+
+// InjectDecoder injects the decoder of the webhook server
+func (v *Validator) InjectDecoder(decoder *admission.Decoder) error {
+	v.decoder = decoder
+	return nil
+}
+
+// Handle admits the object if it passes validation
+func (v *Validator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	obj, err := decode(v.decoder, req.Kind.Kind, req.Object)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var old client.Object
+	if req.Operation == admissionv1.Update {
+		old, err = decode(v.decoder, req.Kind.Kind, req.OldObject)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
+	err = v.Validate(obj, old)
+	if err != nil {
+		log.Info(fmt.Sprintf("Rejected %s '%s': %s", req.Kind.Kind, obj.GetName(), err.Error()))
+		return admission.Denied(err.Error())
+	}
+
+	return admission.Allowed("")
+}
+
+// Validate validates the instance, old is nil for new instances
+func (v *Validator) Validate(obj, old client.Object) error {
+	switch instance := obj.(type) {
+	case *current.IBPCA:
+		var oldInstance *current.IBPCA
+		if old != nil {
+			oldInstance = old.(*current.IBPCA)
+		}
+		return v.ValidateCA(instance, oldInstance)
+	case *current.IBPPeer:
+		var oldInstance *current.IBPPeer
+		if old != nil {
+			oldInstance = old.(*current.IBPPeer)
+		}
+		return v.ValidatePeer(instance, oldInstance)
+	case *current.IBPOrderer:
+		var oldInstance *current.IBPOrderer
+		if old != nil {
+			oldInstance = old.(*current.IBPOrderer)
+		}
+		return v.ValidateOrderer(instance, oldInstance)
+	case *current.IBPConsole:
+		var oldInstance *current.IBPConsole
+		if old != nil {
+			oldInstance = old.(*current.IBPConsole)
+		}
+		return v.ValidateConsole(instance, oldInstance)
+	}
+
+	return nil
+}
+
+// ValidateCA validates the CA
+func (v *Validator) ValidateCA(instance, old *current.IBPCA) error {
+	var maxNameLength *int
+	if instance.Spec.ConfigOverride != nil {
+		maxNameLength = instance.Spec.ConfigOverride.MaxNameLength
+	}
+	err := util.ValidationChecks(instance.TypeMeta, instance.ObjectMeta, "IBPCA", maxNameLength)
+	if err != nil {
+		return err
+	}
+
+	var oldVersion, oldZone, oldRegion string
+	if old != nil {
+		oldVersion, oldZone, oldRegion = old.Spec.FabricVersion, old.Spec.Zone, old.Spec.Region
+	}
+
+	err = v.validateFabricVersion(instance.DeepCopy(), oldVersion, old != nil)
+	if err != nil {
+		return err
+	}
+
+	err = v.validateZoneAndRegion(instance.Spec.Zone, oldZone, instance.Spec.Region, oldRegion)
+	if err != nil {
+		return err
+	}
+
+	return ValidateIngress(instance.Spec.Ingress)
+}
+
+// ValidatePeer validates the peer, the MSP ID and state database of a peer can't be changed
+func (v *Validator) ValidatePeer(instance, old *current.IBPPeer) error {
+	co, err := instance.GetConfigOverride()
+	if err != nil {
+		return errors.Wrap(err, "invalid config override")
+	}
+
+	var maxNameLength *int
+	if override, ok := co.(interface{ GetMaxNameLength() *int }); ok {
+		maxNameLength = override.GetMaxNameLength()
+	}
+	err = util.ValidationChecks(instance.TypeMeta, instance.ObjectMeta, "IBPPeer", maxNameLength)
+	if err != nil {
+		return err
+	}
+
+	var oldVersion, oldZone, oldRegion string
+	if old != nil {
+		oldVersion, oldZone, oldRegion = old.Spec.FabricVersion, old.Spec.Zone, old.Spec.Region
+
+		if old.Spec.MSPID != "" && instance.Spec.MSPID != old.Spec.MSPID {
+			return errors.Errorf("mspID is immutable, can't be changed from '%s' to '%s'", old.Spec.MSPID, instance.Spec.MSPID)
+		}
+
+		if old.Spec.StateDb != "" && instance.Spec.StateDb != old.Spec.StateDb {
+			return errors.Errorf("stateDb is immutable, can't be changed from '%s' to '%s'", old.Spec.StateDb, instance.Spec.StateDb)
+		}
+	}
+
+	err = v.validateFabricVersion(instance.DeepCopy(), oldVersion, old != nil)
+	if err != nil {
+		return err
+	}
+
+	err = v.validateZoneAndRegion(instance.Spec.Zone, oldZone, instance.Spec.Region, oldRegion)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateIngress(instance.Spec.Ingress)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateService(instance.Spec.Service)
+	if err != nil {
+		return err
+	}
+
+	return ValidateSecret(instance.Spec.Secret)
+}
+
+// ValidateOrderer validates the orderer, the MSP ID and orderer type of an orderer can't
+// be changed
+func (v *Validator) ValidateOrderer(instance, old *current.IBPOrderer) error {
+	switch {
+	case instance.Spec.IsRaft():
+	case instance.Spec.IsBFT():
+		err := (&baseorderer.Orderer{}).BFTChecks(instance)
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("orderer type '%s' is not supported", instance.Spec.OrdererType)
+	}
+
+	var maxNameLength *int
+	if instance.Spec.ConfigOverride != nil {
+		override := &ordererconfig.OrdererOverrides{}
+		err := json.Unmarshal(instance.Spec.ConfigOverride.Raw, override)
+		if err != nil {
+			return errors.Wrap(err, "invalid config override")
+		}
+		maxNameLength = override.MaxNameLength
+	}
+	err := util.ValidationChecks(instance.TypeMeta, instance.ObjectMeta, "IBPOrderer", maxNameLength)
+	if err != nil {
+		return err
+	}
+
+	var oldVersion, oldZone, oldRegion string
+	if old != nil {
+		oldVersion, oldZone, oldRegion = old.Spec.FabricVersion, old.Spec.Zone, old.Spec.Region
+
+		if old.Spec.MSPID != "" && instance.Spec.MSPID != old.Spec.MSPID {
+			return errors.Errorf("mspID is immutable, can't be changed from '%s' to '%s'", old.Spec.MSPID, instance.Spec.MSPID)
+		}
+
+		if old.Spec.OrdererType != "" && instance.Spec.OrdererType != old.Spec.OrdererType {
+			return errors.Errorf("ordererType is immutable, can't be changed from '%s' to '%s'", old.Spec.OrdererType, instance.Spec.OrdererType)
+		}
+	}
+
+	err = v.validateFabricVersion(instance.DeepCopy(), oldVersion, old != nil)
+	if err != nil {
+		return err
+	}
+
+	err = v.validateZoneAndRegion(instance.Spec.Zone, oldZone, instance.Spec.Region, oldRegion)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateIngress(instance.Spec.Ingress)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateService(instance.Spec.Service)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateSecret(instance.Spec.Secret)
+	if err != nil {
+		return err
+	}
+
+	for i, secret := range instance.Spec.ClusterSecret {
+		err = ValidateSecret(secret)
+		if err != nil {
+			return errors.Wrapf(err, "invalid cluster secret for node %d", i+1)
+		}
+	}
+
+	return nil
+}
+
+// ValidateConsole validates the console
+func (v *Validator) ValidateConsole(instance, old *current.IBPConsole) error {
+	var maxNameLength *int
+	if instance.Spec.ConfigOverride != nil {
+		maxNameLength = instance.Spec.ConfigOverride.MaxNameLength
+	}
+	err := util.ValidationChecks(instance.TypeMeta, instance.ObjectMeta, "IBPConsole", maxNameLength)
+	if err != nil {
+		return err
+	}
+
+	// ValidateSpec normalizes the registry URL, validate a copy to leave the object as admitted
+	err = (&baseconsole.Console{}).ValidateSpec(instance.DeepCopy())
+	if err != nil {
+		return err
+	}
+
+	var oldZone, oldRegion string
+	if old != nil {
+		oldZone, oldRegion = old.Spec.Zone, old.Spec.Region
+	}
+
+	err = v.validateZoneAndRegion(instance.Spec.Zone, oldZone, instance.Spec.Region, oldRegion)
+	if err != nil {
+		return err
+	}
+
+	return ValidateIngress(instance.Spec.Ingress)
+}
+
+// validateFabricVersion validates the fabric version of new instances and instances whose
+// fabric version changed
+func (v *Validator) validateFabricVersion(instance images.Instance, oldVersion string, update bool) error {
+	fabricVersion := instance.GetFabricVersion()
+	if update && fabricVersion == oldVersion {
+		return nil
+	}
+
+	if fabricVersion == "" {
+		return errors.New("fabric version is not set")
+	}
+
+	// Fabric versions set by the migration of the operator are not in the list of versions
+	if version.IsMigratedFabricVersion(fabricVersion) {
+		return nil
+	}
+
+	if v.Config == nil || v.Config.Operator.Versions == nil {
+		return nil
+	}
+
+	fv := &images.FabricVersion{
+		Versions: v.Config.Operator.Versions,
+	}
+	instance.SetFabricVersion(fv.Normalize(instance))
+
+	return fv.Validate(instance)
+}
+
+// validateZoneAndRegion validates the zone and region if set, unless unchanged
+func (v *Validator) validateZoneAndRegion(zone, oldZone, region, oldRegion string) error {
+	if zone != "" && zone != "select" && zone != oldZone {
+		err := util.ValidateZone(v.Client, zone)
+		if err != nil {
+			return err
+		}
+	}
+
+	if region != "" && region != "select" && region != oldRegion {
+		err := util.ValidateRegion(v.Client, region)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateIngress validates that routes in GatewayAPI mode have a Gateway to attach to
+func ValidateIngress(ingress current.Ingress) error {
+	if !ingress.UsesGatewayAPI() {
+		return nil
+	}
+
+	return gatewayroute.ValidateGateway(gatewayroute.GetGateway(ingress))
+}
+
+// ValidateService validates that the address of a load balancer is only used with a
+// LoadBalancer service
+func ValidateService(service *current.Service) error {
+	if service == nil || !service.UseLoadBalancerAddress {
+		return nil
+	}
+
+	if service.Type != corev1.ServiceTypeLoadBalancer {
+		return errors.Errorf("service.useLoadBalancerAddress requires service.type to be '%s', not '%s'", corev1.ServiceTypeLoadBalancer, service.Type)
+	}
+	return nil
+}
+
+// ValidateSecret validates the enrollment part of the secret spec, and that the crypto
+// material in the MSP part of the secret spec is base64 encoded PEM
+func ValidateSecret(secret *current.SecretSpec) error {
+	if secret == nil {
+		return nil
+	}
+
+	err := ValidateEnrollment(secret.Enrollment)
+	if err != nil {
+		return err
+	}
+
+	if secret.MSP == nil {
+		return nil
+	}
+
+	msps := map[string]*current.MSP{
+		"component":  secret.MSP.Component,
+		"tls":        secret.MSP.TLS,
+		"clientauth": secret.MSP.ClientAuth,
+	}
+
+	for name, msp := range msps {
+		err := ValidateMSP(msp)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s msp", name)
+		}
+	}
+
+	return nil
+}
+
+// ValidateEnrollment validates that only the TLS certificate is issued by cert-manager, and that
+// the cert-manager issuer is named
+func ValidateEnrollment(enrollment *current.EnrollmentSpec) error {
+	if enrollment == nil {
+		return nil
+	}
+
+	if enrollment.Component.UsesCertManager() || enrollment.ClientAuth.UsesCertManager() {
+		return errors.New("certmanager is only supported for the tls enrollment")
+	}
+
+	if enrollment.TLS.UsesCertManager() && enrollment.TLS.CertManager.Name == "" {
+		return errors.New("tls.certmanager.name must be set to the name of the cert-manager issuer")
+	}
+
+	return nil
+}
+
+// ValidateMSP validates that the keystore and certificates of the MSP are base64 encoded PEM
+func ValidateMSP(msp *current.MSP) error {
+	if msp == nil {
+		return nil
+	}
+
+	if msp.KeyStore != "" {
+		err := validatePEM(msp.KeyStore, false)
+		if err != nil {
+			return errors.Wrap(err, "invalid keystore")
+		}
+	}
+
+	if msp.SignCerts != "" {
+		err := validatePEM(msp.SignCerts, true)
+		if err != nil {
+			return errors.Wrap(err, "invalid signcerts")
+		}
+	}
+
+	certs := map[string][]string{
+		"cacerts":           msp.CACerts,
+		"intermediatecerts": msp.IntermediateCerts,
+		"admincerts":        msp.AdminCerts,
+	}
+	for name, values := range certs {
+		for _, value := range values {
+			err := validatePEM(value, true)
+			if err != nil {
+				return errors.Wrapf(err, "invalid %s", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validatePEM(value string, cert bool) error {
+	bytes, err := util.Base64ToBytes(value)
+	if err != nil {
+		return errors.Wrap(err, "not base64 encoded")
+	}
+
+	if cert {
+		_, err = util.GetCertificateFromPEMBytes(bytes)
+		return err
+	}
+
+	block, _ := pem.Decode(bytes)
+	if block == nil {
+		return errors.New("failed to decode PEM bytes")
+	}
+
+	return nil
+}
@@ -0,0 +1,341 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	"github.com/IBM-Blockchain/fabric-operator/controllers/mocks"
+	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/apis/deployer"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/util"
+	"github.com/IBM-Blockchain/fabric-operator/pkg/webhook"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Validator", func() {
+	var (
+		validator      *webhook.Validator
+		mockKubeClient *mocks.Client
+		peer           *current.IBPPeer
+		orderer        *current.IBPOrderer
+	)
+
+	BeforeEach(func() {
+		mockKubeClient = &mocks.Client{}
+		mockKubeClient.ListStub = func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+			switch obj := obj.(type) {
+			case *corev1.NodeList:
+				obj.Items = []corev1.Node{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								"topology.kubernetes.io/zone":   "zone1",
+								"topology.kubernetes.io/region": "region1",
+							},
+						},
+					},
+				}
+			}
+			return nil
+		}
+
+		validator = &webhook.Validator{
+			Client: mockKubeClient,
+			Config: &config.Config{
+				Operator: config.Operator{
+					Versions: &deployer.Versions{
+						Peer: map[string]deployer.VersionPeer{
+							"2.5.4-1": {Default: true, Version: "2.5.4-1"},
+						},
+						Orderer: map[string]deployer.VersionOrderer{
+							"2.5.4-1": {Default: true, Version: "2.5.4-1"},
+						},
+					},
+				},
+			},
+		}
+
+		peer = &current.IBPPeer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "peer1",
+			},
+			Spec: current.IBPPeerSpec{
+				FabricVersion: "2.5.4-1",
+				MSPID:         "org1",
+				StateDb:       "leveldb",
+			},
+		}
+
+		orderer = &current.IBPOrderer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "orderer1",
+			},
+			Spec: current.IBPOrdererSpec{
+				FabricVersion: "2.5.4-1",
+				MSPID:         "ordererorg",
+				OrdererType:   "etcdraft",
+			},
+		}
+	})
+
+	Context("peer", func() {
+		It("admits a valid peer", func() {
+			Expect(validator.Validate(peer, nil)).To(Succeed())
+		})
+
+		It("admits a fabric version without a hyphen", func() {
+			peer.Spec.FabricVersion = "2.5.4"
+			Expect(validator.Validate(peer, nil)).To(Succeed())
+		})
+
+		It("rejects an unsupported fabric version", func() {
+			peer.Spec.FabricVersion = "1.0.0-1"
+			err := validator.Validate(peer, nil)
+			Expect(err).To(MatchError(ContainSubstring("fabric version '1.0.0-1' is not supported for Peer")))
+		})
+
+		It("rejects Gateway API mode without a gateway", func() {
+			peer.Spec.Ingress.Mode = current.GatewayAPIMode
+			err := validator.Validate(peer, nil)
+			Expect(err).To(MatchError(ContainSubstring("ingress.gateway.name must be set")))
+
+			peer.Spec.Ingress.Gateway = &current.GatewayReference{Name: "gateway"}
+			Expect(validator.Validate(peer, nil)).To(Succeed())
+		})
+
+		It("rejects the load balancer address without a LoadBalancer service", func() {
+			peer.Spec.Service = &current.Service{UseLoadBalancerAddress: true}
+			err := validator.Validate(peer, nil)
+			Expect(err).To(MatchError(ContainSubstring("service.useLoadBalancerAddress requires service.type to be 'LoadBalancer'")))
+
+			peer.Spec.Service.Type = corev1.ServiceTypeLoadBalancer
+			Expect(validator.Validate(peer, nil)).To(Succeed())
+		})
+
+		It("rejects a peer without a fabric version", func() {
+			peer.Spec.FabricVersion = ""
+			err := validator.Validate(peer, nil)
+			Expect(err).To(MatchError(ContainSubstring("fabric version is not set")))
+		})
+
+		It("rejects an invalid zone", func() {
+			peer.Spec.Zone = "zone2"
+			err := validator.Validate(peer, nil)
+			Expect(err).To(MatchError(ContainSubstring("Zone 'zone2' is not a valid zone")))
+		})
+
+		It("admits a valid zone and region", func() {
+			peer.Spec.Zone = "zone1"
+			peer.Spec.Region = "region1"
+			Expect(validator.Validate(peer, nil)).To(Succeed())
+		})
+
+		It("rejects an invalid region", func() {
+			peer.Spec.Region = "region2"
+			err := validator.Validate(peer, nil)
+			Expect(err).To(MatchError(ContainSubstring("Region 'region2' is not a valid region")))
+		})
+
+		It("rejects malformed PEM in the msp secret", func() {
+			peer.Spec.Secret = &current.SecretSpec{
+				MSP: &current.MSPSpec{
+					Component: &current.MSP{
+						SignCerts: util.BytesToBase64([]byte("not a certificate")),
+					},
+				},
+			}
+			err := validator.Validate(peer, nil)
+			Expect(err).To(MatchError(ContainSubstring("invalid component msp: invalid signcerts")))
+		})
+
+		It("admits PEM encoded certificates in the msp secret", func() {
+			peer.Spec.Secret = &current.SecretSpec{
+				MSP: &current.MSPSpec{
+					Component: &current.MSP{
+						SignCerts: util.BytesToBase64(generateCert()),
+						CACerts:   []string{util.BytesToBase64(generateCert())},
+					},
+				},
+			}
+			Expect(validator.Validate(peer, nil)).To(Succeed())
+		})
+
+		It("rejects cert-manager for enrollments other than tls", func() {
+			peer.Spec.Secret = &current.SecretSpec{
+				Enrollment: &current.EnrollmentSpec{
+					Component: &current.Enrollment{
+						CertManager: &current.CertManagerIssuer{Name: "issuer"},
+					},
+				},
+			}
+			err := validator.Validate(peer, nil)
+			Expect(err).To(MatchError(ContainSubstring("certmanager is only supported for the tls enrollment")))
+		})
+
+		It("rejects a cert-manager tls enrollment without an issuer name", func() {
+			peer.Spec.Secret = &current.SecretSpec{
+				Enrollment: &current.EnrollmentSpec{
+					TLS: &current.Enrollment{
+						CertManager: &current.CertManagerIssuer{},
+					},
+				},
+			}
+			err := validator.Validate(peer, nil)
+			Expect(err).To(MatchError(ContainSubstring("tls.certmanager.name must be set")))
+
+			peer.Spec.Secret.Enrollment.TLS.CertManager.Name = "issuer"
+			Expect(validator.Validate(peer, nil)).To(Succeed())
+		})
+
+		Context("update", func() {
+			var old *current.IBPPeer
+
+			BeforeEach(func() {
+				old = peer.DeepCopy()
+			})
+
+			It("rejects a change of the msp id", func() {
+				peer.Spec.MSPID = "org2"
+				err := validator.Validate(peer, old)
+				Expect(err).To(MatchError(ContainSubstring("mspID is immutable")))
+			})
+
+			It("rejects a change of the state database", func() {
+				peer.Spec.StateDb = "couchdb"
+				err := validator.Validate(peer, old)
+				Expect(err).To(MatchError(ContainSubstring("stateDb is immutable")))
+			})
+
+			It("does not validate an unchanged fabric version", func() {
+				old.Spec.FabricVersion = "1.0.0-1"
+				peer.Spec.FabricVersion = "1.0.0-1"
+				Expect(validator.Validate(peer, old)).To(Succeed())
+			})
+		})
+	})
+
+	Context("orderer", func() {
+		It("admits a valid orderer", func() {
+			Expect(validator.Validate(orderer, nil)).To(Succeed())
+		})
+
+		It("rejects an unsupported orderer type", func() {
+			orderer.Spec.OrdererType = "solo"
+			err := validator.Validate(orderer, nil)
+			Expect(err).To(MatchError(ContainSubstring("orderer type 'solo' is not supported")))
+		})
+
+		It("rejects Gateway API mode without a gateway", func() {
+			orderer.Spec.Ingress.Mode = current.GatewayAPIMode
+			err := validator.Validate(orderer, nil)
+			Expect(err).To(MatchError(ContainSubstring("ingress.gateway.name must be set")))
+		})
+
+		It("rejects the load balancer address without a LoadBalancer service", func() {
+			orderer.Spec.Service = &current.Service{Type: corev1.ServiceTypeNodePort, UseLoadBalancerAddress: true}
+			err := validator.Validate(orderer, nil)
+			Expect(err).To(MatchError(ContainSubstring("service.useLoadBalancerAddress requires service.type to be 'LoadBalancer'")))
+		})
+
+		Context("BFT", func() {
+			BeforeEach(func() {
+				orderer.Spec.OrdererType = "BFT"
+				orderer.Spec.FabricVersion = "3.0.0"
+				orderer.Spec.ClusterSize = 4
+				orderer.Spec.UseChannelLess = pointer.Bool(true)
+			})
+
+			It("rejects a BFT orderer without a cluster size", func() {
+				orderer.Spec.ClusterSize = 0
+				err := validator.Validate(orderer, nil)
+				Expect(err).To(MatchError(ContainSubstring("requires a cluster size of at least 4 (3f+1), cluster size is 1")))
+			})
+
+			It("rejects a BFT orderer without a fabric version", func() {
+				orderer.Spec.FabricVersion = ""
+				err := validator.Validate(orderer, nil)
+				Expect(err).To(MatchError(ContainSubstring("requires fabric version 3.0.0 or higher")))
+			})
+		})
+
+		It("rejects a change of the orderer type", func() {
+			old := orderer.DeepCopy()
+			orderer.Spec.OrdererType = "bft"
+			err := validator.Validate(orderer, old)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects malformed PEM in a cluster secret", func() {
+			orderer.Spec.ClusterSecret = []*current.SecretSpec{
+				{
+					MSP: &current.MSPSpec{
+						TLS: &current.MSP{
+							KeyStore: "not base64!",
+						},
+					},
+				},
+			}
+			err := validator.Validate(orderer, nil)
+			Expect(err).To(MatchError(ContainSubstring("invalid cluster secret for node 1")))
+		})
+	})
+
+	Context("console", func() {
+		It("rejects a console without network information", func() {
+			console := &current.IBPConsole{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "console",
+				},
+			}
+			err := validator.Validate(console, nil)
+			Expect(err).To(MatchError(ContainSubstring("network information not provided")))
+		})
+	})
+})
+
+func generateCert() []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright contributors to the Hyperledger Fabric Operator project
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at:
+ *
+ * 	  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	current "github.com/IBM-Blockchain/fabric-operator/api/v1beta1"
+	config "github.com/IBM-Blockchain/fabric-operator/operatorconfig"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+var log = logf.Log.WithName("webhook")
+
+// +kubebuilder:webhook:path=/mutate-ibp-com-v1beta1-ibpca,mutating=true,failurePolicy=fail,sideEffects=None,groups=ibp.com,resources=ibpcas,verbs=create;update,versions=v1beta1,name=mibpca.ibp.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-ibp-com-v1beta1-ibppeer,mutating=true,failurePolicy=fail,sideEffects=None,groups=ibp.com,resources=ibppeers,verbs=create;update,versions=v1beta1,name=mibppeer.ibp.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-ibp-com-v1beta1-ibporderer,mutating=true,failurePolicy=fail,sideEffects=None,groups=ibp.com,resources=ibporderers,verbs=create;update,versions=v1beta1,name=mibporderer.ibp.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-ibp-com-v1beta1-ibpconsole,mutating=true,failurePolicy=fail,sideEffects=None,groups=ibp.com,resources=ibpconsoles,verbs=create;update,versions=v1beta1,name=mibpconsole.ibp.com,admissionReviewVersions=v1
+
+// +kubebuilder:webhook:path=/validate-ibp-com-v1beta1-ibpca,mutating=false,failurePolicy=fail,sideEffects=None,groups=ibp.com,resources=ibpcas,verbs=create;update,versions=v1beta1,name=vibpca.ibp.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-ibp-com-v1beta1-ibppeer,mutating=false,failurePolicy=fail,sideEffects=None,groups=ibp.com,resources=ibppeers,verbs=create;update,versions=v1beta1,name=vibppeer.ibp.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-ibp-com-v1beta1-ibporderer,mutating=false,failurePolicy=fail,sideEffects=None,groups=ibp.com,resources=ibporderers,verbs=create;update,versions=v1beta1,name=vibporderer.ibp.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-ibp-com-v1beta1-ibpconsole,mutating=false,failurePolicy=fail,sideEffects=None,groups=ibp.com,resources=ibpconsoles,verbs=create;update,versions=v1beta1,name=vibpconsole.ibp.com,admissionReviewVersions=v1
+
+// ConvertPath is the path of the conversion webhook shared by all versioned kinds
+const ConvertPath = "/convert"
+
+// Kinds are the kinds of the custom resources served by the webhooks
+var Kinds = []string{"IBPCA", "IBPPeer", "IBPOrderer", "IBPConsole"}
+
+// MutatePath returns the path of the defaulting webhook of the kind
+func MutatePath(kind string) string {
+	return fmt.Sprintf("/mutate-ibp-com-v1beta1-%s", strings.ToLower(kind))
+}
+
+// ValidatePath returns the path of the validating webhook of the kind
+func ValidatePath(kind string) string {
+	return fmt.Sprintf("/validate-ibp-com-v1beta1-%s", strings.ToLower(kind))
+}
+
+// AddToManager registers the defaulting, validating and conversion webhooks of the
+// custom resources with the webhook server of the manager
+func AddToManager(mgr manager.Manager, config *config.Config) error {
+	server := mgr.GetWebhookServer()
+	for _, kind := range Kinds {
+		log.Info(fmt.Sprintf("Registering webhooks for %s", kind))
+		server.Register(MutatePath(kind), &ctrlwebhook.Admission{Handler: &Defaulter{Config: config}})
+		server.Register(ValidatePath(kind), &ctrlwebhook.Admission{Handler: &Validator{Client: mgr.GetAPIReader(), Config: config}})
+	}
+
+	// The conversion webhook uses the scheme of the manager, which has both the
+	// v1 and v1beta1 types registered, to convert between the served versions
+	server.Register(ConvertPath, &conversion.Webhook{})
+
+	return nil
+}
+
+func newObject(kind string) (client.Object, error) {
+	switch kind {
+	case "IBPCA":
+		return &current.IBPCA{}, nil
+	case "IBPPeer":
+		return &current.IBPPeer{}, nil
+	case "IBPOrderer":
+		return &current.IBPOrderer{}, nil
+	case "IBPConsole":
+		return &current.IBPConsole{}, nil
+	}
+
+	return nil, errors.Errorf("kind '%s' is not supported by the webhook", kind)
+}
+
+func decode(decoder *admission.Decoder, kind string, raw runtime.RawExtension) (client.Object, error) {
+	obj, err := newObject(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.DecodeRaw(raw, obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s", kind)
+	}
+
+	return obj, nil
+}
@@ -45,6 +45,7 @@ const (
 
 	V2_4_1 = "2.4.1"
 	V2_5_1 = "2.5.1"
+	V3_0_0 = "3.0.0"
 
 	V1_4 = "V1.4"
 
@@ -83,7 +83,8 @@ func GetMajorReleaseVersion(version string) string {
 	version = stripVersionPrefix(version)
 	v := newVersion(version)
 	switch v.Major {
-	case 2:
+	case 2, 3:
+		// Fabric 3.x components keep the 2.x configuration layout
 		return V2
 	case 1:
 		return V1